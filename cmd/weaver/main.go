@@ -27,6 +27,7 @@ import (
 
 	itool "github.com/ServiceWeaver/weaver/internal/tool"
 	"github.com/ServiceWeaver/weaver/internal/tool/callgraph"
+	"github.com/ServiceWeaver/weaver/internal/tool/compat"
 	"github.com/ServiceWeaver/weaver/internal/tool/generate"
 	"github.com/ServiceWeaver/weaver/internal/tool/multi"
 	"github.com/ServiceWeaver/weaver/internal/tool/single"
@@ -38,6 +39,7 @@ const usage = `USAGE
 
   weaver generate                 // weaver code generator
   weaver version                  // show weaver version
+  weaver compat    <old> <new>    // check binaries for wire compatibility
   weaver single    <command> ...  // for single process deployments
   weaver multi     <command> ...  // for multiprocess deployments
   weaver ssh       <command> ...  // for multimachine deployments
@@ -75,6 +77,7 @@ func main() {
 	case "generate":
 		generateFlags := flag.NewFlagSet("generate", flag.ExitOnError)
 		tags := generateFlags.String("tags", "", "Optional tags for the generate command")
+		mocks := generateFlags.Bool("mocks", false, "Also generate component mocks in weaver_gen_test.go")
 		generateFlags.Usage = func() {
 			fmt.Fprintln(os.Stderr, generate.Usage)
 		}
@@ -86,7 +89,7 @@ func main() {
 			// extra validation at some point.
 			buildTags = buildTags + "," + *tags
 		}
-		if err := generate.Generate(".", generateFlags.Args(), generate.Options{BuildTags: buildTags}); err != nil {
+		if err := generate.Generate(".", generateFlags.Args(), generate.Options{BuildTags: buildTags, Mocks: *mocks}); err != nil {
 			fmt.Fprintln(os.Stderr, err)
 			os.Exit(1)
 		}
@@ -128,6 +131,46 @@ Description:
 		fmt.Println(s)
 		return
 
+	case "compat":
+		const usage = `Check the wire compatibility of two application binaries.
+
+Usage:
+  weaver compat <old binary> <new binary>
+
+Flags:
+  -h, --help           Print this help message.
+
+Description:
+  "weaver compat <old binary> <new binary>" reports the component methods
+  whose calls cannot be exchanged between the two binaries: methods whose
+  argument or result types are serialized differently, and methods that exist
+  in only one of the binaries. It exits with a non-zero status if there are
+  any such methods.
+
+  The method fingerprints compared by "weaver compat" are embedded by "weaver
+  generate". Binaries built from code generated by older versions of "weaver
+  generate" cannot be checked.`
+		flags := flag.NewFlagSet("compat", flag.ExitOnError)
+		flags.Usage = func() { fmt.Fprintln(os.Stderr, usage) }
+		flags.Parse(flag.Args()[1:])
+		if flags.NArg() != 2 {
+			fmt.Fprintln(os.Stderr, "ERROR: expected two binaries.")
+			os.Exit(1)
+		}
+		incompatibilities, err := compat.Check(flags.Arg(0), flags.Arg(1))
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		if len(incompatibilities) == 0 {
+			fmt.Println("The binaries are compatible.")
+			return
+		}
+		for _, i := range incompatibilities {
+			fmt.Println(i)
+		}
+		os.Exit(1)
+
 	case "single", "multi", "ssh":
 		os.Args = os.Args[1:]
 		tool.Run("weaver "+flag.Arg(0), internals[flag.Arg(0)])
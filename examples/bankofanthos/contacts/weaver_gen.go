@@ -31,7 +31,7 @@ func init() {
 		ReflectStubFn: func(caller func(string, context.Context, []any, []any) error) any {
 			return t_reflect_stub{caller: caller}
 		},
-		RefData: "",
+		RefData: "⟦5d02b2f0:wEaVeRfInGeRpRiNt:github.com/ServiceWeaver/weaver/examples/bankofanthos/contacts/T→AddContact:0b68743e3d3f8d6e⟧\n⟦78a818d9:wEaVeRfInGeRpRiNt:github.com/ServiceWeaver/weaver/examples/bankofanthos/contacts/T→GetContacts:72435dc9aa2872a9⟧\n",
 	})
 }
 
@@ -220,10 +220,10 @@ func (s t_client_stub) GetContacts(ctx context.Context, a0 string) (r0 []Contact
 // Note that "weaver generate" will always generate the error message below.
 // Everything is okay. The error message is only relevant if you see it when
 // you run "go build" or "go run".
-var _ codegen.LatestVersion = codegen.Version[[0][24]struct{}](`
+var _ codegen.LatestVersion = codegen.Version[[0][25]struct{}](`
 
 ERROR: You generated this file with 'weaver generate' (devel) (codegen
-version v0.24.0). The generated code is incompatible with the version of the
+version v0.25.0). The generated code is incompatible with the version of the
 github.com/ServiceWeaver/weaver module that you're using. The weaver module
 version can be found in your go.mod file or by running the following command.
 
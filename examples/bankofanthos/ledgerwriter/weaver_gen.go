@@ -31,7 +31,7 @@ func init() {
 		ReflectStubFn: func(caller func(string, context.Context, []any, []any) error) any {
 			return t_reflect_stub{caller: caller}
 		},
-		RefData: "⟦7237a6f4:wEaVeReDgE:github.com/ServiceWeaver/weaver/examples/bankofanthos/ledgerwriter/T→github.com/ServiceWeaver/weaver/examples/bankofanthos/balancereader/T⟧\n",
+		RefData: "⟦7237a6f4:wEaVeReDgE:github.com/ServiceWeaver/weaver/examples/bankofanthos/ledgerwriter/T→github.com/ServiceWeaver/weaver/examples/bankofanthos/balancereader/T⟧\n⟦775edba6:wEaVeRfInGeRpRiNt:github.com/ServiceWeaver/weaver/examples/bankofanthos/ledgerwriter/T→AddTransaction:e41b03af8e268429⟧\n",
 	})
 }
 
@@ -137,10 +137,10 @@ func (s t_client_stub) AddTransaction(ctx context.Context, a0 string, a1 string,
 // Note that "weaver generate" will always generate the error message below.
 // Everything is okay. The error message is only relevant if you see it when
 // you run "go build" or "go run".
-var _ codegen.LatestVersion = codegen.Version[[0][24]struct{}](`
+var _ codegen.LatestVersion = codegen.Version[[0][25]struct{}](`
 
 ERROR: You generated this file with 'weaver generate' (devel) (codegen
-version v0.24.0). The generated code is incompatible with the version of the
+version v0.25.0). The generated code is incompatible with the version of the
 github.com/ServiceWeaver/weaver module that you're using. The weaver module
 version can be found in your go.mod file or by running the following command.
 
@@ -32,7 +32,7 @@ func init() {
 		ReflectStubFn: func(caller func(string, context.Context, []any, []any) error) any {
 			return imageScaler_reflect_stub{caller: caller}
 		},
-		RefData: "",
+		RefData: "⟦893f855c:wEaVeRfInGeRpRiNt:github.com/ServiceWeaver/weaver/examples/chat/ImageScaler→Scale:f3ad9f2a67664ec4⟧\n",
 	})
 	codegen.Register(codegen.Registration{
 		Name:  "github.com/ServiceWeaver/weaver/examples/chat/LocalCache",
@@ -50,7 +50,7 @@ func init() {
 		ReflectStubFn: func(caller func(string, context.Context, []any, []any) error) any {
 			return localCache_reflect_stub{caller: caller}
 		},
-		RefData: "",
+		RefData: "⟦859fb0ff:wEaVeRfInGeRpRiNt:github.com/ServiceWeaver/weaver/examples/chat/LocalCache→Get:e6dcbba69a5fafac⟧\n⟦e3f14187:wEaVeRfInGeRpRiNt:github.com/ServiceWeaver/weaver/examples/chat/LocalCache→Put:2405d96ec0681160⟧\n",
 	})
 	codegen.Register(codegen.Registration{
 		Name:      "github.com/ServiceWeaver/weaver/Main",
@@ -86,7 +86,7 @@ func init() {
 		ReflectStubFn: func(caller func(string, context.Context, []any, []any) error) any {
 			return sQLStore_reflect_stub{caller: caller}
 		},
-		RefData: "",
+		RefData: "⟦d2569a9b:wEaVeRfInGeRpRiNt:github.com/ServiceWeaver/weaver/examples/chat/SQLStore→CreatePost:aec613c663d40bc0⟧\n⟦df1f27aa:wEaVeRfInGeRpRiNt:github.com/ServiceWeaver/weaver/examples/chat/SQLStore→CreateThread:256d61c64abded19⟧\n⟦5bae73b8:wEaVeRfInGeRpRiNt:github.com/ServiceWeaver/weaver/examples/chat/SQLStore→GetFeed:0a14ab08c26b0a77⟧\n⟦279972b4:wEaVeRfInGeRpRiNt:github.com/ServiceWeaver/weaver/examples/chat/SQLStore→GetImage:7d123cfb4466b6c6⟧\n",
 	})
 }
 
@@ -120,7 +120,7 @@ func (s imageScaler_local_stub) Scale(ctx context.Context, a0 []byte, a1 int, a2
 	span := trace.SpanFromContext(ctx)
 	if span.SpanContext().IsValid() {
 		// Create a child span for this method.
-		ctx, span = s.tracer.Start(ctx, "main.ImageScaler.Scale", trace.WithSpanKind(trace.SpanKindInternal))
+		ctx, span = s.tracer.Start(ctx, "chat.ImageScaler.Scale", trace.WithSpanKind(trace.SpanKindInternal))
 		defer func() {
 			if err != nil {
 				span.RecordError(err)
@@ -150,7 +150,7 @@ func (s localCache_local_stub) Get(ctx context.Context, a0 string) (r0 string, e
 	span := trace.SpanFromContext(ctx)
 	if span.SpanContext().IsValid() {
 		// Create a child span for this method.
-		ctx, span = s.tracer.Start(ctx, "main.LocalCache.Get", trace.WithSpanKind(trace.SpanKindInternal))
+		ctx, span = s.tracer.Start(ctx, "chat.LocalCache.Get", trace.WithSpanKind(trace.SpanKindInternal))
 		defer func() {
 			if err != nil {
 				span.RecordError(err)
@@ -170,7 +170,7 @@ func (s localCache_local_stub) Put(ctx context.Context, a0 string, a1 string) (e
 	span := trace.SpanFromContext(ctx)
 	if span.SpanContext().IsValid() {
 		// Create a child span for this method.
-		ctx, span = s.tracer.Start(ctx, "main.LocalCache.Put", trace.WithSpanKind(trace.SpanKindInternal))
+		ctx, span = s.tracer.Start(ctx, "chat.LocalCache.Put", trace.WithSpanKind(trace.SpanKindInternal))
 		defer func() {
 			if err != nil {
 				span.RecordError(err)
@@ -210,7 +210,7 @@ func (s sQLStore_local_stub) CreatePost(ctx context.Context, a0 string, a1 time.
 	span := trace.SpanFromContext(ctx)
 	if span.SpanContext().IsValid() {
 		// Create a child span for this method.
-		ctx, span = s.tracer.Start(ctx, "main.SQLStore.CreatePost", trace.WithSpanKind(trace.SpanKindInternal))
+		ctx, span = s.tracer.Start(ctx, "chat.SQLStore.CreatePost", trace.WithSpanKind(trace.SpanKindInternal))
 		defer func() {
 			if err != nil {
 				span.RecordError(err)
@@ -230,7 +230,7 @@ func (s sQLStore_local_stub) CreateThread(ctx context.Context, a0 string, a1 tim
 	span := trace.SpanFromContext(ctx)
 	if span.SpanContext().IsValid() {
 		// Create a child span for this method.
-		ctx, span = s.tracer.Start(ctx, "main.SQLStore.CreateThread", trace.WithSpanKind(trace.SpanKindInternal))
+		ctx, span = s.tracer.Start(ctx, "chat.SQLStore.CreateThread", trace.WithSpanKind(trace.SpanKindInternal))
 		defer func() {
 			if err != nil {
 				span.RecordError(err)
@@ -250,7 +250,7 @@ func (s sQLStore_local_stub) GetFeed(ctx context.Context, a0 string) (r0 []Threa
 	span := trace.SpanFromContext(ctx)
 	if span.SpanContext().IsValid() {
 		// Create a child span for this method.
-		ctx, span = s.tracer.Start(ctx, "main.SQLStore.GetFeed", trace.WithSpanKind(trace.SpanKindInternal))
+		ctx, span = s.tracer.Start(ctx, "chat.SQLStore.GetFeed", trace.WithSpanKind(trace.SpanKindInternal))
 		defer func() {
 			if err != nil {
 				span.RecordError(err)
@@ -270,7 +270,7 @@ func (s sQLStore_local_stub) GetImage(ctx context.Context, a0 string, a1 ImageID
 	span := trace.SpanFromContext(ctx)
 	if span.SpanContext().IsValid() {
 		// Create a child span for this method.
-		ctx, span = s.tracer.Start(ctx, "main.SQLStore.GetImage", trace.WithSpanKind(trace.SpanKindInternal))
+		ctx, span = s.tracer.Start(ctx, "chat.SQLStore.GetImage", trace.WithSpanKind(trace.SpanKindInternal))
 		defer func() {
 			if err != nil {
 				span.RecordError(err)
@@ -302,7 +302,7 @@ func (s imageScaler_client_stub) Scale(ctx context.Context, a0 []byte, a1 int, a
 	span := trace.SpanFromContext(ctx)
 	if span.SpanContext().IsValid() {
 		// Create a child span for this method.
-		ctx, span = s.stub.Tracer().Start(ctx, "main.ImageScaler.Scale", trace.WithSpanKind(trace.SpanKindClient))
+		ctx, span = s.stub.Tracer().Start(ctx, "chat.ImageScaler.Scale", trace.WithSpanKind(trace.SpanKindClient))
 	}
 
 	defer func() {
@@ -371,7 +371,7 @@ func (s localCache_client_stub) Get(ctx context.Context, a0 string) (r0 string,
 	span := trace.SpanFromContext(ctx)
 	if span.SpanContext().IsValid() {
 		// Create a child span for this method.
-		ctx, span = s.stub.Tracer().Start(ctx, "main.LocalCache.Get", trace.WithSpanKind(trace.SpanKindClient))
+		ctx, span = s.stub.Tracer().Start(ctx, "chat.LocalCache.Get", trace.WithSpanKind(trace.SpanKindClient))
 	}
 
 	defer func() {
@@ -427,7 +427,7 @@ func (s localCache_client_stub) Put(ctx context.Context, a0 string, a1 string) (
 	span := trace.SpanFromContext(ctx)
 	if span.SpanContext().IsValid() {
 		// Create a child span for this method.
-		ctx, span = s.stub.Tracer().Start(ctx, "main.LocalCache.Put", trace.WithSpanKind(trace.SpanKindClient))
+		ctx, span = s.stub.Tracer().Start(ctx, "chat.LocalCache.Put", trace.WithSpanKind(trace.SpanKindClient))
 	}
 
 	defer func() {
@@ -502,7 +502,7 @@ func (s sQLStore_client_stub) CreatePost(ctx context.Context, a0 string, a1 time
 	span := trace.SpanFromContext(ctx)
 	if span.SpanContext().IsValid() {
 		// Create a child span for this method.
-		ctx, span = s.stub.Tracer().Start(ctx, "main.SQLStore.CreatePost", trace.WithSpanKind(trace.SpanKindClient))
+		ctx, span = s.stub.Tracer().Start(ctx, "chat.SQLStore.CreatePost", trace.WithSpanKind(trace.SpanKindClient))
 	}
 
 	defer func() {
@@ -555,7 +555,7 @@ func (s sQLStore_client_stub) CreateThread(ctx context.Context, a0 string, a1 ti
 	span := trace.SpanFromContext(ctx)
 	if span.SpanContext().IsValid() {
 		// Create a child span for this method.
-		ctx, span = s.stub.Tracer().Start(ctx, "main.SQLStore.CreateThread", trace.WithSpanKind(trace.SpanKindClient))
+		ctx, span = s.stub.Tracer().Start(ctx, "chat.SQLStore.CreateThread", trace.WithSpanKind(trace.SpanKindClient))
 	}
 
 	defer func() {
@@ -610,7 +610,7 @@ func (s sQLStore_client_stub) GetFeed(ctx context.Context, a0 string) (r0 []Thre
 	span := trace.SpanFromContext(ctx)
 	if span.SpanContext().IsValid() {
 		// Create a child span for this method.
-		ctx, span = s.stub.Tracer().Start(ctx, "main.SQLStore.GetFeed", trace.WithSpanKind(trace.SpanKindClient))
+		ctx, span = s.stub.Tracer().Start(ctx, "chat.SQLStore.GetFeed", trace.WithSpanKind(trace.SpanKindClient))
 	}
 
 	defer func() {
@@ -666,7 +666,7 @@ func (s sQLStore_client_stub) GetImage(ctx context.Context, a0 string, a1 ImageI
 	span := trace.SpanFromContext(ctx)
 	if span.SpanContext().IsValid() {
 		// Create a child span for this method.
-		ctx, span = s.stub.Tracer().Start(ctx, "main.SQLStore.GetImage", trace.WithSpanKind(trace.SpanKindClient))
+		ctx, span = s.stub.Tracer().Start(ctx, "chat.SQLStore.GetImage", trace.WithSpanKind(trace.SpanKindClient))
 	}
 
 	defer func() {
@@ -718,10 +718,10 @@ func (s sQLStore_client_stub) GetImage(ctx context.Context, a0 string, a1 ImageI
 // Note that "weaver generate" will always generate the error message below.
 // Everything is okay. The error message is only relevant if you see it when
 // you run "go build" or "go run".
-var _ codegen.LatestVersion = codegen.Version[[0][24]struct{}](`
+var _ codegen.LatestVersion = codegen.Version[[0][25]struct{}](`
 
 ERROR: You generated this file with 'weaver generate' (devel) (codegen
-version v0.24.0). The generated code is incompatible with the version of the
+version v0.25.0). The generated code is incompatible with the version of the
 github.com/ServiceWeaver/weaver module that you're using. The weaver module
 version can be found in your go.mod file or by running the following command.
 
@@ -30,7 +30,7 @@ func init() {
 		ReflectStubFn: func(caller func(string, context.Context, []any, []any) error) any {
 			return even_reflect_stub{caller: caller}
 		},
-		RefData: "",
+		RefData: "⟦0e9a2fd1:wEaVeRfInGeRpRiNt:github.com/ServiceWeaver/weaver/examples/collatz/Even→Do:c9de9364ec133bcd⟧\n",
 	})
 	codegen.Register(codegen.Registration{
 		Name:      "github.com/ServiceWeaver/weaver/Main",
@@ -65,7 +65,7 @@ func init() {
 		ReflectStubFn: func(caller func(string, context.Context, []any, []any) error) any {
 			return odd_reflect_stub{caller: caller}
 		},
-		RefData: "",
+		RefData: "⟦f071cc8b:wEaVeRfInGeRpRiNt:github.com/ServiceWeaver/weaver/examples/collatz/Odd→Do:c9de9364ec133bcd⟧\n",
 	})
 }
 
@@ -97,7 +97,7 @@ func (s even_local_stub) Do(ctx context.Context, a0 int) (r0 int, err error) {
 	span := trace.SpanFromContext(ctx)
 	if span.SpanContext().IsValid() {
 		// Create a child span for this method.
-		ctx, span = s.tracer.Start(ctx, "main.Even.Do", trace.WithSpanKind(trace.SpanKindInternal))
+		ctx, span = s.tracer.Start(ctx, "collatz.Even.Do", trace.WithSpanKind(trace.SpanKindInternal))
 		defer func() {
 			if err != nil {
 				span.RecordError(err)
@@ -134,7 +134,7 @@ func (s odd_local_stub) Do(ctx context.Context, a0 int) (r0 int, err error) {
 	span := trace.SpanFromContext(ctx)
 	if span.SpanContext().IsValid() {
 		// Create a child span for this method.
-		ctx, span = s.tracer.Start(ctx, "main.Odd.Do", trace.WithSpanKind(trace.SpanKindInternal))
+		ctx, span = s.tracer.Start(ctx, "collatz.Odd.Do", trace.WithSpanKind(trace.SpanKindInternal))
 		defer func() {
 			if err != nil {
 				span.RecordError(err)
@@ -166,7 +166,7 @@ func (s even_client_stub) Do(ctx context.Context, a0 int) (r0 int, err error) {
 	span := trace.SpanFromContext(ctx)
 	if span.SpanContext().IsValid() {
 		// Create a child span for this method.
-		ctx, span = s.stub.Tracer().Start(ctx, "main.Even.Do", trace.WithSpanKind(trace.SpanKindClient))
+		ctx, span = s.stub.Tracer().Start(ctx, "collatz.Even.Do", trace.WithSpanKind(trace.SpanKindClient))
 	}
 
 	defer func() {
@@ -237,7 +237,7 @@ func (s odd_client_stub) Do(ctx context.Context, a0 int) (r0 int, err error) {
 	span := trace.SpanFromContext(ctx)
 	if span.SpanContext().IsValid() {
 		// Create a child span for this method.
-		ctx, span = s.stub.Tracer().Start(ctx, "main.Odd.Do", trace.WithSpanKind(trace.SpanKindClient))
+		ctx, span = s.stub.Tracer().Start(ctx, "collatz.Odd.Do", trace.WithSpanKind(trace.SpanKindClient))
 	}
 
 	defer func() {
@@ -287,10 +287,10 @@ func (s odd_client_stub) Do(ctx context.Context, a0 int) (r0 int, err error) {
 // Note that "weaver generate" will always generate the error message below.
 // Everything is okay. The error message is only relevant if you see it when
 // you run "go build" or "go run".
-var _ codegen.LatestVersion = codegen.Version[[0][24]struct{}](`
+var _ codegen.LatestVersion = codegen.Version[[0][25]struct{}](`
 
 ERROR: You generated this file with 'weaver generate' (devel) (codegen
-version v0.24.0). The generated code is incompatible with the version of the
+version v0.25.0). The generated code is incompatible with the version of the
 github.com/ServiceWeaver/weaver module that you're using. The weaver module
 version can be found in your go.mod file or by running the following command.
 
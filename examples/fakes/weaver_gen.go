@@ -30,7 +30,7 @@ func init() {
 		ReflectStubFn: func(caller func(string, context.Context, []any, []any) error) any {
 			return clock_reflect_stub{caller: caller}
 		},
-		RefData: "",
+		RefData: "⟦8dd07140:wEaVeRfInGeRpRiNt:github.com/ServiceWeaver/weaver/examples/fakes/Clock→UnixMicro:26fbda458a50a5ae⟧\n",
 	})
 }
 
@@ -131,10 +131,10 @@ func (s clock_client_stub) UnixMicro(ctx context.Context) (r0 int64, err error)
 // Note that "weaver generate" will always generate the error message below.
 // Everything is okay. The error message is only relevant if you see it when
 // you run "go build" or "go run".
-var _ codegen.LatestVersion = codegen.Version[[0][24]struct{}](`
+var _ codegen.LatestVersion = codegen.Version[[0][25]struct{}](`
 
 ERROR: You generated this file with 'weaver generate' (devel) (codegen
-version v0.24.0). The generated code is incompatible with the version of the
+version v0.25.0). The generated code is incompatible with the version of the
 github.com/ServiceWeaver/weaver module that you're using. The weaver module
 version can be found in your go.mod file or by running the following command.
 
@@ -47,7 +47,7 @@ func init() {
 		ReflectStubFn: func(caller func(string, context.Context, []any, []any) error) any {
 			return reverser_reflect_stub{caller: caller}
 		},
-		RefData: "",
+		RefData: "⟦6ea422f9:wEaVeRfInGeRpRiNt:github.com/ServiceWeaver/weaver/examples/hello/Reverser→Reverse:e6dcbba69a5fafac⟧\n",
 	})
 }
 
@@ -85,7 +85,7 @@ func (s reverser_local_stub) Reverse(ctx context.Context, a0 string) (r0 string,
 	span := trace.SpanFromContext(ctx)
 	if span.SpanContext().IsValid() {
 		// Create a child span for this method.
-		ctx, span = s.tracer.Start(ctx, "main.Reverser.Reverse", trace.WithSpanKind(trace.SpanKindInternal))
+		ctx, span = s.tracer.Start(ctx, "hello.Reverser.Reverse", trace.WithSpanKind(trace.SpanKindInternal))
 		defer func() {
 			if err != nil {
 				span.RecordError(err)
@@ -124,7 +124,7 @@ func (s reverser_client_stub) Reverse(ctx context.Context, a0 string) (r0 string
 	span := trace.SpanFromContext(ctx)
 	if span.SpanContext().IsValid() {
 		// Create a child span for this method.
-		ctx, span = s.stub.Tracer().Start(ctx, "main.Reverser.Reverse", trace.WithSpanKind(trace.SpanKindClient))
+		ctx, span = s.stub.Tracer().Start(ctx, "hello.Reverser.Reverse", trace.WithSpanKind(trace.SpanKindClient))
 	}
 
 	defer func() {
@@ -174,10 +174,10 @@ func (s reverser_client_stub) Reverse(ctx context.Context, a0 string) (r0 string
 // Note that "weaver generate" will always generate the error message below.
 // Everything is okay. The error message is only relevant if you see it when
 // you run "go build" or "go run".
-var _ codegen.LatestVersion = codegen.Version[[0][24]struct{}](`
+var _ codegen.LatestVersion = codegen.Version[[0][25]struct{}](`
 
 ERROR: You generated this file with 'weaver generate' (devel) (codegen
-version v0.24.0). The generated code is incompatible with the version of the
+version v0.25.0). The generated code is incompatible with the version of the
 github.com/ServiceWeaver/weaver module that you're using. The weaver module
 version can be found in your go.mod file or by running the following command.
 
@@ -31,7 +31,7 @@ func init() {
 		ReflectStubFn: func(caller func(string, context.Context, []any, []any) error) any {
 			return ping1_reflect_stub{caller: caller}
 		},
-		RefData: "⟦544443c5:wEaVeReDgE:github.com/ServiceWeaver/weaver/internal/benchmarks/Ping1→github.com/ServiceWeaver/weaver/internal/benchmarks/Ping2⟧\n",
+		RefData: "⟦544443c5:wEaVeReDgE:github.com/ServiceWeaver/weaver/internal/benchmarks/Ping1→github.com/ServiceWeaver/weaver/internal/benchmarks/Ping2⟧\n⟦a55a6c81:wEaVeRfInGeRpRiNt:github.com/ServiceWeaver/weaver/internal/benchmarks/Ping1→PingC:a47e9f133803edc2⟧\n⟦699574dc:wEaVeRfInGeRpRiNt:github.com/ServiceWeaver/weaver/internal/benchmarks/Ping1→PingS:209355c6649a0309⟧\n",
 	})
 	codegen.Register(codegen.Registration{
 		Name:  "github.com/ServiceWeaver/weaver/internal/benchmarks/Ping10",
@@ -49,7 +49,7 @@ func init() {
 		ReflectStubFn: func(caller func(string, context.Context, []any, []any) error) any {
 			return ping10_reflect_stub{caller: caller}
 		},
-		RefData: "",
+		RefData: "⟦716ffd41:wEaVeRfInGeRpRiNt:github.com/ServiceWeaver/weaver/internal/benchmarks/Ping10→PingC:a47e9f133803edc2⟧\n⟦b8309eb9:wEaVeRfInGeRpRiNt:github.com/ServiceWeaver/weaver/internal/benchmarks/Ping10→PingS:209355c6649a0309⟧\n",
 	})
 	codegen.Register(codegen.Registration{
 		Name:  "github.com/ServiceWeaver/weaver/internal/benchmarks/Ping2",
@@ -67,7 +67,7 @@ func init() {
 		ReflectStubFn: func(caller func(string, context.Context, []any, []any) error) any {
 			return ping2_reflect_stub{caller: caller}
 		},
-		RefData: "⟦b42b173c:wEaVeReDgE:github.com/ServiceWeaver/weaver/internal/benchmarks/Ping2→github.com/ServiceWeaver/weaver/internal/benchmarks/Ping3⟧\n",
+		RefData: "⟦b42b173c:wEaVeReDgE:github.com/ServiceWeaver/weaver/internal/benchmarks/Ping2→github.com/ServiceWeaver/weaver/internal/benchmarks/Ping3⟧\n⟦3fae5785:wEaVeRfInGeRpRiNt:github.com/ServiceWeaver/weaver/internal/benchmarks/Ping2→PingC:a47e9f133803edc2⟧\n⟦ca743855:wEaVeRfInGeRpRiNt:github.com/ServiceWeaver/weaver/internal/benchmarks/Ping2→PingS:209355c6649a0309⟧\n",
 	})
 	codegen.Register(codegen.Registration{
 		Name:  "github.com/ServiceWeaver/weaver/internal/benchmarks/Ping3",
@@ -85,7 +85,7 @@ func init() {
 		ReflectStubFn: func(caller func(string, context.Context, []any, []any) error) any {
 			return ping3_reflect_stub{caller: caller}
 		},
-		RefData: "⟦8c498b47:wEaVeReDgE:github.com/ServiceWeaver/weaver/internal/benchmarks/Ping3→github.com/ServiceWeaver/weaver/internal/benchmarks/Ping4⟧\n",
+		RefData: "⟦8c498b47:wEaVeReDgE:github.com/ServiceWeaver/weaver/internal/benchmarks/Ping3→github.com/ServiceWeaver/weaver/internal/benchmarks/Ping4⟧\n⟦f8758b8f:wEaVeRfInGeRpRiNt:github.com/ServiceWeaver/weaver/internal/benchmarks/Ping3→PingC:a47e9f133803edc2⟧\n⟦f5d905b0:wEaVeRfInGeRpRiNt:github.com/ServiceWeaver/weaver/internal/benchmarks/Ping3→PingS:209355c6649a0309⟧\n",
 	})
 	codegen.Register(codegen.Registration{
 		Name:  "github.com/ServiceWeaver/weaver/internal/benchmarks/Ping4",
@@ -103,7 +103,7 @@ func init() {
 		ReflectStubFn: func(caller func(string, context.Context, []any, []any) error) any {
 			return ping4_reflect_stub{caller: caller}
 		},
-		RefData: "⟦90669915:wEaVeReDgE:github.com/ServiceWeaver/weaver/internal/benchmarks/Ping4→github.com/ServiceWeaver/weaver/internal/benchmarks/Ping5⟧\n",
+		RefData: "⟦90669915:wEaVeReDgE:github.com/ServiceWeaver/weaver/internal/benchmarks/Ping4→github.com/ServiceWeaver/weaver/internal/benchmarks/Ping5⟧\n⟦23701a93:wEaVeRfInGeRpRiNt:github.com/ServiceWeaver/weaver/internal/benchmarks/Ping4→PingC:a47e9f133803edc2⟧\n⟦4706b3f0:wEaVeRfInGeRpRiNt:github.com/ServiceWeaver/weaver/internal/benchmarks/Ping4→PingS:209355c6649a0309⟧\n",
 	})
 	codegen.Register(codegen.Registration{
 		Name:  "github.com/ServiceWeaver/weaver/internal/benchmarks/Ping5",
@@ -121,7 +121,7 @@ func init() {
 		ReflectStubFn: func(caller func(string, context.Context, []any, []any) error) any {
 			return ping5_reflect_stub{caller: caller}
 		},
-		RefData: "⟦a38d1914:wEaVeReDgE:github.com/ServiceWeaver/weaver/internal/benchmarks/Ping5→github.com/ServiceWeaver/weaver/internal/benchmarks/Ping6⟧\n",
+		RefData: "⟦a38d1914:wEaVeReDgE:github.com/ServiceWeaver/weaver/internal/benchmarks/Ping5→github.com/ServiceWeaver/weaver/internal/benchmarks/Ping6⟧\n⟦ce8589b0:wEaVeRfInGeRpRiNt:github.com/ServiceWeaver/weaver/internal/benchmarks/Ping5→PingC:a47e9f133803edc2⟧\n⟦22bc1ce4:wEaVeRfInGeRpRiNt:github.com/ServiceWeaver/weaver/internal/benchmarks/Ping5→PingS:209355c6649a0309⟧\n",
 	})
 	codegen.Register(codegen.Registration{
 		Name:  "github.com/ServiceWeaver/weaver/internal/benchmarks/Ping6",
@@ -139,7 +139,7 @@ func init() {
 		ReflectStubFn: func(caller func(string, context.Context, []any, []any) error) any {
 			return ping6_reflect_stub{caller: caller}
 		},
-		RefData: "⟦ebf8b6d3:wEaVeReDgE:github.com/ServiceWeaver/weaver/internal/benchmarks/Ping6→github.com/ServiceWeaver/weaver/internal/benchmarks/Ping7⟧\n",
+		RefData: "⟦ebf8b6d3:wEaVeReDgE:github.com/ServiceWeaver/weaver/internal/benchmarks/Ping6→github.com/ServiceWeaver/weaver/internal/benchmarks/Ping7⟧\n⟦f2b7e029:wEaVeRfInGeRpRiNt:github.com/ServiceWeaver/weaver/internal/benchmarks/Ping6→PingC:a47e9f133803edc2⟧\n⟦9dae3914:wEaVeRfInGeRpRiNt:github.com/ServiceWeaver/weaver/internal/benchmarks/Ping6→PingS:209355c6649a0309⟧\n",
 	})
 	codegen.Register(codegen.Registration{
 		Name:  "github.com/ServiceWeaver/weaver/internal/benchmarks/Ping7",
@@ -157,7 +157,7 @@ func init() {
 		ReflectStubFn: func(caller func(string, context.Context, []any, []any) error) any {
 			return ping7_reflect_stub{caller: caller}
 		},
-		RefData: "⟦88d68418:wEaVeReDgE:github.com/ServiceWeaver/weaver/internal/benchmarks/Ping7→github.com/ServiceWeaver/weaver/internal/benchmarks/Ping8⟧\n",
+		RefData: "⟦88d68418:wEaVeReDgE:github.com/ServiceWeaver/weaver/internal/benchmarks/Ping7→github.com/ServiceWeaver/weaver/internal/benchmarks/Ping8⟧\n⟦cd08bb7b:wEaVeRfInGeRpRiNt:github.com/ServiceWeaver/weaver/internal/benchmarks/Ping7→PingC:a47e9f133803edc2⟧\n⟦68677471:wEaVeRfInGeRpRiNt:github.com/ServiceWeaver/weaver/internal/benchmarks/Ping7→PingS:209355c6649a0309⟧\n",
 	})
 	codegen.Register(codegen.Registration{
 		Name:  "github.com/ServiceWeaver/weaver/internal/benchmarks/Ping8",
@@ -175,7 +175,7 @@ func init() {
 		ReflectStubFn: func(caller func(string, context.Context, []any, []any) error) any {
 			return ping8_reflect_stub{caller: caller}
 		},
-		RefData: "⟦ed98271d:wEaVeReDgE:github.com/ServiceWeaver/weaver/internal/benchmarks/Ping8→github.com/ServiceWeaver/weaver/internal/benchmarks/Ping9⟧\n",
+		RefData: "⟦ed98271d:wEaVeReDgE:github.com/ServiceWeaver/weaver/internal/benchmarks/Ping8→github.com/ServiceWeaver/weaver/internal/benchmarks/Ping9⟧\n⟦9880d6da:wEaVeRfInGeRpRiNt:github.com/ServiceWeaver/weaver/internal/benchmarks/Ping8→PingC:a47e9f133803edc2⟧\n⟦50da3614:wEaVeRfInGeRpRiNt:github.com/ServiceWeaver/weaver/internal/benchmarks/Ping8→PingS:209355c6649a0309⟧\n",
 	})
 	codegen.Register(codegen.Registration{
 		Name:  "github.com/ServiceWeaver/weaver/internal/benchmarks/Ping9",
@@ -193,7 +193,7 @@ func init() {
 		ReflectStubFn: func(caller func(string, context.Context, []any, []any) error) any {
 			return ping9_reflect_stub{caller: caller}
 		},
-		RefData: "⟦5ceb96a7:wEaVeReDgE:github.com/ServiceWeaver/weaver/internal/benchmarks/Ping9→github.com/ServiceWeaver/weaver/internal/benchmarks/Ping10⟧\n",
+		RefData: "⟦5ceb96a7:wEaVeReDgE:github.com/ServiceWeaver/weaver/internal/benchmarks/Ping9→github.com/ServiceWeaver/weaver/internal/benchmarks/Ping10⟧\n⟦d8370a4c:wEaVeRfInGeRpRiNt:github.com/ServiceWeaver/weaver/internal/benchmarks/Ping9→PingC:a47e9f133803edc2⟧\n⟦3cad9e07:wEaVeRfInGeRpRiNt:github.com/ServiceWeaver/weaver/internal/benchmarks/Ping9→PingS:209355c6649a0309⟧\n",
 	})
 }
 
@@ -1918,10 +1918,10 @@ func (s ping9_client_stub) PingS(ctx context.Context, a0 payloadS, a1 int) (r0 p
 // Note that "weaver generate" will always generate the error message below.
 // Everything is okay. The error message is only relevant if you see it when
 // you run "go build" or "go run".
-var _ codegen.LatestVersion = codegen.Version[[0][24]struct{}](`
+var _ codegen.LatestVersion = codegen.Version[[0][25]struct{}](`
 
 ERROR: You generated this file with 'weaver generate' (devel) (codegen
-version v0.24.0). The generated code is incompatible with the version of the
+version v0.25.0). The generated code is incompatible with the version of the
 github.com/ServiceWeaver/weaver module that you're using. The weaver module
 version can be found in your go.mod file or by running the following command.
 
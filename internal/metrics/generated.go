@@ -21,6 +21,10 @@ const (
 	MethodLatenciesName    = "serviceweaver_method_latency_micros"
 	MethodBytesRequestName = "serviceweaver_method_bytes_request"
 	MethodBytesReplyName   = "serviceweaver_method_bytes_reply"
+	MethodRejectionsName   = "serviceweaver_method_rejected_count"
+
+	CompressionRawBytesName        = "serviceweaver_compression_raw_bytes"
+	CompressionCompressedBytesName = "serviceweaver_compression_compressed_bytes"
 )
 
 // GeneratedBuckets provides rounded bucket boundaries for histograms
@@ -0,0 +1,126 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package net
+
+import (
+	"context"
+	"net"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ServiceWeaver/weaver/internal/net/call"
+	"github.com/ServiceWeaver/weaver/runtime/logging"
+)
+
+// handlerListener is a call.Listener that serves the provided handlers.
+type handlerListener struct {
+	net.Listener
+	handlers *call.HandlerMap
+}
+
+var _ call.Listener = &handlerListener{}
+
+func (l handlerListener) Accept() (net.Conn, *call.HandlerMap, error) {
+	conn, err := l.Listener.Accept()
+	return conn, l.handlers, err
+}
+
+// serveEcho starts an echo RPC server on a local TCP port that delays every
+// call by the provided duration. The server is shut down when the provided
+// benchmark ends.
+func serveEcho(b *testing.B, delay time.Duration) call.Endpoint {
+	ctx, cancel := context.WithCancel(context.Background())
+	b.Cleanup(cancel)
+
+	lis, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		b.Fatal(err)
+	}
+	hm := call.NewHandlerMap()
+	hm.Set("component", "echo", func(_ context.Context, args []byte) ([]byte, error) {
+		time.Sleep(delay)
+		return args, nil
+	})
+	go func() {
+		opts := call.ServerOptions{Logger: logging.NewTestSlogger(b, testing.Verbose())}
+		switch err := call.Serve(ctx, handlerListener{lis, hm}, opts); err {
+		case nil, ctx.Err():
+		case err:
+			b.Log(err)
+		}
+	}()
+	return call.TCP(lis.Addr().String())
+}
+
+// BenchmarkBalancers benchmarks echo calls, made by concurrent clients, to
+// three replicas, one of which is much slower than the others. It reports the
+// median and tail latency of the calls for every balancer.
+func BenchmarkBalancers(b *testing.B) {
+	for _, kind := range []string{"round_robin", "p2c", "least_outstanding"} {
+		b.Run(kind, func(b *testing.B) {
+			resolver := call.NewConstantResolver(
+				serveEcho(b, 100*time.Microsecond),
+				serveEcho(b, 100*time.Microsecond),
+				serveEcho(b, 5*time.Millisecond),
+			)
+			balancer, err := call.NewBalancer(kind)
+			if err != nil {
+				b.Fatal(err)
+			}
+			opts := call.ClientOptions{
+				Balancer: balancer,
+				Logger:   logging.NewTestSlogger(b, testing.Verbose()),
+			}
+			client, err := call.Connect(context.Background(), resolver, opts)
+			if err != nil {
+				b.Fatal(err)
+			}
+			defer client.Close()
+
+			var mu sync.Mutex
+			var latencies []time.Duration
+			ctx := context.Background()
+			msg := []byte("hello")
+			b.ResetTimer()
+			b.RunParallel(func(pb *testing.PB) {
+				var local []time.Duration
+				for pb.Next() {
+					start := time.Now()
+					if _, err := echo(ctx, client, msg); err != nil {
+						b.Error(err)
+						return
+					}
+					local = append(local, time.Since(start))
+				}
+				mu.Lock()
+				defer mu.Unlock()
+				latencies = append(latencies, local...)
+			})
+			b.StopTimer()
+
+			if len(latencies) == 0 {
+				return
+			}
+			sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+			percentile := func(p float64) float64 {
+				return float64(latencies[int(p*float64(len(latencies)-1))].Microseconds())
+			}
+			b.ReportMetric(percentile(0.5), "p50-µs")
+			b.ReportMetric(percentile(0.99), "p99-µs")
+		})
+	}
+}
@@ -14,6 +14,12 @@
 
 package call
 
+import (
+	"fmt"
+	"math/rand"
+	"time"
+)
+
 // ReplicaConnection is a connection to a single replica. A single Connection
 // may consist of many ReplicaConnections (typically one per replica).
 type ReplicaConnection interface {
@@ -22,13 +28,36 @@ type ReplicaConnection interface {
 	Address() string
 }
 
+// ReplicaLoad is the load of a replica, as observed by a client.
+type ReplicaLoad struct {
+	// Outstanding is the number of in-progress calls to the replica,
+	// including streaming calls.
+	Outstanding int
+
+	// Latency is a moving average of the latencies of recent calls to the
+	// replica, or zero if no call has finished yet.
+	Latency time.Duration
+}
+
+// loadReporter is implemented by ReplicaConnections that report their load.
+// The ReplicaConnections passed to a Balancer by a Connection implement
+// loadReporter, and Load may be called from within Pick.
+type loadReporter interface {
+	Load() ReplicaLoad
+}
+
+// load returns the load of the provided connection. A connection that doesn't
+// report its load is considered idle.
+func load(c ReplicaConnection) ReplicaLoad {
+	if r, ok := c.(loadReporter); ok {
+		return r.Load()
+	}
+	return ReplicaLoad{}
+}
+
 // Balancer manages a set of ReplicaConnections and picks one of them per
 // call. A Balancer requires external synchronization (no concurrent calls
 // should be made to the same Balancer).
-//
-// TODO(mwhittaker): Right now, balancers have no load information about
-// endpoints. In the short term, we can at least add information about the
-// number of pending requests for every endpoint.
 type Balancer interface {
 	// Add adds a ReplicaConnection to the set of connections.
 	Add(ReplicaConnection)
@@ -37,8 +66,10 @@ type Balancer interface {
 	Remove(ReplicaConnection)
 
 	// Pick picks a ReplicaConnection from the set of connections.
-	// Pick returns _,false if no connections are available.
-	Pick(CallOptions) (ReplicaConnection, bool)
+	// Pick returns _,false if no connections are available. Pick should
+	// not pick the connection to the replica with address opts.Avoid, if
+	// set, unless there is no other connection.
+	Pick(opts CallOptions) (ReplicaConnection, bool)
 }
 
 // balancerFuncImpl is the implementation of the "functional" balancer
@@ -72,16 +103,134 @@ func RoundRobin() *roundRobin {
 	return &roundRobin{}
 }
 
-func (rr *roundRobin) Pick(CallOptions) (ReplicaConnection, bool) {
-	if len(rr.list) == 0 {
+func (rr *roundRobin) Pick(opts CallOptions) (ReplicaConnection, bool) {
+	n := len(rr.list)
+	if n == 0 {
 		return nil, false
 	}
-	if rr.next >= len(rr.list) {
-		rr.next = 0
+	for i := 0; ; i++ {
+		if rr.next >= n {
+			rr.next = 0
+		}
+		c := rr.list[rr.next]
+		rr.next += 1
+		if c.Address() != opts.Avoid || i == n-1 {
+			return c, true
+		}
+	}
+}
+
+type powerOfTwoChoices struct {
+	connList
+}
+
+var _ Balancer = &powerOfTwoChoices{}
+
+// PowerOfTwoChoices returns a balancer that picks two connections at random
+// and uses the less loaded of the two. The load of a connection is its number
+// of outstanding calls, weighted by its recent call latency, so that slow
+// replicas receive fewer calls.
+func PowerOfTwoChoices() *powerOfTwoChoices {
+	return &powerOfTwoChoices{}
+}
+
+func (p *powerOfTwoChoices) Pick(opts CallOptions) (ReplicaConnection, bool) {
+	list := avoid(p.list, opts.Avoid)
+	switch n := len(list); n {
+	case 0:
+		return nil, false
+	case 1:
+		return list[0], true
+	default:
+		i := rand.Intn(n)
+		j := rand.Intn(n - 1)
+		if j >= i {
+			j++
+		}
+		a, b := list[i], list[j]
+		if cost(load(b)) < cost(load(a)) {
+			return b, true
+		}
+		return a, true
+	}
+}
+
+// cost returns the expected time for a replica with the provided load to
+// serve one more call. Replicas without any latency information have zero
+// cost, so that new replicas are tried promptly.
+func cost(l ReplicaLoad) float64 {
+	return float64(l.Outstanding+1) * float64(l.Latency)
+}
+
+type leastOutstanding struct {
+	connList
+	next int
+}
+
+var _ Balancer = &leastOutstanding{}
+
+// LeastOutstanding returns a balancer that picks the connection with the
+// fewest outstanding calls. Ties are broken in favor of the connection with
+// the lowest recent call latency and then in a round-robin fashion.
+func LeastOutstanding() *leastOutstanding {
+	return &leastOutstanding{}
+}
+
+func (lo *leastOutstanding) Pick(opts CallOptions) (ReplicaConnection, bool) {
+	list := avoid(lo.list, opts.Avoid)
+	n := len(list)
+	if n == 0 {
+		return nil, false
+	}
+	if lo.next >= n {
+		lo.next = 0
+	}
+	best, bestLoad := list[lo.next], load(list[lo.next])
+	for i := 1; i < n; i++ {
+		c := list[(lo.next+i)%n]
+		l := load(c)
+		if l.Outstanding < bestLoad.Outstanding ||
+			(l.Outstanding == bestLoad.Outstanding && l.Latency < bestLoad.Latency) {
+			best, bestLoad = c, l
+		}
+	}
+	lo.next++
+	return best, true
+}
+
+// avoid returns the connections in list other than the connection to the
+// replica with address addr, unless addr is empty or there are no other
+// connections, in which case list is returned unchanged.
+func avoid(list []ReplicaConnection, addr string) []ReplicaConnection {
+	if addr == "" {
+		return list
+	}
+	others := make([]ReplicaConnection, 0, len(list))
+	for _, c := range list {
+		if c.Address() != addr {
+			others = append(others, c)
+		}
+	}
+	if len(others) == 0 {
+		return list
+	}
+	return others
+}
+
+// NewBalancer returns a new balancer of the provided kind: "round_robin"
+// (see RoundRobin), "p2c" (see PowerOfTwoChoices), or "least_outstanding"
+// (see LeastOutstanding). The empty kind is equivalent to "round_robin".
+func NewBalancer(kind string) (Balancer, error) {
+	switch kind {
+	case "", "round_robin":
+		return RoundRobin(), nil
+	case "p2c":
+		return PowerOfTwoChoices(), nil
+	case "least_outstanding":
+		return LeastOutstanding(), nil
+	default:
+		return nil, fmt.Errorf("unknown balancer %q", kind)
 	}
-	c := rr.list[rr.next]
-	rr.next += 1
-	return c, true
 }
 
 // connList is a helper type used by balancers to maintain set of connections.
@@ -0,0 +1,148 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package call_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ServiceWeaver/weaver/internal/net/call"
+)
+
+// loadedConn is a fake call.ReplicaConnection with a fixed load.
+type loadedConn struct {
+	addr string
+	load call.ReplicaLoad
+}
+
+func (c *loadedConn) Address() string        { return c.addr }
+func (c *loadedConn) Load() call.ReplicaLoad { return c.load }
+
+// picks returns the number of times every connection is picked by b, out of n
+// picks, keyed by address.
+func picks(t *testing.T, b call.Balancer, n int) map[string]int {
+	t.Helper()
+	counts := map[string]int{}
+	for i := 0; i < n; i++ {
+		c, ok := b.Pick(call.CallOptions{})
+		if !ok {
+			t.Fatal("no connection picked")
+		}
+		counts[c.Address()]++
+	}
+	return counts
+}
+
+func TestBalancersEmpty(t *testing.T) {
+	for _, kind := range []string{"round_robin", "p2c", "least_outstanding"} {
+		t.Run(kind, func(t *testing.T) {
+			b, err := call.NewBalancer(kind)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if c, ok := b.Pick(call.CallOptions{}); ok {
+				t.Fatalf("Pick: got %v, want no connection", c.Address())
+			}
+		})
+	}
+}
+
+func TestBalancersAvoid(t *testing.T) {
+	for _, kind := range []string{"round_robin", "p2c", "least_outstanding"} {
+		t.Run(kind, func(t *testing.T) {
+			b, err := call.NewBalancer(kind)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// The avoided connection is picked only if it's the only one.
+			b.Add(&loadedConn{addr: "a"})
+			if c, ok := b.Pick(call.CallOptions{Avoid: "a"}); !ok || c.Address() != "a" {
+				t.Fatalf("Pick: got %v, want a", c)
+			}
+			b.Add(&loadedConn{addr: "b"})
+			b.Add(&loadedConn{addr: "c"})
+			for i := 0; i < 100; i++ {
+				c, ok := b.Pick(call.CallOptions{Avoid: "a"})
+				if !ok {
+					t.Fatal("no connection picked")
+				}
+				if c.Address() == "a" {
+					t.Fatalf("pick %d: avoided connection picked", i)
+				}
+			}
+		})
+	}
+}
+
+func TestNewBalancerUnknown(t *testing.T) {
+	if _, err := call.NewBalancer("random"); err == nil {
+		t.Fatal("NewBalancer: unexpected success")
+	}
+}
+
+func TestPowerOfTwoChoices(t *testing.T) {
+	// Three replicas, one of which is slow. The slow replica is never picked
+	// when compared against another replica, so it's never picked at all.
+	b := call.PowerOfTwoChoices()
+	b.Add(&loadedConn{"a", call.ReplicaLoad{Outstanding: 1, Latency: time.Millisecond}})
+	b.Add(&loadedConn{"b", call.ReplicaLoad{Outstanding: 1, Latency: time.Millisecond}})
+	b.Add(&loadedConn{"slow", call.ReplicaLoad{Outstanding: 1, Latency: time.Second}})
+	counts := picks(t, b, 1000)
+	if counts["slow"] != 0 {
+		t.Errorf("slow replica picked %d times, want 0", counts["slow"])
+	}
+	if counts["a"] == 0 || counts["b"] == 0 {
+		t.Errorf("picks %v: want both a and b to be picked", counts)
+	}
+}
+
+func TestPowerOfTwoChoicesOutstanding(t *testing.T) {
+	// Two replicas with the same latency. The replica with more outstanding
+	// calls is never picked.
+	b := call.PowerOfTwoChoices()
+	b.Add(&loadedConn{"idle", call.ReplicaLoad{Latency: time.Millisecond}})
+	b.Add(&loadedConn{"busy", call.ReplicaLoad{Outstanding: 10, Latency: time.Millisecond}})
+	counts := picks(t, b, 100)
+	if counts["busy"] != 0 {
+		t.Errorf("busy replica picked %d times, want 0", counts["busy"])
+	}
+}
+
+func TestLeastOutstanding(t *testing.T) {
+	b := call.LeastOutstanding()
+	b.Add(&loadedConn{"a", call.ReplicaLoad{Outstanding: 3}})
+	b.Add(&loadedConn{"b", call.ReplicaLoad{Outstanding: 1}})
+	b.Add(&loadedConn{"c", call.ReplicaLoad{Outstanding: 2}})
+	if got := picks(t, b, 10); got["b"] != 10 {
+		t.Fatalf("picks: got %v, want b picked every time", got)
+	}
+}
+
+func TestLeastOutstandingTies(t *testing.T) {
+	// Replicas with the same load are picked in a round-robin fashion.
+	b := call.LeastOutstanding()
+	b.Add(&loadedConn{"a", call.ReplicaLoad{Outstanding: 1, Latency: time.Millisecond}})
+	b.Add(&loadedConn{"b", call.ReplicaLoad{Outstanding: 1, Latency: time.Millisecond}})
+	if got := picks(t, b, 10); got["a"] != 5 || got["b"] != 5 {
+		t.Fatalf("picks: got %v, want a and b picked 5 times each", got)
+	}
+
+	// Ties in outstanding calls are broken by latency.
+	b.Add(&loadedConn{"fast", call.ReplicaLoad{Outstanding: 1, Latency: time.Microsecond}})
+	if got := picks(t, b, 10); got["fast"] != 10 {
+		t.Fatalf("picks: got %v, want fast picked every time", got)
+	}
+}
@@ -74,6 +74,7 @@ import (
 	"io"
 	"log/slog"
 	"net"
+	"slices"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -90,6 +91,10 @@ type Connection interface {
 	// Call makes an RPC over a Connection.
 	Call(context.Context, MethodKey, []byte, CallOptions) ([]byte, error)
 
+	// Stream starts a streaming call over a Connection. Streaming calls are
+	// never retried, regardless of CallOptions.Retry.
+	Stream(context.Context, MethodKey, []byte, CallOptions) (codegen.ClientStream, error)
+
 	// Close closes a connection. Pending invocations of Call are cancelled and
 	// return an error. All future invocations of Call fail and return an error
 	// immediately. Close can be called more than once.
@@ -118,6 +123,9 @@ type reconnectingConnection struct {
 	resolver       Resolver
 	cancelResolver func()         // cancels the watchResolver goroutine
 	resolverDone   sync.WaitGroup // used to wait for watchResolver to finish
+
+	latencies  latencies   // recent call latencies, used to pick hedging delays
+	compressor *compressor // compresses requests; nil if disabled
 }
 
 // connState is the state of a clientConnection (connection to a particular
@@ -178,8 +186,17 @@ type clientConnection struct {
 	c              net.Conn         // Active network connection, or nil
 	cbuf           *bufio.Reader    // Buffered reader wrapped around c
 	version        version          // Version number to use for connection
+	compress       bool             // Can the server decompress requests?
 	calls          map[uint64]*call // In-progress calls
 	lastID         uint64           // Last assigned request ID for a call
+	latency        time.Duration    // Moving average of recent call latencies
+	samples        int              // Number of latencies averaged in latency
+
+	// Outlier detection state. Guarded by rc.mu.
+	failures   int         // Number of consecutive failed calls
+	ejected    bool        // Has c been ejected from the balancer?
+	ejections  int         // Number of consecutive ejections
+	ejectTimer *time.Timer // Ends the current ejection, if any
 }
 
 var _ ReplicaConnection = &clientConnection{}
@@ -188,6 +205,8 @@ var _ ReplicaConnection = &clientConnection{}
 type call struct {
 	id         uint64
 	doneSignal chan struct{}
+	start      time.Time   // when the call was started; guarded by rc.mu
+	compressor *compressor // compresses the request, or nil; set by startCall
 
 	// Fields below are accessed across goroutines, but their access is
 	// synchronized via doneSignal, i.e., it is never concurrent.
@@ -198,6 +217,11 @@ type call struct {
 	// This field is accessed across goroutines using atomics.
 	done uint32 // is the call done?
 
+	// Fields below are only used by streaming calls. A streaming call stays
+	// registered with its connection after its response has been received,
+	// until the server has also ended its stream.
+	stream  *stream // element streams; nil for non-streaming calls
+	replied bool    // has doneSignal been closed? Guarded by rc.mu.
 }
 
 // serverConnection manages one network connection on the server-side.
@@ -207,23 +231,27 @@ type serverConnection struct {
 	cbuf        *bufio.Reader // Buffered reader wrapped around c
 	wlock       sync.Mutex    // Guards writes to c
 	mu          sync.Mutex
-	closed      bool              // has c been closed?
-	version     version           // Version number to use for connection
-	cancelFuncs map[uint64]func() // Cancellation functions for in-progress calls
+	closed      bool               // has c been closed?
+	version     version            // Version number to use for connection
+	compress    bool               // Can the client decompress replies?
+	cancelFuncs map[uint64]func()  // Cancellation functions for in-progress calls
+	streams     map[uint64]*stream // Element streams for in-progress streaming calls
+	limits      limiters           // Concurrency limiters, shared with other connections
 }
 
 // serverState tracks all live server-side connections so we can clean things up when canceled.
 type serverState struct {
-	opts  ServerOptions
-	mu    sync.Mutex
-	conns map[*serverConnection]struct{} // Live connections
+	opts   ServerOptions
+	limits limiters
+	mu     sync.Mutex
+	conns  map[*serverConnection]struct{} // Live connections
 }
 
 // Serve starts listening for connections and requests on l. It always returns a
 // non-nil error and closes l.
 func Serve(ctx context.Context, l Listener, opts ServerOptions) error {
 	opts = opts.withDefaults()
-	ss := &serverState{opts: opts}
+	ss := &serverState{opts: opts, limits: newLimiters(opts.ConcurrencyLimits)}
 	defer ss.stop()
 	l = &onceCloseListener{Listener: l, closer: sync.OnceValue(l.Close)}
 
@@ -258,9 +286,11 @@ func (oc *onceCloseListener) Close() error {
 
 // ServeOn serves client requests received over an already established
 // network connection with a client. This can be useful in tests or
-// when using custom networking transports.
+// when using custom networking transports. The concurrency limits in opts
+// apply to the calls received over conn only.
 func ServeOn(ctx context.Context, conn net.Conn, hmap *HandlerMap, opts ServerOptions) {
-	ss := &serverState{opts: opts.withDefaults()}
+	opts = opts.withDefaults()
+	ss := &serverState{opts: opts, limits: newLimiters(opts.ConcurrencyLimits)}
 	ss.serveConnection(ctx, conn, hmap)
 }
 
@@ -271,6 +301,8 @@ func (ss *serverState) serveConnection(ctx context.Context, conn net.Conn, hmap
 		cbuf:        bufio.NewReader(conn),
 		version:     initialVersion, // Updated when we hear from client
 		cancelFuncs: map[uint64]func(){},
+		streams:     map[uint64]*stream{},
+		limits:      ss.limits,
 	}
 	ss.register(c)
 
@@ -310,6 +342,7 @@ func Connect(ctx context.Context, resolver Resolver, opts ClientOptions) (Connec
 		resolver:       resolver,
 		cancelResolver: func() {},
 	}
+	conn.compressor = newCompressor(conn.opts.CompressionThreshold, conn.opts.Component)
 
 	// Compute the initial set of endpoints.
 	endpoints, version, err := resolver.Resolve(ctx, nil)
@@ -368,11 +401,18 @@ func (rc *reconnectingConnection) Close() {
 // Call makes an RPC over connection c, retrying it on network errors if retries are allowed.
 func (rc *reconnectingConnection) Call(ctx context.Context, h MethodKey, arg []byte, opts CallOptions) ([]byte, error) {
 	if !opts.Retry {
-		return rc.callOnce(ctx, h, arg, opts)
-	}
-	for r := retry.Begin(); r.Continue(ctx); {
-		response, err := rc.callOnce(ctx, h, arg, opts)
-		if errors.Is(err, Unreachable) || errors.Is(err, CommunicationError) {
+		return rc.callOnce(ctx, h, arg, opts, nil)
+	}
+	r := retry.Begin()
+	if opts.Backoff > 0 {
+		r = retry.BeginWithOptions(retry.Options{
+			BackoffMultiplier:  retry.DefaultOptions.BackoffMultiplier,
+			BackoffMinDuration: opts.Backoff,
+		})
+	}
+	for attempt := 1; r.Continue(ctx); attempt++ {
+		response, err := rc.callHedged(ctx, h, arg, opts)
+		if failed(err) && (opts.Attempts <= 0 || attempt < opts.Attempts) {
 			continue
 		}
 		return response, err
@@ -380,30 +420,73 @@ func (rc *reconnectingConnection) Call(ctx context.Context, h MethodKey, arg []b
 	return nil, ctx.Err()
 }
 
-func (rc *reconnectingConnection) callOnce(ctx context.Context, h MethodKey, arg []byte, opts CallOptions) ([]byte, error) {
-	var micros int64
-	deadline, haveDeadline := ctx.Deadline()
-	if haveDeadline {
-		// Send the deadline in the header. We use the relative time instead
-		// of absolute in case there is significant clock skew. This does mean
-		// that we will not count transmission delay against the deadline.
-		micros = time.Until(deadline).Microseconds()
-		if micros <= 0 {
-			// Fail immediately without attempting to send a zero or negative
-			// deadline to the server which will be misinterpreted.
-			<-ctx.Done()
-			return nil, ctx.Err()
+// failed returns whether a call failed to execute because of err.
+func failed(err error) bool {
+	return errors.Is(err, Unreachable) || errors.Is(err, CommunicationError) || errors.Is(err, Overloaded)
+}
+
+// callHedged makes a call and, if it hasn't finished after the hedging delay
+// (see CallOptions.Hedge and CallOptions.HedgePercentile), makes the same call
+// again, avoiding the replica of the first call. It returns the first reply,
+// unless the call failed and the other call is still in progress. The other
+// call is canceled.
+func (rc *reconnectingConnection) callHedged(ctx context.Context, h MethodKey, arg []byte, opts CallOptions) ([]byte, error) {
+	delay := opts.Hedge
+	if opts.HedgePercentile > 0 {
+		if d, ok := rc.latencies.percentile(h, opts.HedgePercentile); ok {
+			delay = d
 		}
 	}
+	if delay <= 0 {
+		return rc.callOnce(ctx, h, arg, opts, nil)
+	}
 
-	// Encode the header.
-	hdr := encodeHeader(ctx, h, micros)
+	// Canceling ctx on return cancels the call in progress, if any.
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	type result struct {
+		response []byte
+		err      error
+	}
+	results := make(chan result, 2)
+	call := func(opts CallOptions, picked chan<- string) {
+		response, err := rc.callOnce(ctx, h, arg, opts, picked)
+		results <- result{response, err}
+	}
 
-	// Note that we send the header and the payload as follows:
-	// [header_length][encoded_header][payload]
-	var hdrLen [hdrLenLen]byte
-	binary.LittleEndian.PutUint32(hdrLen[:], uint32(len(hdr)))
-	hdrSlice := append(hdrLen[:], hdr...)
+	picked := make(chan string, 1)
+	go call(opts, picked)
+	pending := 1
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	for {
+		select {
+		case <-timer.C:
+			hedged := opts
+			select {
+			case hedged.Avoid = <-picked:
+			default:
+				// The first call hasn't picked a replica yet.
+			}
+			go call(hedged, nil)
+			pending++
+		case res := <-results:
+			pending--
+			if !failed(res.err) || pending == 0 {
+				return res.response, res.err
+			}
+		}
+	}
+}
+
+// callOnce makes a call, without retrying it. If picked is not nil, the
+// address of the replica picked for the call is sent on picked.
+func (rc *reconnectingConnection) callOnce(ctx context.Context, h MethodKey, arg []byte, opts CallOptions, picked chan<- string) ([]byte, error) {
+	deadline, haveDeadline := ctx.Deadline()
+	hdrSlice, err := encodeRequestHeader(ctx, h)
+	if err != nil {
+		return nil, err
+	}
 
 	rpc := &call{}
 	rpc.doneSignal = make(chan struct{})
@@ -413,16 +496,22 @@ func (rc *reconnectingConnection) callOnce(ctx context.Context, h MethodKey, arg
 	if err != nil {
 		return nil, err
 	}
-	if err := writeMessage(nc, &conn.wlock, requestMessage, rpc.id, hdrSlice, arg, rc.opts.WriteFlattenLimit); err != nil {
+	if picked != nil {
+		picked <- conn.endpoint.Address()
+	}
+	if err := writeMessage(nc, &conn.wlock, requestMessage, rpc.id, hdrSlice, arg, rc.opts.WriteFlattenLimit, rpc.compressor); err != nil {
 		conn.shutdown("client send request", err)
 		conn.endCall(rpc)
-		return nil, fmt.Errorf("%w: %s", CommunicationError, err)
+		err = fmt.Errorf("%w: %s", CommunicationError, err)
+		conn.observeResult(err)
+		return nil, err
 	}
 
 	if rc.opts.OptimisticSpinDuration > 0 {
 		// Optimistically spin, waiting for the results.
 		for start := time.Now(); time.Since(start) < rc.opts.OptimisticSpinDuration; {
 			if atomic.LoadUint32(&rpc.done) > 0 {
+				rc.observeResult(conn, h, rpc, opts)
 				return rpc.response, rpc.err
 			}
 		}
@@ -438,7 +527,7 @@ func (rc *reconnectingConnection) callOnce(ctx context.Context, h MethodKey, arg
 
 			if !haveDeadline || time.Now().Before(deadline) {
 				// Early cancellation. Tell server about it.
-				if err := writeMessage(nc, &conn.wlock, cancelMessage, rpc.id, nil, nil, rc.opts.WriteFlattenLimit); err != nil {
+				if err := writeMessage(nc, &conn.wlock, cancelMessage, rpc.id, nil, nil, rc.opts.WriteFlattenLimit, nil); err != nil {
 					conn.shutdown("client send cancel", err)
 				}
 			}
@@ -448,9 +537,85 @@ func (rc *reconnectingConnection) callOnce(ctx context.Context, h MethodKey, arg
 	} else {
 		<-rpc.doneSignal
 	}
+	rc.observeResult(conn, h, rpc, opts)
 	return rpc.response, rpc.err
 }
 
+// observeResult records the result of a finished call to method h over conn.
+func (rc *reconnectingConnection) observeResult(conn *clientConnection, h MethodKey, rpc *call, opts CallOptions) {
+	conn.observeResult(rpc.err)
+	if opts.HedgePercentile > 0 && rpc.err == nil {
+		rc.latencies.record(h, time.Since(rpc.start))
+	}
+}
+
+// Stream starts a streaming call over connection c.
+func (rc *reconnectingConnection) Stream(ctx context.Context, h MethodKey, arg []byte, opts CallOptions) (codegen.ClientStream, error) {
+	hdrSlice, err := encodeRequestHeader(ctx, h)
+	if err != nil {
+		return nil, err
+	}
+
+	var conn *clientConnection
+	var nc net.Conn
+	rpc := &call{}
+	rpc.doneSignal = make(chan struct{})
+	rpc.stream = newStream(func(mt messageType, payload []byte) error {
+		if err := writeMessage(nc, &conn.wlock, mt, rpc.id, nil, payload, rc.opts.WriteFlattenLimit, nil); err != nil {
+			conn.shutdown("client send stream", err)
+			return fmt.Errorf("%w: %s", CommunicationError, err)
+		}
+		return nil
+	})
+
+	conn, nc, err = rc.startCall(ctx, rpc, opts)
+	if err != nil {
+		return nil, err
+	}
+	if err := writeMessage(nc, &conn.wlock, streamRequestMessage, rpc.id, hdrSlice, arg, rc.opts.WriteFlattenLimit, rpc.compressor); err != nil {
+		conn.shutdown("client send request", err)
+		conn.endCall(rpc)
+		return nil, fmt.Errorf("%w: %s", CommunicationError, err)
+	}
+
+	// Cancel the call if ctx is done before the call finishes.
+	go func() {
+		select {
+		case <-ctx.Done():
+			conn.cancelStream(rpc, nc, ctx.Err())
+		case <-rpc.stream.done:
+		}
+	}()
+	return &clientStream{stream: rpc.stream, rpc: rpc}, nil
+}
+
+// encodeRequestHeader returns the header that precedes the arguments in a
+// request message.
+func encodeRequestHeader(ctx context.Context, h MethodKey) ([]byte, error) {
+	var micros int64
+	if deadline, haveDeadline := ctx.Deadline(); haveDeadline {
+		// Send the deadline in the header. We use the relative time instead
+		// of absolute in case there is significant clock skew. This does mean
+		// that we will not count transmission delay against the deadline.
+		micros = time.Until(deadline).Microseconds()
+		if micros <= 0 {
+			// Fail immediately without attempting to send a zero or negative
+			// deadline to the server which will be misinterpreted.
+			<-ctx.Done()
+			return nil, ctx.Err()
+		}
+	}
+
+	// Encode the header.
+	hdr := encodeHeader(ctx, h, micros)
+
+	// Note that we send the header and the payload as follows:
+	// [header_length][encoded_header][payload]
+	var hdrLen [hdrLenLen]byte
+	binary.LittleEndian.PutUint32(hdrLen[:], uint32(len(hdr)))
+	return append(hdrLen[:], hdr...), nil
+}
+
 // watchResolver watches for updates to the set of endpoints. When a new set of
 // updates is available, watchResolver passes it to updateEndpoints.
 // REQUIRES: version != nil.
@@ -537,6 +702,11 @@ func (rc *reconnectingConnection) startCall(ctx context.Context, rpc *call, opts
 
 		replica, ok := rc.opts.Balancer.Pick(opts)
 		if !ok {
+			if n := rc.ejected(); n > 0 {
+				rc.mu.Unlock()
+				circuitOpenCounts.Get(circuitLabels{Component: rc.opts.Component}).Inc()
+				return nil, nil, fmt.Errorf("%w: all %d replicas ejected", CircuitOpen, n)
+			}
 			rc.mu.Unlock()
 			continue
 		}
@@ -546,9 +716,17 @@ func (rc *reconnectingConnection) startCall(ctx context.Context, rpc *call, opts
 			rc.mu.Unlock()
 			return nil, nil, fmt.Errorf("internal error: wrong connection type %#v returned by load balancer", replica)
 		}
+		if rpc.stream != nil && c.version < streamingVersion {
+			rc.mu.Unlock()
+			return nil, nil, fmt.Errorf("server at %s does not support streaming calls", c.endpoint.Address())
+		}
 
 		c.lastID++
 		rpc.id = c.lastID
+		rpc.start = time.Now()
+		if c.compress {
+			rpc.compressor = rc.compressor
+		}
 		c.calls[rpc.id] = rpc
 		c.callstart()
 		nc := c.c
@@ -564,6 +742,31 @@ func (c *clientConnection) Address() string {
 	return c.endpoint.Address()
 }
 
+// Load implements the loadReporter interface.
+//
+// REQUIRES: rc.mu is held.
+func (c *clientConnection) Load() ReplicaLoad {
+	return ReplicaLoad{Outstanding: len(c.calls), Latency: c.latency}
+}
+
+// observeLatency records the latency of a call that received its response.
+//
+// REQUIRES: rc.mu is held.
+func (c *clientConnection) observeLatency(rpc *call) {
+	if rpc.stream != nil {
+		// Streaming calls last arbitrarily long.
+		return
+	}
+	sample := time.Since(rpc.start)
+	c.samples++
+	if c.latency == 0 {
+		c.latency = sample
+		return
+	}
+	// Exponentially weighted moving average, with weight 1/8 for new samples.
+	c.latency += (sample - c.latency) / 8
+}
+
 // State transition actions: all of these are called with rc.mu held.
 
 func (c *clientConnection) register() {
@@ -658,8 +861,9 @@ func (c *clientConnection) checkInvariants() {
 		panic(fmt.Sprintf("%v connection: wrong net.Conn %v", s, c.c))
 	}
 
-	// connection is in the balancer iff state in {idle, active}
-	if c.inBalancer != (s == idle || s == active) {
+	// connection is in the balancer iff state in {idle, active} and the
+	// connection hasn't been ejected
+	if c.inBalancer != ((s == idle || s == active) && !c.ejected) {
 		panic(fmt.Sprintf("%v connection: wrong balancer presence %v", s, c.inBalancer))
 	}
 
@@ -669,6 +873,22 @@ func (c *clientConnection) checkInvariants() {
 	}
 }
 
+// fixBalancer adds c to or removes c from the balancer, depending on its
+// state and whether it has been ejected.
+func (c *clientConnection) fixBalancer() {
+	if (c.state == idle || c.state == active) && !c.ejected {
+		if !c.inBalancer {
+			c.rc.opts.Balancer.Add(c)
+			c.inBalancer = true
+		}
+	} else {
+		if c.inBalancer {
+			c.rc.opts.Balancer.Remove(c)
+			c.inBalancer = false
+		}
+	}
+}
+
 // setState transitions to state s and updates any related state.
 func (c *clientConnection) setState(s connState) {
 	// idle<-> active transitions may happen a lot, so short-circuit them
@@ -709,17 +929,7 @@ func (c *clientConnection) setState(s connState) {
 	} // else: caller is responsible for setting c.c and c.cbuf
 
 	// Fix balancer membership.
-	if s == idle || s == active {
-		if !c.inBalancer {
-			c.rc.opts.Balancer.Add(c)
-			c.inBalancer = true
-		}
-	} else {
-		if c.inBalancer {
-			c.rc.opts.Balancer.Remove(c)
-			c.inBalancer = false
-		}
-	}
+	c.fixBalancer()
 
 	// Fix in-flight calls.
 	if s == active || s == draining {
@@ -745,6 +955,8 @@ func (c *clientConnection) findAndEndCall(id uint64) *call {
 	defer c.rc.mu.Unlock()
 	rpc := c.calls[id]
 	if rpc != nil {
+		c.observeLatency(rpc)
+		c.checkLatency()
 		delete(c.calls, id)
 		if len(c.calls) == 0 {
 			c.lastdone()
@@ -753,6 +965,78 @@ func (c *clientConnection) findAndEndCall(id uint64) *call {
 	return rpc
 }
 
+// replyToStream delivers the response of the streaming call with the provided
+// id. It returns false if id does not identify an in-progress streaming call.
+func (c *clientConnection) replyToStream(id uint64, response []byte, err error) bool {
+	c.rc.mu.Lock()
+	defer c.rc.mu.Unlock()
+	rpc := c.calls[id]
+	if rpc == nil || rpc.stream == nil {
+		return false
+	}
+	if !rpc.replied {
+		rpc.replied = true
+		rpc.response, rpc.err = response, err
+		atomic.StoreUint32(&rpc.done, 1)
+		close(rpc.doneSignal)
+	}
+	c.maybeEndStream(rpc)
+	return true
+}
+
+// processStreamMessage handles a stream message sent by the server.
+func (c *clientConnection) processStreamMessage(mt messageType, id uint64, msg []byte) error {
+	c.rc.mu.Lock()
+	rpc := c.calls[id]
+	c.rc.mu.Unlock()
+	if rpc == nil || rpc.stream == nil {
+		return nil // May have been canceled
+	}
+	if err := rpc.stream.handle(mt, msg); err != nil {
+		return err
+	}
+	if mt == streamEndMessage {
+		c.rc.mu.Lock()
+		defer c.rc.mu.Unlock()
+		c.maybeEndStream(rpc)
+	}
+	return nil
+}
+
+// maybeEndStream ends the provided streaming call if both its response and
+// the end of the server's stream have been received.
+// REQUIRES: c.rc.mu is held.
+func (c *clientConnection) maybeEndStream(rpc *call) {
+	if !rpc.replied || !rpc.stream.ended() || c.calls[rpc.id] != rpc {
+		return
+	}
+	delete(c.calls, rpc.id)
+	if len(c.calls) == 0 {
+		c.lastdone()
+	}
+	rpc.stream.finish(fmt.Errorf("call finished: %w", io.ErrClosedPipe))
+}
+
+// cancelStream ends the provided streaming call, if it is still in progress,
+// and tells the server about the cancellation.
+func (c *clientConnection) cancelStream(rpc *call, nc net.Conn, err error) {
+	c.rc.mu.Lock()
+	if c.calls[rpc.id] != rpc {
+		c.rc.mu.Unlock()
+		return
+	}
+	delete(c.calls, rpc.id)
+	if len(c.calls) == 0 {
+		c.lastdone()
+	}
+	rpc.end(err)
+	c.rc.mu.Unlock()
+
+	if err := writeMessage(nc, &c.wlock, cancelMessage, rpc.id, nil, nil, c.rc.opts.WriteFlattenLimit, nil); err != nil {
+		c.shutdown("client send cancel", err)
+	}
+}
+
 // shutdown processes an error detected while operating on a connection.
 // It closes the network connection and cancels all requests in progress on the connection.
 // REQUIRES: c.mu is not held.
@@ -766,13 +1050,26 @@ func (c *clientConnection) shutdown(details string, err error) {
 // REQUIRES: c.mu is held.
 func (c *clientConnection) endCalls(err error) {
 	for id, active := range c.calls {
-		active.err = err
-		atomic.StoreUint32(&active.done, 1)
-		close(active.doneSignal)
+		active.end(err)
 		delete(c.calls, id)
 	}
 }
 
+// end ends an in-progress call with the provided error.
+// REQUIRES: c.rc.mu is held, where c is the connection the call was issued on.
+func (rpc *call) end(err error) {
+	if rpc.stream != nil {
+		rpc.stream.finish(err)
+		if rpc.replied {
+			return
+		}
+		rpc.replied = true
+	}
+	rpc.err = err
+	atomic.StoreUint32(&rpc.done, 1)
+	close(rpc.doneSignal)
+}
+
 // manage handles a live clientConnection until it becomes missing.
 func (c *clientConnection) manage(ctx context.Context) {
 	for r := retry.Begin(); r.Continue(ctx); {
@@ -834,11 +1131,12 @@ func (c *clientConnection) exchangeVersions() error {
 	if mt != versionMessage {
 		return fmt.Errorf("wrong message type %d, expecting %d", mt, versionMessage)
 	}
-	v, err := getVersion(id, msg)
+	v, compress, err := getVersion(id, msg)
 	if err != nil {
 		return err
 	}
 	c.version = v
+	c.compress = compress
 	return nil
 }
 
@@ -856,27 +1154,37 @@ func (c *clientConnection) readAndProcessMessage() error {
 	}
 	switch mt {
 	case versionMessage:
-		_, err := getVersion(id, msg)
+		_, _, err := getVersion(id, msg)
 		if err != nil {
 			return err
 		}
 		// Ignore versions sent after initial hand-shake
-	case responseMessage, responseError:
-		rpc := c.findAndEndCall(id)
-		if rpc == nil {
-			return nil // May have been canceled
-		}
-		if mt == responseError {
-			if err, ok := decodeError(msg); ok {
-				rpc.err = err
+	case responseMessage, responseError, overloadedMessage:
+		var response []byte
+		var err error
+		if mt == overloadedMessage {
+			err = rejection{string(msg)}
+		} else if mt == responseError {
+			if e, ok := decodeError(msg); ok {
+				err = e
 			} else {
-				rpc.err = fmt.Errorf("%w: could not decode error", CommunicationError)
+				err = fmt.Errorf("%w: could not decode error", CommunicationError)
 			}
 		} else {
-			rpc.response = msg
+			response = msg
 		}
+		if c.replyToStream(id, response, err) {
+			return nil
+		}
+		rpc := c.findAndEndCall(id)
+		if rpc == nil {
+			return nil // May have been canceled
+		}
+		rpc.response, rpc.err = response, err
 		atomic.StoreUint32(&rpc.done, 1)
 		close(rpc.doneSignal)
+	case streamMessage, streamEndMessage, streamAckMessage, streamCloseMessage:
+		return c.processStreamMessage(mt, id, msg)
 	default:
 		return fmt.Errorf("invalid response %d", mt)
 	}
@@ -895,7 +1203,7 @@ func (c *serverConnection) readRequests(ctx context.Context, hmap *HandlerMap, o
 
 		switch mt {
 		case versionMessage:
-			v, err := getVersion(id, msg)
+			v, compress, err := getVersion(id, msg)
 			if err != nil {
 				c.shutdown("server read version", err)
 				onDone()
@@ -903,6 +1211,7 @@ func (c *serverConnection) readRequests(ctx context.Context, hmap *HandlerMap, o
 			}
 			c.mu.Lock()
 			c.version = v
+			c.compress = compress
 			c.mu.Unlock()
 
 			// Respond with my version.
@@ -918,17 +1227,34 @@ func (c *serverConnection) readRequests(ctx context.Context, hmap *HandlerMap, o
 				t := time.AfterFunc(c.opts.InlineHandlerDuration, func() {
 					c.readRequests(ctx, hmap, onDone)
 				})
-				c.runHandler(hmap, id, msg)
+				c.runHandler(hmap, id, msg, nil)
 				if !t.Stop() {
 					// Another goroutine is reading incoming requests: bail out.
 					return
 				}
 			} else {
 				// Run the handler in a separate goroutine.
-				go c.runHandler(hmap, id, msg)
+				go c.runHandler(hmap, id, msg, nil)
 			}
+		case streamRequestMessage:
+			// Register the call's streams before reading any more messages,
+			// since the client may start sending stream elements right away.
+			// Streaming handlers are never run inline.
+			go c.runHandler(hmap, id, msg, c.startStream(id))
 		case cancelMessage:
 			c.endRequest(id)
+		case streamMessage, streamEndMessage, streamAckMessage, streamCloseMessage:
+			c.mu.Lock()
+			s := c.streams[id]
+			c.mu.Unlock()
+			if s == nil {
+				continue // May have been canceled
+			}
+			if err := s.handle(mt, msg); err != nil {
+				c.shutdown("server read stream", err)
+				onDone()
+				return
+			}
 		default:
 			c.shutdown("server read", fmt.Errorf("invalid request type %d", mt))
 			onDone()
@@ -941,7 +1267,13 @@ func (c *serverConnection) readRequests(ctx context.Context, hmap *HandlerMap, o
 
 // runHandler runs an application specified RPC handler at the server side.
 // The result (or error) from the handler is sent back to the client over c.
-func (c *serverConnection) runHandler(hmap *HandlerMap, id uint64, msg []byte) {
+// For streaming calls, s holds the call's element streams, and runHandler
+// doesn't return until the handler's outgoing stream has ended.
+func (c *serverConnection) runHandler(hmap *HandlerMap, id uint64, msg []byte, s *stream) {
+	if s != nil {
+		defer c.endStream(id, s)
+	}
+
 	msgLen := uint32(len(msg))
 	if msgLen < hdrLenLen {
 		c.shutdown("server handler", fmt.Errorf("missing request header length"))
@@ -991,6 +1323,10 @@ func (c *serverConnection) runHandler(hmap *HandlerMap, id uint64, msg []byte) {
 		defer span.End()
 	}
 
+	if s != nil {
+		ctx = codegen.WithStreamConn(ctx, s)
+	}
+
 	// Call the handler passing it the payload.
 	payload := msg[hdrEndOffset:]
 	var err error
@@ -1005,20 +1341,91 @@ func (c *serverConnection) runHandler(hmap *HandlerMap, id uint64, msg []byte) {
 		}
 		cancelFunc = nil // endRequest() or cancellation will deal with it
 		defer c.endRequest(id)
-		result, err = fn(ctx, payload)
+		var release func()
+		if release, err = c.limits.acquire(ctx, hmap.names[hkey]); err == nil {
+			result, err = fn(ctx, payload)
+			release()
+		}
 	}
 
 	mt := responseMessage
 	if err != nil {
 		mt = responseError
 		result = encodeError(err)
+		if r, ok := err.(rejection); ok && c.peerVersion() >= overloadVersion {
+			mt = overloadedMessage
+			result = []byte(r.name)
+		}
 		span.RecordError(err)
 		span.SetStatus(codes.Error, err.Error())
 	}
 
-	if err := writeMessage(c.c, &c.wlock, mt, id, nil, result, c.opts.WriteFlattenLimit); err != nil {
+	if err := writeMessage(c.c, &c.wlock, mt, id, nil, result, c.opts.WriteFlattenLimit, c.compressor(hmap.names[hkey])); err != nil {
 		c.shutdown("server write "+hmap.names[hkey], err)
 	}
+
+	if s != nil {
+		// The handler (typically a generated server stub) is responsible for
+		// ending the outgoing stream, possibly in a separate goroutine. Keep
+		// the request alive until it does so.
+		if err != nil {
+			s.CloseSend(err)
+		}
+		s.waitSendDone(ctx)
+		s.CloseSend(ctx.Err())
+	}
+}
+
+// startStream registers the element streams for the streaming call with the
+// provided id.
+func (c *serverConnection) startStream(id uint64) *stream {
+	s := newStream(func(mt messageType, payload []byte) error {
+		if err := writeMessage(c.c, &c.wlock, mt, id, nil, payload, c.opts.WriteFlattenLimit, nil); err != nil {
+			c.shutdown("server write stream", err)
+			return err
+		}
+		return nil
+	})
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closed {
+		s.finish(fmt.Errorf("startStream: %w", net.ErrClosed))
+	} else {
+		c.streams[id] = s
+	}
+	return s
+}
+
+// endStream unregisters the element streams for the streaming call with the
+// provided id.
+func (c *serverConnection) endStream(id uint64, s *stream) {
+	c.mu.Lock()
+	if c.streams[id] == s {
+		delete(c.streams, id)
+	}
+	c.mu.Unlock()
+	s.finish(fmt.Errorf("call finished: %w", io.ErrClosedPipe))
+}
+
+// peerVersion returns the protocol version used to communicate with the
+// client.
+func (c *serverConnection) peerVersion() version {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.version
+}
+
+// compressor returns the compressor for replies to calls to the method with
+// the provided full name, or nil if the replies shouldn't be compressed.
+func (c *serverConnection) compressor(name string) *compressor {
+	c.mu.Lock()
+	compress := c.compress
+	c.mu.Unlock()
+	component, _ := splitMethodName(name)
+	if !compress || slices.Contains(c.opts.UncompressedComponents, component) {
+		return nil
+	}
+	return newCompressor(c.opts.CompressionThreshold, component)
 }
 
 func (c *serverConnection) startRequest(id uint64, cancelFunc func()) error {
@@ -1056,6 +1463,10 @@ func (c *serverConnection) shutdown(details string, err error) {
 		cf()
 		delete(c.cancelFuncs, id)
 	}
+	for id, s := range c.streams {
+		s.finish(fmt.Errorf("%w: %s: %s", CommunicationError, details, err))
+		delete(c.streams, id)
+	}
 }
 
 // encodeHeader encodes the header information that is propagated by each message.
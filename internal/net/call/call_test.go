@@ -1554,6 +1554,117 @@ func TestRetry(t *testing.T) {
 	}
 }
 
+func TestRetryAttempts(t *testing.T) {
+	ct := startTest(t)
+	client := ct.connect(call.NewConstantResolver(ct.startTCPServer()))
+
+	// Fail every call, and check that the call is attempted the specified
+	// number of times.
+	for _, attempts := range []int{1, 2, 5} {
+		t.Run(fmt.Sprint(attempts), func(t *testing.T) {
+			var count atomic.Int32
+			opts := call.CallOptions{Retry: true, Attempts: attempts, Backoff: time.Millisecond}
+			_, err := runAtServer(ct.ctx, client, opts, func(context.Context) ([]byte, error) {
+				count.Add(1)
+				return nil, call.CommunicationError
+			})
+			if !errors.Is(err, call.CommunicationError) {
+				t.Fatalf("got %v, expecting %v", err, call.CommunicationError)
+			}
+			if n := int(count.Load()); n != attempts {
+				t.Fatalf("got %d calls, expecting %d", n, attempts)
+			}
+		})
+	}
+}
+
+func TestHedge(t *testing.T) {
+	ct := startTest(t)
+	client := ct.connect(call.NewConstantResolver(ct.startTCPServer()))
+
+	// The first call blocks until canceled, so the call succeeds only if it
+	// is hedged, and the first call is canceled when the second one returns.
+	var count atomic.Int32
+	canceled := make(chan struct{})
+	opts := call.CallOptions{Retry: true, Hedge: 10 * time.Millisecond}
+	reply, err := runAtServer(ct.ctx, client, opts, func(ctx context.Context) ([]byte, error) {
+		if count.Add(1) > 1 {
+			return []byte("hedged"), nil
+		}
+		<-ctx.Done()
+		close(canceled)
+		return nil, ctx.Err()
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := string(reply), "hedged"; got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+	select {
+	case <-canceled:
+	case <-ct.ctx.Done():
+		t.Fatal("first call not canceled")
+	}
+}
+
+func TestHedgeAvoidsReplica(t *testing.T) {
+	// The first call blocks until canceled, and records the server it was
+	// sent to. The hedged call returns the name of its server.
+	var count atomic.Int32
+	var first atomic.Value
+	canceled := make(chan struct{})
+	handler := func(name string) call.Handler {
+		return func(ctx context.Context, _ []byte) ([]byte, error) {
+			if count.Add(1) > 1 {
+				return []byte(name), nil
+			}
+			first.Store(name)
+			<-ctx.Done()
+			close(canceled)
+			return nil, ctx.Err()
+		}
+	}
+	var endpoints []call.Endpoint
+	for _, name := range []string{"a", "b", "c"} {
+		h := handlersFor(name)
+		h.Set("", "hedge", handler(name))
+		endpoints = append(endpoints, &pipeEndpoint{name: name, handlers: h, t: t})
+	}
+	client, err := call.Connect(context.Background(), call.NewConstantResolver(endpoints...), call.ClientOptions{Logger: logger(t)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	// Wait for all replicas to be connected.
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+	seen := map[string]bool{}
+	waitUntil(t, func() bool {
+		who, err := client.Call(ctx, whoKey, nil, call.CallOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		seen[string(who)] = true
+		return len(seen) == len(endpoints)
+	})
+
+	opts := call.CallOptions{Retry: true, Hedge: 10 * time.Millisecond}
+	reply, err := client.Call(ctx, call.MakeMethodKey("", "hedge"), nil, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := string(reply); got == first.Load() {
+		t.Fatalf("hedged call sent to the replica of the first call %q", got)
+	}
+	select {
+	case <-canceled:
+	case <-ctx.Done():
+		t.Fatal("first call not canceled")
+	}
+}
+
 func BenchmarkCall(b *testing.B) {
 	ctx := context.Background()
 	opts := call.ServerOptions{Logger: logger(b)}
@@ -0,0 +1,59 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package call
+
+import (
+	"github.com/ServiceWeaver/weaver/runtime/codegen"
+	"github.com/klauspost/compress/s2"
+)
+
+// compressor compresses the payloads of the messages sent to a peer that can
+// decompress them. A nil compressor doesn't compress anything.
+type compressor struct {
+	threshold int    // smaller payloads aren't compressed
+	component string // full name of the callee component, used in metrics
+}
+
+// newCompressor returns a compressor for the messages of calls to the
+// provided component, or nil if threshold is negative.
+func newCompressor(threshold int, component string) *compressor {
+	if threshold < 0 {
+		return nil
+	}
+	return &compressor{threshold: threshold, component: component}
+}
+
+// compress returns the compression of the concatenation of extraHdr and
+// payload. It returns false if the payload should be sent uncompressed,
+// either because it is too small or because compression doesn't shrink it.
+func (c *compressor) compress(extraHdr, payload []byte) ([]byte, bool) {
+	if c == nil || len(extraHdr)+len(payload) < c.threshold {
+		return nil, false
+	}
+	src := payload
+	if len(extraHdr) > 0 {
+		src = make([]byte, 0, len(extraHdr)+len(payload))
+		src = append(src, extraHdr...)
+		src = append(src, payload...)
+	}
+	compressed := s2.Encode(nil, src)
+	if len(compressed) >= len(src) {
+		return nil, false
+	}
+	raw, comp := codegen.CompressedBytesFor(codegen.CompressionLabels{Component: c.component, Generated: true})
+	raw.Add(float64(len(src)))
+	comp.Add(float64(len(compressed)))
+	return compressed, true
+}
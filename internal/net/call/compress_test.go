@@ -0,0 +1,191 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package call
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"net"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/ServiceWeaver/weaver/runtime/logging"
+)
+
+func TestCompressMessage(t *testing.T) {
+	comp := newCompressor(1<<10, "app/Comp")
+	for _, test := range []struct {
+		name       string
+		payload    []byte
+		compressed bool
+	}{
+		{"Small", bytes.Repeat([]byte("x"), 100), false},
+		{"Large", bytes.Repeat([]byte("x"), 1<<20), true},
+		{"Incompressible", randomBytes(1 << 20), false},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			var wlock sync.Mutex
+			extraHdr := []byte{1, 2, 3, 4}
+			if err := writeMessage(&buf, &wlock, requestMessage, 42, extraHdr, test.payload, 0, comp); err != nil {
+				t.Fatal(err)
+			}
+			if got, want := buf.Bytes()[8]&byte(compressedFlag) != 0, test.compressed; got != want {
+				t.Fatalf("compressed: got %t, want %t", got, want)
+			}
+			mt, id, msg, err := readMessage(&buf)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if mt != requestMessage || id != 42 {
+				t.Fatalf("readMessage: got (%d, %d), want (%d, %d)", mt, id, requestMessage, 42)
+			}
+			if want := append(extraHdr, test.payload...); !bytes.Equal(msg, want) {
+				t.Fatalf("readMessage: got %d bytes, want %d", len(msg), len(want))
+			}
+		})
+	}
+}
+
+func TestNegotiateCompression(t *testing.T) {
+	// A peer that predates compression doesn't send codecs.
+	var old [4]byte
+	binary.LittleEndian.PutUint32(old[:], uint32(overloadVersion))
+	v, compress, err := getVersion(0, old[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v != overloadVersion || compress {
+		t.Fatalf("getVersion: got (%d, %t), want (%d, false)", v, compress, overloadVersion)
+	}
+
+	// A current peer can decompress s2.
+	var buf bytes.Buffer
+	var wlock sync.Mutex
+	if err := writeVersion(&buf, &wlock); err != nil {
+		t.Fatal(err)
+	}
+	_, id, msg, err := readMessage(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	v, compress, err = getVersion(id, msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v != currentVersion || !compress {
+		t.Fatalf("getVersion: got (%d, %t), want (%d, true)", v, compress, currentVersion)
+	}
+}
+
+// countingConn is a net.Conn that counts the bytes read and written.
+type countingConn struct {
+	net.Conn
+	read, written *atomic.Int64
+}
+
+func (c countingConn) Read(b []byte) (int, error) {
+	n, err := c.Conn.Read(b)
+	c.read.Add(int64(n))
+	return n, err
+}
+
+func (c countingConn) Write(b []byte) (int, error) {
+	n, err := c.Conn.Write(b)
+	c.written.Add(int64(n))
+	return n, err
+}
+
+// countingServer is a pipeServer whose connections count the bytes that the
+// client reads and writes.
+type countingServer struct {
+	*pipeServer
+	read, written atomic.Int64
+}
+
+func (c *countingServer) Dial(ctx context.Context) (net.Conn, error) {
+	conn, err := c.pipeServer.Dial(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return countingConn{conn, &c.read, &c.written}, nil
+}
+
+func TestCompressedCall(t *testing.T) {
+	const size = 1 << 20
+	handlers := NewHandlerMap()
+	for _, component := range []string{"app/Compressed", "app/Uncompressed"} {
+		handlers.Set(component, "echo", func(_ context.Context, arg []byte) ([]byte, error) {
+			return arg, nil
+		})
+	}
+	logger := logging.NewTestSlogger(t, testing.Verbose())
+	opts := ServerOptions{Logger: logger, UncompressedComponents: []string{"app/Uncompressed"}}
+
+	for _, test := range []struct {
+		component  string
+		threshold  int
+		compressed bool
+	}{
+		{"app/Compressed", 0, true},
+		{"app/Compressed", 2 * size, false},
+		{"app/Compressed", -1, false},
+		{"app/Uncompressed", -1, false},
+	} {
+		endpoint := &countingServer{pipeServer: &pipeServer{t, handlers, opts}}
+		clientOpts := ClientOptions{Logger: logger, Component: test.component, CompressionThreshold: test.threshold}
+		client, err := Connect(context.Background(), NewConstantResolver(endpoint), clientOpts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer client.Close()
+
+		arg := bytes.Repeat([]byte("x"), size)
+		reply, err := client.Call(context.Background(), MakeMethodKey(test.component, "echo"), arg, CallOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(reply, arg) {
+			t.Fatalf("%s: got %d bytes, want %d", test.component, len(reply), len(arg))
+		}
+
+		// The request is compressed only if the client compresses it, but
+		// the reply is compressed unless the server's component opts out.
+		requestCompressed := endpoint.written.Load() < size
+		replyCompressed := endpoint.read.Load() < size
+		if requestCompressed != test.compressed {
+			t.Errorf("%s (threshold %d): request compressed: got %t, want %t", test.component, test.threshold, requestCompressed, test.compressed)
+		}
+		if want := test.component == "app/Compressed"; replyCompressed != want {
+			t.Errorf("%s (threshold %d): reply compressed: got %t, want %t", test.component, test.threshold, replyCompressed, want)
+		}
+	}
+}
+
+// randomBytes returns n pseudo-random bytes.
+func randomBytes(n int) []byte {
+	b := make([]byte, n)
+	var x uint64 = 1
+	for i := range b {
+		// xorshift64
+		x ^= x << 13
+		x ^= x >> 7
+		x ^= x << 17
+		b[i] = byte(x)
+	}
+	return b
+}
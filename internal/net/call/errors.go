@@ -32,6 +32,17 @@ const (
 	// server is unreachable. Check for it via errors.Is(call.Unreachable).
 	Unreachable
 
+	// CircuitOpen is the type of the error returned by a call when every
+	// server has been ejected by outlier detection. The call fails without
+	// being sent. Check for it via errors.Is(call.CircuitOpen).
+	CircuitOpen
+
+	// Overloaded is the type of the error returned by a call when the server
+	// rejected the call because it was executing too many calls already. The
+	// call was not executed and can be retried. Check for it via
+	// errors.Is(call.Overloaded).
+	Overloaded
+
 	// TODO: Decide what error most applications will want to check for. We may
 	// need to combine CommunicationError and Unreachable. We may also want to
 	// make errors.Is(CommunicationError) return true for both types of errors.
@@ -45,6 +56,10 @@ func (e transportError) Error() string {
 		return "communication error"
 	case Unreachable:
 		return "unreachable"
+	case CircuitOpen:
+		return "circuit open"
+	case Overloaded:
+		return "overloaded"
 	default:
 		return fmt.Sprintf("unknown error %d", e)
 	}
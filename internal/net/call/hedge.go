@@ -0,0 +1,81 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package call
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+const (
+	// hedgeWindow is the number of recent latencies of a method used to
+	// estimate the percentiles of its latency.
+	hedgeWindow = 128
+
+	// minHedgeSamples is the number of latencies of a method needed to
+	// estimate the percentiles of its latency.
+	minHedgeSamples = 20
+)
+
+// latencies holds the latencies of recent successful calls, by method. The
+// zero value is ready to use.
+type latencies struct {
+	mu      sync.Mutex
+	methods map[MethodKey]*latencyWindow
+}
+
+// latencyWindow holds the latencies of the most recent calls to a method.
+type latencyWindow struct {
+	samples []time.Duration // at most hedgeWindow latencies
+	next    int             // index of the oldest latency, once samples is full
+}
+
+// record records the latency of a call to method h.
+func (l *latencies) record(h MethodKey, latency time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.methods == nil {
+		l.methods = map[MethodKey]*latencyWindow{}
+	}
+	w, ok := l.methods[h]
+	if !ok {
+		w = &latencyWindow{samples: make([]time.Duration, 0, hedgeWindow)}
+		l.methods[h] = w
+	}
+	if len(w.samples) < hedgeWindow {
+		w.samples = append(w.samples, latency)
+		return
+	}
+	w.samples[w.next] = latency
+	w.next = (w.next + 1) % hedgeWindow
+}
+
+// percentile returns the p-th percentile of the latencies of recent calls to
+// method h, or false if too few calls have been recorded.
+func (l *latencies) percentile(h MethodKey, p int) (time.Duration, bool) {
+	l.mu.Lock()
+	w, ok := l.methods[h]
+	if !ok || len(w.samples) < minHedgeSamples {
+		l.mu.Unlock()
+		return 0, false
+	}
+	sorted := make([]time.Duration, len(w.samples))
+	copy(sorted, w.samples)
+	l.mu.Unlock()
+
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	return sorted[(len(sorted)-1)*p/100], true
+}
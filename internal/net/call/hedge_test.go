@@ -0,0 +1,62 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package call
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLatencyPercentile(t *testing.T) {
+	var l latencies
+	h := MakeMethodKey("component", "method")
+
+	// Too few latencies have been recorded.
+	for i := 1; i < minHedgeSamples; i++ {
+		l.record(h, time.Duration(i)*time.Millisecond)
+	}
+	if d, ok := l.percentile(h, 95); ok {
+		t.Fatalf("percentile: got %v, want none", d)
+	}
+
+	// Record latencies 1ms, ..., 100ms.
+	for i := minHedgeSamples; i <= 100; i++ {
+		l.record(h, time.Duration(i)*time.Millisecond)
+	}
+	for _, test := range []struct {
+		p    int
+		want time.Duration
+	}{
+		{50, 50 * time.Millisecond},
+		{95, 95 * time.Millisecond},
+	} {
+		if got, ok := l.percentile(h, test.p); !ok || got != test.want {
+			t.Errorf("percentile(%d): got %v, want %v", test.p, got, test.want)
+		}
+	}
+
+	// Old latencies are forgotten. Record 1s latencies to fill the window.
+	for i := 0; i < hedgeWindow; i++ {
+		l.record(h, time.Second)
+	}
+	if got, ok := l.percentile(h, 50); !ok || got != time.Second {
+		t.Errorf("percentile(50): got %v, want %v", got, time.Second)
+	}
+
+	// Other methods have their own latencies.
+	if d, ok := l.percentile(MakeMethodKey("component", "other"), 50); ok {
+		t.Fatalf("percentile: got %v, want none", d)
+	}
+}
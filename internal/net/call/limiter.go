@@ -0,0 +1,225 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package call
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ServiceWeaver/weaver/runtime/codegen"
+)
+
+const (
+	// An adaptive limit is lowered when the latency of a call is more than
+	// adaptiveTolerance times the lowest latency in the current window.
+	adaptiveTolerance = 2.0
+
+	// An adaptive limit is lowered by multiplying it by adaptiveBackoff.
+	adaptiveBackoff = 0.9
+
+	// adaptiveWindow is the number of calls after which the lowest latency is
+	// forgotten, so that an adaptive limit follows lasting latency changes.
+	adaptiveWindow = 1000
+)
+
+// rejection is the error returned by a call that was rejected because the
+// concurrency limit of a component or method was reached.
+type rejection struct {
+	name string // full name of the component or method
+}
+
+// Error implements the error interface.
+func (r rejection) Error() string {
+	return fmt.Sprintf("%v: too many concurrent calls to %s", Overloaded, r.name)
+}
+
+// Unwrap returns Overloaded, so that errors.Is(err, Overloaded) holds.
+func (r rejection) Unwrap() error {
+	return Overloaded
+}
+
+// limiters holds the concurrency limiters of a server, keyed by full
+// component or method name. limiters are shared by all the connections of a
+// server.
+type limiters map[string]*limiter
+
+// newLimiters returns the limiters for the provided limits.
+func newLimiters(limits map[string]ConcurrencyLimit) limiters {
+	ls := limiters{}
+	for name, limit := range limits {
+		ls[name] = newLimiter(limit)
+	}
+	return ls
+}
+
+// acquire waits until a call to the method with the provided full name can
+// be executed without exceeding the limits of the method and its component.
+// It returns a function that must be called once the call has finished. If
+// the call is rejected, acquire returns a rejection error.
+func (ls limiters) acquire(ctx context.Context, name string) (func(), error) {
+	if len(ls) == 0 {
+		return func() {}, nil
+	}
+
+	component, method := splitMethodName(name)
+
+	var acquired []*limiter
+	for _, key := range []string{name, component} {
+		l, ok := ls[key]
+		if !ok {
+			continue
+		}
+		if err := l.acquire(ctx); err != nil {
+			for _, l := range acquired {
+				l.release(0)
+			}
+			if err == Overloaded {
+				labels := codegen.RejectionLabels{Component: component, Method: method, Generated: true}
+				codegen.MethodRejectionsFor(labels).Inc()
+				err = rejection{key}
+			}
+			return nil, err
+		}
+		acquired = append(acquired, l)
+	}
+
+	start := time.Now()
+	return func() {
+		latency := time.Since(start)
+		for _, l := range acquired {
+			l.release(latency)
+		}
+	}, nil
+}
+
+// splitMethodName splits the full name of a method into the full name of its
+// component and the name of the method.
+func splitMethodName(name string) (component, method string) {
+	// Method names have the form "<component>.<method>". Note that component
+	// names may contain dots, but method names don't.
+	if i := strings.LastIndexByte(name, '.'); i >= 0 {
+		return name[:i], name[i+1:]
+	}
+	return name, ""
+}
+
+// limiter bounds the number of calls executed concurrently.
+type limiter struct {
+	max      int  // see ConcurrencyLimit.MaxConcurrent
+	maxQueue int  // see ConcurrencyLimit.MaxQueue
+	adaptive bool // see ConcurrencyLimit.Adaptive
+
+	mu         sync.Mutex
+	limit      float64         // current limit; always max if !adaptive
+	running    int             // number of calls being executed
+	queue      []chan struct{} // calls waiting to be executed, oldest first
+	minLatency time.Duration   // lowest latency in the current window
+	samples    int             // number of calls finished in the current window
+}
+
+// newLimiter returns a new limiter with the provided limit.
+func newLimiter(limit ConcurrencyLimit) *limiter {
+	return &limiter{
+		max:      limit.MaxConcurrent,
+		maxQueue: limit.MaxQueue,
+		adaptive: limit.Adaptive,
+		limit:    float64(limit.MaxConcurrent),
+	}
+}
+
+// acquire waits until a call can be executed. It returns Overloaded if the
+// queue is full, or ctx.Err() if ctx is done while the call is queued.
+func (l *limiter) acquire(ctx context.Context) error {
+	l.mu.Lock()
+	if len(l.queue) == 0 && l.running < int(l.limit) {
+		l.running++
+		l.mu.Unlock()
+		return nil
+	}
+	if len(l.queue) >= l.maxQueue {
+		l.mu.Unlock()
+		return Overloaded
+	}
+	ready := make(chan struct{})
+	l.queue = append(l.queue, ready)
+	l.mu.Unlock()
+
+	select {
+	case <-ready:
+		return nil
+	case <-ctx.Done():
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		for i, c := range l.queue {
+			if c == ready {
+				l.queue = append(l.queue[:i], l.queue[i+1:]...)
+				return ctx.Err()
+			}
+		}
+		// The call was admitted concurrently with ctx being done. Give its
+		// slot to the next call.
+		l.running--
+		l.admit()
+		return ctx.Err()
+	}
+}
+
+// release records that a call, which executed for the provided duration, has
+// finished.
+func (l *limiter) release(latency time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.running--
+	if l.adaptive && latency > 0 {
+		l.adapt(latency)
+	}
+	l.admit()
+}
+
+// adapt updates the limit of an adaptive limiter, using the latency of a
+// call that has finished. The limit is lowered multiplicatively if the latency
+// is well above the lowest recent latency, and raised additively otherwise
+// (by one every limit calls).
+//
+// REQUIRES: l.mu is held.
+func (l *limiter) adapt(latency time.Duration) {
+	if l.samples == 0 || latency < l.minLatency {
+		l.minLatency = latency
+	}
+	l.samples++
+	if float64(latency) > adaptiveTolerance*float64(l.minLatency) {
+		l.limit = max(1, l.limit*adaptiveBackoff)
+	} else {
+		l.limit = min(float64(l.max), l.limit+1/l.limit)
+	}
+	if l.samples == adaptiveWindow {
+		l.samples = 0
+	}
+}
+
+// admit starts the execution of queued calls, oldest first, while the limit
+// allows it.
+//
+// REQUIRES: l.mu is held.
+func (l *limiter) admit() {
+	for len(l.queue) > 0 && l.running < int(l.limit) {
+		close(l.queue[0])
+		l.queue = l.queue[1:]
+		l.running++
+	}
+}
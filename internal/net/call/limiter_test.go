@@ -0,0 +1,201 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package call
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/ServiceWeaver/weaver/runtime/logging"
+)
+
+func TestLimiterQueue(t *testing.T) {
+	ctx := context.Background()
+	l := newLimiter(ConcurrencyLimit{MaxConcurrent: 1, MaxQueue: 1})
+	if err := l.acquire(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	// The second call waits in the queue, and the third call is rejected.
+	admitted := make(chan error)
+	go func() { admitted <- l.acquire(ctx) }()
+	waitForQueue(t, l, 1)
+	if err := l.acquire(ctx); err != Overloaded {
+		t.Fatalf("acquire: got %v, want %v", err, Overloaded)
+	}
+
+	// Once the first call finishes, the second call is admitted.
+	l.release(time.Millisecond)
+	if err := <-admitted; err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLimiterCancelQueued(t *testing.T) {
+	l := newLimiter(ConcurrencyLimit{MaxConcurrent: 1, MaxQueue: 1})
+	if err := l.acquire(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+
+	// A canceled call leaves the queue, making room for another call.
+	ctx, cancel := context.WithCancel(context.Background())
+	admitted := make(chan error)
+	go func() { admitted <- l.acquire(ctx) }()
+	waitForQueue(t, l, 1)
+	cancel()
+	if err := <-admitted; !errors.Is(err, context.Canceled) {
+		t.Fatalf("acquire: got %v, want %v", err, context.Canceled)
+	}
+	waitForQueue(t, l, 0)
+}
+
+func TestAdaptiveLimit(t *testing.T) {
+	l := newLimiter(ConcurrencyLimit{MaxConcurrent: 10, Adaptive: true})
+	finish := func(latency time.Duration) {
+		if err := l.acquire(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+		l.release(latency)
+	}
+
+	// Calls with a steady latency don't lower the limit.
+	for i := 0; i < 10; i++ {
+		finish(time.Millisecond)
+	}
+	if l.limit != 10 {
+		t.Fatalf("limit: got %v, want 10", l.limit)
+	}
+
+	// Slow calls lower the limit, down to 1.
+	for i := 0; i < 100; i++ {
+		finish(10 * time.Millisecond)
+	}
+	if l.limit != 1 {
+		t.Fatalf("limit: got %v, want 1", l.limit)
+	}
+
+	// Fast calls raise the limit again, up to MaxConcurrent.
+	for i := 0; i < 100; i++ {
+		finish(time.Millisecond)
+	}
+	if l.limit != 10 {
+		t.Fatalf("limit: got %v, want 10", l.limit)
+	}
+}
+
+// pipeServer is an Endpoint that serves every connection it dials using
+// ServeOn. Note that every connection has its own concurrency limits.
+type pipeServer struct {
+	t        *testing.T
+	handlers *HandlerMap
+	opts     ServerOptions
+}
+
+func (p *pipeServer) Dial(context.Context) (net.Conn, error) {
+	client, server := net.Pipe()
+	p.t.Cleanup(func() {
+		client.Close()
+		server.Close()
+	})
+	ServeOn(context.Background(), server, p.handlers, p.opts)
+	return client, nil
+}
+
+func (p *pipeServer) Address() string {
+	return "pipe://server"
+}
+
+func TestServerRejectsOverload(t *testing.T) {
+	// Calls to the "block" method block until unblock is closed.
+	unblock := make(chan struct{})
+	started := make(chan struct{})
+	handlers := NewHandlerMap()
+	handlers.Set("app/Comp", "block", func(context.Context, []byte) ([]byte, error) {
+		started <- struct{}{}
+		<-unblock
+		return nil, nil
+	})
+	handlers.Set("app/Comp", "free", func(context.Context, []byte) ([]byte, error) {
+		return nil, nil
+	})
+
+	logger := logging.NewTestSlogger(t, testing.Verbose())
+	endpoint := &pipeServer{t, handlers, ServerOptions{
+		Logger: logger,
+		ConcurrencyLimits: map[string]ConcurrencyLimit{
+			"app/Comp.block": {MaxConcurrent: 1},
+		},
+	}}
+	ctx := context.Background()
+	client, err := Connect(ctx, NewConstantResolver(endpoint), ClientOptions{Logger: logger})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	// Start a blocking call. A second call is rejected, since there is no
+	// queue, but calls to other methods aren't limited.
+	block, free := MakeMethodKey("app/Comp", "block"), MakeMethodKey("app/Comp", "free")
+	done := make(chan error)
+	go func() {
+		_, err := client.Call(ctx, block, nil, CallOptions{})
+		done <- err
+	}()
+	<-started
+	_, err = client.Call(ctx, block, nil, CallOptions{})
+	if !errors.Is(err, Overloaded) {
+		t.Fatalf("Call: got %v, want %v", err, Overloaded)
+	}
+	if want := (rejection{"app/Comp.block"}); err != want {
+		t.Fatalf("Call: got %v, want %v", err, want)
+	}
+	if _, err := client.Call(ctx, free, nil, CallOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Rejected calls are retried, if allowed, until they succeed.
+	retried := make(chan error)
+	go func() {
+		_, err := client.Call(ctx, block, nil, CallOptions{Retry: true, Backoff: time.Millisecond})
+		retried <- err
+	}()
+	close(unblock)
+	if err := <-done; err != nil {
+		t.Fatal(err)
+	}
+	<-started
+	if err := <-retried; err != nil {
+		t.Fatal(err)
+	}
+}
+
+// waitForQueue waits until the queue of l holds n calls.
+func waitForQueue(t *testing.T, l *limiter, n int) {
+	t.Helper()
+	deadline := time.Now().Add(10 * time.Second)
+	for time.Now().Before(deadline) {
+		l.mu.Lock()
+		queued := len(l.queue)
+		l.mu.Unlock()
+		if queued == n {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatalf("timed out waiting for %d queued calls", n)
+}
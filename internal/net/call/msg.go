@@ -20,6 +20,8 @@ import (
 	"io"
 	"net"
 	"sync"
+
+	"github.com/klauspost/compress/s2"
 )
 
 // messageType identifies a type of message sent across the wire.
@@ -31,6 +33,12 @@ const (
 	responseMessage
 	responseError
 	cancelMessage
+	streamRequestMessage
+	streamMessage
+	streamEndMessage
+	streamAckMessage
+	streamCloseMessage
+	overloadedMessage
 	// Other types to add?
 	// - chunked request/response messages?
 	// - health check
@@ -42,9 +50,25 @@ type version uint32
 
 const (
 	initialVersion version = iota
+	streamingVersion
+	overloadVersion
+	compressionVersion
+)
+
+const currentVersion = compressionVersion
+
+// compressedFlag is set in the type of a message whose payload is compressed.
+const compressedFlag messageType = 0x80
+
+// codecs is a set of compression codecs.
+type codecs uint32
+
+const (
+	s2Codec codecs = 1 << iota // github.com/klauspost/compress/s2
 )
 
-const currentVersion = initialVersion
+// supportedCodecs holds the codecs that I can decompress.
+const supportedCodecs = s2Codec
 
 const hdrLenLen = uint32(4) // size of the header length included in each message
 
@@ -52,14 +76,19 @@ const hdrLenLen = uint32(4) // size of the header length included in each messag
 //
 // All messages have the following format:
 //    id        [8]byte       -- identifier used to track the message
-//    type      [1]byte       -- messageType
+//    type      [1]byte       -- messageType, maybe with compressedFlag set
 //    length    [7]byte       -- length of the remainder of the message
 //    payload   [length]byte  -- message-type-specific data
 //
-// The format of payload depends on the message type.
+// The format of payload depends on the message type. If compressedFlag is set
+// in the type, payload holds the s2 compression of the message-type-specific
+// data. A message is compressed only if the peer's version is at least
+// compressionVersion and the peer has announced that it can decompress s2.
 //
 // versionMessage: this is the first message sent on a connection by both sides.
 //    version  [4]byte
+//    codecs   [4]byte  -- compression codecs the sender can decompress; absent
+//                         before compressionVersion
 //
 // requestMessage:
 //    headerLen         [4]byte         -- length of the encoded header
@@ -84,15 +113,43 @@ const hdrLenLen = uint32(4) // size of the header length included in each messag
 //
 // cancelMessage:
 //    payload is empty
+//
+// streamRequestMessage:
+//    same format as requestMessage. Starts a streaming call, whose stream
+//    elements are carried by the stream messages below, all of which use the
+//    id of the streaming call.
+//
+// streamMessage:
+//    payload holds the serialization of a single stream element
+//
+// streamEndMessage:
+//    payload holds an error serialization; a nil error marks the end of the
+//    stream.
+//
+// streamAckMessage:
+//    count    [4]byte  -- number of stream elements consumed by the receiver
+//
+// streamCloseMessage:
+//    payload is empty; the receiver will not consume any more elements.
+//
+// overloadedMessage:
+//    payload holds the full name of the component or method whose concurrency
+//    limit was reached; the server rejected the call without executing it.
+//    Sent in place of responseError if the peer's version is at least
+//    overloadVersion.
 
 // writeMessage formats and sends a message over w.
 //
 // The message payload is formed by concatenating extraHdr and payload.
 // (Allowing two arguments to form the payload avoids unnecessary allocation
 // and copying when we want to prepend some data to application supplied data).
+// If comp is not nil, the payload may be compressed.
 //
 // The write is guarded by wlock, which must not be locked when passed in.
-func writeMessage(w io.Writer, wlock *sync.Mutex, mt messageType, id uint64, extraHdr []byte, payload []byte, flattenLimit int) error {
+func writeMessage(w io.Writer, wlock *sync.Mutex, mt messageType, id uint64, extraHdr []byte, payload []byte, flattenLimit int, comp *compressor) error {
+	if compressed, ok := comp.compress(extraHdr, payload); ok {
+		mt, extraHdr, payload = mt|compressedFlag, nil, compressed
+	}
 	nh, np := len(extraHdr), len(payload)
 	size := 16 + nh + np
 	if size > flattenLimit {
@@ -177,31 +234,54 @@ func readMessage(r io.Reader) (messageType, uint64, []byte, error) {
 	if _, err := io.ReadFull(r, msg); err != nil {
 		return 0, 0, nil, err
 	}
-	return mt, id, msg, nil
+	if mt&compressedFlag == 0 {
+		return mt, id, msg, nil
+	}
+
+	// Decompress the payload.
+	n, err := s2.DecodedLen(msg)
+	if err != nil {
+		return 0, 0, nil, fmt.Errorf("bad compressed message: %w", err)
+	}
+	if n > maxSize {
+		return 0, 0, nil, fmt.Errorf("overly large decompressed message length %d", n)
+	}
+	msg, err = s2.Decode(nil, msg)
+	if err != nil {
+		return 0, 0, nil, fmt.Errorf("bad compressed message: %w", err)
+	}
+	return mt &^ compressedFlag, id, msg, nil
 }
 
-// writeVersion sends my version number to the peer.
+// writeVersion sends my version number, and the codecs I can decompress, to
+// the peer.
 func writeVersion(w io.Writer, wlock *sync.Mutex) error {
-	var msg [4]byte
-	binary.LittleEndian.PutUint32(msg[:], uint32(currentVersion))
+	var msg [8]byte
+	binary.LittleEndian.PutUint32(msg[0:], uint32(currentVersion))
+	binary.LittleEndian.PutUint32(msg[4:], uint32(supportedCodecs))
 	return writeFlat(w, wlock, versionMessage, 0, nil, msg[:])
 }
 
 // getVersion extracts the version number sent by the peer and picks the
-// appropriate version number to use for communicating with the peer.
-func getVersion(id uint64, msg []byte) (version, error) {
+// appropriate version number to use for communicating with the peer. It also
+// reports whether the peer can decompress s2 compressed messages.
+func getVersion(id uint64, msg []byte) (version, bool, error) {
 	if id != 0 {
-		return 0, fmt.Errorf("invalid ID %d in handshake", id)
+		return 0, false, fmt.Errorf("invalid ID %d in handshake", id)
 	}
 	// Allow messages longer than needed so that future updates can send more info.
 	if len(msg) < 4 {
-		return 0, fmt.Errorf("bad version message length %d, must be >= 4", len(msg))
+		return 0, false, fmt.Errorf("bad version message length %d, must be >= 4", len(msg))
 	}
 	v := binary.LittleEndian.Uint32(msg)
 
 	// We use the minimum of the peer and my version numbers.
 	if v < uint32(currentVersion) {
-		return version(v), nil
+		return version(v), false, nil
+	}
+	if len(msg) < 8 {
+		return 0, false, fmt.Errorf("bad version message length %d, must be >= 8", len(msg))
 	}
-	return currentVersion, nil
+	peerCodecs := codecs(binary.LittleEndian.Uint32(msg[4:]))
+	return currentVersion, peerCodecs&s2Codec != 0, nil
 }
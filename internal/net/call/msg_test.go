@@ -50,7 +50,7 @@ func TestConcurrentWrites(t *testing.T) {
 			if rand.Int()%2 == 0 {
 				flattenLimit = 9999999
 			}
-			if err := writeMessage(client, &wlock, requestMessage, uint64(id), extraHdr, payload, flattenLimit); err != nil {
+			if err := writeMessage(client, &wlock, requestMessage, uint64(id), extraHdr, payload, flattenLimit, nil); err != nil {
 				return err
 			}
 			id += numWriters
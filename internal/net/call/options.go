@@ -25,7 +25,13 @@ import (
 
 const (
 	defaultWriteFlattenLimit     = 4 << 10
+	defaultCompressionThreshold  = 64 << 10
 	defaultInlineHandlerDuration = 20 * time.Microsecond
+
+	defaultConsecutiveFailures = 5
+	defaultLatencyFactor       = 10
+	defaultBaseEjectionTime    = 10 * time.Second
+	defaultMaxEjectionTime     = 5 * time.Minute
 )
 
 // ClientOptions are the options to configure an RPC client.
@@ -33,6 +39,14 @@ type ClientOptions struct {
 	// Load balancer. Defaults to RoundRobin() if nil.
 	Balancer Balancer
 
+	// Name of the component called over the connection, if any. Used in logs
+	// and metrics.
+	Component string
+
+	// If non-nil, replicas that fail or are much slower than the others are
+	// ejected from the load balancer for a while. See OutlierDetection.
+	OutlierDetection *OutlierDetection
+
 	// Logger. Defaults to a logger that logs to stderr.
 	Logger *slog.Logger
 
@@ -44,6 +58,34 @@ type ClientOptions struct {
 	// buffer before being written on the connection. If zero, an appropriate
 	// value is picked automatically. If negative, no flattening is done.
 	WriteFlattenLimit int
+
+	// Requests at least this many bytes long are compressed, if the server
+	// can decompress them. If zero, an appropriate value is picked
+	// automatically. If negative, requests are never compressed.
+	CompressionThreshold int
+}
+
+// OutlierDetection configures the ejection of misbehaving replicas. An
+// ejected replica isn't picked by the load balancer until its ejection ends.
+// If every replica is ejected, calls fail fast with a CircuitOpen error.
+type OutlierDetection struct {
+	// A replica is ejected after this many consecutive failed calls. If zero,
+	// an appropriate value is picked automatically. If negative, replicas are
+	// never ejected because of failures.
+	ConsecutiveFailures int
+
+	// A replica is ejected if its recent call latency is more than this many
+	// times the median recent call latency of all replicas. The last
+	// replica that isn't ejected is never ejected because of its latency. If
+	// zero, an appropriate value is picked automatically. If negative,
+	// replicas are never ejected because of their latency.
+	LatencyFactor float64
+
+	// The duration of the first ejection of a replica. The n-th consecutive
+	// ejection of a replica lasts n times longer, up to MaxEjectionTime. If
+	// zero, appropriate values are picked automatically.
+	BaseEjectionTime time.Duration
+	MaxEjectionTime  time.Duration
 }
 
 // ServerOption are the options to configure an RPC server.
@@ -65,6 +107,40 @@ type ServerOptions struct {
 	// buffer before being written on the connection. If zero, an appropriate
 	// value is picked automatically. If negative, no flattening is done.
 	WriteFlattenLimit int
+
+	// Limits on the number of calls executed concurrently, keyed by the full
+	// name of a component (e.g., "github.com/example/app/Cache") or of a
+	// component method (e.g., "github.com/example/app/Cache.Get"). A call is
+	// subject to the limits of both its component and its method. Calls
+	// beyond the limits are rejected with an Overloaded error.
+	ConcurrencyLimits map[string]ConcurrencyLimit
+
+	// Replies at least this many bytes long are compressed, if the client
+	// can decompress them. If zero, an appropriate value is picked
+	// automatically. If negative, replies are never compressed.
+	CompressionThreshold int
+
+	// Full names of the components (e.g., "github.com/example/app/Cache")
+	// whose replies are never compressed.
+	UncompressedComponents []string
+}
+
+// ConcurrencyLimit bounds the number of calls executed concurrently by a
+// server. Calls beyond the limit wait in a queue, and calls received while
+// the queue is full are rejected.
+type ConcurrencyLimit struct {
+	// The maximum number of calls executed concurrently. Must be positive.
+	MaxConcurrent int
+
+	// The maximum number of calls waiting in the queue. If zero, calls beyond
+	// MaxConcurrent are rejected right away.
+	MaxQueue int
+
+	// If true, the number of calls executed concurrently adapts to the
+	// latency of the calls, between 1 and MaxConcurrent. The limit is
+	// lowered multiplicatively when the latency of a call rises well above
+	// the lowest recent latency, and raised additively otherwise.
+	Adaptive bool
 }
 
 // CallOptions are call-specific options.
@@ -73,6 +149,32 @@ type CallOptions struct {
 	// errors should be retried.
 	Retry bool
 
+	// Attempts, if positive, is the maximum number of attempts of a call that
+	// is retried. Otherwise, the call is retried until its context is done.
+	Attempts int
+
+	// Backoff, if positive, is the delay before the first retry of a call.
+	// Otherwise, retry.DefaultOptions are used.
+	Backoff time.Duration
+
+	// Hedge, if positive, is the delay after which an attempt of a call that
+	// is retried is sent a second time, if it hasn't finished yet. The second
+	// attempt avoids the replica of the first attempt (see Avoid). The first
+	// reply is used, and the other attempt is canceled. Only calls that are
+	// safe to execute twice should be hedged.
+	Hedge time.Duration
+
+	// HedgePercentile, if positive, makes the hedging delay the given
+	// percentile of the latencies of recent successful attempts of calls to
+	// the same method that also set HedgePercentile. Hedge is used until
+	// enough attempts have finished.
+	HedgePercentile int
+
+	// Avoid, if not empty, is the address of a replica that a Balancer should
+	// not pick, unless it's the only replica available. A Balancer can choose
+	// to ignore Avoid.
+	Avoid string
+
 	// ShardKey, if not 0, is the shard key that a Balancer can use to route a
 	// call. A Balancer can always choose to ignore the ShardKey.
 	//
@@ -93,6 +195,25 @@ func (c ClientOptions) withDefaults() ClientOptions {
 	if c.WriteFlattenLimit == 0 {
 		c.WriteFlattenLimit = defaultWriteFlattenLimit
 	}
+	if c.CompressionThreshold == 0 {
+		c.CompressionThreshold = defaultCompressionThreshold
+	}
+	if c.OutlierDetection != nil {
+		od := *c.OutlierDetection
+		if od.ConsecutiveFailures == 0 {
+			od.ConsecutiveFailures = defaultConsecutiveFailures
+		}
+		if od.LatencyFactor == 0 {
+			od.LatencyFactor = defaultLatencyFactor
+		}
+		if od.BaseEjectionTime == 0 {
+			od.BaseEjectionTime = defaultBaseEjectionTime
+		}
+		if od.MaxEjectionTime == 0 {
+			od.MaxEjectionTime = defaultMaxEjectionTime
+		}
+		c.OutlierDetection = &od
+	}
 	return c
 }
 
@@ -111,5 +232,8 @@ func (s ServerOptions) withDefaults() ServerOptions {
 	if s.WriteFlattenLimit == 0 {
 		s.WriteFlattenLimit = defaultWriteFlattenLimit
 	}
+	if s.CompressionThreshold == 0 {
+		s.CompressionThreshold = defaultCompressionThreshold
+	}
 	return s
 }
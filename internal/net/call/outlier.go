@@ -0,0 +1,171 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package call
+
+import (
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/ServiceWeaver/weaver/metrics"
+)
+
+// minLatencySamples is the number of calls a replica must have answered
+// before its latency is compared to the latency of other replicas.
+const minLatencySamples = 10
+
+var (
+	ejectionCounts = metrics.NewCounterMap[ejectionLabels](
+		"serviceweaver_system_replica_ejection_count",
+		"Count of replicas ejected by outlier detection",
+	)
+	ejectedReplicas = metrics.NewGaugeMap[circuitLabels](
+		"serviceweaver_system_replica_ejected",
+		"Number of replicas currently ejected by outlier detection",
+	)
+	circuitOpenCounts = metrics.NewCounterMap[circuitLabels](
+		"serviceweaver_system_circuit_open_count",
+		"Count of calls that failed fast because all replicas were ejected",
+	)
+)
+
+type ejectionLabels struct {
+	Component string // full callee component name
+	Reason    string // "failures" or "latency"
+}
+
+type circuitLabels struct {
+	Component string // full callee component name
+}
+
+// observeResult records the result of a call on c. Failed calls may cause c
+// to be ejected.
+//
+// REQUIRES: rc.mu is not held.
+func (c *clientConnection) observeResult(err error) {
+	od := c.rc.opts.OutlierDetection
+	if od == nil || errors.Is(err, Overloaded) {
+		// A replica that rejects calls because it is overloaded is otherwise
+		// healthy. Load-aware balancers steer calls away from it.
+		return
+	}
+	c.rc.mu.Lock()
+	defer c.rc.mu.Unlock()
+	if err == nil {
+		c.failures = 0
+		if !c.ejected {
+			c.ejections = 0
+		}
+		return
+	}
+	c.failures++
+	if od.ConsecutiveFailures > 0 && c.failures >= od.ConsecutiveFailures && !c.ejected {
+		c.eject("failures")
+	}
+}
+
+// checkLatency ejects c if its recent call latency is much higher than the
+// recent call latency of the other replicas.
+//
+// REQUIRES: rc.mu is held.
+func (c *clientConnection) checkLatency() {
+	od := c.rc.opts.OutlierDetection
+	if od == nil || od.LatencyFactor <= 0 || c.ejected || c.samples < minLatencySamples {
+		return
+	}
+
+	var latencies []time.Duration
+	available := 0
+	for _, other := range c.rc.conns {
+		if (other.state != idle && other.state != active) || other.ejected {
+			continue
+		}
+		available++
+		if other.samples >= minLatencySamples {
+			latencies = append(latencies, other.latency)
+		}
+	}
+	if available <= 1 || len(latencies) <= 1 {
+		return
+	}
+	sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+	median := latencies[(len(latencies)-1)/2]
+	if float64(c.latency) > od.LatencyFactor*float64(median) {
+		c.eject("latency")
+	}
+}
+
+// eject removes c from the balancer until its ejection ends.
+//
+// REQUIRES: rc.mu is held.
+func (c *clientConnection) eject(reason string) {
+	od := c.rc.opts.OutlierDetection
+	c.ejections++
+	duration := time.Duration(c.ejections) * od.BaseEjectionTime
+	if duration > od.MaxEjectionTime {
+		duration = od.MaxEjectionTime
+	}
+	c.ejected = true
+	c.fixBalancer()
+
+	component := c.rc.opts.Component
+	c.logger.Warn("Ejected replica", "component", component, "addr", c.endpoint.Address(), "reason", reason, "latency", c.latency, "duration", duration)
+	ejectionCounts.Get(ejectionLabels{Component: component, Reason: reason}).Inc()
+	ejectedReplicas.Get(circuitLabels{Component: component}).Add(1)
+
+	c.ejectTimer = time.AfterFunc(duration, func() {
+		c.rc.mu.Lock()
+		defer c.rc.mu.Unlock()
+		c.reinstate()
+	})
+}
+
+// reinstate ends the ejection of c.
+//
+// REQUIRES: rc.mu is held.
+func (c *clientConnection) reinstate() {
+	if !c.ejected {
+		return
+	}
+	c.ejected = false
+	c.ejectTimer = nil
+	c.failures = 0
+	c.latency = 0 // the old latency led to the ejection
+	c.samples = 0
+	c.fixBalancer()
+
+	component := c.rc.opts.Component
+	c.logger.Info("Reinstated replica", "component", component, "addr", c.endpoint.Address())
+	ejectedReplicas.Get(circuitLabels{Component: component}).Sub(1)
+}
+
+// ejected returns the number of replicas that are ejected, if every replica
+// that could otherwise be picked by the balancer is ejected. Otherwise, it
+// returns zero.
+//
+// REQUIRES: rc.mu is held.
+func (rc *reconnectingConnection) ejected() int {
+	n := 0
+	for _, c := range rc.conns {
+		if c.state != idle && c.state != active {
+			continue
+		}
+		if !c.ejected {
+			return 0
+		}
+		n++
+	}
+	return n
+}
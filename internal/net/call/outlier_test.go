@@ -0,0 +1,167 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package call_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ServiceWeaver/weaver/internal/net/call"
+)
+
+var flakyKey = call.MakeMethodKey("", "flaky")
+
+// flakyServer returns a fake pipe-based endpoint with the given name. Calls
+// to its "flaky" method return the name of the server, after the provided
+// delay, unless fail is set.
+func flakyServer(t testing.TB, name string, delay time.Duration, fail *atomic.Bool) call.Endpoint {
+	h := handlersFor(name)
+	h.Set("", "flaky", func(context.Context, []byte) ([]byte, error) {
+		time.Sleep(delay)
+		if fail != nil && fail.Load() {
+			return nil, fmt.Errorf("server %s failed", name)
+		}
+		return []byte(name), nil
+	})
+	return &pipeEndpoint{name: name, handlers: h, t: t}
+}
+
+// connectWithOutlierDetection returns a round-robin client that ejects
+// replicas according to the provided options.
+func connectWithOutlierDetection(t *testing.T, od call.OutlierDetection, endpoints ...call.Endpoint) call.Connection {
+	t.Helper()
+	opts := call.ClientOptions{
+		Balancer:         call.RoundRobin(),
+		Logger:           logger(t),
+		Component:        "outlier",
+		OutlierDetection: &od,
+	}
+	client, err := call.Connect(context.Background(), call.NewConstantResolver(endpoints...), opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(client.Close)
+
+	// Wait for all replicas to be connected.
+	ctx := context.Background()
+	seen := map[string]bool{}
+	waitUntil(t, func() bool {
+		who, err := client.Call(ctx, whoKey, nil, call.CallOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		seen[string(who)] = true
+		return len(seen) == len(endpoints)
+	})
+	return client
+}
+
+func TestEjectFailingReplica(t *testing.T) {
+	var fail atomic.Bool
+	fail.Store(true)
+	od := call.OutlierDetection{ConsecutiveFailures: 3, BaseEjectionTime: time.Hour}
+	client := connectWithOutlierDetection(t, od,
+		flakyServer(t, "good", 0, nil),
+		flakyServer(t, "bad", 0, &fail))
+
+	// Every call to the bad replica fails. After three failures, it's
+	// ejected and every call succeeds.
+	ctx := context.Background()
+	failures := 0
+	for i := 0; i < 20; i++ {
+		who, err := client.Call(ctx, flakyKey, nil, call.CallOptions{})
+		if err != nil {
+			failures++
+			continue
+		}
+		if string(who) != "good" {
+			t.Fatalf("call %d: got %q, want %q", i, who, "good")
+		}
+	}
+	if failures != 3 {
+		t.Fatalf("got %d failed calls, want 3", failures)
+	}
+}
+
+func TestCircuitOpen(t *testing.T) {
+	var fail atomic.Bool
+	fail.Store(true)
+	od := call.OutlierDetection{ConsecutiveFailures: 3, BaseEjectionTime: 100 * time.Millisecond}
+	client := connectWithOutlierDetection(t, od, flakyServer(t, "bad", 0, &fail))
+
+	// The only replica is ejected after three failures, so the next call
+	// fails fast.
+	ctx := context.Background()
+	for i := 0; i < 3; i++ {
+		if _, err := client.Call(ctx, flakyKey, nil, call.CallOptions{}); err == nil || errors.Is(err, call.CircuitOpen) {
+			t.Fatalf("call %d: got %v, want server error", i, err)
+		}
+	}
+	if _, err := client.Call(ctx, flakyKey, nil, call.CallOptions{Retry: true}); !errors.Is(err, call.CircuitOpen) {
+		t.Fatalf("got %v, want %v", err, call.CircuitOpen)
+	}
+
+	// Once the replica recovers, calls succeed again after the ejection.
+	fail.Store(false)
+	waitUntil(t, func() bool {
+		_, err := client.Call(ctx, flakyKey, nil, call.CallOptions{})
+		return err == nil
+	})
+}
+
+func TestEjectSlowReplica(t *testing.T) {
+	od := call.OutlierDetection{LatencyFactor: 5, BaseEjectionTime: time.Hour}
+	client := connectWithOutlierDetection(t, od,
+		flakyServer(t, "fast1", 0, nil),
+		flakyServer(t, "fast2", 0, nil),
+		flakyServer(t, "slow", 20*time.Millisecond, nil))
+
+	// Once enough calls have finished, the slow replica is ejected.
+	ctx := context.Background()
+	for i := 0; i < 100; i++ {
+		if _, err := client.Call(ctx, flakyKey, nil, call.CallOptions{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for i := 0; i < 20; i++ {
+		who, err := client.Call(ctx, flakyKey, nil, call.CallOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(who) == "slow" {
+			t.Fatalf("call %d: slow replica not ejected", i)
+		}
+	}
+}
+
+func TestNeverEjectLastReplicaForLatency(t *testing.T) {
+	od := call.OutlierDetection{LatencyFactor: 1.5, BaseEjectionTime: time.Hour}
+	client := connectWithOutlierDetection(t, od,
+		flakyServer(t, "fast", 0, nil),
+		flakyServer(t, "slow", 10*time.Millisecond, nil))
+
+	// The slow replica may be ejected, but the fast replica is never ejected
+	// after it, so all calls succeed.
+	ctx := context.Background()
+	for i := 0; i < 100; i++ {
+		if _, err := client.Call(ctx, flakyKey, nil, call.CallOptions{}); err != nil {
+			t.Fatalf("call %d: %v", i, err)
+		}
+	}
+}
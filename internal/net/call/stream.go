@@ -0,0 +1,290 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package call
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/ServiceWeaver/weaver/runtime/codegen"
+)
+
+// streamWindow is the number of stream elements a sender may have in flight
+// before it has to wait for the receiver to acknowledge some of them. The
+// receiver acknowledges elements in batches of streamWindow/2.
+const streamWindow = 16
+
+// stream holds the state of the element streams of a streaming call. It is
+// used on both the client and the server: the outgoing half carries the
+// elements sent by this side of the call, and the incoming half carries the
+// elements sent by the peer.
+type stream struct {
+	write func(mt messageType, payload []byte) error // sends a message to the peer
+	done  chan struct{}                              // closed when the call finishes
+
+	mu       sync.Mutex
+	changed  chan struct{} // closed and replaced on every state change
+	finished bool          // has the call finished?
+
+	// Outgoing half.
+	credits  int   // number of elements we may send without waiting
+	sendDone bool  // has CloseSend been called?
+	sendErr  error // if non-nil, Send fails with this error
+
+	// Incoming half.
+	pending    [][]byte // received elements not yet returned by Recv
+	unacked    uint32   // number of elements returned by Recv but not acked
+	recvErr    error    // if non-nil, the peer has ended its stream
+	recvClosed bool     // has CloseRecv been called?
+}
+
+var _ codegen.StreamConn = &stream{}
+
+func newStream(write func(messageType, []byte) error) *stream {
+	return &stream{
+		write:   write,
+		done:    make(chan struct{}),
+		changed: make(chan struct{}),
+		credits: streamWindow,
+	}
+}
+
+// broadcast wakes up all goroutines waiting for a state change.
+// REQUIRES: s.mu is held.
+func (s *stream) broadcast() {
+	close(s.changed)
+	s.changed = make(chan struct{})
+}
+
+// wait waits for a state change or for ctx to be done.
+// REQUIRES: s.mu is held.
+func (s *stream) wait(ctx context.Context) error {
+	changed := s.changed
+	s.mu.Unlock()
+	defer s.mu.Lock()
+	select {
+	case <-changed:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Send implements the codegen.StreamConn interface.
+func (s *stream) Send(ctx context.Context, msg []byte) error {
+	s.mu.Lock()
+	for {
+		if s.sendDone {
+			s.mu.Unlock()
+			return fmt.Errorf("send on closed stream: %w", io.ErrClosedPipe)
+		}
+		if s.sendErr != nil {
+			err := s.sendErr
+			s.mu.Unlock()
+			return err
+		}
+		if s.credits > 0 {
+			break
+		}
+		if err := s.wait(ctx); err != nil {
+			s.mu.Unlock()
+			return err
+		}
+	}
+	s.credits--
+	s.mu.Unlock()
+	return s.write(streamMessage, msg)
+}
+
+// CloseSend implements the codegen.StreamConn interface.
+func (s *stream) CloseSend(err error) error {
+	s.mu.Lock()
+	if s.sendDone {
+		s.mu.Unlock()
+		return nil
+	}
+	s.sendDone = true
+	finished := s.finished
+	s.broadcast()
+	s.mu.Unlock()
+
+	if finished {
+		return nil
+	}
+	return s.write(streamEndMessage, encodeError(err))
+}
+
+// Recv implements the codegen.StreamConn interface.
+func (s *stream) Recv(ctx context.Context) ([]byte, error) {
+	s.mu.Lock()
+	for len(s.pending) == 0 && s.recvErr == nil && !s.recvClosed {
+		if err := s.wait(ctx); err != nil {
+			s.mu.Unlock()
+			return nil, err
+		}
+	}
+	if s.recvClosed {
+		s.mu.Unlock()
+		return nil, fmt.Errorf("receive on closed stream: %w", io.ErrClosedPipe)
+	}
+	if len(s.pending) == 0 {
+		err := s.recvErr
+		s.mu.Unlock()
+		return nil, err
+	}
+
+	msg := s.pending[0]
+	s.pending[0] = nil
+	s.pending = s.pending[1:]
+
+	// Acknowledge consumed elements in batches, so that the sender can send
+	// more of them.
+	s.unacked++
+	var ack uint32
+	if s.unacked >= streamWindow/2 && s.recvErr == nil && !s.finished {
+		ack = s.unacked
+		s.unacked = 0
+	}
+	s.mu.Unlock()
+
+	if ack > 0 {
+		var payload [4]byte
+		binary.LittleEndian.PutUint32(payload[:], ack)
+		if err := s.write(streamAckMessage, payload[:]); err != nil {
+			return nil, err
+		}
+	}
+	return msg, nil
+}
+
+// CloseRecv implements the codegen.StreamConn interface.
+func (s *stream) CloseRecv() {
+	s.mu.Lock()
+	if s.recvClosed {
+		s.mu.Unlock()
+		return
+	}
+	s.recvClosed = true
+	s.pending = nil
+	notify := s.recvErr == nil && !s.finished
+	s.broadcast()
+	s.mu.Unlock()
+
+	if notify {
+		// Tell the peer to stop sending. Errors are ignored since the peer
+		// will learn about a broken connection on its own.
+		s.write(streamCloseMessage, nil)
+	}
+}
+
+// Done implements the codegen.StreamConn interface.
+func (s *stream) Done() <-chan struct{} {
+	return s.done
+}
+
+// handle processes a stream message received from the peer.
+func (s *stream) handle(mt messageType, msg []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	switch mt {
+	case streamMessage:
+		if s.recvClosed || s.recvErr != nil {
+			return nil // no longer interested in elements
+		}
+		if len(s.pending) >= streamWindow {
+			return fmt.Errorf("stream flow control violated")
+		}
+		s.pending = append(s.pending, msg)
+	case streamEndMessage:
+		if s.recvErr != nil {
+			return nil
+		}
+		err, ok := decodeError(msg)
+		if !ok {
+			err = fmt.Errorf("%w: could not decode stream error", CommunicationError)
+		} else if err == nil {
+			err = io.EOF
+		}
+		s.recvErr = err
+	case streamAckMessage:
+		if len(msg) < 4 {
+			return fmt.Errorf("bad stream ack length %d, must be >= 4", len(msg))
+		}
+		s.credits += int(binary.LittleEndian.Uint32(msg))
+	case streamCloseMessage:
+		if s.sendErr == nil {
+			s.sendErr = fmt.Errorf("stream closed by receiver: %w", io.ErrClosedPipe)
+		}
+	default:
+		return fmt.Errorf("invalid stream message type %d", mt)
+	}
+	s.broadcast()
+	return nil
+}
+
+// ended returns true if the peer has ended its stream.
+func (s *stream) ended() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.recvErr != nil
+}
+
+// waitSendDone waits until the outgoing half of the stream is done or ctx is
+// done.
+func (s *stream) waitSendDone(ctx context.Context) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for !s.sendDone && s.sendErr == nil {
+		if s.wait(ctx) != nil {
+			return
+		}
+	}
+}
+
+// finish marks the call as finished. Any subsequent sends fail with err, and
+// receives fail with err once all buffered elements have been received.
+func (s *stream) finish(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.finished {
+		return
+	}
+	s.finished = true
+	if s.sendErr == nil {
+		s.sendErr = err
+	}
+	if s.recvErr == nil {
+		s.recvErr = err
+	}
+	close(s.done)
+	s.broadcast()
+}
+
+// clientStream is the client side of a streaming call.
+type clientStream struct {
+	*stream
+	rpc *call
+}
+
+var _ codegen.ClientStream = &clientStream{}
+
+// Reply implements the codegen.ClientStream interface.
+func (cs *clientStream) Reply() ([]byte, error) {
+	<-cs.rpc.doneSignal
+	return cs.rpc.response, cs.rpc.err
+}
@@ -0,0 +1,335 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package call_test
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"testing"
+
+	"github.com/ServiceWeaver/weaver/internal/net/call"
+	"github.com/ServiceWeaver/weaver/runtime/codegen"
+)
+
+var (
+	streamEchoKey     = call.MakeMethodKey("", "streamecho")
+	streamCountKey    = call.MakeMethodKey("", "streamcount")
+	streamGenerateKey = call.MakeMethodKey("", "streamgenerate")
+	streamWaitKey     = call.MakeMethodKey("", "streamwait")
+)
+
+func streamHandlers() *call.HandlerMap {
+	m := call.NewHandlerMap()
+	m.Set("", "streamecho", streamEchoHandler)
+	m.Set("", "streamcount", streamCountHandler)
+	m.Set("", "streamgenerate", streamGenerateHandler)
+	m.Set("", "streamwait", streamWaitHandler)
+	return m
+}
+
+// streamEchoHandler echoes every received element back to the client.
+func streamEchoHandler(ctx context.Context, arg []byte) ([]byte, error) {
+	conn, err := codegen.ServerStream(ctx)
+	if err != nil {
+		return nil, err
+	}
+	go func() {
+		for {
+			msg, err := conn.Recv(ctx)
+			if err != nil {
+				if errors.Is(err, io.EOF) {
+					err = nil
+				}
+				conn.CloseSend(err)
+				return
+			}
+			if err := conn.Send(ctx, msg); err != nil {
+				return
+			}
+		}
+	}()
+	return arg, nil
+}
+
+// streamCountHandler returns the number of received elements.
+func streamCountHandler(ctx context.Context, _ []byte) ([]byte, error) {
+	conn, err := codegen.ServerStream(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.CloseSend(nil)
+	n := 0
+	for {
+		_, err := conn.Recv(ctx)
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+		n++
+	}
+	return []byte(strconv.Itoa(n)), nil
+}
+
+// streamGenerateHandler sends the number of elements specified in its
+// argument, or an unbounded number of elements if the argument is empty.
+func streamGenerateHandler(ctx context.Context, arg []byte) ([]byte, error) {
+	conn, err := codegen.ServerStream(ctx)
+	if err != nil {
+		return nil, err
+	}
+	n := -1
+	if len(arg) > 0 {
+		if n, err = strconv.Atoi(string(arg)); err != nil {
+			return nil, err
+		}
+	}
+	go func() {
+		for i := 0; n < 0 || i < n; i++ {
+			var msg [8]byte
+			binary.LittleEndian.PutUint64(msg[:], uint64(i))
+			if err := conn.Send(ctx, msg[:]); err != nil {
+				conn.CloseSend(err)
+				return
+			}
+		}
+		conn.CloseSend(nil)
+	}()
+	return nil, nil
+}
+
+// streamWaitHandler sends nothing until the call is canceled.
+func streamWaitHandler(ctx context.Context, _ []byte) ([]byte, error) {
+	conn, err := codegen.ServerStream(ctx)
+	if err != nil {
+		return nil, err
+	}
+	go func() {
+		<-ctx.Done()
+		conn.CloseSend(ctx.Err())
+	}()
+	return nil, nil
+}
+
+func streamClient(t *testing.T) call.Connection {
+	t.Helper()
+	endpoint := &pipeEndpoint{name: "streams", handlers: streamHandlers(), t: t}
+	client, err := call.Connect(context.Background(), call.NewConstantResolver(endpoint), call.ClientOptions{Logger: logger(t)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(client.Close)
+	return client
+}
+
+// waitDone waits for the streams of a call to finish.
+func waitDone(t *testing.T, ctx context.Context, conn codegen.StreamConn) {
+	t.Helper()
+	select {
+	case <-conn.Done():
+	case <-ctx.Done():
+		t.Fatal("call did not finish")
+	}
+}
+
+func TestStreamEcho(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+	client := streamClient(t)
+
+	conn, err := client.Stream(ctx, streamEchoKey, []byte("hello"), call.CallOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	reply, err := conn.Reply()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := string(reply), "hello"; got != want {
+		t.Fatalf("reply: got %q, want %q", got, want)
+	}
+
+	// Send many more elements than fit in the flow control window,
+	// concurrently with receiving them.
+	const n = 1000
+	go func() {
+		for i := 0; i < n; i++ {
+			if err := conn.Send(ctx, []byte(strconv.Itoa(i))); err != nil {
+				t.Error(err)
+				return
+			}
+		}
+		conn.CloseSend(nil)
+	}()
+	for i := 0; i < n; i++ {
+		msg, err := conn.Recv(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, want := string(msg), strconv.Itoa(i); got != want {
+			t.Fatalf("element %d: got %q, want %q", i, got, want)
+		}
+	}
+	if _, err := conn.Recv(ctx); !errors.Is(err, io.EOF) {
+		t.Fatalf("got %v, want io.EOF", err)
+	}
+	waitDone(t, ctx, conn)
+}
+
+func TestStreamCount(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+	client := streamClient(t)
+
+	conn, err := client.Stream(ctx, streamCountKey, nil, call.CallOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	const n = 100
+	for i := 0; i < n; i++ {
+		if err := conn.Send(ctx, []byte{byte(i)}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := conn.CloseSend(nil); err != nil {
+		t.Fatal(err)
+	}
+	reply, err := conn.Reply()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := string(reply), strconv.Itoa(n); got != want {
+		t.Fatalf("reply: got %q, want %q", got, want)
+	}
+	if _, err := conn.Recv(ctx); !errors.Is(err, io.EOF) {
+		t.Fatalf("got %v, want io.EOF", err)
+	}
+	waitDone(t, ctx, conn)
+}
+
+func TestStreamGenerate(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+	client := streamClient(t)
+
+	const n = 500
+	conn, err := client.Stream(ctx, streamGenerateKey, []byte(strconv.Itoa(n)), call.CallOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := conn.Reply(); err != nil {
+		t.Fatal(err)
+	}
+	conn.CloseSend(nil)
+	for i := 0; i < n; i++ {
+		msg, err := conn.Recv(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := binary.LittleEndian.Uint64(msg); got != uint64(i) {
+			t.Fatalf("element %d: got %d", i, got)
+		}
+	}
+	if _, err := conn.Recv(ctx); !errors.Is(err, io.EOF) {
+		t.Fatalf("got %v, want io.EOF", err)
+	}
+	waitDone(t, ctx, conn)
+}
+
+func TestStreamCloseRecv(t *testing.T) {
+	// Stop receiving from an unbounded stream. The server should notice and
+	// the call should finish.
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+	client := streamClient(t)
+
+	conn, err := client.Stream(ctx, streamGenerateKey, nil, call.CallOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	conn.CloseSend(nil)
+	for i := 0; i < 5; i++ {
+		if _, err := conn.Recv(ctx); err != nil {
+			t.Fatal(err)
+		}
+	}
+	conn.CloseRecv()
+	if _, err := conn.Recv(ctx); !errors.Is(err, io.ErrClosedPipe) {
+		t.Fatalf("got %v, want io.ErrClosedPipe", err)
+	}
+	waitDone(t, ctx, conn)
+}
+
+func TestStreamCancel(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+	client := streamClient(t)
+
+	callCtx, callCancel := context.WithCancel(ctx)
+	conn, err := client.Stream(callCtx, streamWaitKey, nil, call.CallOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	errs := make(chan error, 1)
+	go func() {
+		_, err := conn.Recv(ctx)
+		errs <- err
+	}()
+	callCancel()
+	if err := <-errs; !errors.Is(err, context.Canceled) {
+		t.Fatalf("got %v, want context.Canceled", err)
+	}
+	waitDone(t, ctx, conn)
+}
+
+func TestStreamManyCalls(t *testing.T) {
+	// Run concurrent streaming calls over the same connection.
+	ctx, cancel := context.WithTimeout(context.Background(), testTimeout)
+	defer cancel()
+	client := streamClient(t)
+
+	const calls = 20
+	const n = 50
+	errs := make(chan error, calls)
+	for c := 0; c < calls; c++ {
+		go func() {
+			errs <- func() error {
+				conn, err := client.Stream(ctx, streamGenerateKey, []byte(strconv.Itoa(n)), call.CallOptions{})
+				if err != nil {
+					return err
+				}
+				conn.CloseSend(nil)
+				for i := 0; i < n; i++ {
+					if _, err := conn.Recv(ctx); err != nil {
+						return err
+					}
+				}
+				if _, err := conn.Recv(ctx); !errors.Is(err, io.EOF) {
+					return fmt.Errorf("got %v, want io.EOF", err)
+				}
+				return nil
+			}()
+		}()
+	}
+	for c := 0; c < calls; c++ {
+		if err := <-errs; err != nil {
+			t.Fatal(err)
+		}
+	}
+}
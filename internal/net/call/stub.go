@@ -30,8 +30,10 @@ type stub struct {
 }
 
 type stubMethod struct {
-	key   MethodKey // key for remote component method
-	retry bool      // Whether or not the method should be retred
+	key        MethodKey             // key for remote component method
+	retry      bool                  // Whether or not the method should be retred
+	idempotent bool                  // Whether or not the method can be hedged
+	options    codegen.MethodOptions // method options, if any
 }
 
 var _ codegen.Stub = &stub{}
@@ -55,9 +57,21 @@ func (s *stub) Tracer() trace.Tracer {
 // Run implements the codegen.Stub interface.
 func (s *stub) Run(ctx context.Context, method int, args []byte, shardKey uint64) (result []byte, err error) {
 	m := s.methods[method]
+	if m.options.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, m.options.Timeout)
+		defer cancel()
+	}
 	opts := CallOptions{
 		Retry:    m.retry,
 		ShardKey: shardKey,
+		Attempts: m.options.Attempts,
+		Backoff:  m.options.Backoff,
+	}
+	if m.idempotent {
+		// Never hedge a method that may not be executed twice.
+		opts.Hedge = m.options.Hedge
+		opts.HedgePercentile = m.options.HedgePercentile
 	}
 	n := 1
 	if m.retry {
@@ -70,6 +84,12 @@ func (s *stub) Run(ctx context.Context, method int, args []byte, shardKey uint64
 	return
 }
 
+// Stream implements the codegen.Stub interface.
+func (s *stub) Stream(ctx context.Context, method int, args []byte, shardKey uint64) (codegen.ClientStream, error) {
+	m := s.methods[method]
+	return s.conn.Stream(ctx, m.key, args, CallOptions{ShardKey: shardKey})
+}
+
 // makeStubMethods returns a slice of stub methods for the component methods of reg.
 func makeStubMethods(fullName string, reg *codegen.Registration) []stubMethod {
 	// Construct method info slice.
@@ -83,5 +103,11 @@ func makeStubMethods(fullName string, reg *codegen.Registration) []stubMethod {
 	for _, m := range reg.NoRetry {
 		methods[m].retry = false
 	}
+	for _, m := range reg.Idempotent {
+		methods[m].idempotent = true
+	}
+	for m, opts := range reg.Options {
+		methods[m].options = opts
+	}
 	return methods
 }
@@ -21,6 +21,7 @@ import (
 	"reflect"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/ServiceWeaver/weaver/internal/reflection"
 	"github.com/ServiceWeaver/weaver/runtime/codegen"
@@ -37,6 +38,10 @@ func (c *localClient) Call(ctx context.Context, _ MethodKey, args []byte, _ Call
 	return handleCall(ctx, reflect.ValueOf(c.fn), args)
 }
 
+func (c *localClient) Stream(context.Context, MethodKey, []byte, CallOptions) (codegen.ClientStream, error) {
+	return nil, fmt.Errorf("localClient does not support streaming")
+}
+
 func (c *localClient) Close() {}
 
 func TestCall(t *testing.T) {
@@ -253,6 +258,48 @@ func TestStubMethodRetries(t *testing.T) {
 	}
 }
 
+func TestMakingIdempotentStubMethods(t *testing.T) {
+	reg := &codegen.Registration{
+		Name: "TestInterface",
+		Iface: reflection.Type[interface {
+			A()
+			B()
+			C()
+		}](),
+		Idempotent: []int{0, 2},
+	}
+	want := []bool{true, false, true} // Which methods can be hedged?
+	methods := makeStubMethods(reg.Name, reg)
+	got := make([]bool, len(methods))
+	for i, m := range methods {
+		got[i] = m.idempotent
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("idempotent vector (-want,+got):\n%s\n", diff)
+	}
+}
+
+func TestStubMethodOptions(t *testing.T) {
+	opts := codegen.MethodOptions{Timeout: time.Second, Attempts: 3}
+	reg := &codegen.Registration{
+		Name: "TestInterface",
+		Iface: reflection.Type[interface {
+			A()
+			B()
+		}](),
+		Options: map[int]codegen.MethodOptions{1: opts},
+	}
+	want := []codegen.MethodOptions{{}, opts}
+	methods := makeStubMethods(reg.Name, reg)
+	got := make([]codegen.MethodOptions, len(methods))
+	for i, m := range methods {
+		got[i] = m.options
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("options (-want,+got):\n%s\n", diff)
+	}
+}
+
 // convertCallPanicToError catches and returns errors detected during fn's execution.
 func convertCallPanicToError(fn func() error) (err error) {
 	defer func() {
@@ -20,15 +20,21 @@ import (
 	"math/rand"
 	"net/http"
 	"net/http/httputil"
+	"slices"
 	"sync"
 )
 
 // Proxy is an HTTP proxy that forwards traffic to a set of backends.
+//
+// A fraction of the traffic can be shifted to a different set of backends
+// (e.g., the backends of a new version of an application) using ShiftTraffic.
 type Proxy struct {
 	logger   *slog.Logger          // logger
 	reverse  httputil.ReverseProxy // underlying proxy
-	mu       sync.Mutex            // guards backends
+	mu       sync.Mutex            // guards the following fields
 	backends []string              // backend addresses
+	shifted  []string              // backends receiving shifted traffic
+	fraction float64               // fraction of traffic sent to shifted
 }
 
 // NewProxy returns a new proxy.
@@ -43,24 +49,47 @@ func (p *Proxy) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	p.reverse.ServeHTTP(w, r)
 }
 
-// AddBackend adds a backend to the proxy. Note that backends cannot be
-// removed.
+// AddBackend adds a backend to the proxy.
 func (p *Proxy) AddBackend(backend string) {
 	p.mu.Lock()
 	defer p.mu.Unlock()
 	p.backends = append(p.backends, backend)
 }
 
+// RemoveBackend removes a backend from the proxy, if present.
+func (p *Proxy) RemoveBackend(backend string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.backends = slices.DeleteFunc(p.backends, func(b string) bool {
+		return b == backend
+	})
+}
+
+// ShiftTraffic forwards the provided fraction of the traffic to the provided
+// backends, rather than to the backends added with AddBackend. The fraction
+// must be in the range [0, 1]. A call to ShiftTraffic overrides any previous
+// call.
+func (p *Proxy) ShiftTraffic(backends []string, fraction float64) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.shifted = slices.Clone(backends)
+	p.fraction = fraction
+}
+
 // director implements a ReverseProxy.Director function [1].
 //
 // [1]: https://pkg.go.dev/net/http/httputil#ReverseProxy
 func (p *Proxy) director(r *http.Request) {
 	p.mu.Lock()
 	defer p.mu.Unlock()
-	if len(p.backends) == 0 {
+	backends := p.backends
+	if len(p.shifted) > 0 && rand.Float64() < p.fraction {
+		backends = p.shifted
+	}
+	if len(backends) == 0 {
 		p.logger.Error("director", "err", errors.New("no backends"), "url", r.URL)
 		return
 	}
 	r.URL.Scheme = "http" // TODO(mwhittaker): Support HTTPS.
-	r.URL.Host = p.backends[rand.Intn(len(p.backends))]
+	r.URL.Host = backends[rand.Intn(len(backends))]
 }
@@ -161,3 +161,110 @@ func TestProxyConcurrentAddBackend(t *testing.T) {
 		t.Fatalf("unexpected response body got: %s", string(b))
 	}
 }
+
+// TestProxyRemoveBackend verifies that the proxy stops forwarding requests
+// to a backend server once it is removed.
+func TestProxyRemoveBackend(t *testing.T) {
+	// Create two backend servers.
+	var urls []*url.URL
+	for _, response := range []string{"removed", "kept"} {
+		response := response
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(response))
+		}))
+		defer server.Close()
+		u, err := url.Parse(server.URL)
+		if err != nil {
+			t.Fatal(err)
+		}
+		urls = append(urls, u)
+	}
+
+	// Create a proxy, add both backends, and remove the first one.
+	proxy := NewProxy(slog.Default())
+	proxy.AddBackend(urls[0].Host)
+	proxy.AddBackend(urls[1].Host)
+	proxy.RemoveBackend(urls[0].Host)
+
+	frontend := httptest.NewServer(proxy)
+	defer frontend.Close()
+
+	// Every request should be forwarded to the remaining backend.
+	for i := 0; i < 10; i++ {
+		resp, err := http.Get(frontend.URL)
+		if err != nil {
+			t.Fatal(err)
+		}
+		b, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, want := string(b), "kept"; got != want {
+			t.Fatalf("got body %q; expected %q", got, want)
+		}
+	}
+}
+
+// TestProxyShiftTraffic verifies that the proxy forwards the specified
+// fraction of requests to the backends traffic is shifted to.
+func TestProxyShiftTraffic(t *testing.T) {
+	// Create an old and a new backend server.
+	var hosts []string
+	for _, response := range []string{"old", "new"} {
+		response := response
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(response))
+		}))
+		defer server.Close()
+		u, err := url.Parse(server.URL)
+		if err != nil {
+			t.Fatal(err)
+		}
+		hosts = append(hosts, u.Host)
+	}
+
+	proxy := NewProxy(slog.Default())
+	proxy.AddBackend(hosts[0])
+	frontend := httptest.NewServer(proxy)
+	defer frontend.Close()
+
+	// count returns the number of requests, out of n, forwarded to every
+	// backend.
+	count := func(n int) map[string]int {
+		counts := map[string]int{}
+		for i := 0; i < n; i++ {
+			resp, err := http.Get(frontend.URL)
+			if err != nil {
+				t.Fatal(err)
+			}
+			b, err := io.ReadAll(resp.Body)
+			resp.Body.Close()
+			if err != nil {
+				t.Fatal(err)
+			}
+			counts[string(b)]++
+		}
+		return counts
+	}
+
+	for _, test := range []struct {
+		fraction float64
+		old, new bool // should the old and new backends receive requests?
+	}{
+		{0, true, false},
+		{0.5, true, true},
+		{1, false, true},
+	} {
+		t.Run(fmt.Sprint(test.fraction), func(t *testing.T) {
+			proxy.ShiftTraffic([]string{hosts[1]}, test.fraction)
+			counts := count(100)
+			if got := counts["old"] > 0; got != test.old {
+				t.Errorf("old backend received %d requests", counts["old"])
+			}
+			if got := counts["new"] > 0; got != test.new {
+				t.Errorf("new backend received %d requests", counts["new"])
+			}
+		})
+	}
+}
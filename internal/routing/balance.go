@@ -0,0 +1,399 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package routing
+
+import (
+	"math"
+	"slices"
+	"sort"
+
+	"github.com/ServiceWeaver/weaver/runtime/protos"
+)
+
+const (
+	// A slice is hot, and is split if possible, when its load exceeds
+	// splitFraction of the average load per replica.
+	splitFraction = 0.5
+
+	// Two adjacent slices assigned to the same replica are merged when their
+	// combined load is below mergeFraction of the average load per replica.
+	// mergeFraction is smaller than splitFraction to prevent a split slice
+	// from being merged right back.
+	mergeFraction = 0.25
+
+	// Load is considered balanced when the load of the most and least loaded
+	// replicas differ by at most balanceTolerance of the average load per
+	// replica.
+	balanceTolerance = 0.1
+
+	// Default values for BalanceOptions.
+	defaultMaxMove   = 0.2
+	defaultMaxSlices = 32
+)
+
+// BalanceOptions configures Balance.
+type BalanceOptions struct {
+	// MaxMove bounds the key movement caused by a single call to Balance.
+	// Balance moves slices holding at most MaxMove of the (estimated) keys
+	// between replicas. Slices assigned to removed replicas are always moved,
+	// and don't count towards this limit. If zero, MaxMove defaults to 0.2.
+	MaxMove float64
+
+	// MaxSlices is the maximum number of slices per replica that Balance
+	// forms by splitting hot slices. If zero, MaxSlices defaults to 32.
+	MaxSlices int
+}
+
+// Balance returns an assignment of slices to the provided replicas that
+// balances the load reported by the replicas for the current assignment.
+//
+// Balance starts with the slices of the current assignment. It splits hot
+// slices using the subslice splits in the load reports, reassigns slices from
+// replicas that no longer exist, moves slices from the most loaded replicas to
+// the least loaded ones, and merges adjacent cold slices assigned to the same
+// replica. Load reports for other versions of the assignment are ignored. If
+// there is no load, Balance resorts to EqualSlices when the set of replicas
+// has changed.
+//
+// If the returned assignment is different from the current one, its version
+// is one more than the version of the current assignment. Otherwise, the
+// current assignment is returned as is.
+func Balance(current *protos.Assignment, replicas []string, loads []*protos.LoadReport_ComponentLoad, opts BalanceOptions) *protos.Assignment {
+	if opts.MaxMove == 0 {
+		opts.MaxMove = defaultMaxMove
+	}
+	if opts.MaxSlices == 0 {
+		opts.MaxSlices = defaultMaxSlices
+	}
+	next := func(a *protos.Assignment) *protos.Assignment {
+		a.Version = current.Version + 1
+		return a
+	}
+
+	// Note that replicas are sorted to make the assignment deterministic.
+	replicas = slices.Clone(replicas)
+	sort.Strings(replicas)
+	if len(replicas) == 0 {
+		if len(current.Slices) == 0 {
+			return current
+		}
+		return next(&protos.Assignment{})
+	}
+	if len(current.Slices) == 0 {
+		return next(EqualSlices(replicas))
+	}
+
+	b := newBalancer(current, replicas, loads)
+	if b.total == 0 {
+		if !b.membershipChanged() {
+			return current
+		}
+		return next(EqualSlices(replicas))
+	}
+	b.split(opts.MaxSlices * len(replicas))
+	b.reassign()
+	b.move(opts.MaxMove)
+	b.merge()
+
+	assignment := b.assignment()
+	if sameSlices(current, assignment) {
+		return current
+	}
+	return next(assignment)
+}
+
+// balancer balances load across replicas.
+type balancer struct {
+	replicas []string           // sorted replicas
+	shards   []*shard           // slices, sorted by start
+	load     map[string]float64 // load, by replica
+	total    float64            // total load
+	size     float64            // total estimated number of keys
+}
+
+// A shard is a slice assigned to a single replica.
+type shard struct {
+	start   uint64                            // start of the slice
+	replica string                            // assigned replica, or "" if none
+	load    float64                           // load of the slice
+	size    float64                           // estimated number of keys
+	splits  []*protos.LoadReport_SubsliceLoad // subslices, sorted by start
+}
+
+func newBalancer(current *protos.Assignment, replicas []string, loads []*protos.LoadReport_ComponentLoad) *balancer {
+	b := &balancer{replicas: replicas, load: map[string]float64{}}
+	live := map[string]bool{}
+	for _, replica := range replicas {
+		live[replica] = true
+		b.load[replica] = 0
+	}
+
+	// Form one shard per slice. A slice assigned to multiple replicas is
+	// assigned to the first of them that still exists.
+	index := map[uint64]*shard{}
+	for _, slice := range current.Slices {
+		s := &shard{start: slice.Start}
+		for _, replica := range slice.Replicas {
+			if live[replica] {
+				s.replica = replica
+				break
+			}
+		}
+		b.shards = append(b.shards, s)
+		index[s.start] = s
+	}
+	sort.Slice(b.shards, func(i, j int) bool {
+		return b.shards[i].start < b.shards[j].start
+	})
+
+	// Add the reported load. A slice assigned to multiple replicas has the
+	// sum of their loads, and the splits reported by its most loaded replica.
+	splitLoad := map[*shard]float64{}
+	for _, report := range loads {
+		if report.GetVersion() != current.Version {
+			continue
+		}
+		for _, sl := range report.Load {
+			s, ok := index[sl.Start]
+			if !ok {
+				continue
+			}
+			s.load += sl.Load
+			s.size += float64(sl.Size)
+			if sl.Load > splitLoad[s] {
+				splitLoad[s] = sl.Load
+				s.splits = sl.Splits
+			}
+		}
+	}
+	for _, s := range b.shards {
+		b.total += s.load
+		b.size += s.size
+		if s.replica != "" {
+			b.load[s.replica] += s.load
+		}
+	}
+	return b
+}
+
+// membershipChanged returns whether some slice is assigned to a replica that
+// no longer exists, or some replica is not assigned any slice.
+func (b *balancer) membershipChanged() bool {
+	assigned := map[string]bool{}
+	for _, s := range b.shards {
+		if s.replica == "" {
+			return true
+		}
+		assigned[s.replica] = true
+	}
+	return len(assigned) != len(b.replicas)
+}
+
+// split splits hot shards, as long as there are fewer than max shards.
+func (b *balancer) split(max int) {
+	hot := splitFraction * b.total / float64(len(b.replicas))
+	var shards []*shard
+	work := slices.Clone(b.shards)
+	for len(work) > 0 {
+		s := work[0]
+		work = work[1:]
+		if s.load > hot && len(shards)+len(work)+2 <= max {
+			if left, right, ok := s.split(); ok {
+				// Split the halves further if they are still hot.
+				work = append([]*shard{left, right}, work...)
+				continue
+			}
+		}
+		shards = append(shards, s)
+	}
+	b.shards = shards
+}
+
+// split splits s in two at the subslice boundary that best halves its load.
+// It returns false if s has fewer than two subslices.
+func (s *shard) split() (*shard, *shard, bool) {
+	var splits []*protos.LoadReport_SubsliceLoad
+	var sum float64
+	for _, sub := range s.splits {
+		if len(splits) > 0 && sub.Start <= splits[len(splits)-1].Start {
+			continue // ignore out of order subslices
+		}
+		splits = append(splits, sub)
+		sum += sub.Load
+	}
+	if len(splits) < 2 || sum == 0 {
+		return nil, nil, false
+	}
+
+	// Find the boundary i that minimizes the difference between the load of
+	// splits[:i] and splits[i:].
+	best, bestDiff := 0, math.Inf(1)
+	var prefix float64
+	for i := 1; i < len(splits); i++ {
+		prefix += splits[i-1].Load
+		if diff := math.Abs(sum - 2*prefix); diff < bestDiff {
+			best, bestDiff = i, diff
+		}
+	}
+	if splits[best].Start <= s.start {
+		return nil, nil, false
+	}
+
+	// Divide the load and size in proportion to the load of the subslices.
+	var leftLoad float64
+	for _, sub := range splits[:best] {
+		leftLoad += sub.Load
+	}
+	frac := leftLoad / sum
+	left := &shard{
+		start:   s.start,
+		replica: s.replica,
+		load:    s.load * frac,
+		size:    s.size * frac,
+		splits:  splits[:best],
+	}
+	right := &shard{
+		start:   splits[best].Start,
+		replica: s.replica,
+		load:    s.load - left.load,
+		size:    s.size - left.size,
+		splits:  splits[best:],
+	}
+	return left, right, true
+}
+
+// reassign assigns the shards of removed replicas to the least loaded
+// replicas, hottest shards first.
+func (b *balancer) reassign() {
+	var orphans []*shard
+	for _, s := range b.shards {
+		if s.replica == "" {
+			orphans = append(orphans, s)
+		}
+	}
+	sort.SliceStable(orphans, func(i, j int) bool {
+		return orphans[i].load > orphans[j].load
+	})
+	counts := b.counts()
+	for _, s := range orphans {
+		coldest := b.replicas[0]
+		for _, replica := range b.replicas[1:] {
+			if b.load[replica] < b.load[coldest] ||
+				(b.load[replica] == b.load[coldest] && counts[replica] < counts[coldest]) {
+				coldest = replica
+			}
+		}
+		s.replica = coldest
+		b.load[coldest] += s.load
+		counts[coldest]++
+	}
+}
+
+// move moves shards from the most loaded replica to the least loaded replica
+// until the load is balanced, or until moving another shard would move more
+// than maxMove of the keys.
+func (b *balancer) move(maxMove float64) {
+	// Key movement is measured using the estimated number of keys in every
+	// shard, or using load if the sizes are unknown.
+	cost := func(s *shard) float64 { return s.size }
+	budget := maxMove * b.size
+	if b.size == 0 {
+		cost = func(s *shard) float64 { return s.load }
+		budget = maxMove * b.total
+	}
+
+	tolerance := balanceTolerance * b.total / float64(len(b.replicas))
+	for range b.shards {
+		hottest, coldest := b.replicas[0], b.replicas[0]
+		for _, replica := range b.replicas[1:] {
+			if b.load[replica] > b.load[hottest] {
+				hottest = replica
+			}
+			if b.load[replica] < b.load[coldest] {
+				coldest = replica
+			}
+		}
+		gap := b.load[hottest] - b.load[coldest]
+		if gap <= tolerance {
+			return
+		}
+
+		// Moving a shard with load x from the hottest to the coldest replica
+		// reduces their imbalance if x < gap, and most so if x = gap/2.
+		var best *shard
+		for _, s := range b.shards {
+			if s.replica != hottest || s.load <= 0 || s.load >= gap || cost(s) > budget {
+				continue
+			}
+			if best == nil || math.Abs(s.load-gap/2) < math.Abs(best.load-gap/2) {
+				best = s
+			}
+		}
+		if best == nil {
+			return
+		}
+		best.replica = coldest
+		b.load[hottest] -= best.load
+		b.load[coldest] += best.load
+		budget -= cost(best)
+	}
+}
+
+// merge merges adjacent cold shards assigned to the same replica.
+func (b *balancer) merge() {
+	cold := mergeFraction * b.total / float64(len(b.replicas))
+	shards := []*shard{b.shards[0]}
+	for _, s := range b.shards[1:] {
+		prev := shards[len(shards)-1]
+		if prev.replica == s.replica && prev.load+s.load < cold {
+			prev.load += s.load
+			prev.size += s.size
+			prev.splits = append(slices.Clone(prev.splits), s.splits...)
+			continue
+		}
+		shards = append(shards, s)
+	}
+	b.shards = shards
+}
+
+// counts returns the number of shards assigned to every replica.
+func (b *balancer) counts() map[string]int {
+	counts := map[string]int{}
+	for _, s := range b.shards {
+		if s.replica != "" {
+			counts[s.replica]++
+		}
+	}
+	return counts
+}
+
+// assignment returns the assignment of the shards, with version 0.
+func (b *balancer) assignment() *protos.Assignment {
+	a := &protos.Assignment{}
+	for _, s := range b.shards {
+		a.Slices = append(a.Slices, &protos.Assignment_Slice{
+			Start:    s.start,
+			Replicas: []string{s.replica},
+		})
+	}
+	return a
+}
+
+// sameSlices returns whether two assignments have the same slices.
+func sameSlices(a, b *protos.Assignment) bool {
+	return slices.EqualFunc(a.Slices, b.Slices, func(x, y *protos.Assignment_Slice) bool {
+		return x.Start == y.Start && slices.Equal(x.Replicas, y.Replicas)
+	})
+}
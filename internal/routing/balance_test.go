@@ -0,0 +1,196 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package routing
+
+import (
+	"testing"
+
+	"github.com/ServiceWeaver/weaver/runtime/protos"
+	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
+)
+
+// assignment returns an assignment with the provided version and slices,
+// where slices alternates between slice starts and replicas.
+func assignment(version uint64, slices ...any) *protos.Assignment {
+	a := &protos.Assignment{Version: version}
+	for i := 0; i < len(slices); i += 2 {
+		var start uint64
+		switch x := slices[i].(type) {
+		case int:
+			start = uint64(x)
+		case uint64:
+			start = x
+		}
+		a.Slices = append(a.Slices, &protos.Assignment_Slice{
+			Start:    start,
+			Replicas: []string{slices[i+1].(string)},
+		})
+	}
+	return a
+}
+
+// sliceLoad returns the load of slice [start, end), with one key per unit of
+// load.
+func sliceLoad(start, end uint64, load float64, splits ...*protos.LoadReport_SubsliceLoad) *protos.LoadReport_SliceLoad {
+	return &protos.LoadReport_SliceLoad{
+		Start:  start,
+		End:    end,
+		Load:   load,
+		Size:   uint64(load),
+		Splits: splits,
+	}
+}
+
+func subslice(start uint64, load float64) *protos.LoadReport_SubsliceLoad {
+	return &protos.LoadReport_SubsliceLoad{Start: start, Load: load}
+}
+
+func report(version uint64, loads ...*protos.LoadReport_SliceLoad) *protos.LoadReport_ComponentLoad {
+	return &protos.LoadReport_ComponentLoad{Version: version, Load: loads}
+}
+
+func TestBalance(t *testing.T) {
+	for _, test := range []struct {
+		name     string
+		current  *protos.Assignment
+		replicas []string
+		loads    []*protos.LoadReport_ComponentLoad
+		opts     BalanceOptions
+		want     *protos.Assignment
+	}{
+		{
+			name:     "Initial",
+			current:  &protos.Assignment{},
+			replicas: []string{"b", "a"},
+			want:     assignment(1, 0, "a", 1<<63-1, "b"),
+		},
+		{
+			name:     "NoReplicas",
+			current:  assignment(3, 0, "a"),
+			replicas: []string{},
+			want:     &protos.Assignment{Version: 4},
+		},
+		{
+			name:     "NoLoad",
+			current:  assignment(3, 0, "a", 100, "b"),
+			replicas: []string{"a", "b"},
+			want:     assignment(3, 0, "a", 100, "b"),
+		},
+		{
+			name:     "NoLoadNewReplica",
+			current:  assignment(3, 0, "a", 1<<63-1, "b"),
+			replicas: []string{"a", "b", "c"},
+			want:     assignment(4, 0, "a", 4611686018427387903, "b", 9223372036854775806, "c", uint64(13835058055282163709), "a"),
+		},
+		{
+			name:     "Balanced",
+			current:  assignment(3, 0, "a", 100, "b"),
+			replicas: []string{"a", "b"},
+			loads: []*protos.LoadReport_ComponentLoad{
+				report(3, sliceLoad(0, 100, 10)),
+				report(3, sliceLoad(100, 200, 10)),
+			},
+			want: assignment(3, 0, "a", 100, "b"),
+		},
+		{
+			name:     "StaleLoad",
+			current:  assignment(3, 0, "a", 100, "b", 200, "a", 300, "b"),
+			replicas: []string{"a", "b"},
+			loads: []*protos.LoadReport_ComponentLoad{
+				report(2, sliceLoad(0, 100, 10), sliceLoad(200, 300, 10)),
+			},
+			want: assignment(3, 0, "a", 100, "b", 200, "a", 300, "b"),
+		},
+		{
+			name:     "SplitHotSlice",
+			current:  assignment(3, 0, "a", 100, "b"),
+			replicas: []string{"a", "b"},
+			loads: []*protos.LoadReport_ComponentLoad{
+				report(3, sliceLoad(0, 100, 100,
+					subslice(0, 25), subslice(25, 25), subslice(50, 25), subslice(75, 25))),
+			},
+			opts: BalanceOptions{MaxMove: 1},
+			// [0, 100) is split into four subslices with load 25, and two of
+			// them are moved to b.
+			want: assignment(4, 0, "b", 25, "b", 50, "a", 75, "a", 100, "b"),
+		},
+		{
+			name:     "HotKey",
+			current:  assignment(3, 0, "a", 100, "b"),
+			replicas: []string{"a", "b"},
+			loads: []*protos.LoadReport_ComponentLoad{
+				report(3, sliceLoad(0, 100, 100, subslice(0, 100))),
+			},
+			want: assignment(3, 0, "a", 100, "b"),
+		},
+		{
+			name:     "MoveToColdReplica",
+			current:  assignment(3, 0, "a", 100, "a", 200, "b", 300, "b"),
+			replicas: []string{"a", "b"},
+			loads: []*protos.LoadReport_ComponentLoad{
+				report(3, sliceLoad(0, 100, 20), sliceLoad(100, 200, 20)),
+				report(3, sliceLoad(200, 300, 1), sliceLoad(300, 400, 1)),
+			},
+			opts: BalanceOptions{MaxMove: 1},
+			// [0, 100) is moved to b, and [200, 300) and [300, 400) are
+			// merged.
+			want: assignment(4, 0, "b", 100, "a", 200, "b"),
+		},
+		{
+			name:     "LimitMovement",
+			current:  assignment(3, 0, "a", 100, "a", 200, "a", 300, "a", 400, "b"),
+			replicas: []string{"a", "b"},
+			loads: []*protos.LoadReport_ComponentLoad{
+				report(3, sliceLoad(0, 100, 10), sliceLoad(100, 200, 10),
+					sliceLoad(200, 300, 10), sliceLoad(300, 400, 10)),
+			},
+			// Balancing requires moving two slices, but only one may move.
+			opts: BalanceOptions{MaxMove: 0.25},
+			want: assignment(4, 0, "b", 100, "a", 200, "a", 300, "a", 400, "b"),
+		},
+		{
+			name:     "RemovedReplica",
+			current:  assignment(3, 0, "a", 100, "b", 200, "c", 300, "c"),
+			replicas: []string{"a", "b"},
+			loads: []*protos.LoadReport_ComponentLoad{
+				report(3, sliceLoad(0, 100, 30)),
+				report(3, sliceLoad(100, 200, 10)),
+				report(3, sliceLoad(200, 300, 10), sliceLoad(300, 400, 10)),
+			},
+			opts: BalanceOptions{MaxMove: 0.01},
+			// The slices of c are moved regardless of MaxMove.
+			want: assignment(4, 0, "a", 100, "b", 200, "b", 300, "b"),
+		},
+		{
+			name:     "MergeColdSlices",
+			current:  assignment(3, 0, "a", 100, "a", 200, "a", 300, "b"),
+			replicas: []string{"a", "b"},
+			loads: []*protos.LoadReport_ComponentLoad{
+				report(3, sliceLoad(0, 100, 1), sliceLoad(100, 200, 1), sliceLoad(200, 300, 40)),
+				report(3, sliceLoad(300, 400, 42)),
+			},
+			// [0, 100) and [100, 200) are merged.
+			want: assignment(4, 0, "a", 200, "a", 300, "b"),
+		},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			got := Balance(test.current, test.replicas, test.loads, test.opts)
+			if diff := cmp.Diff(test.want, got, protocmp.Transform()); diff != "" {
+				t.Fatalf("Balance: (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
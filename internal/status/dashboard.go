@@ -70,6 +70,20 @@ var (
 			return strings.Join(s, ", ")
 
 		},
+		"restartjoin": func(replicas []*Replica) string {
+			s := make([]string, len(replicas))
+			for i, x := range replicas {
+				s[i] = fmt.Sprint(len(x.Restarts))
+			}
+			return strings.Join(s, ", ")
+		},
+		"healthjoin": func(replicas []*Replica) string {
+			s := make([]string, len(replicas))
+			for i, x := range replicas {
+				s[i] = healthString(x)
+			}
+			return strings.Join(s, ", ")
+		},
 		"age": func(t *timestamppb.Timestamp) string {
 			return time.Since(t.AsTime()).Truncate(time.Second).String()
 		},
@@ -330,7 +344,7 @@ func (d *dashboard) handleMetrics(w http.ResponseWriter, r *http.Request) {
 	w.Write(b.Bytes())
 }
 
-// handleTraces handles requests to /traces?id=<deployment id>
+// handleTraces handles requests to /traces?id=<deployment id>&q=<query>
 func (d *dashboard) handleTraces(w http.ResponseWriter, r *http.Request) {
 	if d.traceDB == nil {
 		http.Error(w, "trace database cannot be opened", http.StatusInternalServerError)
@@ -363,6 +377,7 @@ func (d *dashboard) handleTraces(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	onlyErrors := r.URL.Query().Get("errs") != ""
+	query := r.URL.Query().Get("q")
 
 	// Weavelets export traces every 5 seconds. In order to (semi-)guarantee
 	// that the database contains all spans for the selected traces, we only
@@ -372,7 +387,7 @@ func (d *dashboard) handleTraces(w http.ResponseWriter, r *http.Request) {
 	endTime := time.Now().Add(-1 * (traceio.ExportInterval + gracePeriod))
 
 	const maxNumTraces = 100
-	ts, err := d.traceDB.QueryTraces(r.Context(), "" /*app*/, id, time.Time{} /*startTime*/, endTime, latencyLower, latencyUpper, onlyErrors, maxNumTraces)
+	ts, err := d.traceDB.QueryFilteredTraces(r.Context(), "" /*app*/, id, query, time.Time{} /*startTime*/, endTime, latencyLower, latencyUpper, onlyErrors, maxNumTraces)
 	if err != nil {
 		http.Error(w, fmt.Sprintf("cannot query trace database: %v", err), http.StatusInternalServerError)
 		return
@@ -381,10 +396,12 @@ func (d *dashboard) handleTraces(w http.ResponseWriter, r *http.Request) {
 	content := struct {
 		Tool   string
 		ID     string
+		Query  string
 		Traces []traces.TraceSummary
 	}{
 		Tool:   d.spec.Tool,
 		ID:     id,
+		Query:  query,
 		Traces: ts,
 	}
 	if err := tracesTemplate.Execute(w, content); err != nil {
@@ -34,10 +34,32 @@ import (
 
 var (
 	profileFlags    = flag.NewFlagSet("profile", flag.ContinueOnError)
-	profileDuration = profileFlags.Duration("duration", 30*time.Second, "Duration of cpu profiles")
-	profileType     = profileFlags.String("type", "cpu", `Profile type; "cpu" or "heap"`)
+	profileDuration = profileFlags.Duration("duration", 30*time.Second, "Duration of cpu, mutex, and block profiles")
+	profileType     = profileFlags.String("type", "cpu", `Profile type; one of "cpu", "heap", "goroutine", "mutex", "block", "allocs", or "threadcreate"`)
 )
 
+// profileTypes maps the values of the --type flag to profile types.
+var profileTypes = map[string]protos.ProfileType{
+	"cpu":          protos.ProfileType_CPU,
+	"heap":         protos.ProfileType_Heap,
+	"goroutine":    protos.ProfileType_Goroutine,
+	"mutex":        protos.ProfileType_Mutex,
+	"block":        protos.ProfileType_Block,
+	"allocs":       protos.ProfileType_Allocs,
+	"threadcreate": protos.ProfileType_ThreadCreate,
+}
+
+// timed returns whether profiles of the provided type are collected over a
+// duration, rather than being a snapshot.
+func timed(typ protos.ProfileType) bool {
+	switch typ {
+	case protos.ProfileType_CPU, protos.ProfileType_Mutex, protos.ProfileType_Block:
+		return true
+	default:
+		return false
+	}
+}
+
 // ProfileCommand returns a "profile" subcommand that gathers pprof profiles.
 func ProfileCommand(toolName string, registry func(context.Context) (*Registry, error)) *tool.Command {
 	const help = `Usage:
@@ -64,8 +86,27 @@ Deployment Ids:
   The prefix "2c8" uniquely identifies the first deployment, but the prefixes
   "2" and "2c" are ambiguous.
 
+Profile Types:
+  cpu           CPU usage over --duration (default)
+  heap          live heap memory
+  goroutine     stack traces of all current goroutines
+  mutex         contended mutexes over --duration
+  block         blocking on synchronization primitives over --duration
+  allocs        all past memory allocations
+  threadcreate  stack traces that led to the creation of OS threads
+
+  The profiles of all replicas are merged into a single profile.
+
 Examples:
-  TODO(rgrandl): Show how to take a profile and pass it to pprof.`
+  # Collect a 30 second CPU profile and visualize it with pprof.
+  profile=$({{.Tool}} profile <deployment>)
+  go tool pprof -http=localhost:9000 $profile
+
+  # Collect a goroutine profile to look for goroutine leaks.
+  {{.Tool}} profile --type=goroutine <deployment>
+
+  # Collect a 10 second mutex profile to look for lock contention.
+  {{.Tool}} profile --type=mutex --duration=10s <deployment>`
 	var b strings.Builder
 	t := template.Must(template.New(toolName).Parse(help))
 	content := struct{ Tool, Flags string }{toolName, tool.FlagsHelp(profileFlags)}
@@ -87,8 +128,9 @@ Examples:
 			if prefix == "" {
 				return fmt.Errorf("usage: %s profile [options] <deployment>", toolName)
 			}
-			if *profileType != "cpu" && *profileType != "heap" {
-				return fmt.Errorf("invalid profile type %q; want %q or %q", *profileType, "cpu", "heap")
+			typ, ok := profileTypes[*profileType]
+			if !ok {
+				return fmt.Errorf("invalid profile type %q; want one of %q, %q, %q, %q, %q, %q, or %q", *profileType, "cpu", "heap", "goroutine", "mutex", "block", "allocs", "threadcreate")
 			}
 
 			// Get the corresponding deployment id.
@@ -125,11 +167,8 @@ Examples:
 			}
 
 			// Form the profile request.
-			req := &protos.GetProfileRequest{}
-			if *profileType == "heap" {
-				req.ProfileType = protos.ProfileType_Heap
-			} else {
-				req.ProfileType = protos.ProfileType_CPU
+			req := &protos.GetProfileRequest{ProfileType: typ}
+			if timed(typ) {
 				req.CpuDurationNs = profileDuration.Nanoseconds()
 			}
 
@@ -143,7 +182,7 @@ Examples:
 
 			// Wait for the profile to finish. If the profile is going to take a long
 			// time, show a spinner to the user, so they know how long to wait.
-			if *profileType == "cpu" && *profileDuration > time.Second {
+			if timed(typ) && *profileDuration > time.Second {
 				spinner("Profiling in progress...", *profileDuration, done)
 			} else {
 				<-done
@@ -25,6 +25,7 @@ import (
 
 	"github.com/ServiceWeaver/weaver/runtime/colors"
 	"github.com/ServiceWeaver/weaver/runtime/logging"
+	"github.com/ServiceWeaver/weaver/runtime/protos"
 	dtool "github.com/ServiceWeaver/weaver/runtime/tool"
 )
 
@@ -79,6 +80,8 @@ func format(statuses []*Status) string {
 	var b strings.Builder
 	formatDeployments(&b, statuses)
 	formatComponents(&b, statuses)
+	formatRestarts(&b, statuses)
+	formatUnhealthy(&b, statuses)
 	formatListeners(&b, statuses)
 	return b.String()
 }
@@ -111,7 +114,7 @@ func formatComponents(w io.Writer, statuses []*Status) {
 	title := []colors.Text{{{S: "COMPONENTS", Bold: true}}}
 	t := colors.NewTabularizer(w, title, colors.PrefixDim)
 	defer t.Flush()
-	t.Row("APP", "DEPLOYMENT", "COMPONENT", "REPLICA PIDS", "WEAVELET IDS")
+	t.Row("APP", "DEPLOYMENT", "COMPONENT", "REPLICA PIDS", "WEAVELET IDS", "RESTARTS", "HEALTH")
 	for _, status := range statuses {
 		sort.Slice(status.Components, func(i, j int) bool {
 			return status.Components[i].Name < status.Components[j].Name
@@ -124,15 +127,95 @@ func formatComponents(w io.Writer, statuses []*Status) {
 			})
 			pids := make([]string, len(component.Replicas))
 			weaveletIds := make([]string, len(component.Replicas))
+			restarts := make([]string, len(component.Replicas))
+			health := make([]string, len(component.Replicas))
 			for i, replica := range component.Replicas {
 				pids[i] = fmt.Sprint(replica.Pid)
 				weaveletIds[i] = replica.WeaveletId[0:8]
+				restarts[i] = fmt.Sprint(len(replica.Restarts))
+				health[i] = healthString(replica)
 			}
-			t.Row(status.App, prefix, c, strings.Join(pids, ", "), strings.Join(weaveletIds, ", "))
+			t.Row(status.App, prefix, c, strings.Join(pids, ", "), strings.Join(weaveletIds, ", "), strings.Join(restarts, ", "), strings.Join(health, ", "))
 		}
 	}
 }
 
+// healthString returns the lowercase health of the provided replica (e.g.,
+// "healthy").
+func healthString(replica *Replica) string {
+	return strings.ToLower(replica.Health.String())
+}
+
+// formatUnhealthy pretty-prints the components' unhealthy replicas. Nothing is
+// printed if every replica is healthy.
+func formatUnhealthy(w io.Writer, statuses []*Status) {
+	type row struct {
+		status    *Status
+		component string
+		replica   *Replica
+	}
+	var rows []row
+	for _, status := range statuses {
+		for _, component := range status.Components {
+			for _, replica := range component.Replicas {
+				if replica.Health == protos.HealthStatus_UNHEALTHY {
+					rows = append(rows, row{status, component.Name, replica})
+				}
+			}
+		}
+	}
+	if len(rows) == 0 {
+		return
+	}
+
+	title := []colors.Text{{{S: "UNHEALTHY REPLICAS", Bold: true}}}
+	t := colors.NewTabularizer(w, title, colors.PrefixDim)
+	defer t.Flush()
+	t.Row("APP", "DEPLOYMENT", "COMPONENT", "PID", "WEAVELET ID", "ERROR")
+	for _, r := range rows {
+		prefix, _ := formatId(r.status.DeploymentId)
+		c := logging.ShortenComponent(r.component)
+		t.Row(r.status.App, prefix, c, fmt.Sprint(r.replica.Pid), r.replica.WeaveletId[0:8], r.replica.HealthError)
+	}
+}
+
+// formatRestarts pretty-prints the restart history of the components'
+// replicas. Nothing is printed if no replica was ever restarted.
+func formatRestarts(w io.Writer, statuses []*Status) {
+	type row struct {
+		status    *Status
+		component string
+		restart   *Restart
+	}
+	var rows []row
+	for _, status := range statuses {
+		for _, component := range status.Components {
+			for _, replica := range component.Replicas {
+				for _, restart := range replica.Restarts {
+					rows = append(rows, row{status, component.Name, restart})
+				}
+			}
+		}
+	}
+	if len(rows) == 0 {
+		return
+	}
+	sort.SliceStable(rows, func(i, j int) bool {
+		return rows[i].restart.Time.AsTime().Before(rows[j].restart.Time.AsTime())
+	})
+
+	title := []colors.Text{{{S: "RESTARTS", Bold: true}}}
+	t := colors.NewTabularizer(w, title, colors.PrefixDim)
+	defer t.Flush()
+	t.Row("APP", "DEPLOYMENT", "COMPONENT", "AGE", "PID", "WEAVELET ID", "ERROR")
+	for _, r := range rows {
+		prefix, _ := formatId(r.status.DeploymentId)
+		c := logging.ShortenComponent(r.component)
+		age := time.Since(r.restart.Time.AsTime()).Truncate(time.Second)
+		t.Row(r.status.App, prefix, c, age, fmt.Sprint(r.restart.Pid), r.restart.WeaveletId[0:8], r.restart.Error)
+	}
+}
+
 // formatDeployments pretty-prints the set of listeners.
 func formatListeners(w io.Writer, statuses []*Status) {
 	title := []colors.Text{{{S: "LISTENERS", Bold: true}}}
@@ -19,6 +19,7 @@ package testdeployer
 
 import (
 	"context"
+	"sync/atomic"
 
 	"github.com/ServiceWeaver/weaver"
 	"github.com/ServiceWeaver/weaver/metrics"
@@ -84,3 +85,13 @@ func (c *cimpl) C(ctx context.Context, x int) (int, error) {
 func (d *dimpl) D(ctx context.Context) (string, error) {
 	return d.Weaver().DeploymentID, nil
 }
+
+// dUnhealthy, if set, is returned by the Health method of d.
+var dUnhealthy atomic.Pointer[error]
+
+func (d *dimpl) Health(context.Context) error {
+	if err := dUnhealthy.Load(); err != nil {
+		return *err
+	}
+	return nil
+}
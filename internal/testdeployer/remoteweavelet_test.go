@@ -16,6 +16,7 @@ package testdeployer
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"net"
@@ -766,7 +767,15 @@ func TestProfile(t *testing.T) {
 	testComponents(d)
 
 	target := d.weavelets["2"]
-	for _, typ := range []protos.ProfileType{protos.ProfileType_Heap, protos.ProfileType_CPU} {
+	for _, typ := range []protos.ProfileType{
+		protos.ProfileType_Heap,
+		protos.ProfileType_CPU,
+		protos.ProfileType_Goroutine,
+		protos.ProfileType_Mutex,
+		protos.ProfileType_Block,
+		protos.ProfileType_Allocs,
+		protos.ProfileType_ThreadCreate,
+	} {
 		typ := typ
 		t.Run(typ.String(), func(t *testing.T) {
 			// Send a profiling request and wait for a reply.
@@ -847,3 +856,47 @@ func TestMetrics(t *testing.T) {
 		call()
 	}
 }
+
+func TestHealth(t *testing.T) {
+	// Start component d, which has a Health method.
+	ctx := context.Background()
+	const group = "1"
+	placement := map[string][]string{group: {componentd}}
+	dep := deploy(t, ctx, placement)
+	defer dep.shutdown()
+	target := dep.weavelets[group]
+	comp, err := target.wlet.GetIntf(reflection.Type[d]())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := comp.(d).D(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	// waitFor waits for the weavelet to report the provided health.
+	waitFor := func(want protos.HealthStatus, wantErr string) {
+		t.Helper()
+		deadline := time.Now().Add(15 * time.Second)
+		for {
+			reply, err := target.wlet.GetHealth(ctx, &protos.GetHealthRequest{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if reply.Status == want && len(reply.Components) == 1 && reply.Components[0].Error == wantErr {
+				return
+			}
+			if time.Now().After(deadline) {
+				t.Fatalf("GetHealth: got %v, want status %v and error %q", reply, want, wantErr)
+			}
+			time.Sleep(100 * time.Millisecond)
+		}
+	}
+
+	waitFor(protos.HealthStatus_HEALTHY, "")
+	unhealthy := errors.New("unhealthy")
+	dUnhealthy.Store(&unhealthy)
+	defer dUnhealthy.Store(nil)
+	waitFor(protos.HealthStatus_UNHEALTHY, "unhealthy")
+	dUnhealthy.Store(nil)
+	waitFor(protos.HealthStatus_HEALTHY, "")
+}
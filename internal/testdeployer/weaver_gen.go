@@ -31,7 +31,7 @@ func init() {
 		ReflectStubFn: func(caller func(string, context.Context, []any, []any) error) any {
 			return a_reflect_stub{caller: caller}
 		},
-		RefData: "⟦d473cf51:wEaVeReDgE:github.com/ServiceWeaver/weaver/internal/testdeployer/a→github.com/ServiceWeaver/weaver/internal/testdeployer/b⟧\n⟦83f71f4e:wEaVeRlIsTeNeRs:github.com/ServiceWeaver/weaver/internal/testdeployer/a→lis⟧\n",
+		RefData: "⟦d473cf51:wEaVeReDgE:github.com/ServiceWeaver/weaver/internal/testdeployer/a→github.com/ServiceWeaver/weaver/internal/testdeployer/b⟧\n⟦83f71f4e:wEaVeRlIsTeNeRs:github.com/ServiceWeaver/weaver/internal/testdeployer/a→lis⟧\n⟦8577fa00:wEaVeRfInGeRpRiNt:github.com/ServiceWeaver/weaver/internal/testdeployer/a→A:c9de9364ec133bcd⟧\n",
 	})
 	codegen.Register(codegen.Registration{
 		Name:  "github.com/ServiceWeaver/weaver/internal/testdeployer/b",
@@ -49,7 +49,7 @@ func init() {
 		ReflectStubFn: func(caller func(string, context.Context, []any, []any) error) any {
 			return b_reflect_stub{caller: caller}
 		},
-		RefData: "⟦54fc5958:wEaVeReDgE:github.com/ServiceWeaver/weaver/internal/testdeployer/b→github.com/ServiceWeaver/weaver/internal/testdeployer/c⟧\n",
+		RefData: "⟦54fc5958:wEaVeReDgE:github.com/ServiceWeaver/weaver/internal/testdeployer/b→github.com/ServiceWeaver/weaver/internal/testdeployer/c⟧\n⟦2d0bb470:wEaVeRfInGeRpRiNt:github.com/ServiceWeaver/weaver/internal/testdeployer/b→B:c9de9364ec133bcd⟧\n",
 	})
 	codegen.Register(codegen.Registration{
 		Name:  "github.com/ServiceWeaver/weaver/internal/testdeployer/c",
@@ -67,7 +67,7 @@ func init() {
 		ReflectStubFn: func(caller func(string, context.Context, []any, []any) error) any {
 			return c_reflect_stub{caller: caller}
 		},
-		RefData: "",
+		RefData: "⟦36e9665a:wEaVeRfInGeRpRiNt:github.com/ServiceWeaver/weaver/internal/testdeployer/c→C:c9de9364ec133bcd⟧\n",
 	})
 	codegen.Register(codegen.Registration{
 		Name:  "github.com/ServiceWeaver/weaver/internal/testdeployer/d",
@@ -85,7 +85,7 @@ func init() {
 		ReflectStubFn: func(caller func(string, context.Context, []any, []any) error) any {
 			return d_reflect_stub{caller: caller}
 		},
-		RefData: "",
+		RefData: "⟦61f9e9d1:wEaVeRfInGeRpRiNt:github.com/ServiceWeaver/weaver/internal/testdeployer/d→D:991af47b1b9c8ae0⟧\n",
 	})
 }
 
@@ -471,10 +471,10 @@ func (s d_client_stub) D(ctx context.Context) (r0 string, err error) {
 // Note that "weaver generate" will always generate the error message below.
 // Everything is okay. The error message is only relevant if you see it when
 // you run "go build" or "go run".
-var _ codegen.LatestVersion = codegen.Version[[0][24]struct{}](`
+var _ codegen.LatestVersion = codegen.Version[[0][25]struct{}](`
 
 ERROR: You generated this file with 'weaver generate' (devel) (codegen
-version v0.24.0). The generated code is incompatible with the version of the
+version v0.25.0). The generated code is incompatible with the version of the
 github.com/ServiceWeaver/weaver module that you're using. The weaver module
 version can be found in your go.mod file or by running the following command.
 
@@ -0,0 +1,115 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package compat contains code to check whether the components of two Service
+// Weaver binaries can call one another.
+package compat
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/ServiceWeaver/weaver/runtime/bin"
+	"github.com/ServiceWeaver/weaver/runtime/codegen"
+	"github.com/ServiceWeaver/weaver/runtime/logging"
+)
+
+// A Change is how a component method differs between two binaries.
+type Change string
+
+const (
+	// The wire format of the method's arguments or results changed.
+	Changed Change = "changed"
+
+	// The method exists only in the old binary.
+	Removed Change = "removed"
+
+	// The method exists only in the new binary.
+	Added Change = "added"
+)
+
+// An Incompatibility is a component method that cannot be called across two
+// binaries.
+type Incompatibility struct {
+	Component string // fully qualified component type name
+	Method    string // method name
+	Change    Change // how the method changed
+}
+
+// String returns a human readable description of the incompatibility.
+func (i Incompatibility) String() string {
+	return fmt.Sprintf("%s.%s: %s", logging.ShortenComponent(i.Component), i.Method, i.Change)
+}
+
+// Check returns the component methods of the provided Service Weaver binaries
+// that cannot be called across them. Calls to a method added or removed in
+// the new binary fail when the caller and callee run different binaries, so
+// these methods are also returned.
+func Check(oldBinary, newBinary string) ([]Incompatibility, error) {
+	read := func(binary string) ([]codegen.MethodFingerprint, error) {
+		fingerprints, err := bin.ReadFingerprints(binary)
+		if err != nil {
+			return nil, fmt.Errorf("read fingerprints from %q: %w", binary, err)
+		}
+		if len(fingerprints) == 0 {
+			return nil, fmt.Errorf("no fingerprints found in %q. Maybe it was generated with an older version of \"weaver generate\"?", binary)
+		}
+		return fingerprints, nil
+	}
+	old, err := read(oldBinary)
+	if err != nil {
+		return nil, err
+	}
+	new, err := read(newBinary)
+	if err != nil {
+		return nil, err
+	}
+	return Compare(old, new), nil
+}
+
+// Compare returns the component methods with different fingerprints in old
+// and new, sorted by component and method.
+func Compare(old, new []codegen.MethodFingerprint) []Incompatibility {
+	type key struct{ component, method string }
+	olds := map[key]string{}
+	for _, f := range old {
+		olds[key{f.Component, f.Method}] = f.Fingerprint
+	}
+	news := map[key]string{}
+	for _, f := range new {
+		news[key{f.Component, f.Method}] = f.Fingerprint
+	}
+
+	var result []Incompatibility
+	for k, fingerprint := range olds {
+		switch other, ok := news[k]; {
+		case !ok:
+			result = append(result, Incompatibility{k.component, k.method, Removed})
+		case other != fingerprint:
+			result = append(result, Incompatibility{k.component, k.method, Changed})
+		}
+	}
+	for k := range news {
+		if _, ok := olds[k]; !ok {
+			result = append(result, Incompatibility{k.component, k.method, Added})
+		}
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if a, b := result[i].Component, result[j].Component; a != b {
+			return a < b
+		}
+		return result[i].Method < result[j].Method
+	})
+	return result
+}
@@ -0,0 +1,48 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compat
+
+import (
+	"testing"
+
+	"github.com/ServiceWeaver/weaver/runtime/codegen"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestCompare(t *testing.T) {
+	old := []codegen.MethodFingerprint{
+		{Component: "a", Method: "Get", Fingerprint: "1"},
+		{Component: "a", Method: "Put", Fingerprint: "2"},
+		{Component: "b", Method: "Delete", Fingerprint: "3"},
+		{Component: "c", Method: "List", Fingerprint: "4"},
+	}
+	new := []codegen.MethodFingerprint{
+		{Component: "a", Method: "Get", Fingerprint: "1"},
+		{Component: "a", Method: "Put", Fingerprint: "5"},
+		{Component: "b", Method: "Create", Fingerprint: "6"},
+		{Component: "c", Method: "List", Fingerprint: "4"},
+	}
+	want := []Incompatibility{
+		{"a", "Put", Changed},
+		{"b", "Create", Added},
+		{"b", "Delete", Removed},
+	}
+	if diff := cmp.Diff(want, Compare(old, new)); diff != "" {
+		t.Fatalf("Compare (-want +got):\n%s", diff)
+	}
+	if got := Compare(old, old); len(got) != 0 {
+		t.Fatalf("Compare(old, old): got %v, want nothing", got)
+	}
+}
@@ -20,6 +20,7 @@ import (
 	"errors"
 	"fmt"
 	"go/ast"
+	"go/constant"
 	"go/format"
 	"go/parser"
 	"go/token"
@@ -32,12 +33,14 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 	"unicode"
 
 	"github.com/ServiceWeaver/weaver/internal/files"
 	"github.com/ServiceWeaver/weaver/internal/tool"
 	"github.com/ServiceWeaver/weaver/runtime/codegen"
 	"github.com/ServiceWeaver/weaver/runtime/colors"
+	"github.com/ServiceWeaver/weaver/runtime/logging"
 	"github.com/ServiceWeaver/weaver/runtime/version"
 	"golang.org/x/exp/maps"
 	"golang.org/x/tools/go/packages"
@@ -50,11 +53,12 @@ import (
 
 const (
 	generatedCodeFile = "weaver_gen.go"
+	generatedMockFile = "weaver_gen_test.go"
 
 	Usage = `Generate code for a Service Weaver application.
 
 Usage:
-  weaver generate [-tags taglist] [packages]
+  weaver generate [-tags taglist] [-mocks] [packages]
 
 Description:
   "weaver generate" generates code for the Service Weaver applications in the
@@ -67,6 +71,11 @@ Description:
   You specify build tags for "weaver generate" in the same way you specify build
   tags for go build. See "go help build" for more information.
 
+  If the -mocks flag is set, "weaver generate" also generates a programmable
+  mock of every component in a weaver_gen_test.go file. For a component
+  interface Foo, the mock is called MockFoo. It can be passed to
+  weavertest.Fake or sim.Fake in place of a hand-written fake.
+
   You specify packages for "weaver generate" in the same way you specify
   packages for go build, go test, go vet, etc. See "go help packages" for more
   information.
@@ -91,6 +100,9 @@ Examples:
   # Generate code for all packages in all subdirectories of current directory.
   weaver generate ./...
 
+  # Generate code, and component mocks, for the package in the current directory.
+  weaver generate -mocks
+
   # Generate code for all files that have a "//go:build good" line at the top of
   the file.
   weaver generate -tags good
@@ -104,6 +116,7 @@ Examples:
 type Options struct {
 	Warn      func(error) // If non-nil, use the specified function to report warnings
 	BuildTags string
+	Mocks     bool // If true, also generate mock components in weaver_gen_test.go
 }
 
 // Generate generates Service Weaver code for the specified packages.
@@ -138,6 +151,12 @@ func Generate(dir string, pkgs []string, opt Options) error {
 		}
 		if err := g.generate(); err != nil {
 			errs = append(errs, err)
+			continue
+		}
+		if opt.Mocks {
+			if err := g.generateMocks(); err != nil {
+				errs = append(errs, err)
+			}
 		}
 	}
 	return errors.Join(errs...)
@@ -273,6 +292,11 @@ func newGenerator(opt Options, pkg *packages.Package, fset *token.FileSet, autom
 			errs = append(errs, err)
 		}
 	}
+	for _, comp := range components {
+		if err := checkMethodOptions(fset, comp); err != nil {
+			errs = append(errs, err)
+		}
+	}
 
 	if err := errors.Join(errs...); err != nil {
 		return nil, err
@@ -322,6 +346,8 @@ func findComponents(opt Options, pkg *packages.Package, f *ast.File, tset *typeS
 func findMethodAttributes(pkg *packages.Package, f *ast.File, components map[string]*component) error {
 	// Look for declarations of the form:
 	//	var _ weaver.NotRetriable = Component.Method
+	//	var _ weaver.Idempotent = Component.Method
+	//	var _ = weaver.MethodOptions{Method: Component.Method, ...}
 	var errs []error
 	for _, decl := range f.Decls {
 		gendecl, ok := decl.(*ast.GenDecl)
@@ -333,19 +359,39 @@ func findMethodAttributes(pkg *packages.Package, f *ast.File, components map[str
 			if !ok {
 				continue
 			}
+			for _, val := range valspec.Values {
+				if tv, ok := pkg.TypesInfo.Types[val]; ok && isWeaverMethodOptions(tv.Type) {
+					if err := findMethodOptions(pkg, components, val); err != nil {
+						errs = append(errs, err)
+					}
+				}
+			}
 			typeAndValue, ok := pkg.TypesInfo.Types[valspec.Type]
 			if !ok {
 				continue
 			}
 			t := typeAndValue.Type
-			if !isWeaverNotRetriable(t) {
+			var attr string
+			switch {
+			case isWeaverNotRetriable(t):
+				attr = "NonRetriable"
+			case isWeaverIdempotent(t):
+				attr = "Idempotent"
+			default:
 				continue
 			}
 			for _, val := range valspec.Values {
 				// We allow non-blank vars for uniformity.
 				comp, method, ok := findComponentMethod(pkg, components, val)
 				if !ok {
-					errs = append(errs, errorf(pkg.Fset, valspec.Pos(), "weaver.NonRetriable should only be assigned a value that identifies a method of a component implemented by this package"))
+					errs = append(errs, errorf(pkg.Fset, valspec.Pos(), "weaver.%s should only be assigned a value that identifies a method of a component implemented by this package", attr))
+					continue
+				}
+				if attr == "Idempotent" {
+					if comp.idempotent == nil {
+						comp.idempotent = map[string]struct{}{}
+					}
+					comp.idempotent[method] = struct{}{}
 					continue
 				}
 				if comp.noretry == nil {
@@ -358,6 +404,105 @@ func findMethodAttributes(pkg *packages.Package, f *ast.File, components map[str
 	return errors.Join(errs...)
 }
 
+// findMethodOptions records the method options declared by val, a
+// weaver.MethodOptions value, in the component whose method they configure.
+func findMethodOptions(pkg *packages.Package, components map[string]*component, val ast.Expr) error {
+	lit, ok := val.(*ast.CompositeLit)
+	if !ok {
+		return errorf(pkg.Fset, val.Pos(), "weaver.MethodOptions should be a composite literal")
+	}
+	var comp *component
+	var method string
+	opts := methodOptions{pos: lit.Pos()}
+	for _, elt := range lit.Elts {
+		kv, ok := elt.(*ast.KeyValueExpr)
+		if !ok {
+			return errorf(pkg.Fset, elt.Pos(), "weaver.MethodOptions literal should use field names")
+		}
+		field := kv.Key.(*ast.Ident).Name
+		if field == "Method" {
+			comp, method, ok = findComponentMethod(pkg, components, kv.Value)
+			if !ok {
+				return errorf(pkg.Fset, kv.Value.Pos(), "weaver.MethodOptions.Method should identify a method of a component implemented by this package")
+			}
+			continue
+		}
+
+		// The remaining fields are integers or durations.
+		tv := pkg.TypesInfo.Types[kv.Value]
+		if tv.Value == nil {
+			return errorf(pkg.Fset, kv.Value.Pos(), "weaver.MethodOptions.%s should be a constant", field)
+		}
+		x, exact := constant.Int64Val(tv.Value)
+		if !exact || x < 0 {
+			return errorf(pkg.Fset, kv.Value.Pos(), "weaver.MethodOptions.%s should be a non-negative integer", field)
+		}
+		switch field {
+		case "Timeout":
+			opts.Timeout = time.Duration(x)
+		case "Attempts":
+			opts.Attempts = int(x)
+		case "Backoff":
+			opts.Backoff = time.Duration(x)
+		case "Hedge":
+			opts.Hedge = time.Duration(x)
+		case "HedgePercentile":
+			if x >= 100 {
+				return errorf(pkg.Fset, kv.Value.Pos(), "weaver.MethodOptions.HedgePercentile should be smaller than 100")
+			}
+			opts.HedgePercentile = int(x)
+		}
+	}
+	if comp == nil {
+		return errorf(pkg.Fset, lit.Pos(), "weaver.MethodOptions should set Method")
+	}
+	if comp.options == nil {
+		comp.options = map[string]methodOptions{}
+	}
+	if existing, ok := comp.options[method]; ok {
+		return errorf(pkg.Fset, lit.Pos(), "duplicate weaver.MethodOptions for method %s.%s, other declaration: %v",
+			comp.intfName(), method, pkg.Fset.Position(existing.pos))
+	}
+	comp.options[method] = opts
+	return nil
+}
+
+// checkMethodOptions checks that the method options and attributes of a
+// component are compatible with its methods.
+func checkMethodOptions(fset *token.FileSet, comp *component) error {
+	var errs []error
+	for _, m := range comp.methods() {
+		_, noretry := comp.noretry[m.Name()]
+		_, idempotent := comp.idempotent[m.Name()]
+		if noretry && idempotent {
+			errs = append(errs, errorf(fset, comp.intf.Obj().Pos(), "method %s.%s is both weaver.NotRetriable and weaver.Idempotent", comp.intfName(), m.Name()))
+		}
+
+		opts, ok := comp.options[m.Name()]
+		if !ok {
+			continue
+		}
+		sig := m.Type().(*types.Signature)
+		if streamArgIndex(sig) >= 0 || streamResultIndex(sig) >= 0 {
+			errs = append(errs, errorf(fset, opts.pos, "weaver.MethodOptions cannot be used with method %s.%s, which has a weaver.Stream argument or result", comp.intfName(), m.Name()))
+			continue
+		}
+		hedged := opts.Hedge > 0 || opts.HedgePercentile > 0
+		switch {
+		case noretry:
+			if opts.Attempts > 1 {
+				errs = append(errs, errorf(fset, opts.pos, "weaver.MethodOptions.Attempts is %d, but method %s.%s is weaver.NotRetriable", opts.Attempts, comp.intfName(), m.Name()))
+			}
+			if hedged {
+				errs = append(errs, errorf(fset, opts.pos, "weaver.MethodOptions.Hedge is set, but method %s.%s is weaver.NotRetriable", comp.intfName(), m.Name()))
+			}
+		case hedged && !idempotent:
+			errs = append(errs, errorf(fset, opts.pos, "weaver.MethodOptions.Hedge is set, but method %s.%s is not weaver.Idempotent", comp.intfName(), m.Name()))
+		}
+	}
+	return errors.Join(errs...)
+}
+
 // findComponentMethod returns the component and method if val is an expression of
 // the form C.M where C is a component listed in components and C has a method named M.
 func findComponentMethod(pkg *packages.Package, components map[string]*component, val ast.Expr) (*component, string, bool) {
@@ -690,15 +835,24 @@ func getListenerNamesFromStructField(pkg *packages.Package, f *ast.Field) ([]str
 //	}
 //	type router struct{}
 type component struct {
-	intf          *types.Named        // component interface
-	impl          *types.Named        // component implementation
-	router        *types.Named        // router, or nil if there is no router
-	routingKey    types.Type          // routing key, or nil if there is no router
-	routedMethods map[string]bool     // the set of methods with a routing function
-	isMain        bool                // intf is weaver.Main
-	refs          []*types.Named      // List of T where a weaver.Ref[T] field is in impl struct
-	listeners     []string            // Names of listener fields declared in impl struct
-	noretry       map[string]struct{} // Methods that should not be retried
+	intf          *types.Named             // component interface
+	impl          *types.Named             // component implementation
+	router        *types.Named             // router, or nil if there is no router
+	routingKey    types.Type               // routing key, or nil if there is no router
+	routedMethods map[string]bool          // the set of methods with a routing function
+	isMain        bool                     // intf is weaver.Main
+	refs          []*types.Named           // List of T where a weaver.Ref[T] field is in impl struct
+	listeners     []string                 // Names of listener fields declared in impl struct
+	noretry       map[string]struct{}      // Methods that should not be retried
+	idempotent    map[string]struct{}      // Methods that are idempotent
+	options       map[string]methodOptions // Methods configured by a weaver.MethodOptions
+}
+
+// methodOptions are the options of a method, declared by a weaver.MethodOptions
+// literal at pos.
+type methodOptions struct {
+	codegen.MethodOptions
+	pos token.Pos
 }
 
 func fullName(t *types.Named) string {
@@ -770,9 +924,28 @@ func validateMethods(pkg *packages.Package, tset *typeSet, intf *types.Named) er
 			errs = append(errs, bad("argument", "The first argument must have type context.Context."))
 		}
 
-		// All arguments but context.Context must be serializable.
+		// All arguments but context.Context must be serializable. A method
+		// can also have a single weaver.Stream[T] argument, where T is
+		// serializable.
+		numStreamArgs := 0
 		for i := 1; i < t.Params().Len(); i++ {
 			arg := t.Params().At(i)
+			if isWeaverStream(arg.Type()) {
+				numStreamArgs++
+				if numStreamArgs == 2 {
+					errs = append(errs, bad("argument", "A method can have at most one weaver.Stream argument."))
+				}
+				if t.Variadic() && i == t.Params().Len()-1 {
+					errs = append(errs, bad("argument", "A weaver.Stream argument cannot be variadic."))
+				}
+				elem := streamElem(arg.Type())
+				if err := errors.Join(tset.checkSerializable(elem)...); err != nil {
+					errs = append(errs, bad("argument",
+						"Argument %d is a stream of type %s, which is not serializable. Stream elements must be serializable.\n%w",
+						i, formatType(pkg, elem), err))
+				}
+				continue
+			}
 			if err := errors.Join(tset.checkSerializable(arg.Type())...); err != nil {
 				// TODO(mwhittaker): Print a link to documentation on which types are serializable.
 				errs = append(errs, bad("argument",
@@ -788,9 +961,24 @@ func validateMethods(pkg *packages.Package, tset *typeSet, intf *types.Named) er
 			errs = append(errs, bad("return", "The last return must have type error."))
 		}
 
-		// All results but error must be serializable.
+		// All results but error must be serializable. A method can also have
+		// a single weaver.Stream[T] result, where T is serializable.
+		numStreamResults := 0
 		for i := 0; i < t.Results().Len()-1; i++ {
 			res := t.Results().At(i)
+			if isWeaverStream(res.Type()) {
+				numStreamResults++
+				if numStreamResults == 2 {
+					errs = append(errs, bad("return", "A method can have at most one weaver.Stream return."))
+				}
+				elem := streamElem(res.Type())
+				if err := errors.Join(tset.checkSerializable(elem)...); err != nil {
+					errs = append(errs, bad("return",
+						"Return %d is a stream of type %v, which is not serializable. Stream elements must be serializable.\n%w",
+						i, formatType(pkg, elem), err))
+				}
+				continue
+			}
 			if err := errors.Join(tset.checkSerializable(res.Type())...); err != nil {
 				// TODO(mwhittaker): Print a link to documentation on which types are serializable.
 				errs = append(errs, bad("return",
@@ -967,8 +1155,12 @@ func (g *generator) generate() error {
 		g.generateImports(fn)
 	}
 
-	// Create a generated file.
-	filename := filepath.Join(g.pkgDir(), generatedCodeFile)
+	return writeGenerated(filepath.Join(g.pkgDir(), generatedCodeFile), header, body)
+}
+
+// writeGenerated formats the provided header and body and writes them to a
+// generated file with the provided name.
+func writeGenerated(filename string, header, body bytes.Buffer) error {
 	dst := files.NewWriter(filename)
 	defer dst.Cleanup()
 
@@ -1245,6 +1437,9 @@ func (g *generator) generateRegisteredComponents(p printFn) {
 		if len(comp.listeners) > 0 {
 			refData.WriteString(codegen.MakeListenersString(myName, comp.listeners))
 		}
+		for _, m := range comp.methods() {
+			refData.WriteString(codegen.MakeFingerprintString(myName, m.Name(), g.fingerprint(m)))
+		}
 
 		// E.g.,
 		//	weaver.Register(weaver.Registration{
@@ -1269,7 +1464,19 @@ func (g *generator) generateRegisteredComponents(p printFn) {
 			p(`		Listeners: []string{%s},`, strings.Join(listeners, ", "))
 		}
 		if len(comp.noretry) > 0 {
-			p(`		NoRetry: []int{%s},`, noRetryString(comp))
+			p(`		NoRetry: []int{%s},`, methodIndices(comp, comp.noretry))
+		}
+		if len(comp.idempotent) > 0 {
+			p(`		Idempotent: []int{%s},`, methodIndices(comp, comp.idempotent))
+		}
+		if len(comp.options) > 0 {
+			p(`		Options: map[int]%s{`, g.codegen().qualify("MethodOptions"))
+			for i, m := range comp.methods() {
+				if opts, ok := comp.options[m.Name()]; ok {
+					p(`			%d: {%s},`, i, g.methodOptionsFields(opts.MethodOptions))
+				}
+			}
+			p(`		},`)
 		}
 		p(`		LocalStubFn: %s,`, localStubFn)
 		p(`		ClientStubFn: %s,`, clientStubFn)
@@ -1281,13 +1488,13 @@ func (g *generator) generateRegisteredComponents(p printFn) {
 	p(`}`)
 }
 
-// noRetryString generates a string of the form "i_1, i_2, ... i_n" where the
-// individual elements are the indices of methods in comp.retry that should not
-// be retried.
-func noRetryString(comp *component) string {
-	list := make([]int, 0, len(comp.noretry))
+// methodIndices generates a string of the form "i_1, i_2, ... i_n" where the
+// individual elements are the indices of the methods of comp in the provided
+// set (e.g., the methods that should not be retried).
+func methodIndices(comp *component, methods map[string]struct{}) string {
+	list := make([]int, 0, len(methods))
 	for i, m := range comp.methods() {
-		if _, ok := comp.noretry[m.Name()]; ok {
+		if _, ok := methods[m.Name()]; ok {
 			list = append(list, i)
 		}
 	}
@@ -1299,6 +1506,49 @@ func noRetryString(comp *component) string {
 	return strings.Join(strs, ", ")
 }
 
+// methodOptionsFields returns the non-zero fields of the provided method
+// options, formatted as the fields of a composite literal.
+func (g *generator) methodOptionsFields(opts codegen.MethodOptions) string {
+	var fields []string
+	if opts.Timeout > 0 {
+		fields = append(fields, "Timeout: "+g.duration(opts.Timeout))
+	}
+	if opts.Attempts > 0 {
+		fields = append(fields, fmt.Sprintf("Attempts: %d", opts.Attempts))
+	}
+	if opts.Backoff > 0 {
+		fields = append(fields, "Backoff: "+g.duration(opts.Backoff))
+	}
+	if opts.Hedge > 0 {
+		fields = append(fields, "Hedge: "+g.duration(opts.Hedge))
+	}
+	if opts.HedgePercentile > 0 {
+		fields = append(fields, fmt.Sprintf("HedgePercentile: %d", opts.HedgePercentile))
+	}
+	return strings.Join(fields, ", ")
+}
+
+// duration returns a Go expression for the provided duration, expressed in the
+// largest unit that divides it (e.g., "100 * time.Millisecond").
+func (g *generator) duration(d time.Duration) string {
+	pkg := g.tset.importPackage("time", "time")
+	for _, unit := range []struct {
+		d    time.Duration
+		name string
+	}{
+		{time.Hour, "Hour"},
+		{time.Minute, "Minute"},
+		{time.Second, "Second"},
+		{time.Millisecond, "Millisecond"},
+		{time.Microsecond, "Microsecond"},
+	} {
+		if d%unit.d == 0 {
+			return fmt.Sprintf("%d * %s", d/unit.d, pkg.qualify(unit.name))
+		}
+	}
+	return fmt.Sprintf("%d * %s", d, pkg.qualify("Nanosecond"))
+}
+
 // generateLocalStubs generates code that creates stubs for the local components.
 func (g *generator) generateLocalStubs(p printFn) {
 	p(``)
@@ -1335,7 +1585,10 @@ func (g *generator) generateLocalStubs(p printFn) {
 			p(`	span := %s(ctx)`, g.trace().qualify("SpanFromContext"))
 			p(`	if span.SpanContext().IsValid() {`)
 			p(`		// Create a child span for this method.`)
-			p(`		ctx, span = s.tracer.Start(ctx, "%s.%s.%s", trace.WithSpanKind(trace.SpanKindInternal))`, g.pkg.Name, comp.intfName(), m.Name())
+			// Note that the span name matches the name of the server span
+			// created by the RPC layer, i.e., the shortened component name
+			// followed by the method name.
+			p(`		ctx, span = s.tracer.Start(ctx, "%s.%s", trace.WithSpanKind(trace.SpanKindInternal))`, logging.ShortenComponent(comp.fullIntfName()), m.Name())
 			p(`		defer func() {`)
 			p(`			if err != nil {`)
 			p(`				span.RecordError(err)`)
@@ -1345,6 +1598,14 @@ func (g *generator) generateLocalStubs(p printFn) {
 			p(`		}()`)
 			p(`	}`)
 
+			// Apply the method's timeout, if any.
+			if opts, ok := comp.options[m.Name()]; ok && opts.Timeout > 0 {
+				p(``)
+				p(`	// Apply the method's timeout.`)
+				p(`	ctx, cancel := %s(ctx, %s)`, g.tset.importPackage("context", "context").qualify("WithTimeout"), g.duration(opts.Timeout))
+				p(`	defer cancel()`)
+			}
+
 			// Call the local method.
 			b.Reset()
 			fmt.Fprintf(&b, "ctx")
@@ -1411,7 +1672,7 @@ func (g *generator) generateClientStubs(p printFn) {
 			p(`	span := %s(ctx)`, g.trace().qualify("SpanFromContext"))
 			p(`	if span.SpanContext().IsValid() {`)
 			p(`		// Create a child span for this method.`)
-			p(`		ctx, span = s.stub.Tracer().Start(ctx, "%s.%s.%s", trace.WithSpanKind(trace.SpanKindClient))`, g.pkg.Name, comp.intfName(), m.Name())
+			p(`		ctx, span = s.stub.Tracer().Start(ctx, "%s.%s", trace.WithSpanKind(trace.SpanKindClient))`, logging.ShortenComponent(comp.fullIntfName()), m.Name())
 			p(`	}`)
 
 			// Handle cleanup.
@@ -1434,8 +1695,10 @@ func (g *generator) generateClientStubs(p printFn) {
 			p(`	}()`)
 			p(``)
 
+			streamArg, streamResult := streamArgIndex(mt), streamResultIndex(mt)
+			streaming := streamArg >= 0 || streamResult >= 0
 			preallocated := false
-			if mt.Params().Len() > 1 {
+			if mt.Params().Len() > 1 && !streaming {
 				// Preallocate a perfectly sized buffer if possible.
 				canPreallocate := true
 				for i := 1; i < mt.Params().Len(); i++ { // Skip initial context.Context
@@ -1460,7 +1723,8 @@ func (g *generator) generateClientStubs(p printFn) {
 
 			// Invoke call.Encode.
 			b.Reset()
-			if mt.Params().Len() > 1 {
+			hasArgs := mt.Params().Len() > 1 && (streamArg < 0 || mt.Params().Len() > 2)
+			if hasArgs {
 				p(``)
 				p(`	// Encode arguments.`)
 				if !preallocated {
@@ -1468,6 +1732,9 @@ func (g *generator) generateClientStubs(p printFn) {
 				}
 			}
 			for i := 1; i < mt.Params().Len(); i++ { // Skip initial context.Context
+				if i == streamArg {
+					continue // Sent separately
+				}
 				at := mt.Params().At(i).Type()
 				arg := fmt.Sprintf("a%d", i-1)
 				p(`	%s`, g.encode("enc", arg, at))
@@ -1489,16 +1756,43 @@ func (g *generator) generateClientStubs(p printFn) {
 				p(`	var shardKey uint64`)
 			}
 
-			// Invoke call.Run.
-			p(``)
-			p(`	// Call the remote method.`)
 			data := "nil"
-			if mt.Params().Len() > 1 {
+			if hasArgs {
 				data = "enc.Data()"
-				p(`	requestBytes = len(enc.Data())`)
 			}
-			p(`	var results []byte`)
-			p(`	results, err = s.stub.Run(ctx, %d, %s, shardKey)`, methodIndex[m.Name()], data)
+			if streaming {
+				// Invoke call.Stream.
+				p(``)
+				p(`	// Start the remote streaming call.`)
+				if hasArgs {
+					p(`	requestBytes = len(enc.Data())`)
+				}
+				p(`	var conn %s`, g.codegen().qualify("ClientStream"))
+				p(`	conn, err = s.stub.Stream(ctx, %d, %s, shardKey)`, methodIndex[m.Name()], data)
+				p(`	if err != nil {`)
+				p(`		err = %s(%s, err)`, g.errorsPackage().qualify("Join"), g.weaver().qualify("RemoteCallError"))
+				p(`		return`)
+				p(`	}`)
+				if streamArg >= 0 {
+					p(``)
+					p(`	// Send the argument stream.`)
+					p(`	go %s(ctx, conn, a%d, %s)`, g.codegen().qualify("SendStream"), streamArg-1,
+						g.streamEncoder(mt.Params().At(streamArg).Type()))
+				}
+				p(``)
+				p(`	// Wait for the results.`)
+				p(`	var results []byte`)
+				p(`	results, err = conn.Reply()`)
+			} else {
+				// Invoke call.Run.
+				p(``)
+				p(`	// Call the remote method.`)
+				if hasArgs {
+					p(`	requestBytes = len(enc.Data())`)
+				}
+				p(`	var results []byte`)
+				p(`	results, err = s.stub.Run(ctx, %d, %s, shardKey)`, methodIndex[m.Name()], data)
+			}
 			p(`	replyBytes = len(results)`)
 			p(`	if err != nil {`)
 			p(`		err = %s(%s, err)`, g.errorsPackage().qualify("Join"), g.weaver().qualify("RemoteCallError"))
@@ -1511,6 +1805,9 @@ func (g *generator) generateClientStubs(p printFn) {
 			p(`	// Decode the results.`)
 			p(`	dec := %s(results)`, g.codegen().qualify("NewDecoder"))
 			for i := 0; i < mt.Results().Len()-1; i++ { // Skip final error
+				if i == streamResult {
+					continue // Received separately
+				}
 				rt := mt.Results().At(i).Type()
 				res := fmt.Sprintf("r%d", i)
 				if x, ok := rt.(*types.Pointer); ok && (g.tset.isProto(x) || g.tset.hasMarshalBinary(x)) {
@@ -1527,6 +1824,14 @@ func (g *generator) generateClientStubs(p printFn) {
 				}
 			}
 			p(`	err = dec.Error()`)
+			if streamResult >= 0 {
+				p(`	if err != nil {`)
+				p(`		conn.CloseRecv()`)
+				p(`		return`)
+				p(`	}`)
+				p(`	r%d = %s(conn, %s)`, streamResult, g.codegen().qualify("NewStreamReader"),
+					g.streamDecoder(mt.Results().At(streamResult).Type()))
+			}
 
 			p(`	return`)
 			p(`}`)
@@ -1534,6 +1839,54 @@ func (g *generator) generateClientStubs(p printFn) {
 	}
 }
 
+// streamArgIndex returns the index of the weaver.Stream argument of the
+// provided signature, or -1 if there is no such argument.
+func streamArgIndex(sig *types.Signature) int {
+	for i := 1; i < sig.Params().Len(); i++ { // Skip initial context.Context
+		if isWeaverStream(sig.Params().At(i).Type()) {
+			return i
+		}
+	}
+	return -1
+}
+
+// streamResultIndex returns the index of the weaver.Stream result of the
+// provided signature, or -1 if there is no such result.
+func streamResultIndex(sig *types.Signature) int {
+	for i := 0; i < sig.Results().Len()-1; i++ { // Skip final error
+		if isWeaverStream(sig.Results().At(i).Type()) {
+			return i
+		}
+	}
+	return -1
+}
+
+// streamEncoder returns a function literal that encodes a single element of
+// the weaver.Stream type t.
+func (g *generator) streamEncoder(t types.Type) string {
+	elem := streamElem(t)
+	return fmt.Sprintf("func(enc *%s, x %s) { %s }",
+		g.codegen().qualify("Encoder"), g.tset.genTypeString(elem), g.encode("enc", "x", elem))
+}
+
+// streamDecoder returns a function literal that decodes a single element of
+// the weaver.Stream type t.
+func (g *generator) streamDecoder(t types.Type) string {
+	elem := streamElem(t)
+	var b strings.Builder
+	fmt.Fprintf(&b, "func(dec *%s) (x %s) {\n", g.codegen().qualify("Decoder"), g.tset.genTypeString(elem))
+	if x, ok := elem.(*types.Pointer); ok && (g.tset.isProto(x) || g.tset.hasMarshalBinary(x)) {
+		// See generateClientStubs for why a temporary is needed.
+		fmt.Fprintf(&b, "var tmp %s\n", g.tset.genTypeString(x.Elem()))
+		fmt.Fprintf(&b, "%s\n", g.decode("dec", "&tmp", x.Elem()))
+		fmt.Fprintf(&b, "x = &tmp\n")
+	} else {
+		fmt.Fprintf(&b, "%s\n", g.decode("dec", "&x", elem))
+	}
+	fmt.Fprintf(&b, "return\n}")
+	return b.String()
+}
+
 // args returns a textual representation of the arguments of the provided
 // signature. The first argument must be a context.Context. The returned code
 // names the first argument ctx and all subsequent arguments a0, a1, and so on.
@@ -1565,6 +1918,20 @@ func (g *generator) returns(sig *types.Signature) string {
 	return fmt.Sprintf("%serr error", returns.String())
 }
 
+// fingerprint returns the fingerprint of the wire format of the provided
+// method, i.e. of the serialization of its arguments and results.
+func (g *generator) fingerprint(m *types.Func) string {
+	sig := m.Type().(*types.Signature)
+	var args, results []string
+	for i := 1; i < sig.Params().Len(); i++ { // Skip initial context.Context
+		args = append(args, g.tset.wireFormat(sig.Params().At(i).Type()))
+	}
+	for i := 0; i < sig.Results().Len()-1; i++ { // Skip final error
+		results = append(results, g.tset.wireFormat(sig.Results().At(i).Type()))
+	}
+	return codegen.Fingerprint(fmt.Sprintf("(%s) (%s)", strings.Join(args, ", "), strings.Join(results, ", ")))
+}
+
 // preallocatable returns whether we can preallocate a buffer of the right size
 // to encode the provided type.
 func (g *generator) preallocatable(t types.Type) bool {
@@ -1889,7 +2256,18 @@ func (g *generator) generateServerStubs(p printFn) {
 			p(`		}`)
 			p(`	}()`)
 
-			if mt.Params().Len() > 1 {
+			streamArg, streamResult := streamArgIndex(mt), streamResultIndex(mt)
+			streaming := streamArg >= 0 || streamResult >= 0
+			if streaming {
+				p(``)
+				p(`	// Get the call's streams.`)
+				p(`	conn, err := %s(ctx)`, g.codegen().qualify("ServerStream"))
+				p(`	if err != nil {`)
+				p(`		return nil, err`)
+				p(`	}`)
+			}
+
+			if mt.Params().Len() > 1 && (streamArg < 0 || mt.Params().Len() > 2) {
 				p(``)
 				p(`	// Decode arguments.`)
 				p(`	dec := %s(args)`, g.codegen().qualify("NewDecoder"))
@@ -1898,6 +2276,10 @@ func (g *generator) generateServerStubs(p printFn) {
 			for i := 1; i < mt.Params().Len(); i++ { // Skip initial context.Context
 				at := mt.Params().At(i).Type()
 				arg := fmt.Sprintf("a%d", i-1)
+				if i == streamArg {
+					p(`	%s := %s(conn, %s)`, arg, g.codegen().qualify("NewStreamReader"), g.streamDecoder(at))
+					continue
+				}
 				if x, ok := at.(*types.Pointer); ok && (g.tset.isProto(x) || g.tset.hasMarshalBinary(x)) {
 					// To decode a pointer *t where t is a proto or
 					// BinaryUnmarshaler, we need to instantiate a zero value
@@ -1958,11 +2340,28 @@ func (g *generator) generateServerStubs(p printFn) {
 
 			b.Reset()
 			for i := 0; i < mt.Results().Len()-1; i++ { // Skip final error
+				if i == streamResult {
+					continue // Sent separately
+				}
 				rt := mt.Results().At(i).Type()
 				res := fmt.Sprintf("r%d", i)
 				p(`	%s`, g.encode("enc", res, rt))
 			}
 			p(`	enc.Error(appErr)`)
+			if streamResult >= 0 {
+				p(``)
+				p(`	// Send the result stream.`)
+				p(`	if appErr != nil {`)
+				p(`		conn.CloseSend(nil)`)
+				p(`	} else {`)
+				p(`		go %s(ctx, conn, r%d, %s)`, g.codegen().qualify("SendStream"), streamResult,
+					g.streamEncoder(mt.Results().At(streamResult).Type()))
+				p(`	}`)
+			} else if streaming {
+				p(``)
+				p(`	// The method has no result stream; end it right away.`)
+				p(`	conn.CloseSend(nil)`)
+			}
 			p(`	return enc.Data(), nil`)
 			p(`}`)
 		}
@@ -2029,6 +2428,7 @@ func (g *generator) generateAutoMarshalMethods(p printFn) {
 	})
 
 	ts := g.tset.genTypeString
+	interfaces := g.serializedInterfaces()
 	for _, t := range sorted {
 		var innerTypes []types.Type
 		s := t.Underlying().(*types.Struct)
@@ -2096,15 +2496,60 @@ func (g *generator) generateAutoMarshalMethods(p printFn) {
 		}
 
 		// Register the type so it can be sent when the compile time type
-		// is an interface (like error). For now, we only do so for types
-		// that implement error. We could conceivably allow other types to
-		// be sent around as interfaces in the future.
-		if g.tset.implementsError(t) {
+		// is an interface (like error). We only do so for types that
+		// implement error or an interface serialized by the package.
+		if g.tset.implementsError(t) || implementsAny(t, interfaces) {
 			p("func init() { %s[*%s]() }", g.codegen().qualify("RegisterSerializable"), ts(t))
 		}
 	}
 }
 
+// serializedInterfaces returns the interface types, other than error, whose
+// values are serialized as part of the arguments and results of the package's
+// component methods or as part of the package's AutoMarshal types.
+func (g *generator) serializedInterfaces() []*types.Interface {
+	var interfaces []*types.Interface
+	var visited typeutil.Map
+	var visit func(t types.Type)
+	visit = func(t types.Type) {
+		if visited.At(t) != nil {
+			return
+		}
+		visited.Set(t, true)
+		if intf, ok := t.Underlying().(*types.Interface); ok && !isError(t) {
+			interfaces = append(interfaces, intf)
+			return
+		}
+		g.tset.forEachNested(t, visit)
+	}
+	for _, comp := range g.components {
+		for _, m := range comp.methods() {
+			sig := m.Type().(*types.Signature)
+			for i := 1; i < sig.Params().Len(); i++ { // Skip initial context.Context
+				visit(serializedType(sig.Params().At(i).Type()))
+			}
+			for i := 0; i < sig.Results().Len()-1; i++ { // Skip final error
+				visit(serializedType(sig.Results().At(i).Type()))
+			}
+		}
+	}
+	for _, t := range g.tset.automarshalCandidates.Keys() {
+		visit(t)
+	}
+	return interfaces
+}
+
+// implementsAny returns whether t or *t implements any of the provided
+// interfaces.
+func implementsAny(t types.Type, interfaces []*types.Interface) bool {
+	for _, intf := range interfaces {
+		if types.Implements(t, intf) || types.Implements(types.NewPointer(t), intf) {
+			return true
+		}
+	}
+	return false
+}
+
 // generateRouterMethods generates methods for router types.
 func (g *generator) generateRouterMethods(p printFn) {
 	printed := false
@@ -2203,6 +2648,8 @@ func (g *generator) encode(stub, e string, t types.Type) string {
 	// enc(stub, e: []t) = serviceweaver_enc_[[]t](&stub, e)
 	// enc(stub, e: map[k]v) = serviceweaver_enc_[map[k]v](&stub, e)
 	// enc(stub, e: struct{...}) = serviceweaver_enc_[struct{...}](&stub, &e)
+	// enc(stub, e: interface{...}) = stub.EncodeInterface(e)
+	// enc(stub, e: error) = stub.Error(e)
 	// enc(stub, e: type t u) = stub.EncodeProto(&e)           // t implements proto.Message
 	// enc(stub, e: type t u) = (e).WeaverMarshal(stub)         // t implements AutoMarshal
 	// enc(stub, e: type t u) = stub.EncodeBinaryMarshaler(&e) // t implements BinaryMarshaler
@@ -2237,7 +2684,13 @@ func (g *generator) encode(stub, e string, t types.Type) string {
 	case *types.Struct:
 		return fmt.Sprintf("%s(%s, %s)", f(x), stub, ref(e))
 
+	case *types.Interface:
+		return fmt.Sprintf("%s.EncodeInterface(%s)", stub, e)
+
 	case *types.Named:
+		if isError(x) {
+			return fmt.Sprintf("%s.Error(%s)", stub, e)
+		}
 		if g.tset.isProto(x) {
 			return fmt.Sprintf("%s.EncodeProto(%s)", stub, ref(e))
 		}
@@ -2251,6 +2704,9 @@ func (g *generator) encode(stub, e string, t types.Type) string {
 		if _, ok := under.(*types.Struct); ok {
 			return fmt.Sprintf("%s(%s, %s)", f(x), stub, ref(e))
 		}
+		if _, ok := under.(*types.Interface); ok {
+			return fmt.Sprintf("%s.EncodeInterface(%s)", stub, e)
+		}
 		return g.encode(stub, fmt.Sprintf("(%s)(%s)", g.tset.genTypeString(x.Underlying()), e), under)
 
 	default:
@@ -2276,6 +2732,9 @@ func (g *generator) decode(stub, v string, t types.Type) string {
 	// dec(stub, v: []t) = v := *v = serviceweaver_dec_[[]t](stub)
 	// dec(stub, v: map[k]v) = *v := serviceweaver_dec_[map[k]v](stub)
 	// dec(stub, v: struct{...}) = serviceweaver_dec_[struct{...}](stub, &v)
+	// dec(stub, v: interface{...}) = *v = codegen.DecodeInterface[interface{...}](stub)
+	// dec(stub, v: error) = *v = stub.Error()
+	// dec(stub, v: type t u) = *v = codegen.DecodeInterface[t](stub) // under(u) = interface{...}
 	// dec(stub, v: type t u) = stub.DecodeProto(v)             // t implements proto.Message
 	// dec(stub, v: type t u) = (v).WeaverUnmarshal(stub)        // t implements AutoMarshal
 	// dec(stub, v: type t u) = stub.DecodeBinaryUnmarshaler(v) // t implements BinaryUnmarshaler
@@ -2310,7 +2769,16 @@ func (g *generator) decode(stub, v string, t types.Type) string {
 	case *types.Struct:
 		return fmt.Sprintf("%s(%s, %s)", f(x), stub, v)
 
+	case *types.Interface:
+		return fmt.Sprintf("%s = %s[%s](%s)", deref(v), g.codegen().qualify("DecodeInterface"), g.tset.genTypeString(x), stub)
+
 	case *types.Named:
+		if isError(x) {
+			return fmt.Sprintf("%s = %s.Error()", deref(v), stub)
+		}
+		if _, ok := x.Underlying().(*types.Interface); ok {
+			return fmt.Sprintf("%s = %s[%s](%s)", deref(v), g.codegen().qualify("DecodeInterface"), g.tset.genTypeString(x), stub)
+		}
 		if g.tset.isProto(x) {
 			return fmt.Sprintf("%s.DecodeProto(%s)", stub, v)
 		}
@@ -2347,17 +2815,27 @@ func (g *generator) generateEncDecMethods(p printFn) {
 
 			// Generate for argument types, skipping the context.Context.
 			for j := 1; j < sig.Params().Len(); j++ {
-				g.generateEncDecMethodsFor(printer, sig.Params().At(j).Type())
+				g.generateEncDecMethodsFor(printer, serializedType(sig.Params().At(j).Type()))
 			}
 
 			// Generate for result types, skipping the error.
 			for j := 0; j < sig.Results().Len()-1; j++ {
-				g.generateEncDecMethodsFor(printer, sig.Results().At(j).Type())
+				g.generateEncDecMethodsFor(printer, serializedType(sig.Results().At(j).Type()))
 			}
 		}
 	}
 }
 
+// serializedType returns the type that is serialized for a method argument or
+// result of type t. This is t itself, except for weaver.Stream[T] types, whose
+// elements of type T are serialized instead.
+func serializedType(t types.Type) types.Type {
+	if isWeaverStream(t) {
+		return streamElem(t)
+	}
+	return t
+}
+
 // generateEncDecMethodsFor generates any necessary encoding and decoding
 // methods for the provided type. generateEncDecMethodsFor is memoized; it will
 // generate code for a type at most once.
@@ -2387,6 +2865,28 @@ func (g *generator) generateEncDecMethodsFor(p printFn, t types.Type) {
 
 		g.generateEncDecMethodsFor(p, x.Elem())
 
+		if g.tset.isRecursive(x) {
+			// Pointers to recursive types may form cycles, so we encode
+			// them in a way that preserves aliasing. Note that EncodeRef
+			// and DecodeRef handle nil pointers.
+			p(``)
+			p(`func serviceweaver_enc_%s(enc *%s, arg %s) {`, sanitize(x), g.codegen().qualify("Encoder"), ts(x))
+			p(`	if %s(enc, arg) {`, g.codegen().qualify("EncodeRef"))
+			p(`		%s`, g.encode("enc", "*arg", x.Elem()))
+			p(`	}`)
+			p(`}`)
+
+			p(``)
+			p(`func serviceweaver_dec_%s(dec *%s) %s {`, sanitize(x), g.codegen().qualify("Decoder"), ts(x))
+			p(`	res, ok := %s[%s](dec)`, g.codegen().qualify("DecodeRef"), ts(x.Elem()))
+			p(`	if ok {`)
+			p(`		%s`, g.decode("dec", "res", x.Elem()))
+			p(`	}`)
+			p(`	return res`)
+			p(`}`)
+			return
+		}
+
 		p(``)
 		p(`func serviceweaver_enc_%s(enc *%s, arg %s) {`, sanitize(x), g.codegen().qualify("Encoder"), ts(x))
 		p(`	if arg == nil {`)
@@ -2492,6 +2992,10 @@ func (g *generator) generateEncDecMethodsFor(p printFn, t types.Type) {
 		// Struct literals are not serializable.
 		panic(fmt.Sprintf("generateEncDecFor: unexpected type: %v", t))
 
+	case *types.Interface:
+		// Interface values don't need encoding or decoding methods. Instead,
+		// we call enc.EncodeInterface(x) and codegen.DecodeInterface[T](dec).
+
 	case *types.Named:
 		if g.tset.isProto(x) || g.tset.automarshals.At(x) != nil || g.tset.implementsAutoMarshal(x) || g.tset.hasMarshalBinary(x) {
 			// Types implementing proto.Marshal, weaver.AutoMarshal, or
@@ -2611,6 +3115,11 @@ func sanitize(t types.Type) string {
 			// The hash suffix below will ensure the names are unique.
 			return "struct"
 
+		case *types.Interface:
+			// Like structs, interface literals are disambiguated by the hash
+			// suffix below.
+			return "interface"
+
 		case *types.Basic:
 			switch x.Kind() {
 			case types.Bool,
@@ -2656,6 +3165,10 @@ func uniqueName(t types.Type) string {
 		return fmt.Sprintf("map[%s]%s", keyName, valName)
 
 	case *types.Named:
+		if x.Obj().Pkg() == nil {
+			// This is a predeclared type, like error.
+			return fmt.Sprintf("Named(%s)", x.Obj().Name())
+		}
 		n := x.TypeArgs().Len()
 		if n == 0 {
 			// This is a plain type.
@@ -2690,6 +3203,21 @@ func uniqueName(t types.Type) string {
 		}
 		return fmt.Sprintf("struct{%s}", strings.Join(fields, "; "))
 
+	case *types.Interface:
+		// Two interfaces are considered equal if they have the same method
+		// sets, including methods of embedded interfaces.
+		qualifier := func(pkg *types.Package) string { return pkg.Path() }
+		methods := make([]string, x.NumMethods())
+		for i := 0; i < x.NumMethods(); i++ {
+			m := x.Method(i)
+			name := m.Name()
+			if !m.Exported() {
+				name = m.Pkg().Path() + "." + name
+			}
+			methods[i] = name + strings.TrimPrefix(types.TypeString(m.Type(), qualifier), "func")
+		}
+		return fmt.Sprintf("interface{%s}", strings.Join(methods, "; "))
+
 	case *types.Basic:
 		switch x.Kind() {
 		case types.Bool,
@@ -2701,7 +3229,6 @@ func uniqueName(t types.Type) string {
 			return x.Name()
 		}
 	}
-	// TODO(mwhittaker): What about Struct and Interface literals?
 	panic(fmt.Sprintf("unsupported type %v (%T)", t, t))
 }
 
@@ -280,6 +280,45 @@ func TestGeneratorBuildWithTags(t *testing.T) {
 	}
 }
 
+// TestGenerateMocks runs "weaver generate -mocks" on the package in
+// testdata/mocks and runs the package's tests, which use the generated mocks.
+func TestGenerateMocks(t *testing.T) {
+	// Copy the package into a temporary directory with a go.mod file.
+	const dir = "testdata/mocks"
+	tmp := t.TempDir()
+	for _, filename := range []string{"mocks.go", "mocks_test.go"} {
+		bits, err := os.ReadFile(filepath.Join(dir, filename))
+		if err != nil {
+			t.Fatalf("cannot read %q: %v", filename, err)
+		}
+		if err := os.WriteFile(filepath.Join(tmp, filename), bits, 0644); err != nil {
+			t.Fatalf("error writing %s: %v", filename, err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(tmp, "go.mod"), []byte(goModFile), 0644); err != nil {
+		t.Fatalf("error writing go.mod: %v", err)
+	}
+
+	run := func(args ...string) {
+		t.Helper()
+		c := exec.Command("go", args...)
+		c.Dir = tmp
+		c.Stdout = os.Stdout
+		c.Stderr = os.Stderr
+		if err := c.Run(); err != nil {
+			t.Fatalf("go %v: %v", args, err)
+		}
+	}
+
+	run("mod", "tidy")
+	opt := Options{Warn: func(err error) { t.Log(err) }, BuildTags: "ignoreWeaverGen", Mocks: true}
+	if err := Generate(tmp, []string{tmp}, opt); err != nil {
+		t.Fatal(err)
+	}
+	run("mod", "tidy")
+	run("test", ".")
+}
+
 // TestGeneratorErrors runs "weaver generate" on all of the files in
 // testdata/errors.
 // Every file in testdata/errors must begin with a single line header that looks
@@ -502,7 +541,7 @@ func TestExampleVersion(t *testing.T) {
 	got := fmt.Sprintf("%x", h.Sum(nil))
 
 	// If weaver_gen.go has changed, the codegen version may need updating.
-	const want = "fdc14ac425413ea0f80ea42d3c8d1bc555d57c511135b5639720f233668f4fe7"
+	const want = "b250f5d57d74eaf1653a66c42d6685553540c2dfcb7e3066c32de936ac2ba996"
 	if got != want {
 		t.Fatalf(`Unexpected SHA-256 hash of examples/weaver_gen.go: got %s, want %s. If this change is meaningful, REMEMBER TO UPDATE THE CODEGEN VERSION in runtime/version/version.go.`, got, want)
 	}
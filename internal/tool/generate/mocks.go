@@ -0,0 +1,260 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generate
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/types"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// generateMocks generates a weaver_gen_test.go file with a programmable mock
+// of every component. For a component interface Foo with a method Bar, the
+// mock looks like this:
+//
+//	type MockFoo struct {...}
+//	func (m *MockFoo) Bar(ctx context.Context, ...) (..., error)
+//	func (m *MockFoo) OnBar(f func(context.Context, ...) (..., error))
+//	func (m *MockFoo) ReturnBar(..., err error)
+//	func (m *MockFoo) BarCalls() []MockFooBarCall
+//	func (m *MockFoo) AssertBarCalls(t testing.TB, n int)
+//
+// The mocks are placed in a test file, so they don't end up in application
+// binaries, and they use their own imports.
+func (g *generator) generateMocks() error {
+	var components []*component
+	for _, comp := range g.components {
+		if !comp.isMain {
+			components = append(components, comp)
+		}
+	}
+	if len(components) == 0 {
+		return nil
+	}
+
+	mset := newTypeSet(g.pkg, g.tset.automarshals, g.tset.automarshalCandidates)
+	var body bytes.Buffer
+	{
+		fn := func(format string, args ...interface{}) {
+			fmt.Fprintln(&body, fmt.Sprintf(format, args...))
+		}
+		for _, comp := range components {
+			generateMock(fn, mset, comp)
+		}
+	}
+
+	var header bytes.Buffer
+	{
+		p := func(format string, args ...interface{}) {
+			fmt.Fprintln(&header, fmt.Sprintf(format, args...))
+		}
+		p(`// Code generated by "weaver generate -mocks". DO NOT EDIT.`)
+		p("//go:build !ignoreWeaverGen")
+		p("")
+		p("package %s", g.pkg.Name)
+		p("")
+		p(`import (`)
+		for _, imp := range mset.imports() {
+			switch {
+			case imp.local:
+				// Already inside desired package
+			case imp.alias == "":
+				p(`	%s`, strconv.Quote(imp.path))
+			default:
+				p(`	%s %s`, imp.alias, strconv.Quote(imp.path))
+			}
+		}
+		p(`)`)
+	}
+
+	return writeGenerated(filepath.Join(g.pkgDir(), generatedMockFile), header, body)
+}
+
+// generateMock generates the mock of the provided component.
+func generateMock(p printFn, mset *typeSet, comp *component) {
+	intf := mset.genTypeString(comp.intf)
+	mock := mockName(comp)
+	contextPkg := mset.importPackage("context", "context")
+	syncPkg := mset.importPackage("sync", "sync")
+	testingPkg := mset.importPackage("testing", "testing")
+
+	p(``)
+	p(`// %s is a programmable mock of the %s component. Program its methods`, mock, intf)
+	p(`// with the On and Return methods, and inspect the calls it received with the`)
+	p(`// Calls and AssertCalls methods. A method that hasn't been programmed`)
+	p(`// returns zero values. Pass a %s to weavertest.Fake or sim.Fake to use it`, mock)
+	p(`// in place of the %s component.`, intf)
+	p(`type %s struct {`, mock)
+	p(`	mu %s`, syncPkg.qualify("Mutex"))
+	for _, m := range comp.methods() {
+		p(`	%sFunc %s`, notExported(m.Name()), mockFuncType(mset, m.Type().(*types.Signature)))
+		p(`	%sCalls []%s`, notExported(m.Name()), mockCallName(comp, m))
+	}
+	p(`}`)
+	p(``)
+	p(`// Check that %s implements the %s interface.`, mock, intf)
+	p(`var _ %s = (*%s)(nil)`, intf, mock)
+
+	for _, m := range comp.methods() {
+		sig := m.Type().(*types.Signature)
+		call := mockCallName(comp, m)
+		funcType := mockFuncType(mset, sig)
+		fields := mockCallFields(sig)
+		name, field := m.Name(), notExported(m.Name())
+
+		// Call record.
+		p(``)
+		p(`// %s holds the arguments of a call to %s.%s.`, call, mock, name)
+		p(`type %s struct {`, call)
+		for i := 1; i < sig.Params().Len(); i++ {
+			p(`	%s %s`, fields[i-1], mset.genTypeString(sig.Params().At(i).Type()))
+		}
+		p(`}`)
+
+		// Interface method.
+		var params, args, record strings.Builder
+		fmt.Fprintf(&params, "ctx %s", contextPkg.qualify("Context"))
+		fmt.Fprintf(&args, "ctx")
+		for i := 1; i < sig.Params().Len(); i++ {
+			at := sig.Params().At(i).Type()
+			if sig.Variadic() && i == sig.Params().Len()-1 {
+				fmt.Fprintf(&params, ", a%d ...%s", i-1, mset.genTypeString(at.(*types.Slice).Elem()))
+				fmt.Fprintf(&args, ", a%d...", i-1)
+			} else {
+				fmt.Fprintf(&params, ", a%d %s", i-1, mset.genTypeString(at))
+				fmt.Fprintf(&args, ", a%d", i-1)
+			}
+			fmt.Fprintf(&record, "%s: a%d, ", fields[i-1], i-1)
+		}
+		var results strings.Builder
+		for i := 0; i < sig.Results().Len()-1; i++ {
+			fmt.Fprintf(&results, "r%d %s, ", i, mset.genTypeString(sig.Results().At(i).Type()))
+		}
+		fmt.Fprintf(&results, "err error")
+		var values strings.Builder
+		for i := 0; i < sig.Results().Len()-1; i++ {
+			fmt.Fprintf(&values, "r%d, ", i)
+		}
+		fmt.Fprintf(&values, "err")
+
+		p(``)
+		p(`// %s implements the %s interface. It records the call and runs the`, name, intf)
+		p(`// function set by On%s, if any.`, name)
+		p(`func (m *%s) %s(%s) (%s) {`, mock, name, params.String(), results.String())
+		p(`	m.mu.Lock()`)
+		p(`	m.%sCalls = append(m.%sCalls, %s{%s})`, field, field, call, strings.TrimSuffix(record.String(), ", "))
+		p(`	f := m.%sFunc`, field)
+		p(`	m.mu.Unlock()`)
+		p(`	if f == nil {`)
+		p(`		return`)
+		p(`	}`)
+		p(`	return f(%s)`, args.String())
+		p(`}`)
+
+		// Programming.
+		p(``)
+		p(`// On%s makes calls to %s run f.`, name, name)
+		p(`func (m *%s) On%s(f %s) {`, mock, name, funcType)
+		p(`	m.mu.Lock()`)
+		p(`	defer m.mu.Unlock()`)
+		p(`	m.%sFunc = f`, field)
+		p(`}`)
+		p(``)
+		p(`// Return%s makes calls to %s return the provided values.`, name, name)
+		p(`func (m *%s) Return%s(%s) {`, mock, name, results.String())
+		p(`	m.On%s(func%s { return %s })`, name, strings.TrimPrefix(funcType, "func"), values.String())
+		p(`}`)
+
+		// Inspection.
+		p(``)
+		p(`// %sCalls returns the arguments of the calls to %s, oldest first.`, name, name)
+		p(`func (m *%s) %sCalls() []%s {`, mock, name, call)
+		p(`	m.mu.Lock()`)
+		p(`	defer m.mu.Unlock()`)
+		p(`	return append([]%s(nil), m.%sCalls...)`, call, field)
+		p(`}`)
+		p(``)
+		p(`// Assert%sCalls reports an error to t unless %s was called n times.`, name, name)
+		p(`func (m *%s) Assert%sCalls(t %s, n int) {`, mock, name, testingPkg.qualify("TB"))
+		p(`	t.Helper()`)
+		p(`	if got := len(m.%sCalls()); got != n {`, name)
+		p(`		t.Errorf("%s.%s: got %%d calls, want %%d", got, n)`, mock, name)
+		p(`	}`)
+		p(`}`)
+	}
+}
+
+// mockName returns the name of the mock of the provided component. The mock
+// is exported iff the component interface is exported.
+func mockName(comp *component) string {
+	if ast.IsExported(comp.intfName()) {
+		return "Mock" + comp.intfName()
+	}
+	return "mock" + exported(comp.intfName())
+}
+
+// mockCallName returns the name of the type that holds the arguments of a
+// call to the provided method of the mock of the provided component.
+func mockCallName(comp *component, m *types.Func) string {
+	return mockName(comp) + m.Name() + "Call"
+}
+
+// mockFuncType returns the type of a function with the provided signature,
+// without parameter or result names.
+func mockFuncType(mset *typeSet, sig *types.Signature) string {
+	var params []string
+	for i := 0; i < sig.Params().Len(); i++ {
+		at := sig.Params().At(i).Type()
+		if sig.Variadic() && i == sig.Params().Len()-1 {
+			params = append(params, "..."+mset.genTypeString(at.(*types.Slice).Elem()))
+		} else {
+			params = append(params, mset.genTypeString(at))
+		}
+	}
+	var results []string
+	for i := 0; i < sig.Results().Len(); i++ {
+		results = append(results, mset.genTypeString(sig.Results().At(i).Type()))
+	}
+	return fmt.Sprintf("func(%s) (%s)", strings.Join(params, ", "), strings.Join(results, ", "))
+}
+
+// mockCallFields returns the names of the fields that hold the arguments of a
+// call with the provided signature, excluding the initial context.Context.
+// The fields are named after the method's parameters, if they all have
+// distinct names, and Arg0, Arg1, and so on otherwise.
+func mockCallFields(sig *types.Signature) []string {
+	var fields []string
+	seen := map[string]bool{}
+	for i := 1; i < sig.Params().Len(); i++ {
+		name := exported(sig.Params().At(i).Name())
+		if name == "" || name == "_" || seen[name] {
+			fields = nil
+			break
+		}
+		seen[name] = true
+		fields = append(fields, name)
+	}
+	if fields != nil || sig.Params().Len() == 1 {
+		return fields
+	}
+	for i := 1; i < sig.Params().Len(); i++ {
+		fields = append(fields, fmt.Sprintf("Arg%d", i-1))
+	}
+	return fields
+}
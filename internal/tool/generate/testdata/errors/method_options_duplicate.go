@@ -0,0 +1,34 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// ERROR: duplicate weaver.MethodOptions for method foo.A
+
+package foo
+
+import (
+	"context"
+	"time"
+
+	"github.com/ServiceWeaver/weaver"
+)
+
+type foo interface {
+	A(context.Context) error
+}
+
+type impl struct{ weaver.Implements[foo] }
+
+func (l *impl) A(context.Context) error { return nil }
+
+var _ = weaver.MethodOptions{Method: foo.A, Timeout: time.Second}
+var _ = weaver.MethodOptions{Method: foo.A, Attempts: 2}
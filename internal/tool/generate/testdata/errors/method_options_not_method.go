@@ -1,4 +1,4 @@
-// Copyright 2022 Google LLC
+// Copyright 2023 Google LLC
 //
 // Licensed under the Apache License, Version 2.0 (the "License");
 // you may not use this file except in compliance with the License.
@@ -11,19 +11,12 @@
 // WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
 // See the License for the specific language governing permissions and
 // limitations under the License.
-
-// ERROR: A is not serializable
+// ERROR: weaver.MethodOptions.Method should identify a method
 
 package foo
 
-import "github.com/ServiceWeaver/weaver"
-
-type A struct {
-	weaver.AutoMarshal
-	*B
-}
+import (
+	"github.com/ServiceWeaver/weaver"
+)
 
-type B struct {
-	weaver.AutoMarshal
-	*A
-}
+var _ = weaver.MethodOptions{Method: 100}
@@ -0,0 +1,70 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// EXPECTED
+// enc.EncodeInterface(a0)
+// codegen.DecodeInterface[Shape](dec)
+// enc.EncodeInterface(arg[i])
+// codegen.DecodeInterface[Shape](dec)
+// enc.Error(x.Err)
+// x.Err = dec.Error()
+// RegisterSerializable[*Circle]()
+// RegisterSerializable[*Square]()
+
+// UNEXPECTED
+// RegisterSerializable[*Result]()
+
+// Verify that interface values are serializable, and that AutoMarshal types
+// implementing the interfaces are registered.
+package foo
+
+import (
+	"context"
+
+	"github.com/ServiceWeaver/weaver"
+)
+
+type Shape interface {
+	Area() float64
+}
+
+type Circle struct {
+	weaver.AutoMarshal
+	Radius float64
+}
+
+func (c Circle) Area() float64 { return 3.14 * c.Radius * c.Radius }
+
+type Square struct {
+	weaver.AutoMarshal
+	Side float64
+}
+
+func (s *Square) Area() float64 { return s.Side * s.Side }
+
+type Result struct {
+	weaver.AutoMarshal
+	Area float64
+	Err  error
+}
+
+type foo interface {
+	Area(context.Context, Shape) (Result, error)
+	Areas(context.Context, []Shape) ([]float64, error)
+}
+
+type impl struct{ weaver.Implements[foo] }
+
+func (impl) Area(context.Context, Shape) (Result, error)       { return Result{}, nil }
+func (impl) Areas(context.Context, []Shape) ([]float64, error) { return nil, nil }
@@ -0,0 +1,67 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// EXPECTED
+// Options: map[int]codegen.MethodOptions{
+// 0: {Timeout: 100 * time.Millisecond, Attempts: 3},
+// 2: {Attempts: 1, Backoff: 2 * time.Second},
+// 3: {Timeout: 90 * time.Second, Hedge: 1500 * time.Microsecond, HedgePercentile: 95},
+// Idempotent: []int{3},
+// ctx, cancel := context.WithTimeout(ctx, 100*time.Millisecond)
+// ctx, cancel := context.WithTimeout(ctx, 90*time.Second)
+
+// Package foo contains a component with configured methods.
+package foo
+
+import (
+	"context"
+	"time"
+
+	"github.com/ServiceWeaver/weaver"
+)
+
+type foo interface {
+	A(context.Context) error
+	B(context.Context) error
+	C(context.Context) error
+	D(context.Context) error
+}
+
+type impl struct{ weaver.Implements[foo] }
+
+func (l *impl) A(context.Context) error { return nil }
+func (l *impl) B(context.Context) error { return nil }
+func (l *impl) C(context.Context) error { return nil }
+func (l *impl) D(context.Context) error { return nil }
+
+const attempts = 3
+
+var _ = weaver.MethodOptions{
+	Method:   foo.A,
+	Timeout:  100 * time.Millisecond,
+	Attempts: attempts,
+}
+
+var (
+	_ weaver.NotRetriable = foo.C
+	_                     = weaver.MethodOptions{Method: foo.C, Attempts: 1, Backoff: 2 * time.Second}
+)
+
+var _ weaver.Idempotent = foo.D
+
+var _ weaver.MethodOptions = weaver.MethodOptions{
+	Method:          foo.D,
+	Timeout:         1.5 * 60 * time.Second,
+	Hedge:           1500 * time.Microsecond,
+	HedgePercentile: 95,
+}
@@ -0,0 +1,59 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package mocks contains components whose mocks are used by mocks_test.go.
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/ServiceWeaver/weaver"
+)
+
+type Store interface {
+	Get(ctx context.Context, key string) ([]byte, error)
+	Put(context.Context, string, []byte, time.Duration) error
+	Keys(_ context.Context, prefixes ...string) ([]string, error)
+}
+
+type store struct {
+	weaver.Implements[Store]
+}
+
+func (*store) Get(context.Context, string) ([]byte, error) {
+	return nil, nil
+}
+
+func (*store) Put(context.Context, string, []byte, time.Duration) error {
+	return nil
+}
+
+func (*store) Keys(context.Context, ...string) ([]string, error) {
+	return nil, nil
+}
+
+type cache interface {
+	Lookup(ctx context.Context, key string) (string, error)
+}
+
+type cacheImpl struct {
+	weaver.Implements[cache]
+	store weaver.Ref[Store]
+}
+
+func (c *cacheImpl) Lookup(ctx context.Context, key string) (string, error) {
+	value, err := c.store.Get().Get(ctx, key)
+	return string(value), err
+}
@@ -0,0 +1,69 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mocks
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ServiceWeaver/weaver/weavertest"
+)
+
+func TestMocks(t *testing.T) {
+	store := &MockStore{}
+	store.ReturnGet([]byte("value"), nil)
+	runner := weavertest.Local
+	runner.Fakes = append(runner.Fakes, weavertest.Fake[Store](store))
+	runner.Test(t, func(t *testing.T, c cache) {
+		value, err := c.Lookup(context.Background(), "key")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if value != "value" {
+			t.Fatalf("Lookup: got %q, want %q", value, "value")
+		}
+	})
+	store.AssertGetCalls(t, 1)
+	if got := store.GetCalls()[0].Key; got != "key" {
+		t.Fatalf("Get: got key %q, want %q", got, "key")
+	}
+
+	// Unprogrammed methods return zero values.
+	if err := store.Put(context.Background(), "key", nil, 0); err != nil {
+		t.Fatal(err)
+	}
+	store.AssertPutCalls(t, 1)
+	if got := store.PutCalls()[0].Arg0; got != "key" {
+		t.Fatalf("Put: got key %q, want %q", got, "key")
+	}
+
+	// Variadic methods record their arguments as slices.
+	failure := errors.New("failure")
+	store.OnKeys(func(_ context.Context, prefixes ...string) ([]string, error) {
+		return prefixes, failure
+	})
+	keys, err := store.Keys(context.Background(), "a", "b")
+	if !errors.Is(err, failure) || len(keys) != 2 {
+		t.Fatalf("Keys: got (%v, %v), want ([a b], %v)", keys, err, failure)
+	}
+	if got := store.KeysCalls()[0].Prefixes; len(got) != 2 {
+		t.Fatalf("Keys: got prefixes %v, want [a b]", got)
+	}
+
+	// Unexported components have unexported mocks.
+	var c mockCache
+	c.AssertLookupCalls(t, 0)
+}
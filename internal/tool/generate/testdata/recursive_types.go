@@ -0,0 +1,66 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// EXPECTED
+// func (x *Comment) WeaverMarshal(enc *codegen.Encoder)
+// serviceweaver_enc_slice_Comment
+// func (x *A) WeaverMarshal(enc *codegen.Encoder)
+// func (x *B) WeaverMarshal(enc *codegen.Encoder)
+// if codegen.EncodeRef(enc, arg) {
+// res, ok := codegen.DecodeRef[A](dec)
+// res, ok := codegen.DecodeRef[B](dec)
+// serviceweaver_enc_map_string_Tree
+
+// UNEXPECTED
+// Preallocate
+
+// Verify that recursive types are serializable, and that pointers to recursive
+// types are encoded with codegen.EncodeRef.
+package foo
+
+import (
+	"context"
+
+	"github.com/ServiceWeaver/weaver"
+)
+
+type Comment struct {
+	weaver.AutoMarshal
+	Text    string
+	Replies []Comment
+}
+
+type A struct {
+	weaver.AutoMarshal
+	*B
+}
+
+type B struct {
+	weaver.AutoMarshal
+	*A
+}
+
+type Tree map[string]Tree
+
+type foo interface {
+	Comments(context.Context, Comment) (Comment, error)
+	Pointers(context.Context, *A) (*B, error)
+	Trees(context.Context, Tree) error
+}
+
+type impl struct{ weaver.Implements[foo] }
+
+func (impl) Comments(_ context.Context, c Comment) (Comment, error) { return c, nil }
+func (impl) Pointers(context.Context, *A) (*B, error)               { return nil, nil }
+func (impl) Trees(context.Context, Tree) error                      { return nil }
@@ -0,0 +1,64 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// EXPECTED
+// conn, err = s.stub.Stream(ctx, 0, nil, shardKey)
+// conn, err = s.stub.Stream(ctx, 1, enc.Data(), shardKey)
+// go codegen.SendStream(ctx, conn, a0, func(enc *codegen.Encoder, x string) { enc.String(x) })
+// r0 = codegen.NewStreamReader(conn, func(dec *codegen.Decoder) (x int) {
+// r0 = codegen.NewStreamReader(conn, func(dec *codegen.Decoder) (x []string) {
+// conn, err := codegen.ServerStream(ctx)
+// a0 := codegen.NewStreamReader(conn, func(dec *codegen.Decoder) (x string) {
+// go codegen.SendStream(ctx, conn, r0, func(enc *codegen.Encoder, x int) { enc.Int(x) })
+// go codegen.SendStream(ctx, conn, r0, func(enc *codegen.Encoder, x []string) { serviceweaver_enc_slice_string_4af10117(enc, x) })
+// conn.CloseSend(nil)
+// results, err = s.stub.Run(ctx, 3, enc.Data(), shardKey)
+
+// UNEXPECTED
+// serviceweaver_enc_weaver_Stream
+// serviceweaver_dec_weaver_Stream
+
+// Package foo contains a component with streaming methods.
+package foo
+
+import (
+	"context"
+
+	"github.com/ServiceWeaver/weaver"
+)
+
+type foo interface {
+	Bidi(context.Context, weaver.Stream[string]) (weaver.Stream[int], error)
+	ClientStream(context.Context, int, weaver.Stream[string]) (bool, error)
+	ServerStream(context.Context, bool) (weaver.Stream[[]string], int, error)
+	Unary(context.Context, int) (int, error)
+}
+
+type impl struct{ weaver.Implements[foo] }
+
+func (l *impl) Bidi(context.Context, weaver.Stream[string]) (weaver.Stream[int], error) {
+	return nil, nil
+}
+
+func (l *impl) ClientStream(context.Context, int, weaver.Stream[string]) (bool, error) {
+	return false, nil
+}
+
+func (l *impl) ServerStream(context.Context, bool) (weaver.Stream[[]string], int, error) {
+	return nil, 0, nil
+}
+
+func (l *impl) Unary(context.Context, int) (int, error) {
+	return 0, nil
+}
@@ -48,6 +48,9 @@ type typeSet struct {
 
 	// If measurable[t] != nil, then measurable[t] == isMeasurableType(t).
 	measurable typeutil.Map
+
+	// If recursive[t] != nil, then recursive[t] == isRecursive(t).
+	recursive typeutil.Map
 }
 
 // importPkg is a package imported by the generated code.
@@ -275,11 +278,11 @@ func (tset *typeSet) checkSerializable(t types.Type) []error {
 			return false
 		}
 
-		// Check for recursive types.
+		// Recursive types are serializable if the rest of the type is. Note
+		// that we don't memoize the result, as we haven't finished checking
+		// t yet.
 		if stack.At(t) != nil {
-			addError(fmt.Errorf("serialization of recursive types not currently supported"))
-			tset.checked.Set(t, false)
-			return false
+			return true
 		}
 		stack.Set(t, struct{}{})
 		defer func() { stack.Delete(t) }()
@@ -329,10 +332,10 @@ func (tset *typeSet) checkSerializable(t types.Type) []error {
 			tset.checked.Set(t, serializable)
 
 		case *types.Interface:
-			// TODO(sanjay): Support types.Interface only if we can figure out
-			// a way to instantiate the type.
-			addError(fmt.Errorf("serialization of interfaces not currently supported"))
-			tset.checked.Set(t, false)
+			// Interface values are serialized along with their concrete
+			// type, which must be registered with codegen.RegisterSerializable
+			// on both ends. The concrete types are checked at runtime.
+			tset.checked.Set(t, true)
 
 		case *types.Struct:
 			addError(fmt.Errorf("struct literals are not serializable"))
@@ -541,6 +544,9 @@ func (tset *typeSet) isMeasurable(t types.Type) bool {
 		} else if x.Obj().Pkg() != rootPkg {
 			tset.measurable.Set(t, false)
 		} else {
+			// Recursive types are not measurable. We mark t as not
+			// measurable while we recurse, to terminate on a cycle.
+			tset.measurable.Set(t, false)
 			tset.measurable.Set(t, tset.isMeasurable(x.Underlying()))
 		}
 
@@ -551,6 +557,165 @@ func (tset *typeSet) isMeasurable(t types.Type) bool {
 	return tset.measurable.At(t).(bool)
 }
 
+// isRecursive returns whether the provided type is recursive, i.e. whether a
+// value of the type can contain other values of the same type. For example,
+// the types *List and Tree below are recursive.
+//
+//	type List struct {
+//	    weaver.AutoMarshal
+//	    Val  int
+//	    Next *List
+//	}
+//
+//	type Tree map[string]Tree
+//
+// Types with custom serialization (e.g., protos) and interfaces are not
+// inspected.
+func (tset *typeSet) isRecursive(t types.Type) bool {
+	if result := tset.recursive.At(t); result != nil {
+		return result.(bool)
+	}
+
+	// Search the graph of types nested in t for t.
+	var visited typeutil.Map
+	found := false
+	var visit func(u types.Type)
+	visit = func(u types.Type) {
+		if found {
+			return
+		}
+		if types.Identical(u, t) {
+			found = true
+			return
+		}
+		if visited.At(u) != nil {
+			return
+		}
+		visited.Set(u, true)
+		tset.forEachNested(u, visit)
+	}
+	tset.forEachNested(t, visit)
+	tset.recursive.Set(t, found)
+	return found
+}
+
+// forEachNested calls f on the types directly nested in type t whose values
+// are serialized as part of the serialization of t.
+func (tset *typeSet) forEachNested(t types.Type, f func(types.Type)) {
+	switch x := t.(type) {
+	case *types.Pointer:
+		f(x.Elem())
+	case *types.Array:
+		f(x.Elem())
+	case *types.Slice:
+		f(x.Elem())
+	case *types.Map:
+		f(x.Key())
+		f(x.Elem())
+	case *types.Struct:
+		for i := 0; i < x.NumFields(); i++ {
+			if ft := x.Field(i).Type(); !isWeaverAutoMarshal(ft) {
+				f(ft)
+			}
+		}
+	case *types.Named:
+		if tset.isProto(x) || tset.hasMarshalBinary(x) {
+			return
+		}
+		if tset.implementsAutoMarshal(x) && tset.automarshals.At(x) == nil && tset.automarshalCandidates.At(x) == nil && !embedsAutoMarshal(x) {
+			// x has a custom WeaverMarshal method.
+			return
+		}
+		f(x.Underlying())
+	}
+}
+
+// wireFormat returns a canonical description of the serialization of t. Two
+// types have the same description if and only if values of one type can be
+// decoded as values of the other. Some examples:
+//
+//   - wireFormat(int) = "int"
+//   - wireFormat([]*string) = "[]*string"
+//   - wireFormat(struct{weaver.AutoMarshal; x int; y string}) = "struct{x int; y string}"
+//   - wireFormat(*pb.Request) = "*proto(example.com/pb.Request)"
+//
+// Type names are ignored, except for types with custom serialization (e.g.,
+// protos and binary marshalers), whose serialization we can't inspect. Field
+// names are included, as reordering two fields of the same type silently
+// changes the meaning of a serialized struct.
+func (tset *typeSet) wireFormat(t types.Type) string {
+	qualifier := func(pkg *types.Package) string { return pkg.Path() }
+	var stack typeutil.Map // named types being described, to detect cycles
+	var describe func(t types.Type) string
+	describe = func(t types.Type) string {
+		switch x := t.(type) {
+		case *types.Basic:
+			// Note that we canonicalize aliases, like byte and rune.
+			return types.Typ[x.Kind()].Name()
+		case *types.Pointer:
+			return "*" + describe(x.Elem())
+		case *types.Array:
+			return fmt.Sprintf("[%d]%s", x.Len(), describe(x.Elem()))
+		case *types.Slice:
+			return "[]" + describe(x.Elem())
+		case *types.Map:
+			return fmt.Sprintf("map[%s]%s", describe(x.Key()), describe(x.Elem()))
+		case *types.Struct:
+			var fields []string
+			for i := 0; i < x.NumFields(); i++ {
+				f := x.Field(i)
+				if isWeaverAutoMarshal(f.Type()) {
+					continue
+				}
+				fields = append(fields, f.Name()+" "+describe(f.Type()))
+			}
+			return "struct{" + strings.Join(fields, "; ") + "}"
+		case *types.Interface:
+			return "interface"
+		case *types.Named:
+			name := types.TypeString(x, qualifier)
+			switch {
+			case isError(x):
+				return "error"
+			case isWeaverStream(x):
+				return "stream(" + describe(streamElem(x)) + ")"
+			case tset.isProto(x):
+				return "proto(" + name + ")"
+			case tset.automarshals.At(x) != nil || tset.automarshalCandidates.At(x) != nil || embedsAutoMarshal(x):
+				// The serialization is generated from the struct's fields.
+			case tset.implementsAutoMarshal(x):
+				return "custom(" + name + ")"
+			case tset.hasMarshalBinary(x):
+				return "binary(" + name + ")"
+			}
+			if stack.At(x) != nil {
+				return "recursive(" + name + ")"
+			}
+			stack.Set(x, true)
+			defer stack.Delete(x)
+			return describe(x.Underlying())
+		default:
+			return types.TypeString(t, qualifier)
+		}
+	}
+	return describe(t)
+}
+
+// embedsAutoMarshal returns whether the provided named type is a struct that
+// embeds weaver.AutoMarshal.
+func embedsAutoMarshal(t *types.Named) bool {
+	s, ok := t.Underlying().(*types.Struct)
+	if !ok {
+		return false
+	}
+	for i := 0; i < s.NumFields(); i++ {
+		if f := s.Field(i); f.Embedded() && isWeaverAutoMarshal(f.Type()) {
+			return true
+		}
+	}
+	return false
+}
+
 // genTypeString returns the string representation of t as to be printed
 // in the generated code, updating import definitions to account for the
 // returned type string.
@@ -893,6 +1058,25 @@ func isWeaverNotRetriable(t types.Type) bool {
 	return isWeaverType(t, "NotRetriable", 0)
 }
 
+func isWeaverIdempotent(t types.Type) bool {
+	return isWeaverType(t, "Idempotent", 0)
+}
+
+func isWeaverMethodOptions(t types.Type) bool {
+	return isWeaverType(t, "MethodOptions", 0)
+}
+
+func isWeaverStream(t types.Type) bool {
+	return isWeaverType(t, "Stream", 1)
+}
+
+// streamElem returns the element type T of the weaver.Stream[T] type t.
+//
+// REQUIRES: isWeaverStream(t)
+func streamElem(t types.Type) types.Type {
+	return t.(*types.Named).TypeArgs().At(0)
+}
+
 func isString(t types.Type) bool {
 	b, ok := t.(*types.Basic)
 	return ok && b.Kind() == types.String
@@ -75,6 +75,25 @@ func (t target) UnmarshalBinary([]byte) error { return nil }
 type target struct{}
 func (t target) MarshalBinary() ([]byte, error) { return nil, nil }
 func (t *target) UnmarshalBinary([]byte) error { return nil }
+`, ""},
+		{"interface", `
+type target interface{
+	MarshalBinary() ([]byte, error)
+	UnmarshalBinary([]byte) error
+}
+`, ""},
+		{"empty interface", "type target []any", ""},
+		{"error", "type target map[string]error", ""},
+		{"simple recursive", `
+type target *target
+`, ""},
+		{"nested recursive", `
+type target []*target
+`, ""},
+		{"mutually recursive", `
+type A []*B
+type B []*A
+type target A
 `, ""},
 		{"BinaryMarshaler recursive", `
 type target struct { next *target }
@@ -99,23 +118,9 @@ type target struct{
 }
 func (t *target) UnmarshalBinary([]byte) error { return nil }
 `, "not serializable"},
-		{"interface", `
-type target interface{
-	MarshalBinary() ([]byte, error)
-	UnmarshalBinary([]byte) error
-}
-`, "not currently supported"},
-		{"simple recursive", `
-type target *target
-`, "not currently supported"},
-		{"nested recursive", `
-type target []*target
-`, "not currently supported"},
-		{"mutually recursive", `
-type A []*B
-type B []*A
-type target A
-`, "not currently supported"},
+		{"recursive non-serializable", `
+type target []*struct{ x target }
+`, "struct literals are not serializable"},
 	} {
 		t.Run(c.label, func(t *testing.T) {
 			tset, target := compile(t, c.contents)
@@ -264,6 +269,80 @@ type target A`, false},
 	}
 }
 
+func TestWireFormat(t *testing.T) {
+	type testCase struct {
+		label    string
+		contents string
+		want     string
+	}
+	for _, c := range []testCase{
+		{"int", "type target int", "int"},
+		{"string", "type target string", "string"},
+		{"array", "type target [42]byte", "[42]uint8"},
+		{"alias", "type target []rune", "[]int32"},
+		{"slice", "type target []*string", "[]*string"},
+		{"map", "type target map[string][]int", "map[string][]int"},
+		{"named", "type A []int; type target map[string]A", "map[string][]int"},
+		{"struct", "type target struct{x int; y string}", "struct{x int; y string}"},
+		{"NestedStruct", `
+type A struct { x B; y []C }
+type B struct { x int }
+type C struct { y bool }
+type target A`, "struct{x struct{x int}; y []struct{y bool}}"},
+		{"OtherPackage", `
+import "time"
+
+type target []time.Duration
+`, "[]int64"},
+		{"BinaryMarshaler", `
+type target struct{ x int }
+func (t *target) MarshalBinary() ([]byte, error) { return nil, nil }
+func (t *target) UnmarshalBinary([]byte) error { return nil }
+`, "binary(foo.target)"},
+		{"recursive", "type target []*target", "[]*recursive(foo.target)"},
+		{"interface", "type A interface{ M() }; type target []A", "[]interface"},
+		{"error", "type target map[string]error", "map[string]error"},
+	} {
+		t.Run(c.label, func(t *testing.T) {
+			tset, target := compile(t, c.contents)
+			if got := tset.wireFormat(target); got != c.want {
+				t.Fatalf("wireFormat: got %q, want %q", got, c.want)
+			}
+		})
+	}
+}
+
+func TestIsRecursive(t *testing.T) {
+	type testCase struct {
+		label    string
+		contents string
+		want     bool
+	}
+	for _, c := range []testCase{
+		{"int", "type target int", false},
+		{"slice", "type target []int", false},
+		{"pointer", "type A struct{ x int }; type target *A", false},
+		{"simple", "type target []target", true},
+		{"pointer to self", "type target *target", true},
+		{"map", "type target map[string]target", true},
+		{"mutual", "type A []*B; type B []*A; type target A", true},
+		{"nested", "type A []*A; type target []A", false},
+		{"interface", "type A interface{ M() target }; type target []A", false},
+		{"BinaryMarshaler", `
+type target struct{ next *target }
+func (t *target) MarshalBinary() ([]byte, error) { return nil, nil }
+func (t *target) UnmarshalBinary([]byte) error { return nil }
+`, false},
+	} {
+		t.Run(c.label, func(t *testing.T) {
+			tset, target := compile(t, c.contents)
+			if got := tset.isRecursive(target); got != c.want {
+				t.Fatalf("isRecursive: got %v, want %v", got, c.want)
+			}
+		})
+	}
+}
+
 func TestIsValidRouterType(t *testing.T) {
 	type testCase struct {
 		label    string
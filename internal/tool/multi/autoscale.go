@@ -0,0 +1,160 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package multi
+
+import (
+	"math"
+	"time"
+
+	imetrics "github.com/ServiceWeaver/weaver/internal/metrics"
+	"github.com/ServiceWeaver/weaver/runtime/metrics"
+)
+
+// The default autoscaling options. See MultiConfig.AutoscalingOptions.
+const (
+	defaultScalingInterval = 10 * time.Second
+	defaultScalingTarget   = 100.0
+)
+
+// autoscaled returns whether any co-location group is autoscaled.
+func (d *deployer) autoscaled() bool {
+	for _, g := range d.groups {
+		if g.maxReplicas > g.minReplicas {
+			return true
+		}
+	}
+	return false
+}
+
+// autoscale periodically resizes the autoscaled co-location groups based on
+// their load. It returns when the deployer is stopped.
+func (d *deployer) autoscale() error {
+	ticker := time.NewTicker(d.scalingInterval)
+	defer ticker.Stop()
+	var tracker loadTracker
+	for {
+		select {
+		case <-ticker.C:
+			loads := tracker.update(time.Now(), d.readMetrics())
+			if loads != nil {
+				d.rescale(loads)
+			}
+		case <-d.ctx.Done():
+			return d.ctx.Err()
+		}
+	}
+}
+
+// rescale resizes the autoscaled co-location groups given the load of every
+// component, in calls per second.
+//
+// REQUIRES: d.mu is NOT held.
+func (d *deployer) rescale(loads map[string]float64) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	done := map[*group]bool{}
+	for _, g := range d.groups {
+		if done[g] || len(g.replicas) == 0 || g.maxReplicas == g.minReplicas {
+			// The group was already rescaled, hasn't been started, or isn't
+			// autoscaled.
+			continue
+		}
+		done[g] = true
+
+		var load float64
+		for component := range g.started {
+			load += loads[component]
+		}
+		n := len(g.replicas)
+		want := desiredReplicas(load, d.scalingTarget, n, g.minReplicas, g.maxReplicas)
+		if want == n {
+			continue
+		}
+		d.logger.Info("Autoscaling", "group", g.name, "load", load, "from", n, "to", want)
+		for len(g.replicas) < want {
+			if err := d.addReplica(g); err != nil {
+				d.logger.Error("Cannot add replica", "group", g.name, "err", err)
+				break
+			}
+		}
+		for len(g.replicas) > want {
+			d.drainReplica(g)
+		}
+	}
+}
+
+// desiredReplicas returns the number of replicas, between lo and hi, needed
+// to keep the average load per replica at or below target. To avoid
+// thrashing, the number of replicas shrinks by at most one at a time.
+func desiredReplicas(load, target float64, current, lo, hi int) int {
+	want := int(math.Ceil(load / target))
+	if want < current-1 {
+		want = current - 1
+	}
+	if want < lo {
+		want = lo
+	}
+	if want > hi {
+		want = hi
+	}
+	return want
+}
+
+// A loadTracker computes the load of components from the cumulative method
+// call counts reported by weavelets.
+type loadTracker struct {
+	last   time.Time            // time of the previous update
+	counts map[countKey]float64 // call counts as of the previous update
+}
+
+// countKey identifies a call count metric exported by a weavelet.
+type countKey struct {
+	weavelet string // the weavelet that exported the metric
+	id       uint64 // the metric's id, unique within the weavelet
+}
+
+// update returns the load of every component, in calls per second, since the
+// previous call to update. On the first call, update returns nil.
+func (l *loadTracker) update(now time.Time, snapshots []*metrics.MetricSnapshot) map[string]float64 {
+	counts := map[countKey]float64{}
+	deltas := map[string]float64{}
+	for _, m := range snapshots {
+		if m.Name != imetrics.MethodCountsName {
+			continue
+		}
+		key := countKey{m.Labels["serviceweaver_node"], m.Id}
+		counts[key] = m.Value
+
+		// A call count that wasn't seen before belongs to a new weavelet or
+		// method and started at zero. Calls counted by weavelets that have
+		// since exited are lost.
+		if delta := m.Value - l.counts[key]; delta > 0 {
+			deltas[m.Labels["component"]] += delta
+		}
+	}
+
+	first := l.counts == nil
+	elapsed := now.Sub(l.last).Seconds()
+	l.last, l.counts = now, counts
+	if first || elapsed <= 0 {
+		return nil
+	}
+	loads := make(map[string]float64, len(deltas))
+	for component, delta := range deltas {
+		loads[component] = delta / elapsed
+	}
+	return loads
+}
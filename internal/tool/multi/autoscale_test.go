@@ -0,0 +1,131 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package multi
+
+import (
+	"testing"
+	"time"
+
+	imetrics "github.com/ServiceWeaver/weaver/internal/metrics"
+	"github.com/ServiceWeaver/weaver/runtime/metrics"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestReplicaBounds(t *testing.T) {
+	for _, test := range []struct {
+		name   string
+		opts   *MultiConfig_ReplicaOptions
+		lo, hi int
+	}{
+		{"Nil", nil, 2, 2},
+		{"Empty", &MultiConfig_ReplicaOptions{}, 2, 2},
+		{"Min", &MultiConfig_ReplicaOptions{Min: 3}, 3, 3},
+		{"Max", &MultiConfig_ReplicaOptions{Max: 5}, 2, 5},
+		{"SmallMax", &MultiConfig_ReplicaOptions{Max: 1}, 1, 1},
+		{"MinMax", &MultiConfig_ReplicaOptions{Min: 1, Max: 4}, 1, 4},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			lo, hi, err := replicaBounds(test.opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if lo != test.lo || hi != test.hi {
+				t.Fatalf("replicaBounds: got (%d, %d), want (%d, %d)", lo, hi, test.lo, test.hi)
+			}
+		})
+	}
+}
+
+func TestReplicaBoundsErrors(t *testing.T) {
+	for _, opts := range []*MultiConfig_ReplicaOptions{
+		{Min: -1},
+		{Max: -1},
+		{Min: 3, Max: 2},
+	} {
+		if _, _, err := replicaBounds(opts); err == nil {
+			t.Errorf("replicaBounds(%v): unexpected success", opts)
+		}
+	}
+}
+
+func TestDesiredReplicas(t *testing.T) {
+	for _, test := range []struct {
+		name    string
+		load    float64
+		current int
+		want    int
+	}{
+		{"Idle", 0, 2, 1},
+		{"AtTarget", 200, 2, 2},
+		{"ScaleUp", 201, 2, 3},
+		{"ScaleUpMany", 450, 2, 5},
+		{"ScaleUpToMax", 10000, 2, 8},
+		{"ScaleDownGradually", 50, 6, 5},
+		{"StayAtMin", 0, 1, 1},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			got := desiredReplicas(test.load, 100, test.current, 1, 8)
+			if got != test.want {
+				t.Fatalf("desiredReplicas(%v, 100, %d, 1, 8): got %d, want %d", test.load, test.current, got, test.want)
+			}
+		})
+	}
+}
+
+func TestLoadTracker(t *testing.T) {
+	count := func(weavelet string, id uint64, component string, value float64) *metrics.MetricSnapshot {
+		return &metrics.MetricSnapshot{
+			Id:   id,
+			Name: imetrics.MethodCountsName,
+			Labels: map[string]string{
+				"serviceweaver_node": weavelet,
+				"component":          component,
+			},
+			Value: value,
+		}
+	}
+
+	var tracker loadTracker
+	start := time.Now()
+	if got := tracker.update(start, []*metrics.MetricSnapshot{
+		count("w1", 1, "A", 100),
+		count("w1", 2, "B", 50),
+	}); got != nil {
+		t.Fatalf("first update: got %v, want nil", got)
+	}
+
+	// w1 served 20 more calls of A and 10 more of B; w2 is new and served 10
+	// calls of A; w1's id 2 is also exported by w2, with an unrelated count.
+	got := tracker.update(start.Add(10*time.Second), []*metrics.MetricSnapshot{
+		count("w1", 1, "A", 120),
+		count("w1", 2, "B", 60),
+		count("w2", 1, "A", 10),
+		count("w2", 2, "C", 5),
+	})
+	want := map[string]float64{"A": 3, "B": 1, "C": 0.5}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("second update (-want +got):\n%s", diff)
+	}
+
+	// w1 exited, so its counts are gone.
+	got = tracker.update(start.Add(20*time.Second), []*metrics.MetricSnapshot{
+		count("w2", 1, "A", 30),
+		count("w2", 2, "C", 5),
+	})
+	want = map[string]float64{"A": 2}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatalf("third update (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,105 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package multi
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ServiceWeaver/weaver/internal/routing"
+	"github.com/ServiceWeaver/weaver/runtime/envelope"
+	"github.com/ServiceWeaver/weaver/runtime/protos"
+	"golang.org/x/exp/maps"
+)
+
+// balanceInterval is how often the load of routed components is collected
+// and their assignments rebalanced.
+const balanceInterval = 10 * time.Second
+
+// balance periodically rebalances the assignments of routed components based
+// on their load. It returns when the deployer is stopped.
+func (d *deployer) balance() error {
+	ticker := time.NewTicker(balanceInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			d.rebalance()
+		case <-d.ctx.Done():
+			return d.ctx.Err()
+		}
+	}
+}
+
+// rebalance collects load reports from the replicas of all co-location groups
+// with routed components and rebalances the components' assignments.
+//
+// REQUIRES: d.mu is NOT held.
+func (d *deployer) rebalance() {
+	// Snapshot the envelopes. Note that we don't hold d.mu while collecting
+	// load, as GetLoad blocks on a weavelet that may be blocked on d.mu.
+	envelopes := map[*group][]*envelope.Envelope{}
+	d.mu.Lock()
+	for _, g := range d.groups {
+		if _, ok := envelopes[g]; ok || len(g.assignments) == 0 {
+			continue
+		}
+		envelopes[g] = nil
+		for _, r := range g.replicas {
+			envelopes[g] = append(envelopes[g], r.envelope)
+		}
+	}
+	d.mu.Unlock()
+
+	// Collect load.
+	loads := map[*group]map[string][]*protos.LoadReport_ComponentLoad{}
+	for g, envs := range envelopes {
+		loads[g] = map[string][]*protos.LoadReport_ComponentLoad{}
+		for _, e := range envs {
+			report, err := e.GetLoad()
+			if err != nil {
+				// The weavelet may have crashed, in which case it is
+				// restarted and its load is reported later.
+				d.logger.Debug("Cannot get load", "group", g.name, "err", err)
+				continue
+			}
+			for component, load := range report.Loads {
+				loads[g][component] = append(loads[g][component], load)
+			}
+		}
+	}
+
+	// Rebalance the assignments, and notify subscribers of the changed ones.
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for g, byComponent := range loads {
+		replicas := maps.Keys(g.addresses)
+		for component, assignment := range g.assignments {
+			g.loads[component] = byComponent[component]
+			next := routingAlgo(assignment, replicas, byComponent[component])
+			if next.Version == assignment.Version {
+				continue
+			}
+			g.assignments[component] = next
+			d.logger.Debug(fmt.Sprintf("Rebalanced assignment for component %s:\n%s", component, routing.FormatAssignment(next)))
+			info := g.routing(component)
+			for _, sub := range g.subscribers[component] {
+				if err := sub.UpdateRoutingInfo(info); err != nil {
+					d.logger.Error("Cannot update routing info", "component", component, "err", err)
+				}
+			}
+		}
+	}
+}
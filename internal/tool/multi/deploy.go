@@ -41,13 +41,29 @@ const (
 	shortConfigKey = "multi"
 )
 
-var deployCmd = tool.Command{
-	Name:        "deploy",
-	Description: "Deploy a Service Weaver app",
-	Help:        "Usage:\n  weaver multi deploy <configfile>",
-	Flags:       flag.NewFlagSet("deploy", flag.ContinueOnError),
-	Fn:          deploy,
-}
+var (
+	deployFlags = flag.NewFlagSet("deploy", flag.ContinueOnError)
+	upgradeFlag = deployFlags.String("upgrade", "", "Deployment id (or a unique prefix) of a deployment to upgrade")
+
+	deployCmd = tool.Command{
+		Name:        "deploy",
+		Description: "Deploy a Service Weaver app",
+		Help: `Usage:
+  weaver multi deploy [--upgrade=<deployment>] <configfile>
+
+Flags:
+  -h, --help	Print this help message.
+` + tool.FlagsHelp(deployFlags) + `
+
+Description:
+  'weaver multi deploy <configfile>' deploys the application configured in
+  <configfile>. With --upgrade, the deployment gradually replaces the
+  provided deployment of the same application, over the rollout duration
+  specified in the [serviceweaver] section of <configfile>.`,
+		Flags: deployFlags,
+		Fn:    deploy,
+	}
+)
 
 // deploy deploys an application on the local machine using a multiprocess
 // deployer. Note that each component is deployed as a separate OS process.
@@ -118,6 +134,22 @@ persists, please file an issue at https://github.com/ServiceWeaver/weaver/issues
 			binary, versions.ModuleVersion, selfVersion)
 	}
 
+	// Find the deployment to upgrade, if any.
+	var old status.Registration
+	if *upgradeFlag != "" {
+		registry, err := defaultRegistry(ctx)
+		if err != nil {
+			return fmt.Errorf("create registry: %w", err)
+		}
+		old, err = findDeployment(ctx, registry, *upgradeFlag)
+		if err != nil {
+			return err
+		}
+		if old.App != appConfig.Name {
+			return fmt.Errorf("cannot upgrade deployment %s of application %q to application %q", old.DeploymentId, old.App, appConfig.Name)
+		}
+	}
+
 	// Make temporary directory.
 	tmpDir, err := runtime.NewTempDir()
 	if err != nil {
@@ -140,6 +172,7 @@ persists, please file an issue at https://github.com/ServiceWeaver/weaver/issues
 	}
 	mux := http.NewServeMux()
 	status.RegisterServer(mux, d, d.logger)
+	d.addUpgradeHandlers(mux)
 	go func() {
 		if err := serveHTTP(ctx, lis, mux); err != nil {
 			fmt.Fprintf(os.Stderr, "status server: %v\n", err)
@@ -147,6 +180,11 @@ persists, please file an issue at https://github.com/ServiceWeaver/weaver/issues
 	}()
 
 	// Deploy main.
+	if old.DeploymentId != "" {
+		d.mu.Lock()
+		d.upgrading = true
+		d.mu.Unlock()
+	}
 	if err := d.startMain(); err != nil {
 		return fmt.Errorf("start main process: %w", err)
 	}
@@ -179,7 +217,26 @@ persists, please file an issue at https://github.com/ServiceWeaver/weaver/issues
 	defer unregister()
 	runtime.OnExitSignal(unregister)
 
-	return d.wait()
+	// Roll out over the old deployment, if any.
+	if old.DeploymentId != "" {
+		d.running.Go(func() error {
+			if err := d.upgrade(d.ctx, old); err != nil {
+				err = fmt.Errorf("upgrade deployment %s: %w", old.DeploymentId, err)
+				d.stop(err)
+				return err
+			}
+			return nil
+		})
+	}
+
+	err = d.wait()
+	if errors.Is(err, errUpgraded) {
+		d.mu.Lock()
+		fmt.Fprintf(os.Stderr, "Deployment %s upgraded to %s\n", deploymentId, d.upgradedTo)
+		d.mu.Unlock()
+		return nil
+	}
+	return err
 }
 
 // defaultRegistry returns a registry in defaultRegistryDir().
@@ -26,6 +26,7 @@ import (
 	"os"
 	"slices"
 	"sync"
+	"sync/atomic"
 	"syscall"
 	"time"
 
@@ -42,7 +43,9 @@ import (
 	"github.com/ServiceWeaver/weaver/runtime/logging"
 	"github.com/ServiceWeaver/weaver/runtime/metrics"
 	"github.com/ServiceWeaver/weaver/runtime/profiling"
+	"github.com/ServiceWeaver/weaver/runtime/protomsg"
 	"github.com/ServiceWeaver/weaver/runtime/protos"
+	"github.com/ServiceWeaver/weaver/runtime/retry"
 	"github.com/ServiceWeaver/weaver/runtime/traces"
 	"github.com/google/uuid"
 	"golang.org/x/exp/maps"
@@ -53,6 +56,20 @@ import (
 // The default number of times a component is replicated.
 const defaultReplication = 2
 
+// How long a drained replica keeps running after it stops receiving new
+// requests, to give in-progress requests a chance to finish.
+const drainGracePeriod = 5 * time.Second
+
+// The default restart policy for crashed weavelets. See
+// MultiConfig.RestartOptions.
+const (
+	defaultRestartLimit  = 5
+	defaultRestartWindow = time.Minute
+)
+
+// The maximum number of restarts kept in the history of a replica.
+const maxRestartHistory = 10
+
 // A deployer manages an application deployment.
 type deployer struct {
 	ctx          context.Context
@@ -67,36 +84,68 @@ type deployer struct {
 	running      errgroup.Group
 	logsDB       *logging.FileStore
 	printer      *logging.PrettyPrinter
-	traceDB      *traces.DB
+	traces       traces.Exporter
+
+	restartLimit    int           // see MultiConfig.RestartOptions.Limit
+	restartWindow   time.Duration // see MultiConfig.RestartOptions.Window
+	scalingInterval time.Duration // see MultiConfig.AutoscalingOptions.Interval
+	scalingTarget   float64       // see MultiConfig.AutoscalingOptions.Target
+	healthInterval  time.Duration // see MultiConfig.HealthOptions.Interval
+	healthThreshold int           // see MultiConfig.HealthOptions.RestartThreshold
 
 	// statsProcessor tracks and computes stats to be rendered on the /statusz page.
 	statsProcessor *imetrics.StatsProcessor
 
-	mu      sync.Mutex            // guards the following
-	err     error                 // error that stopped the babysitter
-	groups  map[string]*group     // groups, by component name
-	proxies map[string]*proxyInfo // proxies, by listener name
+	// requests is the number of requests in flight on the proxies.
+	requests atomic.Int64
+
+	mu         sync.Mutex            // guards the following
+	err        error                 // error that stopped the babysitter
+	groups     map[string]*group     // groups, by component name
+	proxies    map[string]*proxyInfo // proxies, by listener name
+	upgrading  bool                  // is the deployment replacing an old one?
+	upgradedTo string                // id of the deployment replacing this one
 }
 
 // A group contains information about a co-location group.
 type group struct {
-	name        string                          // group name
-	envelopes   []*envelope.Envelope            // envelopes, one per weavelet
-	replicas    []*status.Replica               // stores replica info such as pid, weavelet id
-	started     map[string]bool                 // started components
-	addresses   map[string]bool                 // weavelet addresses
-	assignments map[string]*protos.Assignment   // assignment, by component
-	subscribers map[string][]*envelope.Envelope // routing info subscribers, by component
-	callable    []string                        // callable components for group
-	certPEM     []byte                          // group certificate
-	keyPEM      []byte                          // group private key
+	name        string                                        // group name
+	minReplicas int                                           // minimum number of replicas
+	maxReplicas int                                           // maximum number of replicas
+	replicas    []*replica                                    // replicas, once started
+	started     map[string]bool                               // started components
+	addresses   map[string]bool                               // weavelet addresses
+	assignments map[string]*protos.Assignment                 // assignment, by component
+	loads       map[string][]*protos.LoadReport_ComponentLoad // latest load reports, by component
+	subscribers map[string][]*envelope.Envelope               // routing info subscribers, by component
+	callable    []string                                      // callable components for group
+	certPEM     []byte                                        // group certificate
+	keyPEM      []byte                                        // group private key
+}
+
+// A replica is a replica of a co-location group. A replica runs one weavelet
+// at a time; when the weavelet crashes, it is replaced by a new one.
+type replica struct {
+	envelope *envelope.Envelope // envelope of the current weavelet
+	handler  *handler           // handler of the current weavelet
+	cancel   func()             // kills the current weavelet
+	status   *status.Replica    // pid, weavelet id, and restart history
+	crashes  []time.Time        // times of recent crashes, oldest first
+	backoff  *retry.Retry       // backoff between restarts
+	drained  bool               // has the replica been removed by autoscaling?
+
+	// Health of the current weavelet. See health.go.
+	unhealthy    bool  // is the weavelet removed from routing info?
+	failedChecks int   // number of consecutive failed health checks
+	killed       error // why the weavelet was killed, if it was
 }
 
 // A proxyInfo contains information about a proxy.
 type proxyInfo struct {
-	listener string       // listener associated with the proxy
-	proxy    *proxy.Proxy // the proxy
-	addr     string       // dialable address of the proxy
+	listener  string         // listener associated with the proxy
+	proxy     *proxy.Proxy   // the proxy
+	addr      string         // dialable address of the proxy
+	listeners []net.Listener // network listeners the proxy is served on
 }
 
 // handler handles a connection to a weavelet.
@@ -104,7 +153,8 @@ type handler struct {
 	*deployer
 	g          *group
 	envelope   *envelope.Envelope
-	subscribed map[string]bool // routing info subscriptions, by component
+	subscribed map[string]bool   // routing info subscriptions, by component
+	exported   map[string]string // exported listener addresses, by listener
 }
 
 var _ envelope.EnvelopeHandler = &handler{}
@@ -136,28 +186,97 @@ func newDeployer(ctx context.Context, deploymentId string, config *MultiConfig,
 		}
 	}
 
-	// Create the trace saver.
-	traceDB, err := traces.OpenDB(ctx, perfettoFile)
+	// Parse the restart policy.
+	restartLimit := defaultRestartLimit
+	restartWindow := defaultRestartWindow
+	if opts := config.Restarts; opts != nil {
+		if opts.Limit != 0 {
+			restartLimit = int(opts.Limit)
+		}
+		if opts.Window != "" {
+			restartWindow, err = time.ParseDuration(opts.Window)
+			if err != nil {
+				return nil, fmt.Errorf("invalid restart window %q: %w", opts.Window, err)
+			}
+			if restartWindow <= 0 {
+				return nil, fmt.Errorf("invalid restart window %q: must be positive", opts.Window)
+			}
+		}
+	}
+
+	// Parse the autoscaling options.
+	scalingInterval := defaultScalingInterval
+	scalingTarget := defaultScalingTarget
+	if opts := config.Autoscaling; opts != nil {
+		if opts.Interval != "" {
+			scalingInterval, err = time.ParseDuration(opts.Interval)
+			if err != nil {
+				return nil, fmt.Errorf("invalid autoscaling interval %q: %w", opts.Interval, err)
+			}
+			if scalingInterval <= 0 {
+				return nil, fmt.Errorf("invalid autoscaling interval %q: must be positive", opts.Interval)
+			}
+		}
+		if opts.Target < 0 {
+			return nil, fmt.Errorf("invalid autoscaling target %v: must be positive", opts.Target)
+		}
+		if opts.Target != 0 {
+			scalingTarget = opts.Target
+		}
+	}
+
+	// Parse the health options.
+	healthInterval := defaultHealthInterval
+	var healthThreshold int
+	if opts := config.Health; opts != nil {
+		if opts.Interval != "" {
+			healthInterval, err = time.ParseDuration(opts.Interval)
+			if err != nil {
+				return nil, fmt.Errorf("invalid health interval %q: %w", opts.Interval, err)
+			}
+			if healthInterval <= 0 {
+				return nil, fmt.Errorf("invalid health interval %q: must be positive", opts.Interval)
+			}
+		}
+		if opts.RestartThreshold < 0 {
+			return nil, fmt.Errorf("invalid health restart threshold %d: must not be negative", opts.RestartThreshold)
+		}
+		healthThreshold = int(opts.RestartThreshold)
+	}
+
+	// Create the trace exporter.
+	exporter, err := traces.NewExporter(ctx, config.App.Traces, traces.ExporterOptions{
+		App:          config.App.Name,
+		DeploymentId: deploymentId,
+		DBFile:       perfettoFile,
+		Logger:       logger,
+	})
 	if err != nil {
-		return nil, fmt.Errorf("cannot open Perfetto database: %w", err)
+		return nil, fmt.Errorf("cannot create trace exporter: %w", err)
 	}
 
 	ctx, cancel := context.WithCancel(ctx)
 	d := &deployer{
-		ctx:            ctx,
-		ctxCancel:      cancel,
-		tmpDir:         tmpDir,
-		logger:         logger,
-		caCert:         caCert,
-		caKey:          caKey,
-		logsDB:         logsDB,
-		printer:        printer,
-		traceDB:        traceDB,
-		statsProcessor: imetrics.NewStatsProcessor(),
-		deploymentId:   deploymentId,
-		config:         config,
-		started:        time.Now(),
-		proxies:        map[string]*proxyInfo{},
+		ctx:             ctx,
+		ctxCancel:       cancel,
+		tmpDir:          tmpDir,
+		logger:          logger,
+		caCert:          caCert,
+		caKey:           caKey,
+		logsDB:          logsDB,
+		printer:         printer,
+		traces:          exporter,
+		restartLimit:    restartLimit,
+		restartWindow:   restartWindow,
+		scalingInterval: scalingInterval,
+		scalingTarget:   scalingTarget,
+		healthInterval:  healthInterval,
+		healthThreshold: healthThreshold,
+		statsProcessor:  imetrics.NewStatsProcessor(),
+		deploymentId:    deploymentId,
+		config:          config,
+		started:         time.Now(),
+		proxies:         map[string]*proxyInfo{},
 	}
 
 	// Form co-location groups.
@@ -172,6 +291,29 @@ func newDeployer(ctx context.Context, deploymentId string, config *MultiConfig,
 		return err
 	})
 
+	// Start a goroutine that balances the load of routed components.
+	d.running.Go(func() error {
+		err := d.balance()
+		d.stop(err)
+		return err
+	})
+
+	// Start a goroutine that checks the health of weavelets.
+	d.running.Go(func() error {
+		err := d.checkHealth()
+		d.stop(err)
+		return err
+	})
+
+	// Start a goroutine that autoscales co-location groups, if needed.
+	if d.autoscaled() {
+		d.running.Go(func() error {
+			err := d.autoscale()
+			d.stop(err)
+			return err
+		})
+	}
+
 	// Start a goroutine that watches for context cancelation.
 	d.running.Go(func() error {
 		<-d.ctx.Done()
@@ -221,6 +363,7 @@ func (d *deployer) computeGroups() error {
 			started:     map[string]bool{},
 			addresses:   map[string]bool{},
 			assignments: map[string]*protos.Assignment{},
+			loads:       map[string][]*protos.LoadReport_ComponentLoad{},
 			subscribers: map[string][]*envelope.Envelope{},
 			certPEM:     certPEM,
 			keyPEM:      keyPEM,
@@ -261,10 +404,56 @@ func (d *deployer) computeGroups() error {
 		srcGroup.callable = append(srcGroup.callable, dst)
 	})
 
+	// Compute the number of replicas of every group.
+	opts := map[*group]*MultiConfig_ReplicaOptions{}
+	for component, o := range d.config.Groups {
+		g, ok := groups[component]
+		if !ok {
+			return fmt.Errorf("replica options for unknown component %q", component)
+		}
+		if prev, ok := opts[g]; ok && (prev.Min != o.Min || prev.Max != o.Max) {
+			return fmt.Errorf("conflicting replica options for co-location group %q", g.name)
+		}
+		opts[g] = o
+	}
+	for _, g := range groups {
+		o, ok := opts[g]
+		if !ok {
+			o = d.config.Replicas
+		}
+		var err error
+		g.minReplicas, g.maxReplicas, err = replicaBounds(o)
+		if err != nil {
+			return fmt.Errorf("co-location group %q: %w", g.name, err)
+		}
+	}
+
 	d.groups = groups
 	return nil
 }
 
+// replicaBounds returns the minimum and maximum number of replicas specified
+// by the provided options, which may be nil.
+func replicaBounds(opts *MultiConfig_ReplicaOptions) (int, int, error) {
+	lo, hi := int(opts.GetMin()), int(opts.GetMax())
+	if lo < 0 || hi < 0 {
+		return 0, 0, fmt.Errorf("negative number of replicas")
+	}
+	if lo == 0 {
+		lo = defaultReplication
+		if hi != 0 && hi < lo {
+			lo = hi
+		}
+	}
+	if hi == 0 {
+		hi = lo
+	}
+	if hi < lo {
+		return 0, 0, fmt.Errorf("max replicas %d is smaller than min replicas %d", hi, lo)
+	}
+	return lo, hi, nil
+}
+
 // wait waits for the deployer to terminate. It returns an error that
 // caused the deployer to terminate. This method will never return
 // a non-nil error.
@@ -272,6 +461,14 @@ func (d *deployer) computeGroups() error {
 // REQUIRES: d.mu is NOT held.
 func (d *deployer) wait() error {
 	d.running.Wait()
+
+	// Export the remaining trace spans.
+	ctx, cancel := context.WithTimeout(context.Background(), drainGracePeriod)
+	defer cancel()
+	if err := d.traces.Shutdown(ctx); err != nil {
+		d.logger.Error("Cannot export trace spans", "err", err)
+	}
+
 	d.mu.Lock()
 	defer d.mu.Unlock()
 	return d.err
@@ -313,59 +510,275 @@ func (d *deployer) startColocationGroup(g *group) error {
 	if d.err != nil {
 		return d.err
 	}
-	if len(g.envelopes) == defaultReplication {
-		// Already started.
-		return nil
+	for len(g.replicas) < g.minReplicas {
+		if err := d.addReplica(g); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// addReplica adds a new replica to the provided co-location group.
+//
+// REQUIRES: d.mu is held.
+func (d *deployer) addReplica(g *group) error {
+	r := &replica{
+		status:  &status.Replica{},
+		backoff: retry.BeginWithOptions(retry.Options{BackoffMultiplier: 2, BackoffMinDuration: 100 * time.Millisecond}),
+	}
+	if err := d.startReplica(g, r); err != nil {
+		return err
+	}
+	g.replicas = append(g.replicas, r)
+	return nil
+}
+
+// drainReplica removes the most recently added replica from the provided
+// co-location group. The replica stops receiving requests right away and is
+// killed after a grace period.
+//
+// REQUIRES: d.mu is held.
+func (d *deployer) drainReplica(g *group) {
+	r := g.replicas[len(g.replicas)-1]
+	g.replicas = g.replicas[:len(g.replicas)-1]
+	r.drained = true
+	d.unregisterReplica(g, r.handler)
+	time.AfterFunc(drainGracePeriod, r.cancel)
+}
+
+// startReplica starts a new weavelet for the provided replica, capturing its
+// logs, traces, and metrics.
+//
+// REQUIRES: d.mu is held.
+func (d *deployer) startReplica(g *group, r *replica) error {
+	if d.err != nil {
+		return d.err
+	}
+
+	info := &protos.WeaveletArgs{
+		App:             d.config.App.Name,
+		DeploymentId:    d.deploymentId,
+		Id:              uuid.New().String(),
+		RunMain:         g.started[runtime.Main],
+		Mtls:            d.config.Mtls,
+		InternalAddress: "localhost:0",
+	}
+	r.status.Pid = 0
+	r.status.WeaveletId = info.Id
+	r.status.Health = protos.HealthStatus_UNKNOWN
+	r.status.HealthError = ""
+	r.unhealthy = false
+	r.failedChecks = 0
+	r.killed = nil
+	ctx, cancel := context.WithCancel(d.ctx)
+	e, err := envelope.NewEnvelope(ctx, info, d.config.App, envelope.Options{
+		Logger: d.logger,
+	})
+	if err != nil {
+		cancel()
+		return err
+	}
+
+	h := &handler{
+		deployer:   d,
+		g:          g,
+		subscribed: map[string]bool{},
+		exported:   map[string]string{},
+		envelope:   e,
 	}
 
-	components := maps.Keys(g.started)
-	for r := 0; r < defaultReplication; r++ {
-		// Start the weavelet and capture its logs, traces, and metrics.
-		info := &protos.WeaveletArgs{
-			App:             d.config.App.Name,
-			DeploymentId:    d.deploymentId,
-			Id:              uuid.New().String(),
-			RunMain:         g.started[runtime.Main],
-			Mtls:            d.config.Mtls,
-			InternalAddress: "localhost:0",
+	d.running.Go(func() error {
+		err := e.Serve(h)
+		cancel()
+
+		d.mu.Lock()
+		abandoned := r.envelope != e || r.drained
+		if !abandoned && r.killed != nil {
+			// The weavelet was killed because it was unhealthy.
+			err = r.killed
 		}
-		e, err := envelope.NewEnvelope(d.ctx, info, d.config.App, envelope.Options{
-			Logger: d.logger,
-		})
-		if err != nil {
-			return err
+		d.mu.Unlock()
+		if abandoned {
+			// The weavelet failed to start (see below) or was drained.
+			return nil
 		}
-
-		h := &handler{
-			deployer:   d,
-			g:          g,
-			subscribed: map[string]bool{},
-			envelope:   e,
+		if d.shouldRestart(info, err) {
+			if err = d.restartReplica(g, r, h, err); err == nil {
+				return nil
+			}
 		}
+		d.stop(err)
+		return err
+	})
+	pid, ok := e.Pid()
+	if !ok {
+		panic("multi deployer child must be a real process")
+	}
+	r.status.Pid = int64(pid)
 
-		d.running.Go(func() error {
-			err := e.Serve(h)
-			d.stop(err)
+	// Register the replica. If this fails, abandon the weavelet and kill it.
+	err = d.registerReplica(g, e.WeaveletAddress())
+	if err == nil {
+		err = e.UpdateComponents(maps.Keys(g.started))
+	}
+	if err == nil {
+		err = h.subscribeToRouted()
+	}
+	if err != nil {
+		d.unregisterReplica(g, h)
+		cancel()
+		return err
+	}
+	r.envelope = e
+	r.handler = h
+	r.cancel = cancel
+	return nil
+}
+
+// shouldRestart returns whether the weavelet started with the provided
+// arguments should be restarted, given that its envelope returned the provided
+// error.
+func (d *deployer) shouldRestart(info *protos.WeaveletArgs, err error) bool {
+	if d.ctx.Err() != nil {
+		// The deployer is shutting down.
+		return false
+	}
+	if d.restartLimit < 0 {
+		// Restarts are disabled.
+		return false
+	}
+	// A weavelet that runs main exits cleanly when main returns, in which
+	// case the application is done. Every other exit is a crash.
+	return err != nil || !info.RunMain
+}
+
+// restartReplica replaces the crashed weavelet of the provided replica with a
+// new one, backing off between restarts. It returns an error if the replica
+// crashed too many times or if the deployer was stopped.
+//
+// REQUIRES: d.mu is NOT held.
+func (d *deployer) restartReplica(g *group, r *replica, h *handler, exitErr error) error {
+	d.mu.Lock()
+	d.unregisterReplica(g, h)
+	d.mu.Unlock()
+
+	for {
+		if err := d.recordCrash(g, r, exitErr); err != nil {
 			return err
-		})
-		pid, ok := e.Pid()
-		if !ok {
-			panic("multi deployer child must be a real process")
 		}
-		// Add replica info to group
-		g.replicas = append(g.replicas, &status.Replica{Pid: int64(pid), WeaveletId: info.Id})
-		// Register replica
-		if err := d.registerReplica(g, e.WeaveletAddress()); err != nil {
-			return err
+		if !r.backoff.Continue(d.ctx) {
+			return d.ctx.Err()
 		}
-		if err := e.UpdateComponents(components); err != nil {
-			return err
+		d.mu.Lock()
+		if r.drained {
+			// The replica was drained while it was down.
+			d.mu.Unlock()
+			return nil
+		}
+		err := d.startReplica(g, r)
+		d.mu.Unlock()
+		if err == nil {
+			d.logger.Info("Restarted weavelet", "group", g.name, "pid", r.status.Pid, "weavelet", r.status.WeaveletId)
+			return nil
+		}
+		exitErr = fmt.Errorf("restart: %w", err)
+	}
+}
+
+// recordCrash records a crash of the current weavelet of the provided
+// replica. It returns an error if the replica has crashed more often than
+// allowed by the restart policy.
+//
+// REQUIRES: d.mu is NOT held.
+func (d *deployer) recordCrash(g *group, r *replica, exitErr error) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	now := time.Now()
+	msg := "exited"
+	if exitErr != nil {
+		msg = exitErr.Error()
+	}
+	d.logger.Error("Weavelet crashed", "group", g.name, "pid", r.status.Pid, "weavelet", r.status.WeaveletId, "err", msg)
+
+	r.status.Restarts = append(r.status.Restarts, &status.Restart{
+		Time:       timestamppb.New(now),
+		Pid:        r.status.Pid,
+		WeaveletId: r.status.WeaveletId,
+		Error:      msg,
+	})
+	if n := len(r.status.Restarts); n > maxRestartHistory {
+		r.status.Restarts = r.status.Restarts[n-maxRestartHistory:]
+	}
+
+	// Forget crashes that fall outside the restart window.
+	recent := r.crashes[:0]
+	for _, t := range r.crashes {
+		if now.Sub(t) < d.restartWindow {
+			recent = append(recent, t)
 		}
-		g.envelopes = append(g.envelopes, e)
+	}
+	r.crashes = append(recent, now)
+	if len(r.crashes) == 1 {
+		// The replica was healthy for a while; restart it right away.
+		r.backoff.Reset()
+	}
+	if len(r.crashes) > d.restartLimit {
+		return fmt.Errorf("group %q crash looping: %d crashes in %v, last error: %s", g.name, len(r.crashes), d.restartWindow, msg)
 	}
 	return nil
 }
 
+// unregisterReplica stops routing traffic to the crashed weavelet managed by
+// the provided handler.
+//
+// REQUIRES: d.mu is held.
+func (d *deployer) unregisterReplica(g *group, h *handler) {
+	// Stop sending routing info to the weavelet.
+	for _, other := range d.groups {
+		for component, subs := range other.subscribers {
+			other.subscribers[component] = slices.DeleteFunc(subs, func(e *envelope.Envelope) bool {
+				return e == h.envelope
+			})
+		}
+	}
+
+	// Remove the weavelet's listeners from the proxies.
+	for lis, addr := range h.exported {
+		if p, ok := d.proxies[lis]; ok {
+			p.proxy.RemoveBackend(addr)
+		}
+	}
+
+	d.removeAddress(g, h.envelope.WeaveletAddress())
+}
+
+// removeAddress removes the provided weavelet address from the routing info of
+// the components in the provided co-location group.
+//
+// REQUIRES: d.mu is held.
+func (d *deployer) removeAddress(g *group, addr string) {
+	// Update the assignments and notify subscribers.
+	if !g.addresses[addr] {
+		return
+	}
+	delete(g.addresses, addr)
+	replicas := maps.Keys(g.addresses)
+	for component, assignment := range g.assignments {
+		assignment = routingAlgo(assignment, replicas, g.loads[component])
+		g.assignments[component] = assignment
+		d.logger.Debug(fmt.Sprintf("Updated assignment for component %s:\n%s", component, routing.FormatAssignment(assignment)))
+	}
+	for component := range g.started {
+		routing := g.routing(component)
+		for _, sub := range g.subscribers[component] {
+			if err := sub.UpdateRoutingInfo(routing); err != nil {
+				d.logger.Error("Cannot update routing info", "component", component, "err", err)
+			}
+		}
+	}
+}
+
 func (d *deployer) startMain() error {
 	return d.activateComponent(&protos.ActivateComponentRequest{
 		Component: runtime.Main,
@@ -405,6 +818,26 @@ func (h *handler) subscribeTo(req *protos.ActivateComponentRequest) error {
 	return h.envelope.UpdateRoutingInfo(target.routing(req.Component))
 }
 
+// subscribeToRouted subscribes the weavelet to the routing info of the routed
+// components in its own co-location group. A weavelet collects the load of a
+// routed component with respect to the component's latest assignment, so it
+// needs the assignment even if it never calls the component.
+//
+// REQUIRES: d.mu is held.
+func (h *handler) subscribeToRouted() error {
+	for component := range h.g.assignments {
+		if h.subscribed[component] {
+			continue
+		}
+		h.subscribed[component] = true
+		h.g.subscribers[component] = append(h.g.subscribers[component], h.envelope)
+		if err := h.envelope.UpdateRoutingInfo(h.g.routing(component)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // GetSelfCertificate implements the control.DeployerControl interface.
 func (h *handler) GetSelfCertificate(context.Context, *protos.GetSelfCertificateRequest) (*protos.GetSelfCertificateReply, error) {
 	return &protos.GetSelfCertificateReply{
@@ -481,8 +914,8 @@ func (d *deployer) activateComponent(req *protos.ActivateComponentRequest) error
 
 		// Notify the weavelets.
 		components := maps.Keys(target.started)
-		for _, envelope := range target.envelopes {
-			if err := envelope.UpdateComponents(components); err != nil {
+		for _, r := range target.replicas {
+			if err := r.envelope.UpdateComponents(components); err != nil {
 				return err
 			}
 		}
@@ -490,7 +923,7 @@ func (d *deployer) activateComponent(req *protos.ActivateComponentRequest) error
 		// Create an initial assignment.
 		if req.Routed {
 			replicas := maps.Keys(target.addresses)
-			assignment := routingAlgo(&protos.Assignment{}, replicas)
+			assignment := routingAlgo(&protos.Assignment{}, replicas, nil)
 			target.assignments[req.Component] = assignment
 			d.logger.Debug(fmt.Sprintf("Initial assignment for component %s:\n%s", req.Component, routing.FormatAssignment(assignment)))
 		}
@@ -502,6 +935,17 @@ func (d *deployer) activateComponent(req *protos.ActivateComponentRequest) error
 				return err
 			}
 		}
+
+		// Send the assignment to the replicas hosting the component.
+		if req.Routed {
+			for _, r := range target.replicas {
+				if err := r.handler.subscribeToRouted(); err != nil {
+					// The weavelet may have crashed, in which case it is
+					// sent the assignment when restarted.
+					d.logger.Error("Cannot update routing info", "component", req.Component, "err", err)
+				}
+			}
+		}
 	}
 
 	// Start the co-location group, if it hasn't started already.
@@ -520,7 +964,7 @@ func (d *deployer) registerReplica(g *group, replicaAddr string) error {
 	// Update all assignments.
 	replicas := maps.Keys(g.addresses)
 	for component, assignment := range g.assignments {
-		assignment = routingAlgo(assignment, replicas)
+		assignment = routingAlgo(assignment, replicas, g.loads[component])
 		g.assignments[component] = assignment
 		d.logger.Debug(fmt.Sprintf("Updated assignment for component %s:\n%s", component, routing.FormatAssignment(assignment)))
 	}
@@ -548,7 +992,7 @@ func (d *deployer) LogBatch(ctx context.Context, batch *protos.LogEntryBatch) er
 
 // HandleTraceSpans implements the control.DeployerControl interface.
 func (d *deployer) HandleTraceSpans(ctx context.Context, spans *protos.TraceSpans) error {
-	return d.traceDB.Store(ctx, d.config.App.Name, d.deploymentId, spans)
+	return d.traces.Export(ctx, spans)
 }
 
 // GetListenerAddress implements the control.DeployerControl interface.
@@ -557,13 +1001,15 @@ func (d *deployer) GetListenerAddress(context.Context, *protos.GetListenerAddres
 }
 
 // ExportListener implements the control.DeployerControl interface.
-func (d *deployer) ExportListener(_ context.Context, req *protos.ExportListenerRequest) (*protos.ExportListenerReply, error) {
+func (h *handler) ExportListener(_ context.Context, req *protos.ExportListenerRequest) (*protos.ExportListenerReply, error) {
+	d := h.deployer
 	d.mu.Lock()
 	defer d.mu.Unlock()
 
 	// Update the proxy.
 	if p, ok := d.proxies[req.Listener]; ok {
 		p.proxy.AddBackend(req.Address)
+		h.exported[req.Listener] = req.Address
 		return &protos.ExportListenerReply{ProxyAddress: p.addr}, nil
 	}
 
@@ -573,6 +1019,11 @@ func (d *deployer) ExportListener(_ context.Context, req *protos.ExportListenerR
 	if opts, ok := d.config.Listeners[req.Listener]; ok {
 		proxyAddr = opts.Address
 	}
+	if d.upgrading {
+		// The old version of the application listens on the listener's
+		// address until the upgrade finishes (see upgrade.go).
+		proxyAddr = "localhost:0"
+	}
 
 	lis, err := net.Listen("tcp", proxyAddr)
 	if errors.Is(err, syscall.EADDRINUSE) {
@@ -586,16 +1037,14 @@ func (d *deployer) ExportListener(_ context.Context, req *protos.ExportListenerR
 	d.logger.Info("Proxy listening", "address", addr)
 	proxy := proxy.NewProxy(d.logger)
 	proxy.AddBackend(req.Address)
-	d.proxies[req.Listener] = &proxyInfo{
+	p := &proxyInfo{
 		listener: req.Listener,
 		proxy:    proxy,
 		addr:     addr,
 	}
-	go func() {
-		if err := serveHTTP(d.ctx, lis, proxy); err != nil {
-			d.logger.Error("proxy", "err", err)
-		}
-	}()
+	d.proxies[req.Listener] = p
+	h.exported[req.Listener] = req.Address
+	d.serveProxy(p, lis)
 	return &protos.ExportListenerReply{ProxyAddress: addr}, nil
 }
 
@@ -605,8 +1054,8 @@ func (d *deployer) readMetrics() []*metrics.MetricSnapshot {
 
 	var ms []*metrics.MetricSnapshot
 	for _, group := range d.groups {
-		for _, envelope := range group.envelopes {
-			m, err := envelope.GetMetrics()
+		for _, r := range group.replicas {
+			m, err := r.envelope.GetMetrics()
 			if err != nil {
 				continue
 			}
@@ -623,7 +1072,9 @@ func (d *deployer) Profile(_ context.Context, req *protos.GetProfileRequest) (*p
 	d.mu.Lock()
 	envelopes := map[string][]*envelope.Envelope{}
 	for _, group := range d.groups {
-		envelopes[group.name] = slices.Clone(group.envelopes)
+		for _, r := range group.replicas {
+			envelopes[group.name] = append(envelopes[group.name], r.envelope)
+		}
 	}
 	d.mu.Unlock()
 
@@ -642,10 +1093,15 @@ func (d *deployer) Status(context.Context) (*status.Status, error) {
 	stats := d.statsProcessor.GetStatsStatusz()
 	var components []*status.Component
 	for _, group := range d.groups {
+		// Copy the replica info, which changes when replicas are restarted.
+		replicas := make([]*status.Replica, len(group.replicas))
+		for i, r := range group.replicas {
+			replicas[i] = protomsg.Clone(r.status)
+		}
 		for component := range group.started {
 			c := &status.Component{
 				Name:     component,
-				Replicas: group.replicas,
+				Replicas: replicas,
 			}
 			components = append(components, c)
 
@@ -707,10 +1163,8 @@ func (d *deployer) Metrics(context.Context) (*status.Metrics, error) {
 	return m, nil
 }
 
-func routingAlgo(currAssignment *protos.Assignment, candidates []string) *protos.Assignment {
-	assignment := routing.EqualSlices(candidates)
-	assignment.Version = currAssignment.Version + 1
-	return assignment
+func routingAlgo(currAssignment *protos.Assignment, candidates []string, loads []*protos.LoadReport_ComponentLoad) *protos.Assignment {
+	return routing.Balance(currAssignment, candidates, loads, routing.BalanceOptions{})
 }
 
 // serveHTTP serves HTTP traffic on the provided listener using the provided
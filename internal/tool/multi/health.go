@@ -0,0 +1,144 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package multi
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ServiceWeaver/weaver/runtime/envelope"
+	"github.com/ServiceWeaver/weaver/runtime/logging"
+	"github.com/ServiceWeaver/weaver/runtime/protos"
+)
+
+// defaultHealthInterval is how often the health of weavelets is checked, by
+// default. See MultiConfig.HealthOptions.
+const defaultHealthInterval = 5 * time.Second
+
+// checkHealth periodically checks the health of all weavelets. It returns
+// when the deployer is stopped.
+func (d *deployer) checkHealth() error {
+	ticker := time.NewTicker(d.healthInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			d.recheckHealth()
+		case <-d.ctx.Done():
+			return d.ctx.Err()
+		}
+	}
+}
+
+// recheckHealth checks the health of all weavelets once. Unhealthy weavelets
+// are removed from the routing info of their components, and added back once
+// healthy. Weavelets that stay unhealthy for too long are killed, and then
+// restarted like crashed weavelets.
+//
+// REQUIRES: d.mu is NOT held.
+func (d *deployer) recheckHealth() {
+	type check struct {
+		g *group
+		r *replica
+		e *envelope.Envelope
+	}
+
+	// Snapshot the envelopes. Note that we don't hold d.mu while checking
+	// health, as GetHealth blocks on a weavelet that may be blocked on d.mu.
+	var checks []check
+	seen := map[*group]bool{}
+	d.mu.Lock()
+	for _, g := range d.groups {
+		if seen[g] {
+			continue
+		}
+		seen[g] = true
+		for _, r := range g.replicas {
+			if r.envelope != nil {
+				checks = append(checks, check{g, r, r.envelope})
+			}
+		}
+	}
+	d.mu.Unlock()
+
+	replies := make([]*protos.GetHealthReply, len(checks))
+	for i, c := range checks {
+		replies[i] = c.e.GetHealth()
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for i, c := range checks {
+		g, r := c.g, c.r
+		if r.envelope != c.e || r.drained {
+			// The weavelet was restarted or drained in the meantime.
+			continue
+		}
+		changed, kill := r.observeHealth(replies[i], d.healthThreshold)
+		addr := c.e.WeaveletAddress()
+		switch {
+		case changed && r.unhealthy:
+			d.logger.Error("Weavelet unhealthy", "group", g.name, "pid", r.status.Pid, "weavelet", r.status.WeaveletId, "err", r.status.HealthError)
+			d.removeAddress(g, addr)
+		case changed:
+			d.logger.Info("Weavelet healthy again", "group", g.name, "pid", r.status.Pid, "weavelet", r.status.WeaveletId)
+			if err := d.registerReplica(g, addr); err != nil {
+				d.logger.Error("Cannot update routing info", "group", g.name, "err", err)
+			}
+		}
+		if kill && d.restartLimit >= 0 {
+			r.killed = fmt.Errorf("unhealthy for %d consecutive health checks: %s", r.failedChecks, r.status.HealthError)
+			r.cancel()
+		}
+	}
+}
+
+// observeHealth records the result of a health check of the current weavelet
+// of the replica. It returns whether the weavelet became healthy or unhealthy,
+// and whether it has failed at least threshold consecutive health checks. A
+// weavelet is never killed if threshold is zero.
+//
+// A weavelet whose health is unknown (e.g., because it crashed) is left alone;
+// crashed weavelets are handled separately.
+func (r *replica) observeHealth(reply *protos.GetHealthReply, threshold int) (changed, kill bool) {
+	r.status.Health = reply.Status
+	switch reply.Status {
+	case protos.HealthStatus_HEALTHY:
+		r.status.HealthError = ""
+		r.failedChecks = 0
+		changed = r.unhealthy
+		r.unhealthy = false
+	case protos.HealthStatus_UNHEALTHY:
+		r.status.HealthError = healthError(reply)
+		r.failedChecks++
+		changed = !r.unhealthy
+		r.unhealthy = true
+		kill = threshold > 0 && r.failedChecks >= threshold
+	}
+	return changed, kill
+}
+
+// healthError returns a description of why the components of an unhealthy
+// weavelet are unhealthy.
+func healthError(reply *protos.GetHealthReply) string {
+	var errs []string
+	for _, c := range reply.Components {
+		if c.Status == protos.HealthStatus_UNHEALTHY {
+			errs = append(errs, fmt.Sprintf("%s: %s", logging.ShortenComponent(c.Component), c.Error))
+		}
+	}
+	return strings.Join(errs, "; ")
+}
@@ -0,0 +1,71 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package multi
+
+import (
+	"testing"
+
+	"github.com/ServiceWeaver/weaver/internal/status"
+	"github.com/ServiceWeaver/weaver/runtime/protos"
+)
+
+func TestObserveHealth(t *testing.T) {
+	healthy := &protos.GetHealthReply{Status: protos.HealthStatus_HEALTHY}
+	unhealthy := &protos.GetHealthReply{
+		Status: protos.HealthStatus_UNHEALTHY,
+		Components: []*protos.ComponentHealth{
+			{Component: "github.com/example/app/Cache", Status: protos.HealthStatus_UNHEALTHY, Error: "full"},
+			{Component: "github.com/example/app/Store", Status: protos.HealthStatus_HEALTHY},
+		},
+	}
+	unknown := &protos.GetHealthReply{Status: protos.HealthStatus_UNKNOWN}
+
+	type want struct {
+		changed, kill, unhealthy bool
+	}
+	r := &replica{status: &status.Replica{}}
+	for i, test := range []struct {
+		reply *protos.GetHealthReply
+		want  want
+	}{
+		{healthy, want{false, false, false}},
+		{unhealthy, want{true, false, true}},
+		{unknown, want{false, false, true}},
+		{unhealthy, want{false, false, true}},
+		{healthy, want{true, false, false}},
+		{unhealthy, want{true, false, true}},
+		{unhealthy, want{false, false, true}},
+		{unhealthy, want{false, true, true}},
+	} {
+		changed, kill := r.observeHealth(test.reply, 3)
+		got := want{changed, kill, r.unhealthy}
+		if got != test.want {
+			t.Fatalf("check %d: got %+v, want %+v", i, got, test.want)
+		}
+	}
+	if got, want := r.status.HealthError, "app.Cache: full"; got != want {
+		t.Fatalf("health error: got %q, want %q", got, want)
+	}
+}
+
+func TestObserveHealthNoRestarts(t *testing.T) {
+	unhealthy := &protos.GetHealthReply{Status: protos.HealthStatus_UNHEALTHY}
+	r := &replica{status: &status.Replica{}}
+	for i := 0; i < 10; i++ {
+		if _, kill := r.observeHealth(unhealthy, 0); kill {
+			t.Fatalf("check %d: unexpected kill with zero threshold", i)
+		}
+	}
+}
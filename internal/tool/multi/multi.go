@@ -44,13 +44,14 @@ var (
 				{Label: "cat logs", Command: fmt.Sprintf("weaver multi logs 'version==%q'", logging.Shorten(deploymentId))},
 				{Label: "follow logs", Command: fmt.Sprintf("weaver multi logs --follow 'version==%q'", logging.Shorten(deploymentId))},
 				{Label: "profile", Command: fmt.Sprintf("weaver multi profile --duration=30s %s", deploymentId)},
+				{Label: "traces", Command: fmt.Sprintf("weaver multi traces 'version==%q'", logging.Shorten(deploymentId))},
 			}
 		},
 	}
 
 	purgeSpec = &tool.PurgeSpec{
 		Tool:  "weaver multi",
-		Kill:  "weaver multi (dashboard|deploy|logs|profile)",
+		Kill:  "weaver multi (dashboard|deploy|logs|profile|traces)",
 		Paths: []string{logDir, dataDir},
 	}
 
@@ -62,6 +63,10 @@ var (
 				return logging.FileSource(logDir), nil
 			},
 		}),
+		"traces": tool.TracesCmd(&tool.TracesSpec{
+			Tool:   "weaver multi",
+			DBFile: perfettoFile,
+		}),
 		"dashboard": status.DashboardCommand(dashboardSpec),
 		"status":    status.StatusCommand("weaver multi", defaultRegistry),
 		"metrics":   status.MetricsCommand("weaver multi", defaultRegistry),
@@ -48,6 +48,14 @@ type MultiConfig struct {
 	// one another?
 	Mtls      bool                                    `protobuf:"varint,2,opt,name=mtls,proto3" json:"mtls,omitempty"`
 	Listeners map[string]*MultiConfig_ListenerOptions `protobuf:"bytes,3,rep,name=listeners,proto3" json:"listeners,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Restarts  *MultiConfig_RestartOptions             `protobuf:"bytes,4,opt,name=restarts,proto3" json:"restarts,omitempty"`
+	// Replica options for all co-location groups, unless overridden in groups.
+	Replicas *MultiConfig_ReplicaOptions `protobuf:"bytes,5,opt,name=replicas,proto3" json:"replicas,omitempty"`
+	// Replica options for individual co-location groups, keyed by the name of
+	// any component in the group (e.g., "github.com/example/app/Cache").
+	Groups      map[string]*MultiConfig_ReplicaOptions `protobuf:"bytes,6,rep,name=groups,proto3" json:"groups,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Autoscaling *MultiConfig_AutoscalingOptions        `protobuf:"bytes,7,opt,name=autoscaling,proto3" json:"autoscaling,omitempty"`
+	Health      *MultiConfig_HealthOptions             `protobuf:"bytes,8,opt,name=health,proto3" json:"health,omitempty"`
 }
 
 func (x *MultiConfig) Reset() {
@@ -103,6 +111,157 @@ func (x *MultiConfig) GetListeners() map[string]*MultiConfig_ListenerOptions {
 	return nil
 }
 
+func (x *MultiConfig) GetRestarts() *MultiConfig_RestartOptions {
+	if x != nil {
+		return x.Restarts
+	}
+	return nil
+}
+
+func (x *MultiConfig) GetReplicas() *MultiConfig_ReplicaOptions {
+	if x != nil {
+		return x.Replicas
+	}
+	return nil
+}
+
+func (x *MultiConfig) GetGroups() map[string]*MultiConfig_ReplicaOptions {
+	if x != nil {
+		return x.Groups
+	}
+	return nil
+}
+
+func (x *MultiConfig) GetAutoscaling() *MultiConfig_AutoscalingOptions {
+	if x != nil {
+		return x.Autoscaling
+	}
+	return nil
+}
+
+func (x *MultiConfig) GetHealth() *MultiConfig_HealthOptions {
+	if x != nil {
+		return x.Health
+	}
+	return nil
+}
+
+// ShiftTrafficRequest is a request from a new version of an application, which
+// is being rolled out, to the old version to shift a fraction of the traffic
+// on the old version's listeners to the new version.
+type ShiftTrafficRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	DeploymentId string            `protobuf:"bytes,1,opt,name=deployment_id,json=deploymentId,proto3" json:"deployment_id,omitempty"`                                                               // deployment id of the new version
+	Listeners    map[string]string `protobuf:"bytes,2,rep,name=listeners,proto3" json:"listeners,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"` // proxy address, by listener name
+	Fraction     float64           `protobuf:"fixed64,3,opt,name=fraction,proto3" json:"fraction,omitempty"`                                                                                         // fraction of traffic in [0, 1]
+}
+
+func (x *ShiftTrafficRequest) Reset() {
+	*x = ShiftTrafficRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_tool_multi_multi_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ShiftTrafficRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShiftTrafficRequest) ProtoMessage() {}
+
+func (x *ShiftTrafficRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_tool_multi_multi_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShiftTrafficRequest.ProtoReflect.Descriptor instead.
+func (*ShiftTrafficRequest) Descriptor() ([]byte, []int) {
+	return file_internal_tool_multi_multi_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ShiftTrafficRequest) GetDeploymentId() string {
+	if x != nil {
+		return x.DeploymentId
+	}
+	return ""
+}
+
+func (x *ShiftTrafficRequest) GetListeners() map[string]string {
+	if x != nil {
+		return x.Listeners
+	}
+	return nil
+}
+
+func (x *ShiftTrafficRequest) GetFraction() float64 {
+	if x != nil {
+		return x.Fraction
+	}
+	return 0
+}
+
+// FinishUpgradeRequest is a request from a new version of an application,
+// which has been rolled out, to the old version to stop listening on its
+// listeners' addresses and to drain and stop its weavelets.
+type FinishUpgradeRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	DeploymentId string `protobuf:"bytes,1,opt,name=deployment_id,json=deploymentId,proto3" json:"deployment_id,omitempty"` // deployment id of the new version
+}
+
+func (x *FinishUpgradeRequest) Reset() {
+	*x = FinishUpgradeRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_tool_multi_multi_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FinishUpgradeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FinishUpgradeRequest) ProtoMessage() {}
+
+func (x *FinishUpgradeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_tool_multi_multi_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FinishUpgradeRequest.ProtoReflect.Descriptor instead.
+func (*FinishUpgradeRequest) Descriptor() ([]byte, []int) {
+	return file_internal_tool_multi_multi_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *FinishUpgradeRequest) GetDeploymentId() string {
+	if x != nil {
+		return x.DeploymentId
+	}
+	return ""
+}
+
 // Options for the application listeners, keyed by listener name.
 // If a listener isn't specified in the map, default options will be used.
 type MultiConfig_ListenerOptions struct {
@@ -118,7 +277,7 @@ type MultiConfig_ListenerOptions struct {
 func (x *MultiConfig_ListenerOptions) Reset() {
 	*x = MultiConfig_ListenerOptions{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_internal_tool_multi_multi_proto_msgTypes[1]
+		mi := &file_internal_tool_multi_multi_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -131,7 +290,7 @@ func (x *MultiConfig_ListenerOptions) String() string {
 func (*MultiConfig_ListenerOptions) ProtoMessage() {}
 
 func (x *MultiConfig_ListenerOptions) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_tool_multi_multi_proto_msgTypes[1]
+	mi := &file_internal_tool_multi_multi_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -154,6 +313,260 @@ func (x *MultiConfig_ListenerOptions) GetAddress() string {
 	return ""
 }
 
+// Options for restarting weavelets that crash. A crashed weavelet is
+// restarted with exponential backoff. If a weavelet crashes more than limit
+// times within window, the deployment is stopped.
+type MultiConfig_RestartOptions struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Maximum number of restarts of a weavelet within window. If zero, a
+	// default limit is used. If negative, crashed weavelets are never
+	// restarted.
+	Limit int32 `protobuf:"varint,1,opt,name=limit,proto3" json:"limit,omitempty"`
+	// Length of the sliding window over which restarts are counted, in a
+	// format accepted by time.ParseDuration (e.g., "1m"). If empty, a default
+	// window is used.
+	Window string `protobuf:"bytes,2,opt,name=window,proto3" json:"window,omitempty"`
+}
+
+func (x *MultiConfig_RestartOptions) Reset() {
+	*x = MultiConfig_RestartOptions{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_tool_multi_multi_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MultiConfig_RestartOptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MultiConfig_RestartOptions) ProtoMessage() {}
+
+func (x *MultiConfig_RestartOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_tool_multi_multi_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MultiConfig_RestartOptions.ProtoReflect.Descriptor instead.
+func (*MultiConfig_RestartOptions) Descriptor() ([]byte, []int) {
+	return file_internal_tool_multi_multi_proto_rawDescGZIP(), []int{0, 2}
+}
+
+func (x *MultiConfig_RestartOptions) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *MultiConfig_RestartOptions) GetWindow() string {
+	if x != nil {
+		return x.Window
+	}
+	return ""
+}
+
+// Options for the number of replicas of a co-location group. A group with
+// more than min replicas and up to max replicas is autoscaled based on its
+// load.
+type MultiConfig_ReplicaOptions struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Minimum number of replicas. If zero, defaults to 2, or to max if max is
+	// smaller than 2.
+	Min int32 `protobuf:"varint,1,opt,name=min,proto3" json:"min,omitempty"`
+	// Maximum number of replicas. If zero, defaults to min, which disables
+	// autoscaling.
+	Max int32 `protobuf:"varint,2,opt,name=max,proto3" json:"max,omitempty"`
+}
+
+func (x *MultiConfig_ReplicaOptions) Reset() {
+	*x = MultiConfig_ReplicaOptions{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_tool_multi_multi_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MultiConfig_ReplicaOptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MultiConfig_ReplicaOptions) ProtoMessage() {}
+
+func (x *MultiConfig_ReplicaOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_tool_multi_multi_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MultiConfig_ReplicaOptions.ProtoReflect.Descriptor instead.
+func (*MultiConfig_ReplicaOptions) Descriptor() ([]byte, []int) {
+	return file_internal_tool_multi_multi_proto_rawDescGZIP(), []int{0, 3}
+}
+
+func (x *MultiConfig_ReplicaOptions) GetMin() int32 {
+	if x != nil {
+		return x.Min
+	}
+	return 0
+}
+
+func (x *MultiConfig_ReplicaOptions) GetMax() int32 {
+	if x != nil {
+		return x.Max
+	}
+	return 0
+}
+
+// Options for autoscaling co-location groups. The load of a group is the
+// number of calls per second to the methods of the components in the group.
+// Every interval, an autoscaled group is resized to the number of replicas
+// needed to keep the average load per replica at or below target. Groups
+// are scaled up immediately but scaled down one replica at a time.
+type MultiConfig_AutoscalingOptions struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// How often to check the load, in a format accepted by time.ParseDuration
+	// (e.g., "10s"). If empty, defaults to "10s".
+	Interval string `protobuf:"bytes,1,opt,name=interval,proto3" json:"interval,omitempty"`
+	// Target load per replica, in calls per second. If zero, defaults to 100.
+	Target float64 `protobuf:"fixed64,2,opt,name=target,proto3" json:"target,omitempty"`
+}
+
+func (x *MultiConfig_AutoscalingOptions) Reset() {
+	*x = MultiConfig_AutoscalingOptions{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_tool_multi_multi_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MultiConfig_AutoscalingOptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MultiConfig_AutoscalingOptions) ProtoMessage() {}
+
+func (x *MultiConfig_AutoscalingOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_tool_multi_multi_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MultiConfig_AutoscalingOptions.ProtoReflect.Descriptor instead.
+func (*MultiConfig_AutoscalingOptions) Descriptor() ([]byte, []int) {
+	return file_internal_tool_multi_multi_proto_rawDescGZIP(), []int{0, 5}
+}
+
+func (x *MultiConfig_AutoscalingOptions) GetInterval() string {
+	if x != nil {
+		return x.Interval
+	}
+	return ""
+}
+
+func (x *MultiConfig_AutoscalingOptions) GetTarget() float64 {
+	if x != nil {
+		return x.Target
+	}
+	return 0
+}
+
+// Options for checking the health of weavelets. A weavelet is unhealthy if
+// any of its components is unhealthy, i.e., if the latest call to the
+// component's optional Health method failed. An unhealthy weavelet is
+// removed from the routing info of its components until it is healthy
+// again.
+type MultiConfig_HealthOptions struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// How often to check the health of weavelets, in a format accepted by
+	// time.ParseDuration (e.g., "5s"). If empty, defaults to "5s".
+	Interval string `protobuf:"bytes,1,opt,name=interval,proto3" json:"interval,omitempty"`
+	// If positive, a weavelet that fails this many consecutive health checks
+	// is killed and restarted, subject to the restart policy. If zero,
+	// unhealthy weavelets are never restarted.
+	RestartThreshold int32 `protobuf:"varint,2,opt,name=restart_threshold,json=restartThreshold,proto3" json:"restart_threshold,omitempty"`
+}
+
+func (x *MultiConfig_HealthOptions) Reset() {
+	*x = MultiConfig_HealthOptions{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_tool_multi_multi_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MultiConfig_HealthOptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MultiConfig_HealthOptions) ProtoMessage() {}
+
+func (x *MultiConfig_HealthOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_tool_multi_multi_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MultiConfig_HealthOptions.ProtoReflect.Descriptor instead.
+func (*MultiConfig_HealthOptions) Descriptor() ([]byte, []int) {
+	return file_internal_tool_multi_multi_proto_rawDescGZIP(), []int{0, 6}
+}
+
+func (x *MultiConfig_HealthOptions) GetInterval() string {
+	if x != nil {
+		return x.Interval
+	}
+	return ""
+}
+
+func (x *MultiConfig_HealthOptions) GetRestartThreshold() int32 {
+	if x != nil {
+		return x.RestartThreshold
+	}
+	return 0
+}
+
 var File_internal_tool_multi_multi_proto protoreflect.FileDescriptor
 
 var file_internal_tool_multi_multi_proto_rawDesc = []byte{
@@ -161,7 +574,7 @@ var file_internal_tool_multi_multi_proto_rawDesc = []byte{
 	0x6d, 0x75, 0x6c, 0x74, 0x69, 0x2f, 0x6d, 0x75, 0x6c, 0x74, 0x69, 0x2e, 0x70, 0x72, 0x6f, 0x74,
 	0x6f, 0x12, 0x05, 0x6d, 0x75, 0x6c, 0x74, 0x69, 0x1a, 0x1b, 0x72, 0x75, 0x6e, 0x74, 0x69, 0x6d,
 	0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e,
-	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x97, 0x02, 0x0a, 0x0b, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x43,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0xc8, 0x07, 0x0a, 0x0b, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x43,
 	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x12, 0x24, 0x0a, 0x03, 0x61, 0x70, 0x70, 0x18, 0x01, 0x20, 0x01,
 	0x28, 0x0b, 0x32, 0x12, 0x2e, 0x72, 0x75, 0x6e, 0x74, 0x69, 0x6d, 0x65, 0x2e, 0x41, 0x70, 0x70,
 	0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x03, 0x61, 0x70, 0x70, 0x12, 0x12, 0x0a, 0x04, 0x6d,
@@ -170,19 +583,80 @@ var file_internal_tool_multi_multi_proto_rawDesc = []byte{
 	0x28, 0x0b, 0x32, 0x21, 0x2e, 0x6d, 0x75, 0x6c, 0x74, 0x69, 0x2e, 0x4d, 0x75, 0x6c, 0x74, 0x69,
 	0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x65, 0x72, 0x73,
 	0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x09, 0x6c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x65, 0x72, 0x73,
-	0x1a, 0x2b, 0x0a, 0x0f, 0x4c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x65, 0x72, 0x4f, 0x70, 0x74, 0x69,
-	0x6f, 0x6e, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x1a, 0x60, 0x0a,
-	0x0e, 0x4c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x65, 0x72, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12,
-	0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65,
-	0x79, 0x12, 0x38, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x22, 0x2e, 0x6d, 0x75, 0x6c, 0x74, 0x69, 0x2e, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x43, 0x6f,
-	0x6e, 0x66, 0x69, 0x67, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x65, 0x72, 0x4f, 0x70, 0x74,
-	0x69, 0x6f, 0x6e, 0x73, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x42,
-	0x35, 0x5a, 0x33, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x53, 0x65,
-	0x72, 0x76, 0x69, 0x63, 0x65, 0x57, 0x65, 0x61, 0x76, 0x65, 0x72, 0x2f, 0x77, 0x65, 0x61, 0x76,
-	0x65, 0x72, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x74, 0x6f, 0x6f, 0x6c,
-	0x2f, 0x6d, 0x75, 0x6c, 0x74, 0x69, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x12, 0x3d, 0x0a, 0x08, 0x72, 0x65, 0x73, 0x74, 0x61, 0x72, 0x74, 0x73, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x21, 0x2e, 0x6d, 0x75, 0x6c, 0x74, 0x69, 0x2e, 0x4d, 0x75, 0x6c, 0x74, 0x69,
+	0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x52, 0x65, 0x73, 0x74, 0x61, 0x72, 0x74, 0x4f, 0x70,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x08, 0x72, 0x65, 0x73, 0x74, 0x61, 0x72, 0x74, 0x73, 0x12,
+	0x3d, 0x0a, 0x08, 0x72, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x21, 0x2e, 0x6d, 0x75, 0x6c, 0x74, 0x69, 0x2e, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x43,
+	0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x4f, 0x70, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x52, 0x08, 0x72, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x73, 0x12, 0x36,
+	0x0a, 0x06, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1e,
+	0x2e, 0x6d, 0x75, 0x6c, 0x74, 0x69, 0x2e, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x43, 0x6f, 0x6e, 0x66,
+	0x69, 0x67, 0x2e, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x06,
+	0x67, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x12, 0x47, 0x0a, 0x0b, 0x61, 0x75, 0x74, 0x6f, 0x73, 0x63,
+	0x61, 0x6c, 0x69, 0x6e, 0x67, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x25, 0x2e, 0x6d, 0x75,
+	0x6c, 0x74, 0x69, 0x2e, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e,
+	0x41, 0x75, 0x74, 0x6f, 0x73, 0x63, 0x61, 0x6c, 0x69, 0x6e, 0x67, 0x4f, 0x70, 0x74, 0x69, 0x6f,
+	0x6e, 0x73, 0x52, 0x0b, 0x61, 0x75, 0x74, 0x6f, 0x73, 0x63, 0x61, 0x6c, 0x69, 0x6e, 0x67, 0x12,
+	0x38, 0x0a, 0x06, 0x68, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x20, 0x2e, 0x6d, 0x75, 0x6c, 0x74, 0x69, 0x2e, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x43, 0x6f, 0x6e,
+	0x66, 0x69, 0x67, 0x2e, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x52, 0x06, 0x68, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x1a, 0x2b, 0x0a, 0x0f, 0x4c, 0x69, 0x73,
+	0x74, 0x65, 0x6e, 0x65, 0x72, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x18, 0x0a, 0x07,
+	0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x61,
+	0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x1a, 0x60, 0x0a, 0x0e, 0x4c, 0x69, 0x73, 0x74, 0x65, 0x6e,
+	0x65, 0x72, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x38, 0x0a, 0x05, 0x76, 0x61,
+	0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x6d, 0x75, 0x6c, 0x74,
+	0x69, 0x2e, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x4c, 0x69,
+	0x73, 0x74, 0x65, 0x6e, 0x65, 0x72, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x3e, 0x0a, 0x0e, 0x52, 0x65, 0x73, 0x74,
+	0x61, 0x72, 0x74, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69,
+	0x6d, 0x69, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74,
+	0x12, 0x16, 0x0a, 0x06, 0x77, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x77, 0x69, 0x6e, 0x64, 0x6f, 0x77, 0x1a, 0x34, 0x0a, 0x0e, 0x52, 0x65, 0x70, 0x6c,
+	0x69, 0x63, 0x61, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x10, 0x0a, 0x03, 0x6d, 0x69,
+	0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x6d, 0x69, 0x6e, 0x12, 0x10, 0x0a, 0x03,
+	0x6d, 0x61, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x6d, 0x61, 0x78, 0x1a, 0x5c,
+	0x0a, 0x0b, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a,
+	0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12,
+	0x37, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x21,
+	0x2e, 0x6d, 0x75, 0x6c, 0x74, 0x69, 0x2e, 0x4d, 0x75, 0x6c, 0x74, 0x69, 0x43, 0x6f, 0x6e, 0x66,
+	0x69, 0x67, 0x2e, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x48, 0x0a, 0x12,
+	0x41, 0x75, 0x74, 0x6f, 0x73, 0x63, 0x61, 0x6c, 0x69, 0x6e, 0x67, 0x4f, 0x70, 0x74, 0x69, 0x6f,
+	0x6e, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x76, 0x61, 0x6c, 0x12, 0x16,
+	0x0a, 0x06, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x06,
+	0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x1a, 0x58, 0x0a, 0x0d, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68,
+	0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x69, 0x6e, 0x74, 0x65, 0x72,
+	0x76, 0x61, 0x6c, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x69, 0x6e, 0x74, 0x65, 0x72,
+	0x76, 0x61, 0x6c, 0x12, 0x2b, 0x0a, 0x11, 0x72, 0x65, 0x73, 0x74, 0x61, 0x72, 0x74, 0x5f, 0x74,
+	0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x52, 0x10,
+	0x72, 0x65, 0x73, 0x74, 0x61, 0x72, 0x74, 0x54, 0x68, 0x72, 0x65, 0x73, 0x68, 0x6f, 0x6c, 0x64,
+	0x22, 0xdd, 0x01, 0x0a, 0x13, 0x53, 0x68, 0x69, 0x66, 0x74, 0x54, 0x72, 0x61, 0x66, 0x66, 0x69,
+	0x63, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x23, 0x0a, 0x0d, 0x64, 0x65, 0x70, 0x6c,
+	0x6f, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0c, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x49, 0x64, 0x12, 0x47, 0x0a,
+	0x09, 0x6c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x65, 0x72, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x29, 0x2e, 0x6d, 0x75, 0x6c, 0x74, 0x69, 0x2e, 0x53, 0x68, 0x69, 0x66, 0x74, 0x54, 0x72,
+	0x61, 0x66, 0x66, 0x69, 0x63, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x4c, 0x69, 0x73,
+	0x74, 0x65, 0x6e, 0x65, 0x72, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x09, 0x6c, 0x69, 0x73,
+	0x74, 0x65, 0x6e, 0x65, 0x72, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x66, 0x72, 0x61, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x08, 0x66, 0x72, 0x61, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x1a, 0x3c, 0x0a, 0x0e, 0x4c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x65, 0x72, 0x73, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01,
+	0x22, 0x3b, 0x0a, 0x14, 0x46, 0x69, 0x6e, 0x69, 0x73, 0x68, 0x55, 0x70, 0x67, 0x72, 0x61, 0x64,
+	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x23, 0x0a, 0x0d, 0x64, 0x65, 0x70, 0x6c,
+	0x6f, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x0c, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x49, 0x64, 0x42, 0x35, 0x5a,
+	0x33, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x53, 0x65, 0x72, 0x76,
+	0x69, 0x63, 0x65, 0x57, 0x65, 0x61, 0x76, 0x65, 0x72, 0x2f, 0x77, 0x65, 0x61, 0x76, 0x65, 0x72,
+	0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x74, 0x6f, 0x6f, 0x6c, 0x2f, 0x6d,
+	0x75, 0x6c, 0x74, 0x69, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -197,22 +671,37 @@ func file_internal_tool_multi_multi_proto_rawDescGZIP() []byte {
 	return file_internal_tool_multi_multi_proto_rawDescData
 }
 
-var file_internal_tool_multi_multi_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_internal_tool_multi_multi_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
 var file_internal_tool_multi_multi_proto_goTypes = []interface{}{
-	(*MultiConfig)(nil),                 // 0: multi.MultiConfig
-	(*MultiConfig_ListenerOptions)(nil), // 1: multi.MultiConfig.ListenerOptions
-	nil,                                 // 2: multi.MultiConfig.ListenersEntry
-	(*protos.AppConfig)(nil),            // 3: runtime.AppConfig
+	(*MultiConfig)(nil),                    // 0: multi.MultiConfig
+	(*ShiftTrafficRequest)(nil),            // 1: multi.ShiftTrafficRequest
+	(*FinishUpgradeRequest)(nil),           // 2: multi.FinishUpgradeRequest
+	(*MultiConfig_ListenerOptions)(nil),    // 3: multi.MultiConfig.ListenerOptions
+	nil,                                    // 4: multi.MultiConfig.ListenersEntry
+	(*MultiConfig_RestartOptions)(nil),     // 5: multi.MultiConfig.RestartOptions
+	(*MultiConfig_ReplicaOptions)(nil),     // 6: multi.MultiConfig.ReplicaOptions
+	nil,                                    // 7: multi.MultiConfig.GroupsEntry
+	(*MultiConfig_AutoscalingOptions)(nil), // 8: multi.MultiConfig.AutoscalingOptions
+	(*MultiConfig_HealthOptions)(nil),      // 9: multi.MultiConfig.HealthOptions
+	nil,                                    // 10: multi.ShiftTrafficRequest.ListenersEntry
+	(*protos.AppConfig)(nil),               // 11: runtime.AppConfig
 }
 var file_internal_tool_multi_multi_proto_depIdxs = []int32{
-	3, // 0: multi.MultiConfig.app:type_name -> runtime.AppConfig
-	2, // 1: multi.MultiConfig.listeners:type_name -> multi.MultiConfig.ListenersEntry
-	1, // 2: multi.MultiConfig.ListenersEntry.value:type_name -> multi.MultiConfig.ListenerOptions
-	3, // [3:3] is the sub-list for method output_type
-	3, // [3:3] is the sub-list for method input_type
-	3, // [3:3] is the sub-list for extension type_name
-	3, // [3:3] is the sub-list for extension extendee
-	0, // [0:3] is the sub-list for field type_name
+	11, // 0: multi.MultiConfig.app:type_name -> runtime.AppConfig
+	4,  // 1: multi.MultiConfig.listeners:type_name -> multi.MultiConfig.ListenersEntry
+	5,  // 2: multi.MultiConfig.restarts:type_name -> multi.MultiConfig.RestartOptions
+	6,  // 3: multi.MultiConfig.replicas:type_name -> multi.MultiConfig.ReplicaOptions
+	7,  // 4: multi.MultiConfig.groups:type_name -> multi.MultiConfig.GroupsEntry
+	8,  // 5: multi.MultiConfig.autoscaling:type_name -> multi.MultiConfig.AutoscalingOptions
+	9,  // 6: multi.MultiConfig.health:type_name -> multi.MultiConfig.HealthOptions
+	10, // 7: multi.ShiftTrafficRequest.listeners:type_name -> multi.ShiftTrafficRequest.ListenersEntry
+	3,  // 8: multi.MultiConfig.ListenersEntry.value:type_name -> multi.MultiConfig.ListenerOptions
+	6,  // 9: multi.MultiConfig.GroupsEntry.value:type_name -> multi.MultiConfig.ReplicaOptions
+	10, // [10:10] is the sub-list for method output_type
+	10, // [10:10] is the sub-list for method input_type
+	10, // [10:10] is the sub-list for extension type_name
+	10, // [10:10] is the sub-list for extension extendee
+	0,  // [0:10] is the sub-list for field type_name
 }
 
 func init() { file_internal_tool_multi_multi_proto_init() }
@@ -234,6 +723,30 @@ func file_internal_tool_multi_multi_proto_init() {
 			}
 		}
 		file_internal_tool_multi_multi_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ShiftTrafficRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_tool_multi_multi_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*FinishUpgradeRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_tool_multi_multi_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*MultiConfig_ListenerOptions); i {
 			case 0:
 				return &v.state
@@ -245,6 +758,54 @@ func file_internal_tool_multi_multi_proto_init() {
 				return nil
 			}
 		}
+		file_internal_tool_multi_multi_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MultiConfig_RestartOptions); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_tool_multi_multi_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MultiConfig_ReplicaOptions); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_tool_multi_multi_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MultiConfig_AutoscalingOptions); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_tool_multi_multi_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MultiConfig_HealthOptions); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -252,7 +813,7 @@ func file_internal_tool_multi_multi_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_internal_tool_multi_multi_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   3,
+			NumMessages:   11,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
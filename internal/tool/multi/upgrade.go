@@ -0,0 +1,299 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package multi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ServiceWeaver/weaver/internal/status"
+	"github.com/ServiceWeaver/weaver/runtime/protomsg"
+	"github.com/ServiceWeaver/weaver/runtime/retry"
+)
+
+// Rolling upgrades
+//
+// A new version of an application is rolled out over an old version by
+// running "weaver multi deploy --upgrade=<old deployment> <config>". The new
+// deployment starts its weavelets alongside the old ones, with its proxies
+// listening on local addresses. It then asks the old deployment to shift a
+// growing fraction of the traffic on the old proxies to the new proxies over
+// the rollout duration (the "rollout" field of the [serviceweaver] config
+// section). Once all traffic is shifted, the old deployment stops listening on
+// the listeners' addresses, which the new deployment then takes over. The old
+// deployment waits for in-flight requests to finish and stops.
+//
+// Component method calls never cross versions, as every deployment routes
+// calls only to its own weavelets.
+
+const (
+	// URL paths of the handlers that the old version of an application
+	// exposes to the new version during a rolling upgrade.
+	shiftTrafficURL  = "/multi/upgrade/shift_traffic"
+	finishUpgradeURL = "/multi/upgrade/finish"
+
+	// rolloutStep is how often traffic is shifted during a rollout.
+	rolloutStep = time.Second
+
+	// drainTimeout is how long an upgraded deployment waits for its in-flight
+	// requests to finish before stopping.
+	drainTimeout = 30 * time.Second
+
+	// listenerTimeout is how long a new deployment waits to export the
+	// listeners of the old deployment before giving up on the upgrade.
+	listenerTimeout = time.Minute
+)
+
+// errUpgraded is the error that stops a deployment that has been replaced by
+// a new version.
+var errUpgraded = errors.New("deployment upgraded")
+
+// addUpgradeHandlers adds the handlers used to upgrade the deployment to the
+// provided mux.
+func (d *deployer) addUpgradeHandlers(mux *http.ServeMux) {
+	mux.HandleFunc(shiftTrafficURL, protomsg.HandlerDo(d.logger, d.shiftTraffic))
+	mux.HandleFunc(finishUpgradeURL, protomsg.HandlerDo(d.logger, d.finishUpgrade))
+}
+
+// serveProxy serves the provided proxy on the provided listener, counting the
+// requests in flight.
+//
+// REQUIRES: d.mu is held.
+func (d *deployer) serveProxy(p *proxyInfo, lis net.Listener) {
+	p.listeners = append(p.listeners, lis)
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		d.requests.Add(1)
+		defer d.requests.Add(-1)
+		p.proxy.ServeHTTP(w, r)
+	})
+	go func() {
+		err := serveHTTP(d.ctx, lis, handler)
+		if err != nil && !errors.Is(err, net.ErrClosed) {
+			d.logger.Error("proxy", "err", err)
+		}
+	}()
+}
+
+// shiftTraffic shifts a fraction of the traffic on the deployment's proxies to
+// the proxies of a new version of the application.
+func (d *deployer) shiftTraffic(_ context.Context, req *ShiftTrafficRequest) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if req.Fraction < 0 || req.Fraction > 1 {
+		return fmt.Errorf("invalid fraction %v: must be in the range [0, 1]", req.Fraction)
+	}
+	if d.upgradedTo != "" {
+		return fmt.Errorf("deployment already upgraded to %s", d.upgradedTo)
+	}
+	for name, p := range d.proxies {
+		addr, ok := req.Listeners[name]
+		if !ok {
+			// The new version doesn't export the listener (yet).
+			p.proxy.ShiftTraffic(nil, 0)
+			continue
+		}
+		p.proxy.ShiftTraffic([]string{addr}, req.Fraction)
+	}
+	return nil
+}
+
+// finishUpgrade stops listening on the addresses of the deployment's proxies,
+// and stops the deployment once its in-flight requests have finished.
+func (d *deployer) finishUpgrade(_ context.Context, req *FinishUpgradeRequest) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.upgradedTo != "" {
+		return fmt.Errorf("deployment already upgraded to %s", d.upgradedTo)
+	}
+	d.upgradedTo = req.DeploymentId
+	for _, p := range d.proxies {
+		for _, lis := range p.listeners {
+			lis.Close()
+		}
+	}
+
+	// Note that we drain in the background, as the new version takes over
+	// the listeners' addresses once this call returns.
+	go func() {
+		d.drain()
+		d.logger.Info("Upgraded", "to", req.DeploymentId)
+		d.stop(errUpgraded)
+	}()
+	return nil
+}
+
+// drain waits for the requests in flight on the deployment's proxies to
+// finish, or for drainTimeout, whichever comes first.
+func (d *deployer) drain() {
+	ctx, cancel := context.WithTimeout(d.ctx, drainTimeout)
+	defer cancel()
+	ticker := time.NewTicker(100 * time.Millisecond)
+	defer ticker.Stop()
+	for d.requests.Load() > 0 {
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// upgrade rolls out the deployment over the provided old deployment of the
+// same application.
+//
+// REQUIRES: d.mu is NOT held.
+func (d *deployer) upgrade(ctx context.Context, old status.Registration) error {
+	client := status.NewClient(old.Addr)
+	s, err := client.Status(ctx)
+	if err != nil {
+		return fmt.Errorf("get status of deployment %s: %w", old.DeploymentId, err)
+	}
+
+	// Wait for the new version to export all the listeners of the old one.
+	d.logger.Info("Upgrading", "from", old.DeploymentId)
+	if err := d.waitForListeners(ctx, s.Listeners); err != nil {
+		return err
+	}
+
+	// Shift traffic gradually.
+	shift := func(ctx context.Context, fraction float64) error {
+		return protomsg.Call(ctx, protomsg.CallArgs{
+			Client:  http.DefaultClient,
+			Addr:    "http://" + old.Addr,
+			URLPath: shiftTrafficURL,
+			Request: &ShiftTrafficRequest{
+				DeploymentId: d.deploymentId,
+				Listeners:    d.proxyAddresses(),
+				Fraction:     fraction,
+			},
+		})
+	}
+	rollout := time.Duration(d.config.App.RolloutNanos)
+	start := time.Now()
+	ticker := time.NewTicker(rolloutStep)
+	defer ticker.Stop()
+	for {
+		fraction := 1.0
+		if rollout > 0 {
+			fraction = min(1.0, float64(time.Since(start))/float64(rollout))
+		}
+		if err := shift(ctx, fraction); err != nil {
+			// Shift the traffic back to the old version. Note that we use a
+			// fresh context, as ctx may be cancelled.
+			shift(context.Background(), 0)
+			return fmt.Errorf("shift traffic: %w", err)
+		}
+		d.logger.Debug("Shifted traffic", "fraction", fraction)
+		if fraction == 1.0 {
+			break
+		}
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			shift(context.Background(), 0)
+			return ctx.Err()
+		}
+	}
+
+	// Take over the listeners' addresses.
+	if err := protomsg.Call(ctx, protomsg.CallArgs{
+		Client:  http.DefaultClient,
+		Addr:    "http://" + old.Addr,
+		URLPath: finishUpgradeURL,
+		Request: &FinishUpgradeRequest{DeploymentId: d.deploymentId},
+	}); err != nil {
+		return fmt.Errorf("finish upgrade: %w", err)
+	}
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.upgrading = false
+	for _, lis := range s.Listeners {
+		p := d.proxies[lis.Name]
+		l, err := net.Listen("tcp", lis.Addr)
+		if err != nil {
+			return fmt.Errorf("proxy listen: %w", err)
+		}
+		d.logger.Info("Proxy listening", "address", lis.Addr)
+		d.serveProxy(p, l)
+		p.addr = lis.Addr
+	}
+	return nil
+}
+
+// waitForListeners waits for the deployment to export the provided listeners,
+// or for listenerTimeout, whichever comes first. It returns an error naming the
+// listeners that weren't exported in time.
+//
+// REQUIRES: d.mu is NOT held.
+func (d *deployer) waitForListeners(ctx context.Context, listeners []*status.Listener) error {
+	ctx, cancel := context.WithTimeout(ctx, listenerTimeout)
+	defer cancel()
+	var missing []string
+	for r := retry.Begin(); r.Continue(ctx); {
+		exported := d.proxyAddresses()
+		missing = missing[:0]
+		for _, lis := range listeners {
+			if _, ok := exported[lis.Name]; !ok {
+				missing = append(missing, lis.Name)
+			}
+		}
+		if len(missing) == 0 {
+			return nil
+		}
+	}
+	return fmt.Errorf("new version did not export listeners %q of the old version: %w", missing, ctx.Err())
+}
+
+// proxyAddresses returns the addresses of the deployment's proxies, by
+// listener name.
+//
+// REQUIRES: d.mu is NOT held.
+func (d *deployer) proxyAddresses() map[string]string {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	addrs := map[string]string{}
+	for name, p := range d.proxies {
+		addrs[name] = p.addr
+	}
+	return addrs
+}
+
+// findDeployment returns the registration of the deployment with the provided
+// deployment id prefix.
+func findDeployment(ctx context.Context, registry *status.Registry, prefix string) (status.Registration, error) {
+	regs, err := registry.List(ctx)
+	if err != nil {
+		return status.Registration{}, fmt.Errorf("get registrations: %w", err)
+	}
+	var candidates []status.Registration
+	for _, reg := range regs {
+		if strings.HasPrefix(reg.DeploymentId, prefix) {
+			candidates = append(candidates, reg)
+		}
+	}
+	switch len(candidates) {
+	case 0:
+		return status.Registration{}, fmt.Errorf("no deployment with prefix %q found", prefix)
+	case 1:
+		return candidates[0], nil
+	default:
+		return status.Registration{}, fmt.Errorf("multiple deployments with prefix %q found", prefix)
+	}
+}
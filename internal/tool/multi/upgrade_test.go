@@ -0,0 +1,183 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package multi
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ServiceWeaver/weaver/internal/proxy"
+	"github.com/ServiceWeaver/weaver/internal/status"
+	"github.com/ServiceWeaver/weaver/runtime/logging"
+	"github.com/ServiceWeaver/weaver/runtime/protomsg"
+	"github.com/ServiceWeaver/weaver/runtime/protos"
+)
+
+// oldDeployment is a fake old deployment being upgraded. It serves the status
+// and upgrade handlers of a real deployment, and records the requests it
+// receives.
+type oldDeployment struct {
+	reg       status.Registration // registration of the deployment
+	listeners []net.Listener      // listeners taken over by the new version
+
+	mu        sync.Mutex
+	fractions []float64 // fractions of traffic shifted, in order
+	finished  string    // id of the deployment that finished the upgrade
+}
+
+// newOldDeployment returns a new fake old deployment that listens on the
+// provided listeners.
+func newOldDeployment(t *testing.T, listeners ...string) *oldDeployment {
+	t.Helper()
+	logger := logging.NewTestSlogger(t, testing.Verbose())
+	old := &oldDeployment{}
+	s := &status.Status{DeploymentId: "old"}
+	for _, name := range listeners {
+		lis, err := net.Listen("tcp", "localhost:0")
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { lis.Close() })
+		old.listeners = append(old.listeners, lis)
+		s.Listeners = append(s.Listeners, &status.Listener{Name: name, Addr: lis.Addr().String()})
+	}
+
+	mux := http.NewServeMux()
+	status.RegisterServer(mux, fakeStatusServer{s}, logger)
+	mux.HandleFunc(shiftTrafficURL, protomsg.HandlerDo(logger, func(_ context.Context, req *ShiftTrafficRequest) error {
+		old.mu.Lock()
+		defer old.mu.Unlock()
+		old.fractions = append(old.fractions, req.Fraction)
+		return nil
+	}))
+	mux.HandleFunc(finishUpgradeURL, protomsg.HandlerDo(logger, func(_ context.Context, req *FinishUpgradeRequest) error {
+		old.mu.Lock()
+		defer old.mu.Unlock()
+		old.finished = req.DeploymentId
+		for _, lis := range old.listeners {
+			lis.Close()
+		}
+		return nil
+	}))
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	old.reg = status.Registration{
+		DeploymentId: "old",
+		Addr:         strings.TrimPrefix(server.URL, "http://"),
+	}
+	return old
+}
+
+// fakeStatusServer is a status.Server that returns a fixed status.
+type fakeStatusServer struct {
+	status *status.Status
+}
+
+func (f fakeStatusServer) Status(context.Context) (*status.Status, error) {
+	return f.status, nil
+}
+
+func (f fakeStatusServer) Metrics(context.Context) (*status.Metrics, error) {
+	return &status.Metrics{}, nil
+}
+
+func (f fakeStatusServer) Profile(context.Context, *protos.GetProfileRequest) (*protos.GetProfileReply, error) {
+	return &protos.GetProfileReply{}, nil
+}
+
+// newUpgradingDeployer returns a new deployer that is upgrading an old
+// deployment and has exported the provided listeners.
+func newUpgradingDeployer(t *testing.T, listeners ...string) *deployer {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	logger := logging.NewTestSlogger(t, testing.Verbose())
+	d := &deployer{
+		ctx:          ctx,
+		ctxCancel:    cancel,
+		deploymentId: "new",
+		config:       &MultiConfig{App: &protos.AppConfig{}},
+		logger:       logger,
+		proxies:      map[string]*proxyInfo{},
+		upgrading:    true,
+	}
+	for i, name := range listeners {
+		d.proxies[name] = &proxyInfo{
+			listener: name,
+			proxy:    proxy.NewProxy(logger),
+			addr:     fmt.Sprintf("localhost:%d", 10000+i),
+		}
+	}
+	return d
+}
+
+func TestUpgrade(t *testing.T) {
+	old := newOldDeployment(t, "a", "b")
+	d := newUpgradingDeployer(t, "a", "b")
+	if err := d.upgrade(context.Background(), old.reg); err != nil {
+		t.Fatal(err)
+	}
+
+	old.mu.Lock()
+	defer old.mu.Unlock()
+	if got, want := old.fractions, []float64{1}; !slices.Equal(got, want) {
+		t.Errorf("shifted fractions: got %v, want %v", got, want)
+	}
+	if got, want := old.finished, "new"; got != want {
+		t.Errorf("finished by: got %q, want %q", got, want)
+	}
+
+	// The new deployment should have taken over the old listeners' addresses.
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.upgrading {
+		t.Error("deployment still upgrading")
+	}
+	for i, name := range []string{"a", "b"} {
+		if got, want := d.proxies[name].addr, old.listeners[i].Addr().String(); got != want {
+			t.Errorf("listener %q: got address %q, want %q", name, got, want)
+		}
+	}
+}
+
+func TestUpgradeMissingListener(t *testing.T) {
+	// The new version never exports listener "b", so the upgrade should fail
+	// without shifting any traffic.
+	old := newOldDeployment(t, "a", "b")
+	d := newUpgradingDeployer(t, "a")
+	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+	defer cancel()
+	err := d.upgrade(ctx, old.reg)
+	if err == nil || !strings.Contains(err.Error(), `"b"`) {
+		t.Fatalf("upgrade: got %v, want error naming listener %q", err, "b")
+	}
+
+	old.mu.Lock()
+	defer old.mu.Unlock()
+	if len(old.fractions) > 0 {
+		t.Errorf("shifted fractions %v, want none", old.fractions)
+	}
+	if old.finished != "" {
+		t.Errorf("upgrade finished by %q, want unfinished", old.finished)
+	}
+}
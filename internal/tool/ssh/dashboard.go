@@ -28,8 +28,10 @@ var dashboardSpec = &status.DashboardSpec{
 	Registry:     impl.DefaultRegistry,
 	Commands: func(deploymentId string) []status.Command {
 		return []status.Command{
+			{Label: "status", Command: "weaver ssh status"},
 			{Label: "cat logs", Command: fmt.Sprintf("weaver ssh logs 'version==%q'", logging.Shorten(deploymentId))},
 			{Label: "follow logs", Command: fmt.Sprintf("weaver ssh logs --follow 'version==%q'", logging.Shorten(deploymentId))},
+			{Label: "profile", Command: fmt.Sprintf("weaver ssh profile --duration=30s %s", deploymentId)},
 		}
 	},
 }
@@ -138,6 +138,9 @@ persists, please file an issue at https://github.com/ServiceWeaver/weaver/issues
 	if err != nil {
 		return err
 	}
+	if err := recordDeployment(config.DepId, locations); err != nil {
+		return fmt.Errorf("record deployment: %w", err)
+	}
 
 	// Run the manager.
 	stopFn, err := impl.RunManager(ctx, config, locations)
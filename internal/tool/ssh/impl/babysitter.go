@@ -16,14 +16,19 @@ package impl
 
 import (
 	"context"
+	"crypto/x509"
+	"encoding/pem"
 	"fmt"
+	"io"
 	"log/slog"
+	"net"
 	"net/http"
 	"os"
 	"sync"
 	"time"
 
 	"github.com/ServiceWeaver/weaver/internal/proto"
+	"github.com/ServiceWeaver/weaver/internal/tool/certs"
 	"github.com/ServiceWeaver/weaver/runtime/envelope"
 	"github.com/ServiceWeaver/weaver/runtime/logging"
 	"github.com/ServiceWeaver/weaver/runtime/metrics"
@@ -33,6 +38,19 @@ import (
 	"github.com/google/uuid"
 )
 
+const (
+	// loadReportInterval is how often a babysitter reports the load of its
+	// weavelet to the manager.
+	loadReportInterval = 10 * time.Second
+
+	// healthReportInterval is how often a babysitter reports the health of
+	// its weavelet to the manager.
+	healthReportInterval = 5 * time.Second
+
+	// URL suffixes for the SSH babysitter handlers.
+	runProfilingURL = "/babysitter/run_profiling"
+)
+
 // babysitter starts and manages weavelets belonging to a single colocation
 // group for a single application version, on the local machine.
 type babysitter struct {
@@ -41,6 +59,7 @@ type babysitter struct {
 	logger       *slog.Logger
 	exportTraces func(spans *protos.TraceSpans) error // exports to the manager
 	envelope     *envelope.Envelope
+	caCert       *x509.Certificate // CA certificate, if mTLS is enabled
 
 	mu                  sync.Mutex
 	watchingRoutingInfo map[string]bool
@@ -51,9 +70,13 @@ var _ envelope.EnvelopeHandler = &babysitter{}
 // RunBabysitter creates and runs an envelope.Envelope and a metrics collector for a
 // weavelet deployed with SSH.
 func RunBabysitter(ctx context.Context) error {
-	// Retrieve the deployment information.
+	// Retrieve the deployment information, which the manager passes via stdin.
+	input, err := io.ReadAll(os.Stdin)
+	if err != nil {
+		return fmt.Errorf("unable to read deployment info: %w", err)
+	}
 	info := &BabysitterInfo{}
-	if err := proto.FromEnv(os.Getenv(babysitterInfoKey), info); err != nil {
+	if err := proto.FromEnv(string(input), info); err != nil {
 		return fmt.Errorf("unable to retrieve deployment info: %w", err)
 	}
 
@@ -88,6 +111,15 @@ func RunBabysitter(ctx context.Context) error {
 		},
 		watchingRoutingInfo: map[string]bool{},
 	}
+	if info.Mtls {
+		block, _ := pem.Decode(info.CaCert)
+		if block == nil {
+			return fmt.Errorf("invalid CA certificate")
+		}
+		if b.caCert, err = x509.ParseCertificate(block.Bytes); err != nil {
+			return fmt.Errorf("invalid CA certificate: %w", err)
+		}
+	}
 
 	// Start the envelope.
 	wlet := &protos.WeaveletArgs{
@@ -95,6 +127,7 @@ func RunBabysitter(ctx context.Context) error {
 		DeploymentId: info.DepId,
 		Id:           id,
 		RunMain:      info.RunMain,
+		Mtls:         info.Mtls,
 	}
 	e, err := envelope.NewEnvelope(ctx, wlet, info.App, envelope.Options{
 		Logger: b.logger,
@@ -108,14 +141,97 @@ func RunBabysitter(ctx context.Context) error {
 	if !ok {
 		panic("ssh deployer child must be a real process")
 	}
-	if err := b.registerReplica(e.WeaveletAddress(), pid, id); err != nil {
+
+	// Serve the requests of the manager.
+	host, err := os.Hostname()
+	if err != nil {
+		return fmt.Errorf("babysitter: get hostname: %w", err)
+	}
+	lis, err := net.Listen("tcp", fmt.Sprintf("%s:0", host))
+	if err != nil {
+		return fmt.Errorf("listen: %w", err)
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc(runProfilingURL, protomsg.HandlerFunc(b.logger, b.runProfiling))
+	go func() {
+		if err := serveHTTP(ctx, lis, mux); err != nil {
+			b.logger.Error("Unable to start HTTP server", "err", err)
+		}
+	}()
+
+	addr := fmt.Sprintf("http://%s", lis.Addr())
+	if err := b.registerReplica(e.WeaveletAddress(), pid, id, addr); err != nil {
 		return err
 	}
 	c := metricsCollector{logger: b.logger, envelope: e, info: info}
 	go c.run(ctx)
+	go b.reportLoad()
+	go b.reportHealth(id)
 	return e.Serve(b)
 }
 
+// reportLoad periodically reports the load of the weavelet's routed
+// components to the manager.
+func (b *babysitter) reportLoad() {
+	ticker := time.NewTicker(loadReportInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			load, err := b.envelope.GetLoad()
+			if err != nil {
+				b.logger.Error("Unable to collect load", "err", err)
+				continue
+			}
+			if len(load.Loads) == 0 {
+				// The weavelet doesn't host any routed components.
+				continue
+			}
+			if err := protomsg.Call(b.ctx, protomsg.CallArgs{
+				Client:  http.DefaultClient,
+				Addr:    b.info.ManagerAddr,
+				URLPath: recvLoadURL,
+				Request: &BabysitterLoad{
+					Group:   b.info.Group,
+					Address: b.envelope.WeaveletAddress(),
+					Load:    load,
+				},
+			}); err != nil {
+				b.logger.Error("Error reporting load", "err", err)
+			}
+		case <-b.ctx.Done():
+			return
+		}
+	}
+}
+
+// reportHealth periodically reports the health of the weavelet with the
+// provided id to the manager.
+func (b *babysitter) reportHealth(weaveletId string) {
+	ticker := time.NewTicker(healthReportInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			if err := protomsg.Call(b.ctx, protomsg.CallArgs{
+				Client:  http.DefaultClient,
+				Addr:    b.info.ManagerAddr,
+				URLPath: recvHealthURL,
+				Request: &BabysitterHealth{
+					Group:      b.info.Group,
+					Address:    b.envelope.WeaveletAddress(),
+					WeaveletId: weaveletId,
+					Health:     b.envelope.GetHealth(),
+				},
+			}); err != nil {
+				b.logger.Error("Error reporting health", "err", err)
+			}
+		case <-b.ctx.Done():
+			return
+		}
+	}
+}
+
 type metricsCollector struct {
 	logger   *slog.Logger
 	envelope *envelope.Envelope
@@ -169,27 +285,34 @@ func (b *babysitter) ActivateComponent(_ context.Context, req *protos.ActivateCo
 		return nil, err
 	}
 
+	b.watch(req.Component, req.Routed)
+	return &protos.ActivateComponentReply{}, nil
+}
+
+// watch starts watching the routing info for the provided component, if it
+// isn't already being watched.
+func (b *babysitter) watch(component string, routed bool) {
 	b.mu.Lock()
 	defer b.mu.Unlock()
-	if !b.watchingRoutingInfo[req.Component] {
-		b.watchingRoutingInfo[req.Component] = true
-		go b.watchRoutingInfo(req.Component, req.Routed)
+	if !b.watchingRoutingInfo[component] {
+		b.watchingRoutingInfo[component] = true
+		go b.watchRoutingInfo(component, routed)
 	}
-	return &protos.ActivateComponentReply{}, nil
 }
 
 // registerReplica registers the information about a colocation group replica
 // (i.e., a weavelet).
-func (b *babysitter) registerReplica(replicaAddr string, pid int, weaveletId string) error {
+func (b *babysitter) registerReplica(replicaAddr string, pid int, weaveletId, babysitterAddr string) error {
 	if err := protomsg.Call(b.ctx, protomsg.CallArgs{
 		Client:  http.DefaultClient,
 		Addr:    b.info.ManagerAddr,
 		URLPath: registerReplicaURL,
 		Request: &ReplicaToRegister{
-			Group:      b.info.Group,
-			Address:    replicaAddr,
-			Pid:        int64(pid),
-			WeaveletId: weaveletId,
+			Group:             b.info.Group,
+			Address:           replicaAddr,
+			Pid:               int64(pid),
+			WeaveletId:        weaveletId,
+			BabysitterAddress: babysitterAddr,
 		},
 	}); err != nil {
 		return err
@@ -199,6 +322,15 @@ func (b *babysitter) registerReplica(replicaAddr string, pid int, weaveletId str
 	return nil
 }
 
+// runProfiling profiles the weavelet.
+func (b *babysitter) runProfiling(_ context.Context, req *protos.GetProfileRequest) (*protos.GetProfileReply, error) {
+	data, err := b.envelope.GetProfile(req)
+	if err != nil {
+		return nil, err
+	}
+	return &protos.GetProfileReply{Data: data}, nil
+}
+
 // GetListenerAddress implements the protos.EnvelopeHandler interface.
 func (b *babysitter) GetListenerAddress(context.Context, *protos.GetListenerAddressRequest) (*protos.GetListenerAddressReply, error) {
 	host, err := os.Hostname()
@@ -225,20 +357,70 @@ func (b *babysitter) ExportListener(_ context.Context, req *protos.ExportListene
 
 // GetSelfCertificate implements the envelope.EnvelopeHandler interface.
 func (b *babysitter) GetSelfCertificate(context.Context, *protos.GetSelfCertificateRequest) (*protos.GetSelfCertificateReply, error) {
-	// TODO(spetrovic): Implement this functionality.
-	panic("unimplemented")
+	if !b.info.Mtls {
+		return nil, fmt.Errorf("mTLS is not enabled")
+	}
+	return &protos.GetSelfCertificateReply{
+		Cert: b.info.Cert,
+		Key:  b.info.Key,
+	}, nil
 }
 
 // VerifyClientCertificate implements the envelope.EnvelopeHandler interface.
-func (b *babysitter) VerifyClientCertificate(context.Context, *protos.VerifyClientCertificateRequest) (*protos.VerifyClientCertificateReply, error) {
-	// TODO(spetrovic): Implement this functionality.
-	panic("unimplemented")
+func (b *babysitter) VerifyClientCertificate(_ context.Context, req *protos.VerifyClientCertificateRequest) (*protos.VerifyClientCertificateReply, error) {
+	group, err := b.verifyCertificate(req.CertChain)
+	if err != nil {
+		return nil, err
+	}
+
+	// Find which weavelet components the client is allowed to call.
+	callable, ok := b.info.Callable[group]
+	if !ok {
+		// The group doesn't call any components.
+		return &protos.VerifyClientCertificateReply{}, nil
+	}
+	return &protos.VerifyClientCertificateReply{Components: callable.Components}, nil
 }
 
 // VerifyServerCertificate implements the envelope.EnvelopeHandler interface.
-func (b *babysitter) VerifyServerCertificate(context.Context, *protos.VerifyServerCertificateRequest) (*protos.VerifyServerCertificateReply, error) {
-	// TODO(spetrovic): Implement this functionality.
-	panic("unimplemented")
+func (b *babysitter) VerifyServerCertificate(_ context.Context, req *protos.VerifyServerCertificateRequest) (*protos.VerifyServerCertificateReply, error) {
+	actual, err := b.verifyCertificate(req.CertChain)
+	if err != nil {
+		return nil, err
+	}
+
+	// Find the expected group name for the target component.
+	expected, ok := b.info.Groups[req.TargetComponent]
+	if !ok {
+		return nil, fmt.Errorf("unknown group for component %q", req.TargetComponent)
+	}
+	if expected != actual {
+		return nil, fmt.Errorf("invalid server identity for target component %s: want %q, got %q", req.TargetComponent, expected, actual)
+	}
+	return &protos.VerifyServerCertificateReply{}, nil
+}
+
+// verifyCertificate verifies and returns the group name stored in the given
+// certificate chain.
+func (b *babysitter) verifyCertificate(certChain [][]byte) (string, error) {
+	if !b.info.Mtls {
+		return "", fmt.Errorf("mTLS is not enabled")
+	}
+	if n := len(certChain); n != 1 {
+		return "", fmt.Errorf("invalid cert chain length: want 1, got %d", n)
+	}
+	names, err := certs.VerifySignedCert(certChain[0], b.caCert)
+	if err != nil {
+		return "", fmt.Errorf("cannot verify the cert: %w", err)
+	}
+	if len(names) != 1 {
+		return "", fmt.Errorf("invalid cert: expected a single name, got %v", names)
+	}
+	name := names[0]
+	if name == "" {
+		return "", fmt.Errorf("empty group name %q in cert", name)
+	}
+	return name, nil
 }
 
 func (b *babysitter) getRoutingInfo(component string, routed bool, version string) (*protos.RoutingInfo, string, error) {
@@ -283,7 +465,7 @@ func (b *babysitter) watchRoutingInfo(component string, routed bool) {
 	}
 }
 
-func (b *babysitter) getComponentsToStart(version string) ([]string, string, error) {
+func (b *babysitter) getComponentsToStart(version string) (*GetComponentsReply, error) {
 	req := &GetComponentsRequest{Group: b.info.Group, Version: version}
 	reply := &GetComponentsReply{}
 	if err := protomsg.Call(b.ctx, protomsg.CallArgs{
@@ -293,24 +475,31 @@ func (b *babysitter) getComponentsToStart(version string) ([]string, string, err
 		Request: req,
 		Reply:   reply,
 	}); err != nil {
-		return nil, "", err
+		return nil, err
 	}
-	return reply.Components, reply.Version, nil
+	return reply, nil
 }
 
 func (b *babysitter) watchComponents() {
 	version := ""
 	for r := retry.Begin(); r.Continue(b.ctx); {
-		components, newVersion, err := b.getComponentsToStart(version)
+		reply, err := b.getComponentsToStart(version)
 		if err != nil {
 			b.logger.Error("cannot get components to start; will retry", "err", err)
 			continue
 		}
-		version = newVersion
-		if err := b.envelope.UpdateComponents(components); err != nil {
+		version = reply.Version
+		if err := b.envelope.UpdateComponents(reply.Components); err != nil {
 			b.logger.Error("cannot update components to start; will retry", "err", err)
 			continue
 		}
+
+		// The weavelet collects the load of the routed components it hosts
+		// with respect to their latest assignments, so it needs their
+		// routing info, even if it never calls them.
+		for _, component := range reply.Routed {
+			b.watch(component, true)
+		}
 		r.Reset()
 	}
 }
@@ -0,0 +1,147 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package impl
+
+import (
+	"context"
+	"crypto/x509"
+	"slices"
+	"testing"
+
+	"github.com/ServiceWeaver/weaver/internal/tool/certs"
+	"github.com/ServiceWeaver/weaver/runtime/protos"
+)
+
+// mtlsBabysitter returns a babysitter with mTLS enabled for an application
+// with groups "main" and "a", where "main" calls "a", along with a function
+// that returns the DER-encoded certificate for a group, signed by the
+// babysitter's CA.
+func mtlsBabysitter(t *testing.T) (*babysitter, func(group string) []byte) {
+	t.Helper()
+	ca, caKey, err := certs.GenerateCACert()
+	if err != nil {
+		t.Fatal(err)
+	}
+	b := &babysitter{
+		info: &BabysitterInfo{
+			Mtls:   true,
+			Groups: map[string]string{"Main": "main", "A": "a", "B": "a"},
+			Callable: map[string]*Components{
+				"main": {Components: []string{"A"}},
+			},
+		},
+		caCert: ca,
+	}
+	cert := func(group string) []byte {
+		cert, _, err := certs.GenerateSignedCert(ca, caKey, group)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return cert.Raw
+	}
+	return b, cert
+}
+
+func TestVerifyClientCertificate(t *testing.T) {
+	b, cert := mtlsBabysitter(t)
+	for _, test := range []struct {
+		group string
+		want  []string
+	}{
+		{"main", []string{"A"}},
+		{"a", nil}, // "a" doesn't call any components
+	} {
+		t.Run(test.group, func(t *testing.T) {
+			req := &protos.VerifyClientCertificateRequest{CertChain: [][]byte{cert(test.group)}}
+			reply, err := b.VerifyClientCertificate(context.Background(), req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := reply.Components; !slices.Equal(got, test.want) {
+				t.Fatalf("callable components: got %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestVerifyServerCertificate(t *testing.T) {
+	b, cert := mtlsBabysitter(t)
+	req := &protos.VerifyServerCertificateRequest{
+		CertChain:       [][]byte{cert("a")},
+		TargetComponent: "B",
+	}
+	if _, err := b.VerifyServerCertificate(context.Background(), req); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestVerifyCertificateErrors(t *testing.T) {
+	b, cert := mtlsBabysitter(t)
+
+	// Generate a certificate signed by a different CA.
+	otherCA, otherKey, err := certs.GenerateCACert()
+	if err != nil {
+		t.Fatal(err)
+	}
+	forged, _, err := certs.GenerateSignedCert(otherCA, otherKey, "main")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx := context.Background()
+	for _, test := range []struct {
+		name string
+		call func() error
+	}{
+		{"ForgedClient", func() error {
+			req := &protos.VerifyClientCertificateRequest{CertChain: [][]byte{forged.Raw}}
+			_, err := b.VerifyClientCertificate(ctx, req)
+			return err
+		}},
+		{"ForgedServer", func() error {
+			req := &protos.VerifyServerCertificateRequest{CertChain: [][]byte{forged.Raw}, TargetComponent: "Main"}
+			_, err := b.VerifyServerCertificate(ctx, req)
+			return err
+		}},
+		{"LongChain", func() error {
+			req := &protos.VerifyClientCertificateRequest{CertChain: [][]byte{cert("main"), cert("main")}}
+			_, err := b.VerifyClientCertificate(ctx, req)
+			return err
+		}},
+		{"WrongServer", func() error {
+			// Component B runs in group "a", not "main".
+			req := &protos.VerifyServerCertificateRequest{CertChain: [][]byte{cert("main")}, TargetComponent: "B"}
+			_, err := b.VerifyServerCertificate(ctx, req)
+			return err
+		}},
+		{"UnknownComponent", func() error {
+			req := &protos.VerifyServerCertificateRequest{CertChain: [][]byte{cert("a")}, TargetComponent: "D"}
+			_, err := b.VerifyServerCertificate(ctx, req)
+			return err
+		}},
+		{"NoMTLS", func() error {
+			b := &babysitter{info: &BabysitterInfo{}, caCert: &x509.Certificate{}}
+			req := &protos.VerifyClientCertificateRequest{CertChain: [][]byte{cert("main")}}
+			_, err := b.VerifyClientCertificate(ctx, req)
+			return err
+		}},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			if err := test.call(); err == nil {
+				t.Fatal("unexpected success")
+			}
+		})
+	}
+}
@@ -16,6 +16,9 @@ package impl
 
 import (
 	"context"
+	"crypto"
+	"crypto/x509"
+	"encoding/pem"
 	"errors"
 	"fmt"
 	"log/slog"
@@ -24,6 +27,8 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"slices"
+	"strings"
 	"sync"
 	"syscall"
 	"time"
@@ -31,8 +36,12 @@ import (
 	imetrics "github.com/ServiceWeaver/weaver/internal/metrics"
 	"github.com/ServiceWeaver/weaver/internal/must"
 	"github.com/ServiceWeaver/weaver/internal/routing"
+	"github.com/ServiceWeaver/weaver/internal/tool/certs"
 	"github.com/ServiceWeaver/weaver/runtime"
+	"github.com/ServiceWeaver/weaver/runtime/bin"
+	"github.com/ServiceWeaver/weaver/runtime/graph"
 	"github.com/ServiceWeaver/weaver/runtime/metrics"
+	"github.com/ServiceWeaver/weaver/runtime/profiling"
 	"github.com/ServiceWeaver/weaver/runtime/protos"
 	"github.com/ServiceWeaver/weaver/runtime/traces"
 	"golang.org/x/exp/maps"
@@ -58,20 +67,19 @@ const (
 	recvLogEntryURL         = "/manager/recv_log_entry"
 	recvTraceSpansURL       = "/manager/recv_trace_spans"
 	recvMetricsURL          = "/manager/recv_metrics"
-
-	// babysitterInfoKey is the name of the env variable that contains deployment
-	// information for a babysitter deployed using SSH.
-	babysitterInfoKey = "SERVICEWEAVER_BABYSITTER_INFO"
+	recvLoadURL             = "/manager/recv_load"
+	recvHealthURL           = "/manager/recv_health"
 )
 
 var (
 	// The directories and files where "weaver ssh" stores data.
 	//
 	// TODO(mwhittaker): Take these as arguments and move them to ssh.go.
-	LogDir       = filepath.Join(runtime.LogsDir(), "ssh")
-	dataDir      = filepath.Join(must.Must(runtime.DataDir()), "ssh")
-	registryDir  = filepath.Join(dataDir, "registry")
-	PerfettoFile = filepath.Join(dataDir, "traces.DB")
+	LogDir         = filepath.Join(runtime.LogsDir(), "ssh")
+	DataDir        = filepath.Join(must.Must(runtime.DataDir()), "ssh")
+	registryDir    = filepath.Join(DataDir, "registry")
+	PerfettoFile   = filepath.Join(DataDir, "traces.DB")
+	DeploymentsDir = filepath.Join(DataDir, "deployments")
 )
 
 // manager manages an application version deployment across a set of locations,
@@ -116,6 +124,15 @@ type manager struct {
 	// itself.
 	colocation map[string]string
 
+	// mTLS state, set iff config.Mtls is true. groupNames maps every
+	// component to the name of its colocation group, and callable maps every
+	// group to the components it is allowed to call, as derived from the call
+	// graph.
+	caCert     *x509.Certificate
+	caKey      crypto.PrivateKey
+	groupNames map[string]string
+	callable   map[string][]string
+
 	mu      sync.Mutex                                    // guards following structures, but not contents
 	groups  map[string]*group                             // groups, by group name
 	proxies map[string]*proxyInfo                         // proxies, by listener name
@@ -125,12 +142,16 @@ type manager struct {
 type group struct {
 	name       string
 	components *versioned.Versioned[map[string]bool] // started components
-
-	mu        sync.Mutex                                           // guards the following
-	started   bool                                                 // has this group been started?
-	addresses map[string]bool                                      // weavelet addresses
-	routings  map[string]*versioned.Versioned[*protos.RoutingInfo] // routing info, by component
-	replicas  []*status.Replica                                    // stores replica info such as pid, weavelet id
+	routed     map[string]bool                       // routed components, guarded by components
+
+	mu          sync.Mutex                                           // guards the following
+	started     bool                                                 // has this group been started?
+	addresses   map[string]bool                                      // weavelet addresses
+	unhealthy   map[string]bool                                      // unhealthy weavelet addresses
+	routings    map[string]*versioned.Versioned[*protos.RoutingInfo] // routing info, by component
+	loads       map[string]*protos.LoadReport                        // latest load, by weavelet address
+	replicas    []*status.Replica                                    // stores replica info such as pid, weavelet id
+	babysitters []string                                             // babysitter addresses
 }
 
 type proxyInfo struct {
@@ -147,7 +168,7 @@ type groupReplicaInfo struct {
 var _ status.Server = &manager{}
 
 // RunManager creates and runs a new manager.
-func RunManager(ctx context.Context, config *SshConfig, locations map[string]string) (func() error, error) {
+func RunManager(ctx context.Context, config *SshConfig, locations map[string]string) (_ func() error, err error) {
 	app := config.App
 	// Create log saver.
 	fs, err := logging.NewFileStore(LogDir)
@@ -167,12 +188,22 @@ func RunManager(ctx context.Context, config *SshConfig, locations map[string]str
 	})
 
 	// Create the trace saver.
-	traceDB, err := traces.OpenDB(ctx, PerfettoFile)
+	exporter, err := traces.NewExporter(ctx, app.Traces, traces.ExporterOptions{
+		App:          app.Name,
+		DeploymentId: config.DepId,
+		DBFile:       PerfettoFile,
+		Logger:       logger,
+	})
 	if err != nil {
-		return nil, fmt.Errorf("cannot open Perfetto database: %w", err)
+		return nil, fmt.Errorf("cannot create trace exporter: %w", err)
 	}
+	defer func() {
+		if err != nil {
+			exporter.Shutdown(context.Background())
+		}
+	}()
 	traceSaver := func(spans *protos.TraceSpans) error {
-		return traceDB.Store(ctx, app.Name, config.DepId, spans)
+		return exporter.Export(ctx, spans)
 	}
 
 	// Form co-location.
@@ -183,6 +214,24 @@ func RunManager(ctx context.Context, config *SshConfig, locations map[string]str
 		}
 	}
 
+	// Generate the signing certificate and compute the components each
+	// group is allowed to call, which babysitters use to authorize
+	// inter-weavelet connections.
+	var caCert *x509.Certificate
+	var caKey crypto.PrivateKey
+	var groupNames map[string]string
+	var callable map[string][]string
+	if config.Mtls {
+		caCert, caKey, err = certs.GenerateCACert()
+		if err != nil {
+			return nil, fmt.Errorf("cannot generate signing certificate: %w", err)
+		}
+		groupNames, callable, err = callGraph(app.Binary, colocation)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// Create the manager.
 	m := &manager{
 		ctx:            ctx,
@@ -194,6 +243,10 @@ func RunManager(ctx context.Context, config *SshConfig, locations map[string]str
 		statsProcessor: imetrics.NewStatsProcessor(),
 		started:        time.Now(),
 		colocation:     colocation,
+		caCert:         caCert,
+		caKey:          caKey,
+		groupNames:     groupNames,
+		callable:       callable,
 		groups:         map[string]*group{},
 		proxies:        map[string]*proxyInfo{},
 		metrics:        map[groupReplicaInfo][]*protos.MetricSnapshot{},
@@ -227,7 +280,10 @@ func RunManager(ctx context.Context, config *SshConfig, locations map[string]str
 	}()
 
 	return func() error {
-		return m.registry.Unregister(m.ctx, config.DepId)
+		// Note that we use a fresh context, as m.ctx may be cancelled.
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		return errors.Join(exporter.Shutdown(ctx), m.registry.Unregister(m.ctx, config.DepId))
 	}, nil
 }
 
@@ -296,6 +352,8 @@ func (m *manager) addHTTPHandlers(mux *http.ServeMux) {
 	mux.HandleFunc(recvLogEntryURL, protomsg.HandlerDo(m.logger, m.handleLogEntry))
 	mux.HandleFunc(recvTraceSpansURL, protomsg.HandlerDo(m.logger, m.handleTraceSpans))
 	mux.HandleFunc(recvMetricsURL, protomsg.HandlerDo(m.logger, m.handleRecvMetrics))
+	mux.HandleFunc(recvLoadURL, protomsg.HandlerDo(m.logger, m.handleRecvLoad))
+	mux.HandleFunc(recvHealthURL, protomsg.HandlerDo(m.logger, m.handleRecvHealth))
 }
 
 // registerStatusPages registers the status pages with the provided mux.
@@ -314,10 +372,18 @@ func (m *manager) Status(ctx context.Context) (*status.Status, error) {
 		g.components.Lock()
 		cs := maps.Keys(g.components.Val)
 		g.components.Unlock()
+
+		// Copy the replica info, which changes when replicas report health.
+		g.mu.Lock()
+		replicas := make([]*status.Replica, len(g.replicas))
+		for i, r := range g.replicas {
+			replicas[i] = protomsg.Clone(r)
+		}
+		g.mu.Unlock()
 		for _, component := range cs {
 			c := &status.Component{
 				Name:     component,
-				Replicas: g.replicas,
+				Replicas: replicas,
 			}
 			components = append(components, c)
 
@@ -384,8 +450,36 @@ func (m *manager) Metrics(context.Context) (*status.Metrics, error) {
 }
 
 // Profile implements the status.Server interface.
-func (m *manager) Profile(context.Context, *protos.GetProfileRequest) (*protos.GetProfileReply, error) {
-	return nil, nil
+func (m *manager) Profile(ctx context.Context, req *protos.GetProfileRequest) (*protos.GetProfileReply, error) {
+	// Profile every replica through its babysitter. Note that we don't hold
+	// any locks while profiling, as a profile can last a long time.
+	var groups [][]func() ([]byte, error)
+	for _, g := range m.allGroups() {
+		g.mu.Lock()
+		babysitters := slices.Clone(g.babysitters)
+		g.mu.Unlock()
+
+		group := make([]func() ([]byte, error), 0, len(babysitters))
+		for _, addr := range babysitters {
+			addr := addr
+			group = append(group, func() ([]byte, error) {
+				reply := &protos.GetProfileReply{}
+				if err := protomsg.Call(ctx, protomsg.CallArgs{
+					Client:  http.DefaultClient,
+					Addr:    addr,
+					URLPath: runProfilingURL,
+					Request: req,
+					Reply:   reply,
+				}); err != nil {
+					return nil, err
+				}
+				return reply.Data, nil
+			})
+		}
+		groups = append(groups, group)
+	}
+	data, err := profiling.ProfileGroups(groups)
+	return &protos.GetProfileReply{Data: data}, err
 }
 
 // group returns the named co-location group.
@@ -405,8 +499,11 @@ func (m *manager) group(component string) *group {
 		g = &group{
 			name:       name,
 			addresses:  map[string]bool{},
+			unhealthy:  map[string]bool{},
 			components: versioned.Version(map[string]bool{}),
+			routed:     map[string]bool{},
 			routings:   map[string]*versioned.Versioned[*protos.RoutingInfo]{},
+			loads:      map[string]*protos.LoadReport{},
 			replicas:   []*status.Replica{},
 		}
 		m.groups[name] = g
@@ -453,6 +550,7 @@ func (m *manager) getComponentsToStart(_ context.Context, req *GetComponentsRequ
 	return &GetComponentsReply{
 		Components: maps.Keys(g.components.Val),
 		Version:    version,
+		Routed:     maps.Keys(g.routed),
 	}, nil
 }
 
@@ -469,23 +567,34 @@ func (m *manager) registerReplica(_ context.Context, req *ReplicaToRegister) err
 		}
 		g.addresses[req.Address] = true
 		g.replicas = append(g.replicas, &status.Replica{Pid: req.Pid, WeaveletId: req.WeaveletId})
+		g.babysitters = append(g.babysitters, req.BabysitterAddress)
 		return false
 	}
 	if record() {
 		return nil
 	}
 
-	// Update routing.
+	g.updateRouting()
+	return nil
+}
+
+// updateRouting updates the routing info of the group's components to route
+// to the group's current addresses.
+//
+// REQUIRES: g.mu is NOT held.
+func (g *group) updateRouting() {
 	replicas := g.allAddresses()
-	for _, routing := range g.routings {
+	g.mu.Lock()
+	routings := maps.Values(g.routings)
+	g.mu.Unlock()
+	for _, routing := range routings {
 		routing.Lock()
 		routing.Val.Replicas = replicas
 		if routing.Val.Assignment != nil {
-			routing.Val.Assignment = routingAlgo(routing.Val.Assignment, replicas)
+			routing.Val.Assignment = routingAlgo(routing.Val.Assignment, replicas, nil)
 		}
 		routing.Unlock()
 	}
-	return nil
 }
 
 func (m *manager) exportListener(_ context.Context, req *protos.ExportListenerRequest) (*protos.ExportListenerReply, error) {
@@ -542,6 +651,9 @@ func (m *manager) startComponent(ctx context.Context, req *protos.ActivateCompon
 			return true
 		}
 		g.components.Val[req.Component] = true
+		if req.Routed {
+			g.routed[req.Component] = true
+		}
 		return false
 	}
 	if record() { // already started
@@ -557,7 +669,7 @@ func (m *manager) startComponent(ctx context.Context, req *protos.ActivateCompon
 
 		routing.Val.Replicas = addresses
 		if req.Routed {
-			routing.Val.Assignment = routingAlgo(&protos.Assignment{}, routing.Val.Replicas)
+			routing.Val.Assignment = routingAlgo(&protos.Assignment{}, routing.Val.Replicas, nil)
 		}
 	}
 	update()
@@ -581,6 +693,16 @@ func (m *manager) startColocationGroup(g *group, runMain bool) error {
 	//
 	// TODO(rgrandl): Implement some smarter logic to determine the number of
 	// replicas for each group.
+	var certPEM, keyPEM []byte
+	if m.config.Mtls {
+		cert, key, err := certs.GenerateSignedCert(m.caCert, m.caKey, g.name)
+		if err != nil {
+			return fmt.Errorf("cannot generate cert for group %s: %w", g.name, err)
+		}
+		if certPEM, keyPEM, err = certs.PEMEncode(cert, key); err != nil {
+			return err
+		}
+	}
 	replicaId := 0
 	for loc := range m.locations {
 		info := &BabysitterInfo{
@@ -592,6 +714,17 @@ func (m *manager) startColocationGroup(g *group, runMain bool) error {
 			LogDir:      LogDir,
 			RunMain:     runMain,
 		}
+		if m.config.Mtls {
+			info.Mtls = true
+			info.CaCert = m.caCertPEM()
+			info.Cert = certPEM
+			info.Key = keyPEM
+			info.Groups = m.groupNames
+			info.Callable = map[string]*Components{}
+			for group, components := range m.callable {
+				info.Callable[group] = &Components{Components: components}
+			}
+		}
 		if err := m.startBabysitter(loc, info); err != nil {
 			return fmt.Errorf("unable to start babysitter for group %s at location %s: %w\n", g.name, loc, err)
 		}
@@ -620,6 +753,107 @@ func (m *manager) handleRecvMetrics(_ context.Context, metrics *BabysitterMetric
 	return nil
 }
 
+func (m *manager) handleRecvLoad(_ context.Context, load *BabysitterLoad) error {
+	g := m.group(load.Group)
+	g.mu.Lock()
+	g.loads[load.Address] = load.Load
+	addresses := maps.Keys(g.addresses)
+	loads := maps.Clone(g.loads)
+	routings := maps.Clone(g.routings)
+	g.mu.Unlock()
+
+	for component, routing := range routings {
+		m.rebalance(component, routing, addresses, loads)
+	}
+	return nil
+}
+
+// handleRecvHealth records the health of a weavelet. Unhealthy weavelets are
+// removed from the routing info of their components until they are healthy
+// again. Note that, unlike the multiprocess deployer, the SSH deployer never
+// restarts weavelets.
+func (m *manager) handleRecvHealth(_ context.Context, health *BabysitterHealth) error {
+	g := m.group(health.Group)
+	changed := func() bool {
+		g.mu.Lock()
+		defer g.mu.Unlock()
+		for _, r := range g.replicas {
+			if r.WeaveletId == health.WeaveletId {
+				r.Health = health.Health.Status
+				r.HealthError = ""
+				for _, c := range health.Health.Components {
+					if c.Status == protos.HealthStatus_UNHEALTHY {
+						r.HealthError = fmt.Sprintf("%s: %s", logging.ShortenComponent(c.Component), c.Error)
+						break
+					}
+				}
+			}
+		}
+
+		switch health.Health.Status {
+		case protos.HealthStatus_HEALTHY:
+			if !g.unhealthy[health.Address] {
+				return false
+			}
+			delete(g.unhealthy, health.Address)
+			g.addresses[health.Address] = true
+			m.logger.Info("Weavelet healthy again", "group", g.name, "weavelet", health.WeaveletId)
+			return true
+		case protos.HealthStatus_UNHEALTHY:
+			if !g.addresses[health.Address] {
+				return false
+			}
+			delete(g.addresses, health.Address)
+			g.unhealthy[health.Address] = true
+			m.logger.Error("Weavelet unhealthy", "group", g.name, "weavelet", health.WeaveletId)
+			return true
+		default:
+			return false
+		}
+	}()
+	if changed {
+		g.updateRouting()
+	}
+	return nil
+}
+
+// rebalance rebalances the assignment of a routed component, once every
+// replica has reported its load for the current assignment.
+func (m *manager) rebalance(component string, routing *versioned.Versioned[*protos.RoutingInfo], addresses []string, loads map[string]*protos.LoadReport) {
+	// Note that the version of a Versioned is never empty, so RLock doesn't
+	// block.
+	routing.RLock("")
+	current := routing.Val.Assignment
+	routing.RUnlock()
+	if current == nil {
+		// The component is not routed.
+		return
+	}
+
+	var reports []*protos.LoadReport_ComponentLoad
+	for _, addr := range addresses {
+		report := loads[addr].GetLoads()[component]
+		if report.GetVersion() != current.Version {
+			// Wait for the replica to report load for the current assignment.
+			return
+		}
+		reports = append(reports, report)
+	}
+	next := routingAlgo(current, addresses, reports)
+	if next.Version == current.Version {
+		return
+	}
+
+	routing.Lock()
+	defer routing.Unlock()
+	if routing.Val.Assignment != current {
+		// The assignment was concurrently updated.
+		return
+	}
+	routing.Val.Assignment = next
+	m.logger.Debug("Rebalanced assignment", "component", component, "version", next.Version)
+}
+
 // startBabysitter starts a new babysitter that manages a colocation group using SSH.
 func (m *manager) startBabysitter(loc string, info *BabysitterInfo) error {
 	input, err := proto.ToEnv(info)
@@ -627,12 +861,46 @@ func (m *manager) startBabysitter(loc string, info *BabysitterInfo) error {
 		return err
 	}
 
-	env := fmt.Sprintf("%s=%s", babysitterInfoKey, input)
+	// Note that the info is passed via stdin, rather than as an environment
+	// variable on the command line, as it may contain the group's private
+	// key, which shouldn't show up in process listings.
 	binaryPath := filepath.Join(m.locations[loc], "weaver")
-	cmd := exec.Command("ssh", loc, env, binaryPath, "ssh", "babysitter")
+	cmd := exec.Command("ssh", loc, binaryPath, "ssh", "babysitter")
+	cmd.Stdin = strings.NewReader(input)
 	return cmd.Start()
 }
 
+// caCertPEM returns the PEM-encoded CA certificate.
+func (m *manager) caCertPEM() []byte {
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: m.caCert.Raw})
+}
+
+// callGraph reads the call graph from the provided application binary. It
+// returns the name of the colocation group of every component, and the
+// components every group is allowed to call.
+func callGraph(binary string, colocation map[string]string) (map[string]string, map[string][]string, error) {
+	components, g, err := bin.ReadComponentGraph(binary)
+	if err != nil {
+		return nil, nil, fmt.Errorf("cannot read the call graph from the application binary: %w", err)
+	}
+	groupName := func(component string) string {
+		if name, ok := colocation[component]; ok {
+			return name
+		}
+		return component
+	}
+	groups := map[string]string{}
+	g.PerNode(func(n graph.Node) {
+		groups[components[n]] = groupName(components[n])
+	})
+	callable := map[string][]string{}
+	graph.PerEdge(g, func(e graph.Edge) {
+		src := groupName(components[e.Src])
+		callable[src] = append(callable[src], components[e.Dst])
+	})
+	return groups, callable, nil
+}
+
 func (m *manager) getRoutingInfo(_ context.Context, req *GetRoutingInfoRequest) (*GetRoutingInfoReply, error) {
 	g := m.group(req.RequestingGroup)
 	target := m.group(req.Component)
@@ -656,10 +924,8 @@ func (m *manager) getRoutingInfo(_ context.Context, req *GetRoutingInfoRequest)
 	}, nil
 }
 
-func routingAlgo(currAssignment *protos.Assignment, candidates []string) *protos.Assignment {
-	assignment := routing.EqualSlices(candidates)
-	assignment.Version = currAssignment.Version + 1
-	return assignment
+func routingAlgo(currAssignment *protos.Assignment, candidates []string, loads []*protos.LoadReport_ComponentLoad) *protos.Assignment {
+	return routing.Balance(currAssignment, candidates, loads, routing.BalanceOptions{})
 }
 
 // serveHTTP serves HTTP traffic on the provided listener using the provided
@@ -0,0 +1,59 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package impl
+
+import (
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestCallGraph(t *testing.T) {
+	// Build the test program, whose call graph is Main -> A -> {B, C}.
+	binary := filepath.Join(t.TempDir(), "bin")
+	cmd := exec.Command("go", "build", "-o", binary, "../../../../runtime/bin/testprogram")
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("build test program: %v\n%s", err, out)
+	}
+
+	const pkg = "github.com/ServiceWeaver/weaver/runtime/bin/testprogram"
+	main := "github.com/ServiceWeaver/weaver/Main"
+	a, b, c := pkg+"/A", pkg+"/B", pkg+"/C"
+
+	// Co-locate A and B.
+	colocation := map[string]string{a: a, b: a}
+	groups, callable, err := callGraph(binary, colocation)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, components := range callable {
+		slices.Sort(components)
+	}
+
+	wantGroups := map[string]string{main: main, a: a, b: a, c: c}
+	if diff := cmp.Diff(wantGroups, groups); diff != "" {
+		t.Errorf("groups (-want +got):\n%s", diff)
+	}
+	wantCallable := map[string][]string{
+		main: {a},
+		a:    {b, c},
+	}
+	if diff := cmp.Diff(wantCallable, callable); diff != "" {
+		t.Errorf("callable (-want +got):\n%s", diff)
+	}
+}
@@ -49,6 +49,9 @@ type SshConfig struct {
 	// File that contains the IP addresses of all locations where the application
 	// can run.
 	Locations string `protobuf:"bytes,4,opt,name=locations,proto3" json:"locations,omitempty"`
+	// Should the components use the mTLS protocol to communicate with
+	// each other?
+	Mtls bool `protobuf:"varint,5,opt,name=mtls,proto3" json:"mtls,omitempty"`
 }
 
 func (x *SshConfig) Reset() {
@@ -111,6 +114,13 @@ func (x *SshConfig) GetLocations() string {
 	return ""
 }
 
+func (x *SshConfig) GetMtls() bool {
+	if x != nil {
+		return x.Mtls
+	}
+	return false
+}
+
 // BabysitterInfo contains app deployment information that is needed by a
 // babysitter started using SSH to manage a colocation group.
 type BabysitterInfo struct {
@@ -125,6 +135,13 @@ type BabysitterInfo struct {
 	ManagerAddr string            `protobuf:"bytes,5,opt,name=manager_addr,json=managerAddr,proto3" json:"manager_addr,omitempty"`
 	LogDir      string            `protobuf:"bytes,6,opt,name=logDir,proto3" json:"logDir,omitempty"`
 	RunMain     bool              `protobuf:"varint,7,opt,name=run_main,json=runMain,proto3" json:"run_main,omitempty"`
+	// mTLS information. The fields below are set iff mtls is true.
+	Mtls     bool                   `protobuf:"varint,8,opt,name=mtls,proto3" json:"mtls,omitempty"`
+	CaCert   []byte                 `protobuf:"bytes,9,opt,name=ca_cert,json=caCert,proto3" json:"ca_cert,omitempty"`                                                                                // PEM-encoded certificate of the deployment's CA
+	Cert     []byte                 `protobuf:"bytes,10,opt,name=cert,proto3" json:"cert,omitempty"`                                                                                                 // PEM-encoded certificate of the group
+	Key      []byte                 `protobuf:"bytes,11,opt,name=key,proto3" json:"key,omitempty"`                                                                                                   // PEM-encoded private key of the group
+	Groups   map[string]string      `protobuf:"bytes,12,rep,name=groups,proto3" json:"groups,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`     // group name, by component
+	Callable map[string]*Components `protobuf:"bytes,13,rep,name=callable,proto3" json:"callable,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"` // callable components, by group
 }
 
 func (x *BabysitterInfo) Reset() {
@@ -208,6 +225,96 @@ func (x *BabysitterInfo) GetRunMain() bool {
 	return false
 }
 
+func (x *BabysitterInfo) GetMtls() bool {
+	if x != nil {
+		return x.Mtls
+	}
+	return false
+}
+
+func (x *BabysitterInfo) GetCaCert() []byte {
+	if x != nil {
+		return x.CaCert
+	}
+	return nil
+}
+
+func (x *BabysitterInfo) GetCert() []byte {
+	if x != nil {
+		return x.Cert
+	}
+	return nil
+}
+
+func (x *BabysitterInfo) GetKey() []byte {
+	if x != nil {
+		return x.Key
+	}
+	return nil
+}
+
+func (x *BabysitterInfo) GetGroups() map[string]string {
+	if x != nil {
+		return x.Groups
+	}
+	return nil
+}
+
+func (x *BabysitterInfo) GetCallable() map[string]*Components {
+	if x != nil {
+		return x.Callable
+	}
+	return nil
+}
+
+// Components is a list of component names.
+type Components struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Components []string `protobuf:"bytes,1,rep,name=components,proto3" json:"components,omitempty"`
+}
+
+func (x *Components) Reset() {
+	*x = Components{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_tool_ssh_impl_ssh_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Components) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Components) ProtoMessage() {}
+
+func (x *Components) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_tool_ssh_impl_ssh_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Components.ProtoReflect.Descriptor instead.
+func (*Components) Descriptor() ([]byte, []int) {
+	return file_internal_tool_ssh_impl_ssh_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Components) GetComponents() []string {
+	if x != nil {
+		return x.Components
+	}
+	return nil
+}
+
 // A request from the babysitter to the manager to get the latest set of
 // components to run.
 type GetComponentsRequest struct {
@@ -222,7 +329,7 @@ type GetComponentsRequest struct {
 func (x *GetComponentsRequest) Reset() {
 	*x = GetComponentsRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_internal_tool_ssh_impl_ssh_proto_msgTypes[2]
+		mi := &file_internal_tool_ssh_impl_ssh_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -235,7 +342,7 @@ func (x *GetComponentsRequest) String() string {
 func (*GetComponentsRequest) ProtoMessage() {}
 
 func (x *GetComponentsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_tool_ssh_impl_ssh_proto_msgTypes[2]
+	mi := &file_internal_tool_ssh_impl_ssh_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -248,7 +355,7 @@ func (x *GetComponentsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetComponentsRequest.ProtoReflect.Descriptor instead.
 func (*GetComponentsRequest) Descriptor() ([]byte, []int) {
-	return file_internal_tool_ssh_impl_ssh_proto_rawDescGZIP(), []int{2}
+	return file_internal_tool_ssh_impl_ssh_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *GetComponentsRequest) GetGroup() string {
@@ -272,12 +379,13 @@ type GetComponentsReply struct {
 
 	Components []string `protobuf:"bytes,1,rep,name=components,proto3" json:"components,omitempty"`
 	Version    string   `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
+	Routed     []string `protobuf:"bytes,3,rep,name=routed,proto3" json:"routed,omitempty"` // Routed components, a subset of components.
 }
 
 func (x *GetComponentsReply) Reset() {
 	*x = GetComponentsReply{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_internal_tool_ssh_impl_ssh_proto_msgTypes[3]
+		mi := &file_internal_tool_ssh_impl_ssh_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -290,7 +398,7 @@ func (x *GetComponentsReply) String() string {
 func (*GetComponentsReply) ProtoMessage() {}
 
 func (x *GetComponentsReply) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_tool_ssh_impl_ssh_proto_msgTypes[3]
+	mi := &file_internal_tool_ssh_impl_ssh_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -303,7 +411,7 @@ func (x *GetComponentsReply) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetComponentsReply.ProtoReflect.Descriptor instead.
 func (*GetComponentsReply) Descriptor() ([]byte, []int) {
-	return file_internal_tool_ssh_impl_ssh_proto_rawDescGZIP(), []int{3}
+	return file_internal_tool_ssh_impl_ssh_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *GetComponentsReply) GetComponents() []string {
@@ -320,6 +428,13 @@ func (x *GetComponentsReply) GetVersion() string {
 	return ""
 }
 
+func (x *GetComponentsReply) GetRouted() []string {
+	if x != nil {
+		return x.Routed
+	}
+	return nil
+}
+
 // A request from the babysitter to the manager to get the latest routing info
 // for a component.
 type GetRoutingInfoRequest struct {
@@ -338,7 +453,7 @@ type GetRoutingInfoRequest struct {
 func (x *GetRoutingInfoRequest) Reset() {
 	*x = GetRoutingInfoRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_internal_tool_ssh_impl_ssh_proto_msgTypes[4]
+		mi := &file_internal_tool_ssh_impl_ssh_proto_msgTypes[5]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -351,7 +466,7 @@ func (x *GetRoutingInfoRequest) String() string {
 func (*GetRoutingInfoRequest) ProtoMessage() {}
 
 func (x *GetRoutingInfoRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_tool_ssh_impl_ssh_proto_msgTypes[4]
+	mi := &file_internal_tool_ssh_impl_ssh_proto_msgTypes[5]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -364,7 +479,7 @@ func (x *GetRoutingInfoRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetRoutingInfoRequest.ProtoReflect.Descriptor instead.
 func (*GetRoutingInfoRequest) Descriptor() ([]byte, []int) {
-	return file_internal_tool_ssh_impl_ssh_proto_rawDescGZIP(), []int{4}
+	return file_internal_tool_ssh_impl_ssh_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *GetRoutingInfoRequest) GetRequestingGroup() string {
@@ -407,7 +522,7 @@ type GetRoutingInfoReply struct {
 func (x *GetRoutingInfoReply) Reset() {
 	*x = GetRoutingInfoReply{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_internal_tool_ssh_impl_ssh_proto_msgTypes[5]
+		mi := &file_internal_tool_ssh_impl_ssh_proto_msgTypes[6]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -420,7 +535,7 @@ func (x *GetRoutingInfoReply) String() string {
 func (*GetRoutingInfoReply) ProtoMessage() {}
 
 func (x *GetRoutingInfoReply) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_tool_ssh_impl_ssh_proto_msgTypes[5]
+	mi := &file_internal_tool_ssh_impl_ssh_proto_msgTypes[6]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -433,7 +548,7 @@ func (x *GetRoutingInfoReply) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetRoutingInfoReply.ProtoReflect.Descriptor instead.
 func (*GetRoutingInfoReply) Descriptor() ([]byte, []int) {
-	return file_internal_tool_ssh_impl_ssh_proto_rawDescGZIP(), []int{5}
+	return file_internal_tool_ssh_impl_ssh_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *GetRoutingInfoReply) GetRoutingInfo() *protos.RoutingInfo {
@@ -465,7 +580,7 @@ type BabysitterMetrics struct {
 func (x *BabysitterMetrics) Reset() {
 	*x = BabysitterMetrics{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_internal_tool_ssh_impl_ssh_proto_msgTypes[6]
+		mi := &file_internal_tool_ssh_impl_ssh_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -478,7 +593,7 @@ func (x *BabysitterMetrics) String() string {
 func (*BabysitterMetrics) ProtoMessage() {}
 
 func (x *BabysitterMetrics) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_tool_ssh_impl_ssh_proto_msgTypes[6]
+	mi := &file_internal_tool_ssh_impl_ssh_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -491,7 +606,7 @@ func (x *BabysitterMetrics) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BabysitterMetrics.ProtoReflect.Descriptor instead.
 func (*BabysitterMetrics) Descriptor() ([]byte, []int) {
-	return file_internal_tool_ssh_impl_ssh_proto_rawDescGZIP(), []int{6}
+	return file_internal_tool_ssh_impl_ssh_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *BabysitterMetrics) GetGroupName() string {
@@ -515,6 +630,144 @@ func (x *BabysitterMetrics) GetMetrics() []*protos.MetricSnapshot {
 	return nil
 }
 
+// BabysitterLoad is the load of a weavelet, as collected by a babysitter for a
+// given colocation group.
+type BabysitterLoad struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Group   string             `protobuf:"bytes,1,opt,name=group,proto3" json:"group,omitempty"`
+	Address string             `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"` // Replica internal address.
+	Load    *protos.LoadReport `protobuf:"bytes,3,opt,name=load,proto3" json:"load,omitempty"`
+}
+
+func (x *BabysitterLoad) Reset() {
+	*x = BabysitterLoad{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_tool_ssh_impl_ssh_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BabysitterLoad) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BabysitterLoad) ProtoMessage() {}
+
+func (x *BabysitterLoad) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_tool_ssh_impl_ssh_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BabysitterLoad.ProtoReflect.Descriptor instead.
+func (*BabysitterLoad) Descriptor() ([]byte, []int) {
+	return file_internal_tool_ssh_impl_ssh_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *BabysitterLoad) GetGroup() string {
+	if x != nil {
+		return x.Group
+	}
+	return ""
+}
+
+func (x *BabysitterLoad) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *BabysitterLoad) GetLoad() *protos.LoadReport {
+	if x != nil {
+		return x.Load
+	}
+	return nil
+}
+
+// BabysitterHealth is the health of a weavelet, as collected by a babysitter
+// for a given colocation group.
+type BabysitterHealth struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Group      string                 `protobuf:"bytes,1,opt,name=group,proto3" json:"group,omitempty"`
+	Address    string                 `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`                         // Replica internal address.
+	WeaveletId string                 `protobuf:"bytes,3,opt,name=weavelet_id,json=weaveletId,proto3" json:"weavelet_id,omitempty"` // Replica weavelet id.
+	Health     *protos.GetHealthReply `protobuf:"bytes,4,opt,name=health,proto3" json:"health,omitempty"`
+}
+
+func (x *BabysitterHealth) Reset() {
+	*x = BabysitterHealth{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_tool_ssh_impl_ssh_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BabysitterHealth) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BabysitterHealth) ProtoMessage() {}
+
+func (x *BabysitterHealth) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_tool_ssh_impl_ssh_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BabysitterHealth.ProtoReflect.Descriptor instead.
+func (*BabysitterHealth) Descriptor() ([]byte, []int) {
+	return file_internal_tool_ssh_impl_ssh_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *BabysitterHealth) GetGroup() string {
+	if x != nil {
+		return x.Group
+	}
+	return ""
+}
+
+func (x *BabysitterHealth) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+func (x *BabysitterHealth) GetWeaveletId() string {
+	if x != nil {
+		return x.WeaveletId
+	}
+	return ""
+}
+
+func (x *BabysitterHealth) GetHealth() *protos.GetHealthReply {
+	if x != nil {
+		return x.Health
+	}
+	return nil
+}
+
 // ReplicaToRegister is a request to the manager to register a replica of
 // a given colocation group (i.e., a weavelet).
 type ReplicaToRegister struct {
@@ -522,16 +775,17 @@ type ReplicaToRegister struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Group      string `protobuf:"bytes,1,opt,name=group,proto3" json:"group,omitempty"`
-	Address    string `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`       // Replica internal address.
-	Pid        int64  `protobuf:"varint,3,opt,name=pid,proto3" json:"pid,omitempty"`              // Replica pid.
-	WeaveletId string `protobuf:"bytes,4,opt,name=weaveletId,proto3" json:"weaveletId,omitempty"` // Replica weavelet id
+	Group             string `protobuf:"bytes,1,opt,name=group,proto3" json:"group,omitempty"`
+	Address           string `protobuf:"bytes,2,opt,name=address,proto3" json:"address,omitempty"`                                              // Replica internal address.
+	Pid               int64  `protobuf:"varint,3,opt,name=pid,proto3" json:"pid,omitempty"`                                                     // Replica pid.
+	WeaveletId        string `protobuf:"bytes,4,opt,name=weaveletId,proto3" json:"weaveletId,omitempty"`                                        // Replica weavelet id
+	BabysitterAddress string `protobuf:"bytes,5,opt,name=babysitter_address,json=babysitterAddress,proto3" json:"babysitter_address,omitempty"` // Address of the replica's babysitter.
 }
 
 func (x *ReplicaToRegister) Reset() {
 	*x = ReplicaToRegister{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_internal_tool_ssh_impl_ssh_proto_msgTypes[7]
+		mi := &file_internal_tool_ssh_impl_ssh_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -544,7 +798,7 @@ func (x *ReplicaToRegister) String() string {
 func (*ReplicaToRegister) ProtoMessage() {}
 
 func (x *ReplicaToRegister) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_tool_ssh_impl_ssh_proto_msgTypes[7]
+	mi := &file_internal_tool_ssh_impl_ssh_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -557,7 +811,7 @@ func (x *ReplicaToRegister) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ReplicaToRegister.ProtoReflect.Descriptor instead.
 func (*ReplicaToRegister) Descriptor() ([]byte, []int) {
-	return file_internal_tool_ssh_impl_ssh_proto_rawDescGZIP(), []int{7}
+	return file_internal_tool_ssh_impl_ssh_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *ReplicaToRegister) GetGroup() string {
@@ -588,6 +842,70 @@ func (x *ReplicaToRegister) GetWeaveletId() string {
 	return ""
 }
 
+func (x *ReplicaToRegister) GetBabysitterAddress() string {
+	if x != nil {
+		return x.BabysitterAddress
+	}
+	return ""
+}
+
+// SshDeployment records the locations where an application version was
+// deployed, so that its processes and files can be purged later.
+type SshDeployment struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	DepId     string            `protobuf:"bytes,1,opt,name=dep_id,json=depId,proto3" json:"dep_id,omitempty"`
+	Locations map[string]string `protobuf:"bytes,2,rep,name=locations,proto3" json:"locations,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"` // deployment directory, by location
+}
+
+func (x *SshDeployment) Reset() {
+	*x = SshDeployment{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_internal_tool_ssh_impl_ssh_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SshDeployment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SshDeployment) ProtoMessage() {}
+
+func (x *SshDeployment) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_tool_ssh_impl_ssh_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SshDeployment.ProtoReflect.Descriptor instead.
+func (*SshDeployment) Descriptor() ([]byte, []int) {
+	return file_internal_tool_ssh_impl_ssh_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *SshDeployment) GetDepId() string {
+	if x != nil {
+		return x.DepId
+	}
+	return ""
+}
+
+func (x *SshDeployment) GetLocations() map[string]string {
+	if x != nil {
+		return x.Locations
+	}
+	return nil
+}
+
 // Options for the application listeners, keyed by listener name.
 // If a listener isn't specified in the map, default options will be used.
 type SshConfig_ListenerOptions struct {
@@ -603,7 +921,7 @@ type SshConfig_ListenerOptions struct {
 func (x *SshConfig_ListenerOptions) Reset() {
 	*x = SshConfig_ListenerOptions{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_internal_tool_ssh_impl_ssh_proto_msgTypes[8]
+		mi := &file_internal_tool_ssh_impl_ssh_proto_msgTypes[12]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -616,7 +934,7 @@ func (x *SshConfig_ListenerOptions) String() string {
 func (*SshConfig_ListenerOptions) ProtoMessage() {}
 
 func (x *SshConfig_ListenerOptions) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_tool_ssh_impl_ssh_proto_msgTypes[8]
+	mi := &file_internal_tool_ssh_impl_ssh_proto_msgTypes[12]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -648,7 +966,7 @@ var file_internal_tool_ssh_impl_ssh_proto_rawDesc = []byte{
 	0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e,
 	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1c, 0x72, 0x75, 0x6e, 0x74, 0x69, 0x6d, 0x65, 0x2f, 0x70,
 	0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2f, 0x72, 0x75, 0x6e, 0x74, 0x69, 0x6d, 0x65, 0x2e, 0x70, 0x72,
-	0x6f, 0x74, 0x6f, 0x22, 0xb0, 0x02, 0x0a, 0x09, 0x53, 0x73, 0x68, 0x43, 0x6f, 0x6e, 0x66, 0x69,
+	0x6f, 0x74, 0x6f, 0x22, 0xc4, 0x02, 0x0a, 0x09, 0x53, 0x73, 0x68, 0x43, 0x6f, 0x6e, 0x66, 0x69,
 	0x67, 0x12, 0x24, 0x0a, 0x03, 0x61, 0x70, 0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12,
 	0x2e, 0x72, 0x75, 0x6e, 0x74, 0x69, 0x6d, 0x65, 0x2e, 0x41, 0x70, 0x70, 0x43, 0x6f, 0x6e, 0x66,
 	0x69, 0x67, 0x52, 0x03, 0x61, 0x70, 0x70, 0x12, 0x15, 0x0a, 0x06, 0x64, 0x65, 0x70, 0x5f, 0x69,
@@ -658,75 +976,132 @@ var file_internal_tool_ssh_impl_ssh_proto_rawDesc = []byte{
 	0x69, 0x67, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x65, 0x72, 0x73, 0x45, 0x6e, 0x74, 0x72,
 	0x79, 0x52, 0x09, 0x6c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x65, 0x72, 0x73, 0x12, 0x1c, 0x0a, 0x09,
 	0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x09, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x1a, 0x2b, 0x0a, 0x0f, 0x4c, 0x69,
-	0x73, 0x74, 0x65, 0x6e, 0x65, 0x72, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x18, 0x0a,
-	0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
-	0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x1a, 0x5d, 0x0a, 0x0e, 0x4c, 0x69, 0x73, 0x74, 0x65,
-	0x6e, 0x65, 0x72, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x35, 0x0a, 0x05, 0x76,
-	0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x69, 0x6d, 0x70,
-	0x6c, 0x2e, 0x53, 0x73, 0x68, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x4c, 0x69, 0x73, 0x74,
-	0x65, 0x6e, 0x65, 0x72, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x05, 0x76, 0x61, 0x6c,
-	0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xd8, 0x01, 0x0a, 0x0e, 0x42, 0x61, 0x62, 0x79, 0x73,
-	0x69, 0x74, 0x74, 0x65, 0x72, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x24, 0x0a, 0x03, 0x61, 0x70, 0x70,
-	0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x72, 0x75, 0x6e, 0x74, 0x69, 0x6d, 0x65,
-	0x2e, 0x41, 0x70, 0x70, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x03, 0x61, 0x70, 0x70, 0x12,
-	0x15, 0x0a, 0x06, 0x64, 0x65, 0x70, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x05, 0x64, 0x65, 0x70, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x18,
-	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x12, 0x1d, 0x0a, 0x0a,
-	0x72, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x5f, 0x69, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05,
-	0x52, 0x09, 0x72, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x49, 0x64, 0x12, 0x21, 0x0a, 0x0c, 0x6d,
-	0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x0b, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x41, 0x64, 0x64, 0x72, 0x12, 0x16,
-	0x0a, 0x06, 0x6c, 0x6f, 0x67, 0x44, 0x69, 0x72, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
-	0x6c, 0x6f, 0x67, 0x44, 0x69, 0x72, 0x12, 0x19, 0x0a, 0x08, 0x72, 0x75, 0x6e, 0x5f, 0x6d, 0x61,
-	0x69, 0x6e, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x72, 0x75, 0x6e, 0x4d, 0x61, 0x69,
-	0x6e, 0x22, 0x46, 0x0a, 0x14, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6d, 0x70, 0x6f, 0x6e, 0x65, 0x6e,
-	0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x67, 0x72, 0x6f,
-	0x75, 0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x12,
-	0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x4e, 0x0a, 0x12, 0x47, 0x65, 0x74,
-	0x43, 0x6f, 0x6d, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12,
-	0x1e, 0x0a, 0x0a, 0x63, 0x6f, 0x6d, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x01, 0x20,
-	0x03, 0x28, 0x09, 0x52, 0x0a, 0x63, 0x6f, 0x6d, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x73, 0x12,
+	0x09, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x6d, 0x74,
+	0x6c, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x08, 0x52, 0x04, 0x6d, 0x74, 0x6c, 0x73, 0x1a, 0x2b,
+	0x0a, 0x0f, 0x4c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x65, 0x72, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x1a, 0x5d, 0x0a, 0x0e, 0x4c,
+	0x69, 0x73, 0x74, 0x65, 0x6e, 0x65, 0x72, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a,
+	0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12,
+	0x35, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1f,
+	0x2e, 0x69, 0x6d, 0x70, 0x6c, 0x2e, 0x53, 0x73, 0x68, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e,
+	0x4c, 0x69, 0x73, 0x74, 0x65, 0x6e, 0x65, 0x72, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52,
+	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xaf, 0x04, 0x0a, 0x0e, 0x42,
+	0x61, 0x62, 0x79, 0x73, 0x69, 0x74, 0x74, 0x65, 0x72, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x24, 0x0a,
+	0x03, 0x61, 0x70, 0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x12, 0x2e, 0x72, 0x75, 0x6e,
+	0x74, 0x69, 0x6d, 0x65, 0x2e, 0x41, 0x70, 0x70, 0x43, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x52, 0x03,
+	0x61, 0x70, 0x70, 0x12, 0x15, 0x0a, 0x06, 0x64, 0x65, 0x70, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x05, 0x64, 0x65, 0x70, 0x49, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x67, 0x72,
+	0x6f, 0x75, 0x70, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x67, 0x72, 0x6f, 0x75, 0x70,
+	0x12, 0x1d, 0x0a, 0x0a, 0x72, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x5f, 0x69, 0x64, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x72, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x49, 0x64, 0x12,
+	0x21, 0x0a, 0x0c, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x6d, 0x61, 0x6e, 0x61, 0x67, 0x65, 0x72, 0x41, 0x64,
+	0x64, 0x72, 0x12, 0x16, 0x0a, 0x06, 0x6c, 0x6f, 0x67, 0x44, 0x69, 0x72, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x6c, 0x6f, 0x67, 0x44, 0x69, 0x72, 0x12, 0x19, 0x0a, 0x08, 0x72, 0x75,
+	0x6e, 0x5f, 0x6d, 0x61, 0x69, 0x6e, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x72, 0x75,
+	0x6e, 0x4d, 0x61, 0x69, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x6d, 0x74, 0x6c, 0x73, 0x18, 0x08, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x04, 0x6d, 0x74, 0x6c, 0x73, 0x12, 0x17, 0x0a, 0x07, 0x63, 0x61, 0x5f,
+	0x63, 0x65, 0x72, 0x74, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x06, 0x63, 0x61, 0x43, 0x65,
+	0x72, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x63, 0x65, 0x72, 0x74, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0c,
+	0x52, 0x04, 0x63, 0x65, 0x72, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x0b, 0x20,
+	0x01, 0x28, 0x0c, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x38, 0x0a, 0x06, 0x67, 0x72, 0x6f, 0x75,
+	0x70, 0x73, 0x18, 0x0c, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x20, 0x2e, 0x69, 0x6d, 0x70, 0x6c, 0x2e,
+	0x42, 0x61, 0x62, 0x79, 0x73, 0x69, 0x74, 0x74, 0x65, 0x72, 0x49, 0x6e, 0x66, 0x6f, 0x2e, 0x47,
+	0x72, 0x6f, 0x75, 0x70, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x06, 0x67, 0x72, 0x6f, 0x75,
+	0x70, 0x73, 0x12, 0x3e, 0x0a, 0x08, 0x63, 0x61, 0x6c, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x18, 0x0d,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x69, 0x6d, 0x70, 0x6c, 0x2e, 0x42, 0x61, 0x62, 0x79,
+	0x73, 0x69, 0x74, 0x74, 0x65, 0x72, 0x49, 0x6e, 0x66, 0x6f, 0x2e, 0x43, 0x61, 0x6c, 0x6c, 0x61,
+	0x62, 0x6c, 0x65, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x08, 0x63, 0x61, 0x6c, 0x6c, 0x61, 0x62,
+	0x6c, 0x65, 0x1a, 0x39, 0x0a, 0x0b, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x73, 0x45, 0x6e, 0x74, 0x72,
+	0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
+	0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x4d, 0x0a,
+	0x0d, 0x43, 0x61, 0x6c, 0x6c, 0x61, 0x62, 0x6c, 0x65, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10,
+	0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79,
+	0x12, 0x26, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x10, 0x2e, 0x69, 0x6d, 0x70, 0x6c, 0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74,
+	0x73, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x2c, 0x0a, 0x0a,
+	0x43, 0x6f, 0x6d, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x1e, 0x0a, 0x0a, 0x63, 0x6f,
+	0x6d, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0a,
+	0x63, 0x6f, 0x6d, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x73, 0x22, 0x46, 0x0a, 0x14, 0x47, 0x65,
+	0x74, 0x43, 0x6f, 0x6d, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x05, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73,
+	0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69,
+	0x6f, 0x6e, 0x22, 0x66, 0x0a, 0x12, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6d, 0x70, 0x6f, 0x6e, 0x65,
+	0x6e, 0x74, 0x73, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x1e, 0x0a, 0x0a, 0x63, 0x6f, 0x6d, 0x70,
+	0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0a, 0x63, 0x6f,
+	0x6d, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73,
+	0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69,
+	0x6f, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x64, 0x18, 0x03, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x06, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x64, 0x22, 0x92, 0x01, 0x0a, 0x15, 0x47,
+	0x65, 0x74, 0x52, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x29, 0x0a, 0x10, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x69,
+	0x6e, 0x67, 0x5f, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f,
+	0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x67, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x12,
+	0x1c, 0x0a, 0x09, 0x63, 0x6f, 0x6d, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x09, 0x63, 0x6f, 0x6d, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x12, 0x16, 0x0a,
+	0x06, 0x72, 0x6f, 0x75, 0x74, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x72,
+	0x6f, 0x75, 0x74, 0x65, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x22,
+	0x68, 0x0a, 0x13, 0x47, 0x65, 0x74, 0x52, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x49, 0x6e, 0x66,
+	0x6f, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x37, 0x0a, 0x0c, 0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e,
+	0x67, 0x5f, 0x69, 0x6e, 0x66, 0x6f, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x72,
+	0x75, 0x6e, 0x74, 0x69, 0x6d, 0x65, 0x2e, 0x52, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x49, 0x6e,
+	0x66, 0x6f, 0x52, 0x0b, 0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x49, 0x6e, 0x66, 0x6f, 0x12,
 	0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
-	0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x92, 0x01, 0x0a, 0x15, 0x47, 0x65,
-	0x74, 0x52, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x12, 0x29, 0x0a, 0x10, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x69, 0x6e,
-	0x67, 0x5f, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x72,
-	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x69, 0x6e, 0x67, 0x47, 0x72, 0x6f, 0x75, 0x70, 0x12, 0x1c,
-	0x0a, 0x09, 0x63, 0x6f, 0x6d, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x09, 0x63, 0x6f, 0x6d, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x12, 0x16, 0x0a, 0x06,
-	0x72, 0x6f, 0x75, 0x74, 0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x72, 0x6f,
-	0x75, 0x74, 0x65, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18,
-	0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x68,
-	0x0a, 0x13, 0x47, 0x65, 0x74, 0x52, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x49, 0x6e, 0x66, 0x6f,
-	0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x37, 0x0a, 0x0c, 0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67,
-	0x5f, 0x69, 0x6e, 0x66, 0x6f, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x72, 0x75,
-	0x6e, 0x74, 0x69, 0x6d, 0x65, 0x2e, 0x52, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x49, 0x6e, 0x66,
-	0x6f, 0x52, 0x0b, 0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x67, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x18,
-	0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x84, 0x01, 0x0a, 0x11, 0x42, 0x61, 0x62,
-	0x79, 0x73, 0x69, 0x74, 0x74, 0x65, 0x72, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x12, 0x1d,
-	0x0a, 0x0a, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x09, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1d, 0x0a,
-	0x0a, 0x72, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x05, 0x52, 0x09, 0x72, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x49, 0x64, 0x12, 0x31, 0x0a, 0x07,
-	0x6d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17, 0x2e,
-	0x72, 0x75, 0x6e, 0x74, 0x69, 0x6d, 0x65, 0x2e, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x53, 0x6e,
-	0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x52, 0x07, 0x6d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x22,
-	0x75, 0x0a, 0x11, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x54, 0x6f, 0x52, 0x65, 0x67, 0x69,
-	0x73, 0x74, 0x65, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x05, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x64,
-	0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x61, 0x64, 0x64,
-	0x72, 0x65, 0x73, 0x73, 0x12, 0x10, 0x0a, 0x03, 0x70, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28,
-	0x03, 0x52, 0x03, 0x70, 0x69, 0x64, 0x12, 0x1e, 0x0a, 0x0a, 0x77, 0x65, 0x61, 0x76, 0x65, 0x6c,
-	0x65, 0x74, 0x49, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x77, 0x65, 0x61, 0x76,
-	0x65, 0x6c, 0x65, 0x74, 0x49, 0x64, 0x42, 0x38, 0x5a, 0x36, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62,
-	0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x57, 0x65, 0x61, 0x76,
-	0x65, 0x72, 0x2f, 0x77, 0x65, 0x61, 0x76, 0x65, 0x72, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e,
-	0x61, 0x6c, 0x2f, 0x74, 0x6f, 0x6f, 0x6c, 0x2f, 0x73, 0x73, 0x68, 0x2f, 0x69, 0x6d, 0x70, 0x6c,
-	0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x84, 0x01, 0x0a, 0x11, 0x42, 0x61,
+	0x62, 0x79, 0x73, 0x69, 0x74, 0x74, 0x65, 0x72, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x12,
+	0x1d, 0x0a, 0x0a, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x09, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1d,
+	0x0a, 0x0a, 0x72, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01,
+	0x28, 0x05, 0x52, 0x09, 0x72, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x49, 0x64, 0x12, 0x31, 0x0a,
+	0x07, 0x6d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x17,
+	0x2e, 0x72, 0x75, 0x6e, 0x74, 0x69, 0x6d, 0x65, 0x2e, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x53,
+	0x6e, 0x61, 0x70, 0x73, 0x68, 0x6f, 0x74, 0x52, 0x07, 0x6d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73,
+	0x22, 0x69, 0x0a, 0x0e, 0x42, 0x61, 0x62, 0x79, 0x73, 0x69, 0x74, 0x74, 0x65, 0x72, 0x4c, 0x6f,
+	0x61, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x05, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72,
+	0x65, 0x73, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65,
+	0x73, 0x73, 0x12, 0x27, 0x0a, 0x04, 0x6c, 0x6f, 0x61, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x13, 0x2e, 0x72, 0x75, 0x6e, 0x74, 0x69, 0x6d, 0x65, 0x2e, 0x4c, 0x6f, 0x61, 0x64, 0x52,
+	0x65, 0x70, 0x6f, 0x72, 0x74, 0x52, 0x04, 0x6c, 0x6f, 0x61, 0x64, 0x22, 0x94, 0x01, 0x0a, 0x10,
+	0x42, 0x61, 0x62, 0x79, 0x73, 0x69, 0x74, 0x74, 0x65, 0x72, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68,
+	0x12, 0x14, 0x0a, 0x05, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x05, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x12, 0x18, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73,
+	0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73,
+	0x12, 0x1f, 0x0a, 0x0b, 0x77, 0x65, 0x61, 0x76, 0x65, 0x6c, 0x65, 0x74, 0x5f, 0x69, 0x64, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x77, 0x65, 0x61, 0x76, 0x65, 0x6c, 0x65, 0x74, 0x49,
+	0x64, 0x12, 0x2f, 0x0a, 0x06, 0x68, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x18, 0x04, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x17, 0x2e, 0x72, 0x75, 0x6e, 0x74, 0x69, 0x6d, 0x65, 0x2e, 0x47, 0x65, 0x74, 0x48,
+	0x65, 0x61, 0x6c, 0x74, 0x68, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x52, 0x06, 0x68, 0x65, 0x61, 0x6c,
+	0x74, 0x68, 0x22, 0xa4, 0x01, 0x0a, 0x11, 0x52, 0x65, 0x70, 0x6c, 0x69, 0x63, 0x61, 0x54, 0x6f,
+	0x52, 0x65, 0x67, 0x69, 0x73, 0x74, 0x65, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x67, 0x72, 0x6f, 0x75,
+	0x70, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x67, 0x72, 0x6f, 0x75, 0x70, 0x12, 0x18,
+	0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x10, 0x0a, 0x03, 0x70, 0x69, 0x64, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x70, 0x69, 0x64, 0x12, 0x1e, 0x0a, 0x0a, 0x77, 0x65,
+	0x61, 0x76, 0x65, 0x6c, 0x65, 0x74, 0x49, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a,
+	0x77, 0x65, 0x61, 0x76, 0x65, 0x6c, 0x65, 0x74, 0x49, 0x64, 0x12, 0x2d, 0x0a, 0x12, 0x62, 0x61,
+	0x62, 0x79, 0x73, 0x69, 0x74, 0x74, 0x65, 0x72, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73,
+	0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x11, 0x62, 0x61, 0x62, 0x79, 0x73, 0x69, 0x74, 0x74,
+	0x65, 0x72, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x22, 0xa6, 0x01, 0x0a, 0x0d, 0x53, 0x73,
+	0x68, 0x44, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x12, 0x15, 0x0a, 0x06, 0x64,
+	0x65, 0x70, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x64, 0x65, 0x70,
+	0x49, 0x64, 0x12, 0x40, 0x0a, 0x09, 0x6c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18,
+	0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x22, 0x2e, 0x69, 0x6d, 0x70, 0x6c, 0x2e, 0x53, 0x73, 0x68,
+	0x44, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x2e, 0x4c, 0x6f, 0x63, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x09, 0x6c, 0x6f, 0x63, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x1a, 0x3c, 0x0a, 0x0e, 0x4c, 0x6f, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75,
+	0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02,
+	0x38, 0x01, 0x42, 0x38, 0x5a, 0x36, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d,
+	0x2f, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x57, 0x65, 0x61, 0x76, 0x65, 0x72, 0x2f, 0x77,
+	0x65, 0x61, 0x76, 0x65, 0x72, 0x2f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x2f, 0x74,
+	0x6f, 0x6f, 0x6c, 0x2f, 0x73, 0x73, 0x68, 0x2f, 0x69, 0x6d, 0x70, 0x6c, 0x62, 0x06, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -741,34 +1116,49 @@ func file_internal_tool_ssh_impl_ssh_proto_rawDescGZIP() []byte {
 	return file_internal_tool_ssh_impl_ssh_proto_rawDescData
 }
 
-var file_internal_tool_ssh_impl_ssh_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
+var file_internal_tool_ssh_impl_ssh_proto_msgTypes = make([]protoimpl.MessageInfo, 17)
 var file_internal_tool_ssh_impl_ssh_proto_goTypes = []interface{}{
 	(*SshConfig)(nil),                 // 0: impl.SshConfig
 	(*BabysitterInfo)(nil),            // 1: impl.BabysitterInfo
-	(*GetComponentsRequest)(nil),      // 2: impl.GetComponentsRequest
-	(*GetComponentsReply)(nil),        // 3: impl.GetComponentsReply
-	(*GetRoutingInfoRequest)(nil),     // 4: impl.GetRoutingInfoRequest
-	(*GetRoutingInfoReply)(nil),       // 5: impl.GetRoutingInfoReply
-	(*BabysitterMetrics)(nil),         // 6: impl.BabysitterMetrics
-	(*ReplicaToRegister)(nil),         // 7: impl.ReplicaToRegister
-	(*SshConfig_ListenerOptions)(nil), // 8: impl.SshConfig.ListenerOptions
-	nil,                               // 9: impl.SshConfig.ListenersEntry
-	(*protos.AppConfig)(nil),          // 10: runtime.AppConfig
-	(*protos.RoutingInfo)(nil),        // 11: runtime.RoutingInfo
-	(*protos.MetricSnapshot)(nil),     // 12: runtime.MetricSnapshot
+	(*Components)(nil),                // 2: impl.Components
+	(*GetComponentsRequest)(nil),      // 3: impl.GetComponentsRequest
+	(*GetComponentsReply)(nil),        // 4: impl.GetComponentsReply
+	(*GetRoutingInfoRequest)(nil),     // 5: impl.GetRoutingInfoRequest
+	(*GetRoutingInfoReply)(nil),       // 6: impl.GetRoutingInfoReply
+	(*BabysitterMetrics)(nil),         // 7: impl.BabysitterMetrics
+	(*BabysitterLoad)(nil),            // 8: impl.BabysitterLoad
+	(*BabysitterHealth)(nil),          // 9: impl.BabysitterHealth
+	(*ReplicaToRegister)(nil),         // 10: impl.ReplicaToRegister
+	(*SshDeployment)(nil),             // 11: impl.SshDeployment
+	(*SshConfig_ListenerOptions)(nil), // 12: impl.SshConfig.ListenerOptions
+	nil,                               // 13: impl.SshConfig.ListenersEntry
+	nil,                               // 14: impl.BabysitterInfo.GroupsEntry
+	nil,                               // 15: impl.BabysitterInfo.CallableEntry
+	nil,                               // 16: impl.SshDeployment.LocationsEntry
+	(*protos.AppConfig)(nil),          // 17: runtime.AppConfig
+	(*protos.RoutingInfo)(nil),        // 18: runtime.RoutingInfo
+	(*protos.MetricSnapshot)(nil),     // 19: runtime.MetricSnapshot
+	(*protos.LoadReport)(nil),         // 20: runtime.LoadReport
+	(*protos.GetHealthReply)(nil),     // 21: runtime.GetHealthReply
 }
 var file_internal_tool_ssh_impl_ssh_proto_depIdxs = []int32{
-	10, // 0: impl.SshConfig.app:type_name -> runtime.AppConfig
-	9,  // 1: impl.SshConfig.listeners:type_name -> impl.SshConfig.ListenersEntry
-	10, // 2: impl.BabysitterInfo.app:type_name -> runtime.AppConfig
-	11, // 3: impl.GetRoutingInfoReply.routing_info:type_name -> runtime.RoutingInfo
-	12, // 4: impl.BabysitterMetrics.metrics:type_name -> runtime.MetricSnapshot
-	8,  // 5: impl.SshConfig.ListenersEntry.value:type_name -> impl.SshConfig.ListenerOptions
-	6,  // [6:6] is the sub-list for method output_type
-	6,  // [6:6] is the sub-list for method input_type
-	6,  // [6:6] is the sub-list for extension type_name
-	6,  // [6:6] is the sub-list for extension extendee
-	0,  // [0:6] is the sub-list for field type_name
+	17, // 0: impl.SshConfig.app:type_name -> runtime.AppConfig
+	13, // 1: impl.SshConfig.listeners:type_name -> impl.SshConfig.ListenersEntry
+	17, // 2: impl.BabysitterInfo.app:type_name -> runtime.AppConfig
+	14, // 3: impl.BabysitterInfo.groups:type_name -> impl.BabysitterInfo.GroupsEntry
+	15, // 4: impl.BabysitterInfo.callable:type_name -> impl.BabysitterInfo.CallableEntry
+	18, // 5: impl.GetRoutingInfoReply.routing_info:type_name -> runtime.RoutingInfo
+	19, // 6: impl.BabysitterMetrics.metrics:type_name -> runtime.MetricSnapshot
+	20, // 7: impl.BabysitterLoad.load:type_name -> runtime.LoadReport
+	21, // 8: impl.BabysitterHealth.health:type_name -> runtime.GetHealthReply
+	16, // 9: impl.SshDeployment.locations:type_name -> impl.SshDeployment.LocationsEntry
+	12, // 10: impl.SshConfig.ListenersEntry.value:type_name -> impl.SshConfig.ListenerOptions
+	2,  // 11: impl.BabysitterInfo.CallableEntry.value:type_name -> impl.Components
+	12, // [12:12] is the sub-list for method output_type
+	12, // [12:12] is the sub-list for method input_type
+	12, // [12:12] is the sub-list for extension type_name
+	12, // [12:12] is the sub-list for extension extendee
+	0,  // [0:12] is the sub-list for field type_name
 }
 
 func init() { file_internal_tool_ssh_impl_ssh_proto_init() }
@@ -802,7 +1192,7 @@ func file_internal_tool_ssh_impl_ssh_proto_init() {
 			}
 		}
 		file_internal_tool_ssh_impl_ssh_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetComponentsRequest); i {
+			switch v := v.(*Components); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -814,7 +1204,7 @@ func file_internal_tool_ssh_impl_ssh_proto_init() {
 			}
 		}
 		file_internal_tool_ssh_impl_ssh_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetComponentsReply); i {
+			switch v := v.(*GetComponentsRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -826,7 +1216,7 @@ func file_internal_tool_ssh_impl_ssh_proto_init() {
 			}
 		}
 		file_internal_tool_ssh_impl_ssh_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetRoutingInfoRequest); i {
+			switch v := v.(*GetComponentsReply); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -838,7 +1228,7 @@ func file_internal_tool_ssh_impl_ssh_proto_init() {
 			}
 		}
 		file_internal_tool_ssh_impl_ssh_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetRoutingInfoReply); i {
+			switch v := v.(*GetRoutingInfoRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -850,7 +1240,7 @@ func file_internal_tool_ssh_impl_ssh_proto_init() {
 			}
 		}
 		file_internal_tool_ssh_impl_ssh_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*BabysitterMetrics); i {
+			switch v := v.(*GetRoutingInfoReply); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -862,7 +1252,7 @@ func file_internal_tool_ssh_impl_ssh_proto_init() {
 			}
 		}
 		file_internal_tool_ssh_impl_ssh_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ReplicaToRegister); i {
+			switch v := v.(*BabysitterMetrics); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -874,6 +1264,54 @@ func file_internal_tool_ssh_impl_ssh_proto_init() {
 			}
 		}
 		file_internal_tool_ssh_impl_ssh_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BabysitterLoad); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_tool_ssh_impl_ssh_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BabysitterHealth); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_tool_ssh_impl_ssh_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ReplicaToRegister); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_tool_ssh_impl_ssh_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SshDeployment); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_internal_tool_ssh_impl_ssh_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*SshConfig_ListenerOptions); i {
 			case 0:
 				return &v.state
@@ -892,7 +1330,7 @@ func file_internal_tool_ssh_impl_ssh_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_internal_tool_ssh_impl_ssh_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   10,
+			NumMessages:   17,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -0,0 +1,126 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ssh
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	"github.com/ServiceWeaver/weaver/internal/tool/ssh/impl"
+	"github.com/ServiceWeaver/weaver/runtime/tool"
+	"google.golang.org/protobuf/proto"
+)
+
+var purgeSpec = &tool.PurgeSpec{
+	Tool:   "weaver ssh",
+	Kill:   "weaver ssh (dashboard|deploy|logs|profile)",
+	Paths:  []string{impl.LogDir, impl.DataDir},
+	Remote: purgeRemote,
+}
+
+// recordDeployment records the locations where an application version is
+// deployed, so that "weaver ssh purge" can later purge them.
+func recordDeployment(depId string, locations map[string]string) error {
+	return writeDeploymentRecord(impl.DeploymentsDir, depId, locations)
+}
+
+// writeDeploymentRecord writes the record of a deployment to the provided
+// directory.
+func writeDeploymentRecord(recordsDir, depId string, locations map[string]string) error {
+	if err := os.MkdirAll(recordsDir, 0700); err != nil {
+		return err
+	}
+	data, err := proto.Marshal(&impl.SshDeployment{DepId: depId, Locations: locations})
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(recordsDir, depId), data, 0600)
+}
+
+// purgeRemote kills the processes and deletes the deployment directories of
+// all recorded deployments, at all of their locations.
+func purgeRemote(context.Context) error {
+	return purgeDeployments(impl.DeploymentsDir, purgeLocation)
+}
+
+// purgeDeployments purges every location of every deployment recorded in the
+// provided directory using the provided purge function. The record of a
+// deployment is deleted once all of its locations are purged, so that a
+// failed purge can be retried.
+func purgeDeployments(recordsDir string, purge func(loc, dir, depId string) error) error {
+	entries, err := os.ReadDir(recordsDir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		file := filepath.Join(recordsDir, entry.Name())
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return err
+		}
+		dep := &impl.SshDeployment{}
+		if err := proto.Unmarshal(data, dep); err != nil {
+			return fmt.Errorf("invalid deployment record %s: %w", file, err)
+		}
+		for loc, dir := range dep.Locations {
+			fmt.Printf("Purging deployment %s at %s... ", dep.DepId, loc)
+			if err := purge(loc, dir, dep.DepId); err != nil {
+				fmt.Println("❌")
+				return err
+			}
+			fmt.Println("✅")
+		}
+		if err := os.Remove(file); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// killRegex returns the quoted pkill regex that matches the processes of the
+// provided deployment. Note that the regex "[x]yz" matches "xyz", but not the
+// command line of the remote shell that runs pkill, which contains "[x]yz".
+func killRegex(depId string) string {
+	return fmt.Sprintf("'[%c]%s'", depId[0], depId[1:])
+}
+
+// purgeLocation kills the processes of the provided deployment at the
+// provided location, and deletes its deployment directory.
+func purgeLocation(loc, dir, depId string) error {
+	// Note that pkill exits with code 1 if no processes matched, which we
+	// don't treat as an error.
+	cmd := exec.Command("ssh", loc, "pkill", "-f", killRegex(depId))
+	var exit *exec.ExitError
+	if err := cmd.Run(); err != nil && !(errors.As(err, &exit) && exit.ExitCode() == 1) {
+		return fmt.Errorf("unable to kill deployment at location %s: %w", loc, err)
+	}
+
+	cmd = exec.Command("ssh", loc, "rm", "-rf", dir)
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("unable to delete deployment directory at location %s: %w", loc, err)
+	}
+
+	// The deployment directory is created inside a unique temporary directory
+	// (see getTmpDirs), which we remove as well if it's empty. Note that we
+	// ignore errors, as the directory may already be gone.
+	exec.Command("ssh", loc, "rmdir", filepath.Dir(dir)).Run()
+	return nil
+}
@@ -0,0 +1,111 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ssh
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestPurgeDeployments(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "deployments")
+	deployments := map[string]map[string]string{
+		"dep1": {"host1": "/tmp/a/dep1", "host2": "/tmp/b/dep1"},
+		"dep2": {"host1": "/tmp/c/dep2"},
+	}
+	for depId, locations := range deployments {
+		if err := writeDeploymentRecord(dir, depId, locations); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var purged []string
+	purge := func(loc, dir, depId string) error {
+		purged = append(purged, fmt.Sprintf("%s %s %s", depId, loc, dir))
+		return nil
+	}
+	if err := purgeDeployments(dir, purge); err != nil {
+		t.Fatal(err)
+	}
+	slices.Sort(purged)
+	want := []string{
+		"dep1 host1 /tmp/a/dep1",
+		"dep1 host2 /tmp/b/dep1",
+		"dep2 host1 /tmp/c/dep2",
+	}
+	if !slices.Equal(purged, want) {
+		t.Fatalf("purged: got %v, want %v", purged, want)
+	}
+
+	// Every record should be deleted.
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 0 {
+		t.Fatalf("got %d deployment records, want 0", len(entries))
+	}
+}
+
+func TestPurgeDeploymentsFailure(t *testing.T) {
+	// The record of a deployment that fails to be purged should be kept, so
+	// that the purge can be retried.
+	dir := filepath.Join(t.TempDir(), "deployments")
+	if err := writeDeploymentRecord(dir, "dep", map[string]string{"host": "/tmp/dep"}); err != nil {
+		t.Fatal(err)
+	}
+	purge := func(loc, dir, depId string) error {
+		return fmt.Errorf("%s unreachable", loc)
+	}
+	if err := purgeDeployments(dir, purge); err == nil {
+		t.Fatal("unexpected success")
+	}
+	if _, err := os.Stat(filepath.Join(dir, "dep")); err != nil {
+		t.Fatalf("deployment record: %v", err)
+	}
+}
+
+func TestPurgeDeploymentsNoRecords(t *testing.T) {
+	purge := func(loc, dir, depId string) error {
+		t.Fatalf("unexpected purge of %s at %s", depId, loc)
+		return nil
+	}
+	if err := purgeDeployments(filepath.Join(t.TempDir(), "missing"), purge); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestKillRegex(t *testing.T) {
+	const depId = "5f3a1d2c-9b9e-4d8a-8c7e-0b1f2e3d4c5b"
+	quoted := killRegex(depId)
+	re := regexp.MustCompile(strings.Trim(quoted, "'"))
+
+	// The regex should match the command lines of the deployment's
+	// processes, but not the command line of the shell that runs pkill.
+	if cmd := "weaver ssh babysitter --dep " + depId; !re.MatchString(cmd) {
+		t.Errorf("%s doesn't match %q", quoted, cmd)
+	}
+	if cmd := "pkill -f " + quoted; re.MatchString(cmd) {
+		t.Errorf("%s matches %q", quoted, cmd)
+	}
+	if cmd := "weaver ssh babysitter --dep other"; re.MatchString(cmd) {
+		t.Errorf("%s matches %q", quoted, cmd)
+	}
+}
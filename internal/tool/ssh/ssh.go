@@ -17,6 +17,7 @@ package ssh
 import (
 	"github.com/ServiceWeaver/weaver/internal/status"
 	itool "github.com/ServiceWeaver/weaver/internal/tool"
+	"github.com/ServiceWeaver/weaver/internal/tool/ssh/impl"
 	"github.com/ServiceWeaver/weaver/runtime/tool"
 )
 
@@ -25,6 +26,10 @@ var (
 		"deploy":    &deployCmd,
 		"logs":      tool.LogsCmd(&logsSpec),
 		"dashboard": status.DashboardCommand(dashboardSpec),
+		"status":    status.StatusCommand("weaver ssh", impl.DefaultRegistry),
+		"metrics":   status.MetricsCommand("weaver ssh", impl.DefaultRegistry),
+		"profile":   status.ProfileCommand("weaver ssh", impl.DefaultRegistry),
+		"purge":     tool.PurgeCmd(purgeSpec),
 		"version":   itool.VersionCmd("weaver ssh"),
 
 		// Hidden commands.
@@ -30,6 +30,12 @@ const (
 	WeaveletIdTraceKey   = attribute.Key("serviceweaver.weavelet_id")
 )
 
+// InstrumentationLibrary is the name of the instrumentation library that
+// creates the spans of component method calls. These spans are named
+// <component>.<method>, where <component> is the shortened component name
+// (e.g., "store.Store").
+const InstrumentationLibrary = "github.com/ServiceWeaver/weaver/serviceweaver"
+
 // TestTracer returns a simple tracer suitable for tests.
 func TestTracer() trace.Tracer {
 	exporter, _ := stdouttrace.New(stdouttrace.WithPrettyPrint())
@@ -0,0 +1,77 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package weaver
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ServiceWeaver/weaver/runtime/codegen"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// A FaultInjector injects faults into the remote method calls of a
+// RemoteWeavelet. It is used by weavertest to test how an application behaves
+// when remote calls are slow or fail.
+type FaultInjector interface {
+	// Inject is called before a remote call of the provided component method
+	// is sent, and may delay the call or restart component replicas before
+	// returning. If Inject returns a non-nil error, the call fails with the
+	// error without being executed. Otherwise, if drop is true, the call is
+	// executed, but its reply is dropped and the call fails.
+	Inject(ctx context.Context, component, method string) (drop bool, err error)
+}
+
+// errDropped is the error returned by a call whose reply was dropped by a
+// FaultInjector.
+var errDropped = errors.New("reply dropped by fault injector")
+
+// faultyStub is a codegen.Stub that injects faults into the calls of an
+// underlying stub.
+type faultyStub struct {
+	stub      codegen.Stub          // underlying stub
+	reg       *codegen.Registration // component being called
+	component string                // full component name
+	faults    FaultInjector         // injects faults
+}
+
+var _ codegen.Stub = &faultyStub{}
+
+// Tracer implements the codegen.Stub interface.
+func (s *faultyStub) Tracer() trace.Tracer {
+	return s.stub.Tracer()
+}
+
+// Run implements the codegen.Stub interface.
+func (s *faultyStub) Run(ctx context.Context, method int, args []byte, shardKey uint64) ([]byte, error) {
+	drop, err := s.faults.Inject(ctx, s.component, s.reg.Iface.Method(method).Name)
+	if err != nil {
+		return nil, err
+	}
+	results, err := s.stub.Run(ctx, method, args, shardKey)
+	if err == nil && drop {
+		return nil, errDropped
+	}
+	return results, err
+}
+
+// Stream implements the codegen.Stub interface. The replies of streaming
+// calls are never dropped.
+func (s *faultyStub) Stream(ctx context.Context, method int, args []byte, shardKey uint64) (codegen.ClientStream, error) {
+	if _, err := s.faults.Inject(ctx, s.component, s.reg.Iface.Method(method).Name); err != nil {
+		return nil, err
+	}
+	return s.stub.Stream(ctx, method, args, shardKey)
+}
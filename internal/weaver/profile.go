@@ -18,12 +18,30 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"runtime"
 	"runtime/pprof"
+	"sync"
 	"time"
 
 	"github.com/ServiceWeaver/weaver/runtime/protos"
+	"github.com/google/pprof/profile"
 )
 
+const (
+	// mutexProfileFraction is the fraction of mutex contention events that
+	// are reported while a mutex profile is collected, if mutex profiling
+	// wasn't already enabled. See runtime.SetMutexProfileFraction.
+	mutexProfileFraction = 10
+
+	// blockProfileRate is the block profiling rate used while a block
+	// profile is collected. See runtime.SetBlockProfileRate.
+	blockProfileRate = int(10 * time.Microsecond)
+)
+
+// contentionProfiling is held while a mutex or block profile is collected.
+// Like CPU profiles, only one of these profiles can be collected at a time.
+var contentionProfiling sync.Mutex
+
 // getProfile collects a profile of this process.
 func getProfile(ctx context.Context, req *protos.GetProfileRequest) ([]byte, error) {
 	var buf bytes.Buffer
@@ -48,8 +66,92 @@ func getProfile(ctx context.Context, req *protos.GetProfileRequest) ([]byte, err
 			// All done
 		}
 		pprof.StopCPUProfile()
+	case protos.ProfileType_Goroutine:
+		if err := pprof.Lookup("goroutine").WriteTo(&buf, 0); err != nil {
+			return nil, err
+		}
+	case protos.ProfileType_Allocs:
+		if err := pprof.Lookup("allocs").WriteTo(&buf, 0); err != nil {
+			return nil, err
+		}
+	case protos.ProfileType_ThreadCreate:
+		if err := pprof.Lookup("threadcreate").WriteTo(&buf, 0); err != nil {
+			return nil, err
+		}
+	case protos.ProfileType_Mutex, protos.ProfileType_Block:
+		return getContentionProfile(ctx, req)
 	default:
 		return nil, fmt.Errorf("unspecified profile collection type")
 	}
 	return buf.Bytes(), nil
 }
+
+// getContentionProfile collects a mutex or block profile of the contention
+// observed during the requested duration. Sampling of the corresponding
+// events is enabled for the duration of the profile.
+func getContentionProfile(ctx context.Context, req *protos.GetProfileRequest) ([]byte, error) {
+	name := "mutex"
+	if req.ProfileType == protos.ProfileType_Block {
+		name = "block"
+	}
+	if req.CpuDurationNs == 0 {
+		return nil, fmt.Errorf("invalid zero duration for the %s profile collection", name)
+	}
+	dur := time.Duration(req.CpuDurationNs) * time.Nanosecond
+	if !contentionProfiling.TryLock() {
+		return nil, fmt.Errorf("a mutex or block profile is already in progress")
+	}
+	defer contentionProfiling.Unlock()
+
+	if req.ProfileType == protos.ProfileType_Mutex {
+		if prev := runtime.SetMutexProfileFraction(-1); prev == 0 {
+			runtime.SetMutexProfileFraction(mutexProfileFraction)
+			defer runtime.SetMutexProfileFraction(0)
+		}
+	} else {
+		// Note that the runtime doesn't expose the current block profiling
+		// rate, so we can't restore it. Block profiling is disabled once the
+		// profile is collected.
+		runtime.SetBlockProfileRate(blockProfileRate)
+		defer runtime.SetBlockProfileRate(0)
+	}
+
+	// The mutex and block profiles are cumulative. We report the difference
+	// between the profiles at the start and at the end of the duration.
+	before, err := lookupProfile(name)
+	if err != nil {
+		return nil, err
+	}
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-time.After(dur):
+		// All done
+	}
+	after, err := lookupProfile(name)
+	if err != nil {
+		return nil, err
+	}
+	before.Scale(-1)
+	delta, err := profile.Merge([]*profile.Profile{before, after})
+	if err != nil {
+		return nil, fmt.Errorf("compute %s profile: %w", name, err)
+	}
+	delta.TimeNanos = after.TimeNanos
+	delta.DurationNanos = after.TimeNanos - before.TimeNanos
+
+	var buf bytes.Buffer
+	if err := delta.Write(&buf); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// lookupProfile returns the current contents of the named runtime profile.
+func lookupProfile(name string) (*profile.Profile, error) {
+	var buf bytes.Buffer
+	if err := pprof.Lookup(name).WriteTo(&buf, 0); err != nil {
+		return nil, err
+	}
+	return profile.Parse(&buf)
+}
@@ -25,10 +25,12 @@ import (
 	"os"
 	"os/signal"
 	"reflect"
+	"slices"
 	"strings"
 	"sync"
 	"sync/atomic"
 	"syscall"
+	"time"
 
 	"github.com/ServiceWeaver/weaver/internal/config"
 	"github.com/ServiceWeaver/weaver/internal/control"
@@ -52,10 +54,16 @@ import (
 // clients to check if a component is ready.
 const readyMethodName = "ready"
 
+// healthInterval is how often a weavelet calls the Health methods of its
+// components. A call to a Health method that takes longer than healthInterval
+// fails.
+const healthInterval = 5 * time.Second
+
 // RemoteWeaveletOptions configure a RemoteWeavelet.
 type RemoteWeaveletOptions struct {
 	Fakes         map[reflect.Type]any // component fakes, by component interface type
 	InjectRetries int                  // Number of artificial retries to inject per retriable call
+	Faults        FaultInjector        // injects faults into remote calls, if not nil
 }
 
 // RemoteWeavelet is a weavelet that runs some components locally, but
@@ -84,7 +92,9 @@ type RemoteWeavelet struct {
 	initDone   chan struct{}
 
 	// Ready to use by the time initDone is closed.
-	sectionConfig map[string]string
+	sectionConfig     map[string]string
+	concurrencyLimits map[string]call.ConcurrencyLimit
+	uncompressed      []string // components whose calls aren't compressed
 
 	// channel that is closed when deployer is ready.
 	deployerReady chan struct{}
@@ -130,6 +140,9 @@ type component struct {
 
 	local register.WriteOnce[bool] // routed locally?
 	load  *loadCollector           // non-nil for routed components
+
+	healthMu  sync.Mutex // guards healthErr
+	healthErr error      // error returned by the latest call to Health
 }
 
 // listener is a network listener and the proxy address that should be used to
@@ -278,8 +291,10 @@ func NewRemoteWeavelet(ctx context.Context, regs []*codegen.Registration, bootst
 	servers.Go(func() error {
 		server := &server{Listener: lis, wlet: w}
 		opts := call.ServerOptions{
-			Logger: w.syslogger,
-			Tracer: w.tracer,
+			Logger:                 w.syslogger,
+			Tracer:                 w.tracer,
+			ConcurrencyLimits:      w.concurrencyLimits,
+			UncompressedComponents: w.uncompressed,
 		}
 		if err := call.Serve(w.ctx, server, opts); err != nil {
 			w.syslogger.Error("RPC server failed", "err", err)
@@ -288,6 +303,12 @@ func NewRemoteWeavelet(ctx context.Context, regs []*codegen.Registration, bootst
 		return nil
 	})
 
+	// Periodically check the health of the components.
+	servers.Go(func() error {
+		w.checkHealth(ctx)
+		return nil
+	})
+
 	// Start a signal handler to detect when the process is killed. This isn't
 	// perfect, as we can't catch a SIGKILL, but it's good in the common case.
 	done := make(chan os.Signal, 1)
@@ -317,6 +338,29 @@ func (w *RemoteWeavelet) InitWeavelet(ctx context.Context, req *protos.InitWeave
 	w.initMu.Lock()
 	defer w.initMu.Unlock()
 	if !w.initCalled {
+		// Install the configured balancers. No connections have been added
+		// to the balancers yet, as stubs are created only after
+		// initialization.
+		for name, kind := range req.Balancers {
+			c, ok := w.componentsByName[name]
+			if !ok {
+				continue
+			}
+			b, err := call.NewBalancer(kind)
+			if err != nil {
+				return nil, fmt.Errorf("component %q: %w", name, err)
+			}
+			c.balancer.balancer = b
+		}
+		w.concurrencyLimits = map[string]call.ConcurrencyLimit{}
+		for name, limit := range req.ConcurrencyLimits {
+			w.concurrencyLimits[name] = call.ConcurrencyLimit{
+				MaxConcurrent: int(limit.MaxConcurrent),
+				MaxQueue:      int(limit.MaxQueue),
+				Adaptive:      limit.Adaptive,
+			}
+		}
+		w.uncompressed = req.Uncompressed
 		w.sectionConfig = req.Sections
 		w.initCalled = true
 		close(w.initDone)
@@ -513,6 +557,11 @@ func (w *RemoteWeavelet) createComponent(ctx context.Context, reg *codegen.Regis
 func (w *RemoteWeavelet) getStub(c *component) (codegen.Stub, error) {
 	c.stubInit.Do(func() {
 		c.stub, c.stubErr = w.makeStub(c.reg.Name, c.reg, c.resolver, c.balancer, true)
+		if c.stubErr == nil && w.opts.Faults != nil {
+			// Faults are only injected into calls between application
+			// components, never into calls redirected to the runtime.
+			c.stub = &faultyStub{stub: c.stub, reg: c.reg, component: c.reg.Name, faults: w.opts.Faults}
+		}
 	})
 	return c.stub, c.stubErr
 }
@@ -523,8 +572,13 @@ func (w *RemoteWeavelet) makeStub(fullName string, reg *codegen.Registration, re
 	name := logging.ShortenComponent(fullName)
 	w.syslogger.Debug("Connecting to remote", "component", name)
 	opts := call.ClientOptions{
-		Balancer: balancer,
-		Logger:   w.syslogger,
+		Balancer:         balancer,
+		Logger:           w.syslogger,
+		Component:        fullName,
+		OutlierDetection: &call.OutlierDetection{},
+	}
+	if slices.Contains(w.uncompressed, fullName) {
+		opts.CompressionThreshold = -1
 	}
 	conn, err := call.Connect(w.ctx, resolver, opts)
 	if err != nil {
@@ -666,18 +720,70 @@ func (w *RemoteWeavelet) UpdateRoutingInfo(_ context.Context, req *protos.Update
 
 // GetHealth implements controller.GetHealth.
 func (w *RemoteWeavelet) GetHealth(context.Context, *protos.GetHealthRequest) (*protos.GetHealthReply, error) {
-	// Get the health status for all components. For now, we consider a component
-	// healthy iff it has been successfully initialized. In the future, we will
-	// maintain a real-time health for each component.
+	// Get the health status for all components. A component is healthy iff it
+	// has been successfully initialized and the latest call to its Health
+	// method, if any, succeeded.
 	reply := &protos.GetHealthReply{Status: protos.HealthStatus_HEALTHY}
 	for cname, c := range w.componentsByName {
-		if c.implReady.Load() {
+		if !c.implReady.Load() {
+			continue
+		}
+		health := &protos.ComponentHealth{
+			Component: cname,
+			Status:    protos.HealthStatus_HEALTHY,
+		}
+		c.healthMu.Lock()
+		err := c.healthErr
+		c.healthMu.Unlock()
+		if err != nil {
+			health.Status = protos.HealthStatus_UNHEALTHY
+			health.Error = err.Error()
+			reply.Status = protos.HealthStatus_UNHEALTHY
+		} else {
 			reply.HealthyComponents = append(reply.HealthyComponents, cname)
 		}
+		reply.Components = append(reply.Components, health)
 	}
 	return reply, nil
 }
 
+// checkHealth periodically calls the Health method of every initialized
+// component that has one, until the provided context is canceled.
+func (w *RemoteWeavelet) checkHealth(ctx context.Context) {
+	ticker := time.NewTicker(healthInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return
+		}
+
+		for _, c := range w.componentsByName {
+			if !c.implReady.Load() {
+				continue
+			}
+			i, ok := c.impl.(interface{ Health(context.Context) error })
+			if !ok {
+				continue
+			}
+			hctx, cancel := context.WithTimeout(ctx, healthInterval)
+			err := i.Health(hctx)
+			cancel()
+
+			c.healthMu.Lock()
+			changed := (err == nil) != (c.healthErr == nil)
+			c.healthErr = err
+			c.healthMu.Unlock()
+			if changed && err != nil {
+				w.syslogger.Error("Component unhealthy", "component", c.reg.Name, "err", err)
+			} else if changed {
+				w.syslogger.Info("Component healthy again", "component", c.reg.Name)
+			}
+		}
+	}
+}
+
 // GetMetrics implements controller.GetMetrics.
 func (w *RemoteWeavelet) GetMetrics(context.Context, *protos.GetMetricsRequest) (*protos.GetMetricsReply, error) {
 	// TODO(sanjay): The protocol is currently brittle; if we ever lose a set of
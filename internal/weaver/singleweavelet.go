@@ -77,6 +77,7 @@ type SingleWeavelet struct {
 	// Logging, tracing, and metrics.
 	pp     *logging.PrettyPrinter   // pretty printer for logger
 	tracer trace.Tracer             // tracer used by all components
+	traces traces.Exporter          // exports the spans produced by tracer
 	stats  *imetrics.StatsProcessor // metrics aggregator
 
 	// Components and listeners.
@@ -106,7 +107,7 @@ func NewSingleWeavelet(ctx context.Context, regs []*codegen.Registration, opts S
 	// Set up tracer.
 	deploymentId := uuid.New().String()
 	id := uuid.New().String()
-	tracer, err := singleTracer(ctx, config.App.Name, deploymentId, id)
+	tracer, exporter, err := singleTracer(ctx, config.App, deploymentId, id)
 	if err != nil {
 		return nil, err
 	}
@@ -146,6 +147,7 @@ func NewSingleWeavelet(ctx context.Context, regs []*codegen.Registration, opts S
 		createdAt:    time.Now(),
 		pp:           logging.NewPrettyPrinter(colors.Enabled()),
 		tracer:       tracer,
+		traces:       exporter,
 		stats:        imetrics.NewStatsProcessor(),
 		components:   map[string]any{},
 		listeners:    map[string]net.Listener{},
@@ -168,6 +170,9 @@ func NewSingleWeavelet(ctx context.Context, regs []*codegen.Registration, opts S
 				}
 			}
 		}
+		if err := w.Shutdown(context.Background()); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+		}
 		os.Exit(1)
 	}()
 
@@ -208,16 +213,31 @@ func parseSingleConfig(regs []*codegen.Registration, filename, contents string)
 	return config, nil
 }
 
-// singleTracer returns a tracer for single process execution.
-func singleTracer(ctx context.Context, app, deploymentId, id string) (trace.Tracer, error) {
-	traceDB, err := traces.OpenDB(ctx, single.PerfettoFile)
+// singleTracer returns a tracer for single process execution, along with the
+// exporter that exports its spans.
+func singleTracer(ctx context.Context, app *protos.AppConfig, deploymentId, id string) (trace.Tracer, traces.Exporter, error) {
+	exporter, err := traces.NewExporter(ctx, app.Traces, traces.ExporterOptions{
+		App:          app.Name,
+		DeploymentId: deploymentId,
+		DBFile:       single.PerfettoFile,
+	})
 	if err != nil {
-		return nil, fmt.Errorf("cannot open Perfetto database: %w", err)
+		return nil, nil, fmt.Errorf("cannot create trace exporter: %w", err)
 	}
-	exporter := traceio.NewWriter(func(spans *protos.TraceSpans) error {
-		return traceDB.Store(ctx, app, deploymentId, spans)
+	writer := traceio.NewWriter(func(spans *protos.TraceSpans) error {
+		return exporter.Export(ctx, spans)
 	})
-	return tracer(exporter, app, deploymentId, id), nil
+	return tracer(writer, app.Name, deploymentId, id), exporter, nil
+}
+
+// Shutdown exports any buffered trace spans and releases the resources held by
+// the weavelet's trace exporter. The weavelet shouldn't be used after it is
+// shut down.
+func (w *SingleWeavelet) Shutdown(ctx context.Context) error {
+	if err := w.traces.Shutdown(ctx); err != nil {
+		return fmt.Errorf("cannot export trace spans: %w", err)
+	}
+	return nil
 }
 
 // GetIntf implements the Weavelet interface.
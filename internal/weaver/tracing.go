@@ -37,7 +37,6 @@ import (
 //
 // [1] https://github.com/open-telemetry/opentelemetry-go/blob/v1.20.0/semconv/v1.7.0/resource.go#L813
 func tracer(exporter sdktrace.SpanExporter, app, deploymentId, weaveletId string) trace.Tracer {
-	const instrumentationLibrary = "github.com/ServiceWeaver/weaver/serviceweaver"
 	const instrumentationVersion = "0.0.1"
 	tracerProvider := sdktrace.NewTracerProvider(
 		sdktrace.WithBatcher(exporter),
@@ -51,7 +50,7 @@ func tracer(exporter sdktrace.SpanExporter, app, deploymentId, weaveletId string
 			traceio.WeaveletIdTraceKey.String(weaveletId),
 		)),
 	)
-	tracer := tracerProvider.Tracer(instrumentationLibrary, trace.WithInstrumentationVersion(instrumentationVersion))
+	tracer := tracerProvider.Tracer(traceio.InstrumentationLibrary, trace.WithInstrumentationVersion(instrumentationVersion))
 
 	// Set global tracing defaults.
 	otel.SetTracerProvider(tracerProvider)
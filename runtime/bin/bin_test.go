@@ -20,6 +20,7 @@ import (
 	"os/exec"
 	"path/filepath"
 	"sort"
+	"strings"
 	"testing"
 
 	"github.com/ServiceWeaver/weaver/runtime/codegen"
@@ -138,6 +139,49 @@ func TestReadListeners(t *testing.T) {
 	}
 }
 
+func TestReadFingerprints(t *testing.T) {
+	for _, test := range []struct{ os, arch string }{
+		{"linux", "amd64"},
+		{"windows", "amd64"},
+		{"darwin", "arm64"},
+	} {
+		t.Run(fmt.Sprintf("%s/%s", test.os, test.arch), func(t *testing.T) {
+			// Build the binary for os/arch.
+			d := t.TempDir()
+			binary := filepath.Join(d, "bin")
+			cmd := exec.Command("go", "build", "-o", binary, "./testprogram")
+			cmd.Env = append(os.Environ(), "GOOS="+test.os, "GOARCH="+test.arch)
+			if err := cmd.Run(); err != nil {
+				t.Fatal(err)
+			}
+
+			// Read fingerprints.
+			all, err := ReadFingerprints(binary)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			// Ignore the components of the weaver package, which are linked
+			// into every binary.
+			pkg := "github.com/ServiceWeaver/weaver/runtime/bin/testprogram/"
+			var got []codegen.MethodFingerprint
+			for _, f := range all {
+				if strings.HasPrefix(f.Component, pkg) {
+					got = append(got, f)
+				}
+			}
+			b := pkg + "B"
+			want := []codegen.MethodFingerprint{
+				{Component: b, Method: "Add", Fingerprint: codegen.Fingerprint("(int, int) (int)")},
+				{Component: b, Method: "Names", Fingerprint: codegen.Fingerprint("(map[string]bool) ([]string)")},
+			}
+			if diff := cmp.Diff(want, got); diff != "" {
+				t.Fatalf("unexpected fingerprints (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestExtractVersion(t *testing.T) {
 	for _, want := range []version.SemVer{
 		{Major: 4, Minor: 5, Patch: 6},
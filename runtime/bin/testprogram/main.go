@@ -24,7 +24,10 @@ import (
 //go:generate ../../../cmd/weaver/weaver generate
 
 type A interface{}
-type B interface{}
+type B interface {
+	Add(context.Context, int, int) (int, error)
+	Names(context.Context, map[string]bool) ([]string, error)
+}
 type C interface{}
 
 type app struct {
@@ -48,6 +51,16 @@ type b struct {
 	weaver.Implements[B]
 }
 
+func (*b) Add(_ context.Context, x, y int) (int, error) { return x + y, nil }
+
+func (*b) Names(_ context.Context, set map[string]bool) ([]string, error) {
+	var names []string
+	for name := range set {
+		names = append(names, name)
+	}
+	return names, nil
+}
+
 type c struct {
 	weaver.Listener `weaver:"cLis"`
 	weaver.Implements[C]
@@ -5,8 +5,10 @@ package main
 
 import (
 	"context"
+	"errors"
 	"github.com/ServiceWeaver/weaver"
 	"github.com/ServiceWeaver/weaver/runtime/codegen"
+	"go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/trace"
 	"reflect"
 )
@@ -35,16 +37,18 @@ func init() {
 		Impl:      reflect.TypeOf(b{}),
 		Listeners: []string{"Listener"},
 		LocalStubFn: func(impl any, caller string, tracer trace.Tracer) any {
-			return b_local_stub{impl: impl.(B), tracer: tracer}
+			return b_local_stub{impl: impl.(B), tracer: tracer, addMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/runtime/bin/testprogram/B", Method: "Add", Remote: false, Generated: true}), namesMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/runtime/bin/testprogram/B", Method: "Names", Remote: false, Generated: true})}
+		},
+		ClientStubFn: func(stub codegen.Stub, caller string) any {
+			return b_client_stub{stub: stub, addMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/runtime/bin/testprogram/B", Method: "Add", Remote: true, Generated: true}), namesMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/runtime/bin/testprogram/B", Method: "Names", Remote: true, Generated: true})}
 		},
-		ClientStubFn: func(stub codegen.Stub, caller string) any { return b_client_stub{stub: stub} },
 		ServerStubFn: func(impl any, addLoad func(uint64, float64)) codegen.Server {
 			return b_server_stub{impl: impl.(B), addLoad: addLoad}
 		},
 		ReflectStubFn: func(caller func(string, context.Context, []any, []any) error) any {
 			return b_reflect_stub{caller: caller}
 		},
-		RefData: "⟦7551e870:wEaVeRlIsTeNeRs:github.com/ServiceWeaver/weaver/runtime/bin/testprogram/B→Listener⟧\n",
+		RefData: "⟦7551e870:wEaVeRlIsTeNeRs:github.com/ServiceWeaver/weaver/runtime/bin/testprogram/B→Listener⟧\n⟦6035ec6a:wEaVeRfInGeRpRiNt:github.com/ServiceWeaver/weaver/runtime/bin/testprogram/B→Add:da291c07184c703e⟧\n⟦b27a46a4:wEaVeRfInGeRpRiNt:github.com/ServiceWeaver/weaver/runtime/bin/testprogram/B→Names:4f91947de4a13a8d⟧\n",
 	})
 	codegen.Register(codegen.Registration{
 		Name:      "github.com/ServiceWeaver/weaver/runtime/bin/testprogram/C",
@@ -105,13 +109,55 @@ type a_local_stub struct {
 var _ A = (*a_local_stub)(nil)
 
 type b_local_stub struct {
-	impl   B
-	tracer trace.Tracer
+	impl         B
+	tracer       trace.Tracer
+	addMetrics   *codegen.MethodMetrics
+	namesMetrics *codegen.MethodMetrics
 }
 
 // Check that b_local_stub implements the B interface.
 var _ B = (*b_local_stub)(nil)
 
+func (s b_local_stub) Add(ctx context.Context, a0 int, a1 int) (r0 int, err error) {
+	// Update metrics.
+	begin := s.addMetrics.Begin()
+	defer func() { s.addMetrics.End(begin, err != nil, 0, 0) }()
+	span := trace.SpanFromContext(ctx)
+	if span.SpanContext().IsValid() {
+		// Create a child span for this method.
+		ctx, span = s.tracer.Start(ctx, "testprogram.B.Add", trace.WithSpanKind(trace.SpanKindInternal))
+		defer func() {
+			if err != nil {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+			}
+			span.End()
+		}()
+	}
+
+	return s.impl.Add(ctx, a0, a1)
+}
+
+func (s b_local_stub) Names(ctx context.Context, a0 map[string]bool) (r0 []string, err error) {
+	// Update metrics.
+	begin := s.namesMetrics.Begin()
+	defer func() { s.namesMetrics.End(begin, err != nil, 0, 0) }()
+	span := trace.SpanFromContext(ctx)
+	if span.SpanContext().IsValid() {
+		// Create a child span for this method.
+		ctx, span = s.tracer.Start(ctx, "testprogram.B.Names", trace.WithSpanKind(trace.SpanKindInternal))
+		defer func() {
+			if err != nil {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+			}
+			span.End()
+		}()
+	}
+
+	return s.impl.Names(ctx, a0)
+}
+
 type c_local_stub struct {
 	impl   C
 	tracer trace.Tracer
@@ -138,12 +184,123 @@ type a_client_stub struct {
 var _ A = (*a_client_stub)(nil)
 
 type b_client_stub struct {
-	stub codegen.Stub
+	stub         codegen.Stub
+	addMetrics   *codegen.MethodMetrics
+	namesMetrics *codegen.MethodMetrics
 }
 
 // Check that b_client_stub implements the B interface.
 var _ B = (*b_client_stub)(nil)
 
+func (s b_client_stub) Add(ctx context.Context, a0 int, a1 int) (r0 int, err error) {
+	// Update metrics.
+	var requestBytes, replyBytes int
+	begin := s.addMetrics.Begin()
+	defer func() { s.addMetrics.End(begin, err != nil, requestBytes, replyBytes) }()
+
+	span := trace.SpanFromContext(ctx)
+	if span.SpanContext().IsValid() {
+		// Create a child span for this method.
+		ctx, span = s.stub.Tracer().Start(ctx, "testprogram.B.Add", trace.WithSpanKind(trace.SpanKindClient))
+	}
+
+	defer func() {
+		// Catch and return any panics detected during encoding/decoding/rpc.
+		if err == nil {
+			err = codegen.CatchPanics(recover())
+			if err != nil {
+				err = errors.Join(weaver.RemoteCallError, err)
+			}
+		}
+
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+
+	}()
+
+	// Preallocate a buffer of the right size.
+	size := 0
+	size += 8
+	size += 8
+	enc := codegen.NewEncoder()
+	enc.Reset(size)
+
+	// Encode arguments.
+	enc.Int(a0)
+	enc.Int(a1)
+	var shardKey uint64
+
+	// Call the remote method.
+	requestBytes = len(enc.Data())
+	var results []byte
+	results, err = s.stub.Run(ctx, 0, enc.Data(), shardKey)
+	replyBytes = len(results)
+	if err != nil {
+		err = errors.Join(weaver.RemoteCallError, err)
+		return
+	}
+
+	// Decode the results.
+	dec := codegen.NewDecoder(results)
+	r0 = dec.Int()
+	err = dec.Error()
+	return
+}
+
+func (s b_client_stub) Names(ctx context.Context, a0 map[string]bool) (r0 []string, err error) {
+	// Update metrics.
+	var requestBytes, replyBytes int
+	begin := s.namesMetrics.Begin()
+	defer func() { s.namesMetrics.End(begin, err != nil, requestBytes, replyBytes) }()
+
+	span := trace.SpanFromContext(ctx)
+	if span.SpanContext().IsValid() {
+		// Create a child span for this method.
+		ctx, span = s.stub.Tracer().Start(ctx, "testprogram.B.Names", trace.WithSpanKind(trace.SpanKindClient))
+	}
+
+	defer func() {
+		// Catch and return any panics detected during encoding/decoding/rpc.
+		if err == nil {
+			err = codegen.CatchPanics(recover())
+			if err != nil {
+				err = errors.Join(weaver.RemoteCallError, err)
+			}
+		}
+
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+
+	}()
+
+	// Encode arguments.
+	enc := codegen.NewEncoder()
+	serviceweaver_enc_map_string_bool_049fe780(enc, a0)
+	var shardKey uint64
+
+	// Call the remote method.
+	requestBytes = len(enc.Data())
+	var results []byte
+	results, err = s.stub.Run(ctx, 1, enc.Data(), shardKey)
+	replyBytes = len(results)
+	if err != nil {
+		err = errors.Join(weaver.RemoteCallError, err)
+		return
+	}
+
+	// Decode the results.
+	dec := codegen.NewDecoder(results)
+	r0 = serviceweaver_dec_slice_string_4af10117(dec)
+	err = dec.Error()
+	return
+}
+
 type c_client_stub struct {
 	stub codegen.Stub
 }
@@ -161,10 +318,10 @@ var _ weaver.Main = (*main_client_stub)(nil)
 // Note that "weaver generate" will always generate the error message below.
 // Everything is okay. The error message is only relevant if you see it when
 // you run "go build" or "go run".
-var _ codegen.LatestVersion = codegen.Version[[0][24]struct{}](`
+var _ codegen.LatestVersion = codegen.Version[[0][25]struct{}](`
 
 ERROR: You generated this file with 'weaver generate' (devel) (codegen
-version v0.24.0). The generated code is incompatible with the version of the
+version v0.25.0). The generated code is incompatible with the version of the
 github.com/ServiceWeaver/weaver module that you're using. The weaver module
 version can be found in your go.mod file or by running the following command.
 
@@ -210,11 +367,67 @@ var _ codegen.Server = (*b_server_stub)(nil)
 // GetStubFn implements the codegen.Server interface.
 func (s b_server_stub) GetStubFn(method string) func(ctx context.Context, args []byte) ([]byte, error) {
 	switch method {
+	case "Add":
+		return s.add
+	case "Names":
+		return s.names
 	default:
 		return nil
 	}
 }
 
+func (s b_server_stub) add(ctx context.Context, args []byte) (res []byte, err error) {
+	// Catch and return any panics detected during encoding/decoding/rpc.
+	defer func() {
+		if err == nil {
+			err = codegen.CatchPanics(recover())
+		}
+	}()
+
+	// Decode arguments.
+	dec := codegen.NewDecoder(args)
+	var a0 int
+	a0 = dec.Int()
+	var a1 int
+	a1 = dec.Int()
+
+	// TODO(rgrandl): The deferred function above will recover from panics in the
+	// user code: fix this.
+	// Call the local method.
+	r0, appErr := s.impl.Add(ctx, a0, a1)
+
+	// Encode the results.
+	enc := codegen.NewEncoder()
+	enc.Int(r0)
+	enc.Error(appErr)
+	return enc.Data(), nil
+}
+
+func (s b_server_stub) names(ctx context.Context, args []byte) (res []byte, err error) {
+	// Catch and return any panics detected during encoding/decoding/rpc.
+	defer func() {
+		if err == nil {
+			err = codegen.CatchPanics(recover())
+		}
+	}()
+
+	// Decode arguments.
+	dec := codegen.NewDecoder(args)
+	var a0 map[string]bool
+	a0 = serviceweaver_dec_map_string_bool_049fe780(dec)
+
+	// TODO(rgrandl): The deferred function above will recover from panics in the
+	// user code: fix this.
+	// Call the local method.
+	r0, appErr := s.impl.Names(ctx, a0)
+
+	// Encode the results.
+	enc := codegen.NewEncoder()
+	serviceweaver_enc_slice_string_4af10117(enc, r0)
+	enc.Error(appErr)
+	return enc.Data(), nil
+}
+
 type c_server_stub struct {
 	impl    C
 	addLoad func(key uint64, load float64)
@@ -263,6 +476,16 @@ type b_reflect_stub struct {
 // Check that b_reflect_stub implements the B interface.
 var _ B = (*b_reflect_stub)(nil)
 
+func (s b_reflect_stub) Add(ctx context.Context, a0 int, a1 int) (r0 int, err error) {
+	err = s.caller("Add", ctx, []any{a0, a1}, []any{&r0})
+	return
+}
+
+func (s b_reflect_stub) Names(ctx context.Context, a0 map[string]bool) (r0 []string, err error) {
+	err = s.caller("Names", ctx, []any{a0}, []any{&r0})
+	return
+}
+
 type c_reflect_stub struct {
 	caller func(string, context.Context, []any, []any) error
 }
@@ -277,3 +500,55 @@ type main_reflect_stub struct {
 // Check that main_reflect_stub implements the weaver.Main interface.
 var _ weaver.Main = (*main_reflect_stub)(nil)
 
+// Encoding/decoding implementations.
+
+func serviceweaver_enc_map_string_bool_049fe780(enc *codegen.Encoder, arg map[string]bool) {
+	if arg == nil {
+		enc.Len(-1)
+		return
+	}
+	enc.Len(len(arg))
+	for k, v := range arg {
+		enc.String(k)
+		enc.Bool(v)
+	}
+}
+
+func serviceweaver_dec_map_string_bool_049fe780(dec *codegen.Decoder) map[string]bool {
+	n := dec.Len()
+	if n == -1 {
+		return nil
+	}
+	res := make(map[string]bool, n)
+	var k string
+	var v bool
+	for i := 0; i < n; i++ {
+		k = dec.String()
+		v = dec.Bool()
+		res[k] = v
+	}
+	return res
+}
+
+func serviceweaver_enc_slice_string_4af10117(enc *codegen.Encoder, arg []string) {
+	if arg == nil {
+		enc.Len(-1)
+		return
+	}
+	enc.Len(len(arg))
+	for i := 0; i < len(arg); i++ {
+		enc.String(arg[i])
+	}
+}
+
+func serviceweaver_dec_slice_string_4af10117(dec *codegen.Decoder) []string {
+	n := dec.Len()
+	if n == -1 {
+		return nil
+	}
+	res := make([]string, n)
+	for i := 0; i < n; i++ {
+		res[i] = dec.String()
+	}
+	return res
+}
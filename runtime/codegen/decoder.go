@@ -44,11 +44,12 @@ func (d decoderError) Unwrap() error {
 // Decoder deserializes data from a byte slice data in the expected results.
 type Decoder struct {
 	data []byte
+	refs []any // Pointers decoded by DecodeRef, by id.
 }
 
 // NewDecoder instantiates a new Decoder for a given byte slice.
 func NewDecoder(data []byte) *Decoder {
-	return &Decoder{data}
+	return &Decoder{data: data}
 }
 
 // Empty returns true iff all bytes in d have been consumed.
@@ -282,6 +283,71 @@ func (d *Decoder) Interface() any {
 	return result.Interface()
 }
 
+// DecodeInterface decodes an interface value of type T encoded by
+// Encoder.EncodeInterface.
+//
+// NOTE that DecodeInterface is a function, rather than a method, as methods
+// cannot have type parameters.
+func DecodeInterface[T any](d *Decoder) T {
+	var zero T
+	var value any
+	switch tag := d.Uint8(); tag {
+	case nilInterface:
+		return zero
+	case serializedVal:
+		value = d.Interface()
+	case serializedPtr:
+		value = pointee(d.Interface())
+	case nilPointer:
+		key := d.String()
+		typesMu.Lock()
+		t, ok := types[key]
+		typesMu.Unlock()
+		if !ok {
+			panic(makeDecodeError("received value for non-registered type %q", key))
+		}
+		value = reflect.Zero(t).Interface()
+	default:
+		panic(makeDecodeError("invalid interface tag %d", tag))
+	}
+	result, ok := value.(T)
+	if !ok {
+		panic(makeDecodeError("received value of type %T, which is not a %v", value, reflect.TypeOf(&zero).Elem()))
+	}
+	return result
+}
+
+// DecodeRef decodes a pointer encoded by EncodeRef. It returns true if the
+// pointer was not decoded before, in which case the caller must decode the
+// value it points to next.
+//
+// NOTE that this function should be called only in the generated code, which
+// uses it to decode pointers to recursive types.
+func DecodeRef[T any](d *Decoder) (*T, bool) {
+	switch tag := d.Uint8(); tag {
+	case nilRef:
+		return nil, false
+	case newRef:
+		// Note that we record p before its value is decoded, as the value may
+		// refer back to p.
+		p := new(T)
+		d.refs = append(d.refs, p)
+		return p, true
+	case prevRef:
+		id := d.Uint64()
+		if id >= uint64(len(d.refs)) {
+			panic(makeDecodeError("invalid pointer id %d", id))
+		}
+		p, ok := d.refs[id].(*T)
+		if !ok {
+			panic(makeDecodeError("pointer %d has type %T, not %T", id, d.refs[id], p))
+		}
+		return p, false
+	default:
+		panic(makeDecodeError("invalid pointer tag %d", tag))
+	}
+}
+
 // decodedError is an error used for non-serializable decoded errors.
 // It supports Error() by returning the Error() string precomputed at
 // the send. It partially supports Is() by comparing the string
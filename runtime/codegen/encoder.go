@@ -19,6 +19,7 @@ import (
 	"encoding/binary"
 	"fmt"
 	"math"
+	"reflect"
 
 	"google.golang.org/protobuf/proto"
 )
@@ -41,8 +42,9 @@ func (e encoderError) Unwrap() error {
 
 // Encoder serializes data in a byte slice data.
 type Encoder struct {
-	data  []byte    // Contains the serialized arguments.
-	space [100]byte // Prellocated buffer to avoid allocations for small size arguments.
+	data  []byte         // Contains the serialized arguments.
+	space [100]byte      // Prellocated buffer to avoid allocations for small size arguments.
+	refs  map[any]uint64 // Ids of the pointers encoded by EncodeRef.
 }
 
 func NewEncoder() *Encoder {
@@ -62,6 +64,7 @@ func (e *Encoder) Reset(n int) {
 	} else {
 		e.data = make([]byte, 0, n)
 	}
+	e.refs = nil
 }
 
 // makeEncodeError creates and returns an encoder error.
@@ -335,3 +338,95 @@ func (e *Encoder) Interface(value AutoMarshal) {
 	e.String(typeKey(value))
 	value.WeaverMarshal(e)
 }
+
+// Interface value encoding
+//
+// An interface value is encoded as one of:
+//
+// <nilInterface> for a nil interface value.
+//
+// <serializedVal,typeKey,serial> where the value's type is a registered
+// serializable type.
+//
+// <serializedPtr,typeKey,serial> where a pointer to the value's type has been
+// registered as serializable.
+//
+// <nilPointer,typeKey> for a nil pointer of a registered serializable type.
+const (
+	nilInterface  uint8 = 0
+	serializedVal uint8 = 1
+	serializedPtr uint8 = 2
+	nilPointer    uint8 = 3
+)
+
+// EncodeInterface encodes an interface value. The concrete type of value, or
+// a pointer to it, must have been registered using RegisterSerializable.
+func (e *Encoder) EncodeInterface(value any) {
+	if value == nil {
+		e.Uint8(nilInterface)
+		return
+	}
+	registered := func(value any) bool {
+		typesMu.Lock()
+		defer typesMu.Unlock()
+		_, ok := typeKeys[reflect.TypeOf(value)]
+		return ok
+	}
+	if am, ok := value.(AutoMarshal); ok && registered(value) {
+		if v := reflect.ValueOf(value); v.Kind() == reflect.Pointer && v.IsNil() {
+			e.Uint8(nilPointer)
+			e.String(typeKey(value))
+			return
+		}
+		e.Uint8(serializedVal)
+		e.Interface(am)
+		return
+	}
+	if am, ok := pointerTo(value).(AutoMarshal); ok && registered(am) {
+		e.Uint8(serializedPtr)
+		e.Interface(am)
+		return
+	}
+	panic(makeEncodeError("unable to encode value of type %T: not a registered serializable type", value))
+}
+
+// Pointer encoding
+//
+// A pointer encoded by EncodeRef is encoded as one of:
+//
+// <nilRef> for a nil pointer.
+//
+// <newRef,serial> for a pointer that has not been encoded before.
+//
+// <prevRef,id> for a pointer that has been encoded before, where id is the
+// number of distinct pointers encoded before it.
+const (
+	nilRef  uint8 = 0
+	newRef  uint8 = 1
+	prevRef uint8 = 2
+)
+
+// EncodeRef encodes the pointer p such that pointers that alias one another,
+// including cyclic pointers, are decoded as aliases by DecodeRef. EncodeRef
+// returns true if p is not nil and has not been encoded before, in which case
+// the caller must encode *p next.
+//
+// NOTE that this function should be called only in the generated code, which
+// uses it to encode pointers to recursive types.
+func EncodeRef[T any](e *Encoder, p *T) bool {
+	if p == nil {
+		e.Uint8(nilRef)
+		return false
+	}
+	if id, ok := e.refs[p]; ok {
+		e.Uint8(prevRef)
+		e.Uint64(id)
+		return false
+	}
+	if e.refs == nil {
+		e.refs = map[any]uint64{}
+	}
+	e.refs[p] = uint64(len(e.refs))
+	e.Uint8(newRef)
+	return true
+}
@@ -139,7 +139,7 @@ func TestErrorDecUnableToRead(t *testing.T) {
 		enc := newEncoder()
 		enc.Int(12345)
 
-		dec := Decoder{enc.data}
+		dec := Decoder{data: enc.data}
 		dec.Int()
 		dec.Bool()
 	})
@@ -156,7 +156,7 @@ func TestErrorUnableToDecBool(t *testing.T) {
 		enc := newEncoder()
 		enc.Int(123)
 
-		dec := Decoder{enc.data}
+		dec := Decoder{data: enc.data}
 		dec.Bool()
 	})
 	if !strings.Contains(err.Error(), "unable to decode bool") {
@@ -172,7 +172,7 @@ func TestErrorUnableToDecBytes(t *testing.T) {
 		enc := newEncoder()
 		enc.Int(-10)
 
-		dec := Decoder{enc.data}
+		dec := Decoder{data: enc.data}
 		dec.Bytes()
 	})
 	if !strings.Contains(err.Error(), "unable to decode bytes; expected length") {
@@ -272,6 +272,108 @@ func TestErrorValues(t *testing.T) {
 	}
 }
 
+// node is a manually serializable recursive type. Its WeaverMarshal and
+// WeaverUnmarshal methods mirror the code generated for recursive types.
+type node struct {
+	val  int
+	next *node
+}
+
+func (n *node) WeaverMarshal(e *Encoder) {
+	e.Int(n.val)
+	if EncodeRef(e, n.next) {
+		n.next.WeaverMarshal(e)
+	}
+}
+
+func (n *node) WeaverUnmarshal(d *Decoder) {
+	n.val = d.Int()
+	var ok bool
+	if n.next, ok = DecodeRef[node](d); ok {
+		n.next.WeaverUnmarshal(d)
+	}
+}
+
+func TestRefs(t *testing.T) {
+	// Create a cycle a -> b -> c -> a.
+	a, b, c := &node{val: 1}, &node{val: 2}, &node{val: 3}
+	a.next, b.next, c.next = b, c, a
+
+	enc := newEncoder()
+	if EncodeRef(&enc, a) {
+		a.WeaverMarshal(&enc)
+	}
+	dec := Decoder{data: enc.data}
+	got, ok := DecodeRef[node](&dec)
+	if !ok {
+		t.Fatal("DecodeRef: got false, want true")
+	}
+	got.WeaverUnmarshal(&dec)
+	if !dec.Empty() {
+		t.Fatalf("leftover bytes in decoder")
+	}
+
+	for i, want := range []int{1, 2, 3} {
+		n := got
+		for j := 0; j < i; j++ {
+			n = n.next
+		}
+		if n.val != want {
+			t.Errorf("node %d: got %d, want %d", i, n.val, want)
+		}
+	}
+	if got.next.next.next != got {
+		t.Errorf("cycle not preserved")
+	}
+}
+
+type shape interface{ area() float64 }
+
+type square struct{ side float64 }
+
+func (s square) area() float64               { return s.side * s.side }
+func (s *square) WeaverMarshal(e *Encoder)   { e.Float64(s.side) }
+func (s *square) WeaverUnmarshal(d *Decoder) { s.side = d.Float64() }
+
+func init() {
+	RegisterSerializable[*square]()
+}
+
+func TestInterfaceValues(t *testing.T) {
+	for _, test := range []struct {
+		name string
+		val  shape
+	}{
+		{"nil", nil},
+		{"value", square{2}},
+		{"pointer", &square{3}},
+		{"nil pointer", (*square)(nil)},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			enc := newEncoder()
+			enc.EncodeInterface(test.val)
+			dec := Decoder{data: enc.data}
+			got := DecodeInterface[shape](&dec)
+			if !dec.Empty() {
+				t.Fatalf("leftover bytes in decoder")
+			}
+			if !reflect.DeepEqual(got, test.val) {
+				t.Fatalf("got %#v, want %#v", got, test.val)
+			}
+		})
+	}
+}
+
+func TestUnregisteredInterfaceValue(t *testing.T) {
+	defer func() {
+		if err := recover(); err == nil {
+			t.Fatal("unexpected success encoding an unregistered type")
+		}
+	}()
+	enc := newEncoder()
+	enc.EncodeInterface(42)
+}
+
 func TestCyclicError(t *testing.T) {
 	// Special test for cyclic errors since errors.Is etc. can get
 	// into an infinite loop on cycles.
@@ -0,0 +1,99 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package codegen
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"regexp"
+	"sort"
+)
+
+// The wire format of every component method, i.e. the serialized form of its
+// arguments and results, is embedded in the generated binary as a specially
+// formatted fingerprint string. Comparing the fingerprints extracted from two
+// binaries reveals the methods whose calls cannot be exchanged between them.
+//
+// The fingerprint of a method is represented by a string fragment that looks
+// like:
+// ⟦checksum:wEaVeRfInGeRpRiNt:component→method:fingerprint⟧
+//
+// checksum is the first 8 bytes of the hex encoding of the SHA-256 of
+// the string "wEaVeRfInGeRpRiNt:component→method:fingerprint"; component is
+// the fully qualified component type name; method is the method name; and
+// fingerprint is the hex encoding of a hash of the method's wire format.
+
+// MakeFingerprintString returns a string that should be emitted into generated
+// code to represent the fingerprint of the provided component method.
+func MakeFingerprintString(component, method, fingerprint string) string {
+	return fmt.Sprintf("⟦%s:wEaVeRfInGeRpRiNt:%s→%s:%s⟧\n",
+		checksumFingerprint(component, method, fingerprint), component, method, fingerprint)
+}
+
+// Fingerprint returns the fingerprint of a method with the provided wire
+// format description.
+func Fingerprint(description string) string {
+	sum := sha256.Sum256([]byte(description))
+	return fmt.Sprintf("%0x", sum)[:16]
+}
+
+// MethodFingerprint is the fingerprint of a component method.
+type MethodFingerprint struct {
+	// Fully qualified component type name, e.g.,
+	//   github.com/ServiceWeaver/weaver/Main.
+	Component string
+
+	// The method name.
+	Method string
+
+	// The fingerprint of the method's wire format. Two versions of a method
+	// with different fingerprints cannot call one another.
+	Fingerprint string
+}
+
+// ExtractFingerprints returns the method fingerprints encoded using
+// MakeFingerprintString() in data.
+func ExtractFingerprints(data []byte) []MethodFingerprint {
+	var results []MethodFingerprint
+	re := regexp.MustCompile(`⟦([0-9a-fA-F]+):wEaVeRfInGeRpRiNt:([a-zA-Z0-9\-.~_/]*?)→([\p{L}\p{Nd}_]+):([0-9a-fA-F]+)⟧`)
+	for _, m := range re.FindAllSubmatch(data, -1) {
+		if len(m) != 5 {
+			continue
+		}
+		sum, component, method, fingerprint := string(m[1]), string(m[2]), string(m[3]), string(m[4])
+		if sum != checksumFingerprint(component, method, fingerprint) {
+			continue
+		}
+		results = append(results, MethodFingerprint{
+			Component:   component,
+			Method:      method,
+			Fingerprint: fingerprint,
+		})
+	}
+	// Generate a stable list.
+	sort.Slice(results, func(i, j int) bool {
+		if a, b := results[i].Component, results[j].Component; a != b {
+			return a < b
+		}
+		return results[i].Method < results[j].Method
+	})
+	return results
+}
+
+func checksumFingerprint(component, method, fingerprint string) string {
+	str := fmt.Sprintf("wEaVeRfInGeRpRiNt:%s→%s:%s", component, method, fingerprint)
+	sum := sha256.Sum256([]byte(str))
+	return fmt.Sprintf("%0x", sum)[:8]
+}
@@ -0,0 +1,41 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package codegen_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ServiceWeaver/weaver/runtime/codegen"
+)
+
+func TestFingerprints(t *testing.T) {
+	b := codegen.MakeFingerprintString("b", "Get", "0123456789abcdef")
+	a2 := codegen.MakeFingerprintString("a/x", "Put", "fedcba9876543210")
+	a1 := codegen.MakeFingerprintString("a/x", "Get", "00112233aabbccdd")
+	corrupt := "⟦00000000:wEaVeRfInGeRpRiNt:c→Get:0123456789abcdef⟧\n"
+	data := b + a2 + a1 + corrupt
+	t.Log(data)
+
+	got := codegen.ExtractFingerprints([]byte(data))
+	want := []codegen.MethodFingerprint{
+		{"a/x", "Get", "00112233aabbccdd"},
+		{"a/x", "Put", "fedcba9876543210"},
+		{"b", "Get", "0123456789abcdef"},
+	}
+	if !reflect.DeepEqual(want, got) {
+		t.Errorf("ExtractFingerprints: expecting %v, got %v", want, got)
+	}
+}
@@ -46,6 +46,18 @@ var (
 		"Number of bytes in Service Weaver component method replies",
 		imetrics.GeneratedBuckets,
 	)
+	methodRejections = metrics.NewCounterMap[RejectionLabels](
+		imetrics.MethodRejectionsName,
+		"Count of Service Weaver component method invocations rejected because the component was overloaded",
+	)
+	compressionRawBytes = metrics.NewCounterMap[CompressionLabels](
+		imetrics.CompressionRawBytesName,
+		"Number of bytes in compressed Service Weaver component method requests and replies, before compression",
+	)
+	compressionCompressedBytes = metrics.NewCounterMap[CompressionLabels](
+		imetrics.CompressionCompressedBytesName,
+		"Number of bytes in compressed Service Weaver component method requests and replies, after compression",
+	)
 )
 
 type MethodLabels struct {
@@ -56,6 +68,21 @@ type MethodLabels struct {
 	Generated bool   `weaver:"serviceweaver_generated"` // Is this an autogenerated metric?
 }
 
+// RejectionLabels are the labels of the rejected method invocations metric.
+// Invocations are rejected by the callee, which doesn't know the calling
+// component.
+type RejectionLabels struct {
+	Component string // full callee component name
+	Method    string // callee component method's name
+	Generated bool   `weaver:"serviceweaver_generated"` // Is this an autogenerated metric?
+}
+
+// CompressionLabels are the labels of the compressed bytes metrics.
+type CompressionLabels struct {
+	Component string // full callee component name
+	Generated bool   `weaver:"serviceweaver_generated"` // Is this an autogenerated metric?
+}
+
 // MethodMetrics contains metrics for a single Service Weaver component method.
 type MethodMetrics struct {
 	remote       bool
@@ -102,3 +129,15 @@ func (m *MethodMetrics) End(h MethodCallHandle, failed bool, requestBytes, reply
 		m.bytesReply.Put(float64(replyBytes))
 	}
 }
+
+// MethodRejectionsFor returns the count of rejected invocations of the
+// specified method.
+func MethodRejectionsFor(labels RejectionLabels) *metrics.Counter {
+	return methodRejections.Get(labels)
+}
+
+// CompressedBytesFor returns the number of bytes in compressed requests to,
+// and replies from, the specified component, before and after compression.
+func CompressedBytesFor(labels CompressionLabels) (raw, compressed *metrics.Counter) {
+	return compressionRawBytes.Get(labels), compressionCompressedBytes.Get(labels)
+}
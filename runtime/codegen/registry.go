@@ -21,6 +21,7 @@ import (
 	"reflect"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/ServiceWeaver/weaver/internal/config"
 	"github.com/ServiceWeaver/weaver/runtime"
@@ -59,12 +60,17 @@ type registry struct {
 
 // Registration is the configuration needed to register a Service Weaver component.
 type Registration struct {
-	Name      string       // full package-prefixed component name
-	Iface     reflect.Type // interface type for the component
-	Impl      reflect.Type // implementation type (struct)
-	Routed    bool         // True if calls to this component should be routed
-	Listeners []string     // the names of any weaver.Listeners
-	NoRetry   []int        // indices of methods that should not be retried
+	Name       string       // full package-prefixed component name
+	Iface      reflect.Type // interface type for the component
+	Impl       reflect.Type // implementation type (struct)
+	Routed     bool         // True if calls to this component should be routed
+	Listeners  []string     // the names of any weaver.Listeners
+	NoRetry    []int        // indices of methods that should not be retried
+	Idempotent []int        // indices of methods that are idempotent
+
+	// Options holds the options of the methods configured with a
+	// weaver.MethodOptions, by method index.
+	Options map[int]MethodOptions
 
 	// Functions that return different types of stubs.
 	LocalStubFn   func(impl any, caller string, tracer trace.Tracer) any
@@ -77,6 +83,16 @@ type Registration struct {
 	RefData string
 }
 
+// MethodOptions configures the calls to a component method. See
+// weaver.MethodOptions for details.
+type MethodOptions struct {
+	Timeout         time.Duration // bounds the duration of a call, if positive
+	Attempts        int           // maximum number of attempts, if positive
+	Backoff         time.Duration // initial delay between attempts, if positive
+	Hedge           time.Duration // delay before a hedged attempt, if positive
+	HedgePercentile int           // latency percentile used as the Hedge delay, if positive
+}
+
 // register registers a Service Weaver component. If the registry's close method was
 // previously called, Register will fail and return a non-nil error.
 func (r *registry) register(reg Registration) error {
@@ -0,0 +1,168 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package codegen
+
+import (
+	"context"
+	"errors"
+	"io"
+)
+
+// A StreamConn carries the serialized elements of the streams passed to and
+// returned by a streaming method call. A StreamConn has an outgoing half
+// (Send and CloseSend) and an incoming half (Recv and CloseRecv).
+type StreamConn interface {
+	// Send sends a serialized element on the outgoing half of the stream. It
+	// blocks until the receiver has room for the element, and returns
+	// io.ErrClosedPipe if the receiver is no longer accepting elements.
+	Send(ctx context.Context, msg []byte) error
+
+	// CloseSend closes the outgoing half of the stream. If err is nil, the
+	// receiver observes io.EOF after receiving all sent elements. Otherwise,
+	// the receiver observes err.
+	CloseSend(err error) error
+
+	// Recv receives a serialized element from the incoming half of the
+	// stream. It returns io.EOF when the sender has closed the stream.
+	Recv(ctx context.Context) ([]byte, error)
+
+	// CloseRecv closes the incoming half of the stream, informing the
+	// sender that no more elements will be received.
+	CloseRecv()
+
+	// Done returns a channel that is closed when the call that owns the
+	// streams has finished.
+	Done() <-chan struct{}
+}
+
+// A ClientStream is the client side of a streaming method call.
+type ClientStream interface {
+	StreamConn
+
+	// Reply blocks until the method's (non-stream) results are available and
+	// returns them.
+	Reply() ([]byte, error)
+}
+
+// StreamSource is the receiving end of a stream. weaver.Stream[T] satisfies
+// StreamSource[T].
+type StreamSource[T any] interface {
+	Recv(ctx context.Context) (T, error)
+	Close() error
+}
+
+type streamConnKey struct{}
+
+// WithStreamConn returns a context that carries the provided StreamConn. It
+// is used to pass the StreamConn of a streaming call to the server stub.
+func WithStreamConn(ctx context.Context, conn StreamConn) context.Context {
+	return context.WithValue(ctx, streamConnKey{}, conn)
+}
+
+// ServerStream returns the StreamConn stored in ctx by WithStreamConn.
+func ServerStream(ctx context.Context) (StreamConn, error) {
+	conn, ok := ctx.Value(streamConnKey{}).(StreamConn)
+	if !ok {
+		return nil, errors.New("streaming method invoked without a stream")
+	}
+	return conn, nil
+}
+
+// StreamReader is a StreamSource[T] that decodes the elements received over a
+// StreamConn.
+type StreamReader[T any] struct {
+	conn   StreamConn
+	decode func(*Decoder) T
+}
+
+var _ StreamSource[int] = &StreamReader[int]{}
+
+// NewStreamReader returns a StreamReader that receives elements from conn and
+// decodes them using decode.
+func NewStreamReader[T any](conn StreamConn, decode func(*Decoder) T) *StreamReader[T] {
+	return &StreamReader[T]{conn: conn, decode: decode}
+}
+
+// Recv receives the next element of the stream.
+func (r *StreamReader[T]) Recv(ctx context.Context) (value T, err error) {
+	msg, err := r.conn.Recv(ctx)
+	if err != nil {
+		return value, err
+	}
+	defer func() {
+		if perr := CatchPanics(recover()); perr != nil {
+			err = perr
+		}
+	}()
+	return r.decode(NewDecoder(msg)), nil
+}
+
+// Close stops receiving elements from the stream.
+func (r *StreamReader[T]) Close() error {
+	r.conn.CloseRecv()
+	return nil
+}
+
+// SendStream sends every element received from src over conn, encoding each
+// element using encode. When src is exhausted, SendStream closes the outgoing
+// half of conn, forwarding any error returned by src. If the receiver stops
+// accepting elements, or the call finishes, SendStream closes src.
+//
+// A nil src is treated as an empty stream.
+func SendStream[T any](ctx context.Context, conn StreamConn, src StreamSource[T], encode func(*Encoder, T)) {
+	if src == nil {
+		conn.CloseSend(nil)
+		return
+	}
+	defer src.Close()
+
+	// Stop reading from src when the call finishes.
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go func() {
+		select {
+		case <-conn.Done():
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	for {
+		value, err := src.Recv(ctx)
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				err = nil
+			}
+			conn.CloseSend(err)
+			return
+		}
+		if err := sendElement(ctx, conn, value, encode); err != nil {
+			return
+		}
+	}
+}
+
+// sendElement encodes and sends a single stream element.
+func sendElement[T any](ctx context.Context, conn StreamConn, value T, encode func(*Encoder, T)) (err error) {
+	defer func() {
+		if perr := CatchPanics(recover()); perr != nil {
+			conn.CloseSend(perr)
+			err = perr
+		}
+	}()
+	enc := NewEncoder()
+	encode(enc, value)
+	return conn.Send(ctx, enc.Data())
+}
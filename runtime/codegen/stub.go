@@ -35,6 +35,13 @@ type Stub interface {
 	// serialized arguments and results, respectively. shardKey is the shard
 	// key for routed components, and 0 otherwise.
 	Run(ctx context.Context, method int, args []byte, shardKey uint64) (results []byte, err error)
+
+	// Stream starts a call of the provided streaming method with the provided
+	// serialized (non-stream) arguments. The elements of the method's
+	// argument and result streams are sent and received over the returned
+	// ClientStream, and the method's remaining results are returned by its
+	// Reply method. Streaming calls are never retried.
+	Stream(ctx context.Context, method int, args []byte, shardKey uint64) (ClientStream, error)
 }
 
 // A Server allows a Service Weaver component in one process to receive and execute
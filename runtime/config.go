@@ -100,15 +100,39 @@ func extractApp(file string, config *protos.AppConfig) error {
 	const appKey = "github.com/ServiceWeaver/weaver"
 	const shortAppKey = "serviceweaver"
 
+	// traceExporter holds the data for a trace exporter in the TOML config.
+	// It matches the contents of the TraceExporter proto.
+	type traceExporter struct {
+		Kind       string
+		Endpoint   string
+		Insecure   bool
+		Headers    map[string]string
+		BatchSize  int32 `toml:"batch_size"`
+		BufferSize int32 `toml:"buffer_size"`
+	}
+
+	// concurrencyLimit holds the data for a concurrency limit in the TOML
+	// config. It matches the contents of the ConcurrencyLimit proto.
+	type concurrencyLimit struct {
+		MaxConcurrent int32 `toml:"max_concurrent"`
+		MaxQueue      int32 `toml:"max_queue"`
+		Adaptive      bool
+	}
+
 	// appConfig holds the data from under appKey in the TOML config.
 	// It matches the contents of the Config proto.
 	type appConfig struct {
-		Name     string
-		Binary   string
-		Args     []string
-		Env      []string
-		Colocate [][]string
-		Rollout  time.Duration
+		Name         string
+		Binary       string
+		Args         []string
+		Env          []string
+		Colocate     [][]string
+		Rollout      time.Duration
+		Traces       []traceExporter
+		Balancers    map[string]string
+		Uncompressed []string
+
+		ConcurrencyLimits map[string]concurrencyLimit `toml:"concurrency_limits"`
 	}
 
 	parsed := &appConfig{}
@@ -122,10 +146,32 @@ func extractApp(file string, config *protos.AppConfig) error {
 	config.Args = parsed.Args
 	config.Env = parsed.Env
 	config.RolloutNanos = int64(parsed.Rollout)
+	config.Balancers = parsed.Balancers
+	config.Uncompressed = parsed.Uncompressed
 	for _, colocate := range parsed.Colocate {
 		group := &protos.ComponentGroup{Components: colocate}
 		config.Colocate = append(config.Colocate, group)
 	}
+	for name, l := range parsed.ConcurrencyLimits {
+		if config.ConcurrencyLimits == nil {
+			config.ConcurrencyLimits = map[string]*protos.ConcurrencyLimit{}
+		}
+		config.ConcurrencyLimits[name] = &protos.ConcurrencyLimit{
+			MaxConcurrent: l.MaxConcurrent,
+			MaxQueue:      l.MaxQueue,
+			Adaptive:      l.Adaptive,
+		}
+	}
+	for _, t := range parsed.Traces {
+		config.Traces = append(config.Traces, &protos.TraceExporter{
+			Kind:       t.Kind,
+			Endpoint:   t.Endpoint,
+			Insecure:   t.Insecure,
+			Headers:    t.Headers,
+			BatchSize:  t.BatchSize,
+			BufferSize: t.BufferSize,
+		})
+	}
 
 	// Canonicalize the config.
 	if err := canonicalizeConfig(config, filepath.Dir(file)); err != nil {
@@ -163,6 +209,62 @@ func canonicalizeConfig(c *protos.AppConfig, dir string) error {
 	if err := checkSameProcess(c); err != nil {
 		return err
 	}
+
+	// Validate the trace exporters.
+	if err := checkTraces(c); err != nil {
+		return err
+	}
+
+	// Validate the balancers.
+	if err := checkBalancers(c); err != nil {
+		return err
+	}
+
+	// Validate the concurrency limits.
+	if err := checkConcurrencyLimits(c); err != nil {
+		return err
+	}
+	return nil
+}
+
+// checkConcurrencyLimits checks that the concurrency_limits entry is valid.
+func checkConcurrencyLimits(c *protos.AppConfig) error {
+	for name, l := range c.ConcurrencyLimits {
+		if l.MaxConcurrent <= 0 {
+			return fmt.Errorf("concurrency limit %q: non-positive max_concurrent %d", name, l.MaxConcurrent)
+		}
+		if l.MaxQueue < 0 {
+			return fmt.Errorf("concurrency limit %q: negative max_queue %d", name, l.MaxQueue)
+		}
+	}
+	return nil
+}
+
+// checkBalancers checks that the balancers entry is valid.
+func checkBalancers(c *protos.AppConfig) error {
+	for component, balancer := range c.Balancers {
+		switch balancer {
+		case "round_robin", "p2c", "least_outstanding":
+		default:
+			return fmt.Errorf("component %q: unknown balancer %q; want %q, %q, or %q", component, balancer, "round_robin", "p2c", "least_outstanding")
+		}
+	}
+	return nil
+}
+
+// checkTraces checks that the traces entry is valid.
+func checkTraces(c *protos.AppConfig) error {
+	for _, t := range c.Traces {
+		if t.Kind == "" {
+			return fmt.Errorf("trace exporter with no kind")
+		}
+		if t.BatchSize < 0 {
+			return fmt.Errorf("trace exporter %q: negative batch_size %d", t.Kind, t.BatchSize)
+		}
+		if t.BufferSize < 0 {
+			return fmt.Errorf("trace exporter %q: negative buffer_size %d", t.Kind, t.BufferSize)
+		}
+	}
 	return nil
 }
 
@@ -22,7 +22,9 @@ import (
 
 	"github.com/ServiceWeaver/weaver/runtime"
 	"github.com/ServiceWeaver/weaver/runtime/codegen"
+	"github.com/ServiceWeaver/weaver/runtime/protos"
 	"github.com/google/go-cmp/cmp"
+	"google.golang.org/protobuf/testing/protocmp"
 )
 
 func TestBinaryPath(t *testing.T) {
@@ -57,6 +59,58 @@ func TestBinaryPath(t *testing.T) {
 	}
 }
 
+func TestBalancers(t *testing.T) {
+	const config = `
+[serviceweaver]
+balancers = { "github.com/example/app/Cache" = "p2c", "github.com/example/app/Store" = "least_outstanding" }
+`
+	cfg, err := runtime.ParseConfig("weaver.toml", config, codegen.ComponentConfigValidator)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]string{
+		"github.com/example/app/Cache": "p2c",
+		"github.com/example/app/Store": "least_outstanding",
+	}
+	if diff := cmp.Diff(want, cfg.Balancers); diff != "" {
+		t.Fatalf("balancers (-want +got):\n%s", diff)
+	}
+}
+
+func TestConcurrencyLimits(t *testing.T) {
+	const config = `
+[serviceweaver.concurrency_limits]
+"github.com/example/app/Cache" = { max_concurrent = 100, max_queue = 1000 }
+"github.com/example/app/Cache.Get" = { max_concurrent = 10, adaptive = true }
+`
+	cfg, err := runtime.ParseConfig("weaver.toml", config, codegen.ComponentConfigValidator)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]*protos.ConcurrencyLimit{
+		"github.com/example/app/Cache":     {MaxConcurrent: 100, MaxQueue: 1000},
+		"github.com/example/app/Cache.Get": {MaxConcurrent: 10, Adaptive: true},
+	}
+	if diff := cmp.Diff(want, cfg.ConcurrencyLimits, protocmp.Transform()); diff != "" {
+		t.Fatalf("concurrency limits (-want +got):\n%s", diff)
+	}
+}
+
+func TestUncompressed(t *testing.T) {
+	const config = `
+[serviceweaver]
+uncompressed = ["github.com/example/app/Cache"]
+`
+	cfg, err := runtime.ParseConfig("weaver.toml", config, codegen.ComponentConfigValidator)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"github.com/example/app/Cache"}
+	if diff := cmp.Diff(want, cfg.Uncompressed); diff != "" {
+		t.Fatalf("uncompressed (-want +got):\n%s", diff)
+	}
+}
+
 func TestParseConfigSection(t *testing.T) {
 	type section struct {
 		Foo string
@@ -102,6 +156,42 @@ func TestParseConfigSection(t *testing.T) {
 	}
 }
 
+func TestTraces(t *testing.T) {
+	const config = `
+[serviceweaver]
+binary = "/tmp/foo"
+
+[[serviceweaver.traces]]
+kind = "sqlite"
+
+[[serviceweaver.traces]]
+kind = "otlp-grpc"
+endpoint = "localhost:4317"
+insecure = true
+headers = { api-key = "secret" }
+batch_size = 100
+buffer_size = 1000
+`
+	cfg, err := runtime.ParseConfig("weaver.toml", config, codegen.ComponentConfigValidator)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []*protos.TraceExporter{
+		{Kind: "sqlite"},
+		{
+			Kind:       "otlp-grpc",
+			Endpoint:   "localhost:4317",
+			Insecure:   true,
+			Headers:    map[string]string{"api-key": "secret"},
+			BatchSize:  100,
+			BufferSize: 1000,
+		},
+	}
+	if diff := cmp.Diff(want, cfg.Traces, protocmp.Transform()); diff != "" {
+		t.Fatalf("traces (-want +got):\n%s", diff)
+	}
+}
+
 func TestConfigErrors(t *testing.T) {
 	type testCase struct {
 		name          string
@@ -152,6 +242,46 @@ rollout = "hello"
 `,
 			expectedError: "invalid duration",
 		},
+		{
+			name: "trace exporter without kind",
+			cfg: `
+[serviceweaver]
+traces = [{ endpoint = "localhost:4317" }]
+`,
+			expectedError: "no kind",
+		},
+		{
+			name: "negative trace buffer size",
+			cfg: `
+[serviceweaver]
+traces = [{ kind = "stdout", buffer_size = -1 }]
+`,
+			expectedError: "negative buffer_size",
+		},
+		{
+			name: "unknown balancer",
+			cfg: `
+[serviceweaver]
+balancers = { "github.com/example/app/Cache" = "random" }
+`,
+			expectedError: "unknown balancer",
+		},
+		{
+			name: "zero max concurrent calls",
+			cfg: `
+[serviceweaver]
+concurrency_limits = { "github.com/example/app/Cache" = { max_queue = 10 } }
+`,
+			expectedError: "non-positive max_concurrent",
+		},
+		{
+			name: "negative max queue",
+			cfg: `
+[serviceweaver]
+concurrency_limits = { "github.com/example/app/Cache" = { max_concurrent = 10, max_queue = -1 } }
+`,
+			expectedError: "negative max_queue",
+		},
 	} {
 		t.Run(c.name, func(t *testing.T) {
 			_, err := runtime.ParseConfig("weaver.toml", c.cfg, codegen.ComponentConfigValidator)
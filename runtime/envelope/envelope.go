@@ -19,6 +19,7 @@ package envelope
 import (
 	"bufio"
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"log/slog"
@@ -207,7 +208,10 @@ func NewEnvelope(ctx context.Context, wlet *protos.WeaveletArgs, config *protos.
 	}
 
 	reply, err := controller.InitWeavelet(e.ctx, &protos.InitWeaveletRequest{
-		Sections: config.Sections,
+		Sections:          config.Sections,
+		Balancers:         config.Balancers,
+		ConcurrencyLimits: config.ConcurrencyLimits,
+		Uncompressed:      config.Uncompressed,
 	})
 	if err != nil {
 		return nil, err
@@ -229,9 +233,10 @@ func (e *Envelope) WeaveletControl() control.WeaveletControl { return e.controll
 
 // Serve accepts incoming messages from the weavelet. RPC requests are handled
 // serially in the order they are received. Serve blocks until the connection
-// terminates, returning the error that caused it to terminate. You can cancel
-// the connection by cancelling the context passed to [NewEnvelope]. This
-// method never returns a non-nil error.
+// terminates, returning the error that caused it to terminate. If the
+// weavelet exits on its own, Serve returns the weavelet's exit error (e.g.,
+// an *exec.ExitError), which is nil if the weavelet exited cleanly. You can
+// cancel the connection by cancelling the context passed to [NewEnvelope].
 func (e *Envelope) Serve(h EnvelopeHandler) error {
 	// Cleanup when we are done with the envelope.
 	if e.tmpDirOwned {
@@ -292,7 +297,12 @@ func (e *Envelope) Serve(h EnvelopeHandler) error {
 	// Wait for the weavelet command to finish. This needs to be done after
 	// we're done reading from stdout/stderr pipes, per comments on
 	// exec.Cmd.StdoutPipe and exec.Cmd.StderrPipe.
-	stop(e.child.Wait())
+	//
+	// If the weavelet exited on its own, report its exit status rather than
+	// the end of its stdout or stderr.
+	if err := e.child.Wait(); errors.Is(stopErr, io.EOF) {
+		stopErr = err
+	}
 
 	return stopErr
 }
@@ -144,9 +144,14 @@ func (MetricType) EnumDescriptor() ([]byte, []int) {
 type ProfileType int32
 
 const (
-	ProfileType_Unspecified ProfileType = 0
-	ProfileType_Heap        ProfileType = 1
-	ProfileType_CPU         ProfileType = 2
+	ProfileType_Unspecified  ProfileType = 0
+	ProfileType_Heap         ProfileType = 1
+	ProfileType_CPU          ProfileType = 2
+	ProfileType_Goroutine    ProfileType = 3 // stack traces of all current goroutines
+	ProfileType_Mutex        ProfileType = 4 // stack traces of holders of contended mutexes
+	ProfileType_Block        ProfileType = 5 // stack traces that led to blocking on sync primitives
+	ProfileType_Allocs       ProfileType = 6 // a sampling of all past memory allocations
+	ProfileType_ThreadCreate ProfileType = 7 // stack traces that led to the creation of OS threads
 )
 
 // Enum value maps for ProfileType.
@@ -155,11 +160,21 @@ var (
 		0: "Unspecified",
 		1: "Heap",
 		2: "CPU",
+		3: "Goroutine",
+		4: "Mutex",
+		5: "Block",
+		6: "Allocs",
+		7: "ThreadCreate",
 	}
 	ProfileType_value = map[string]int32{
-		"Unspecified": 0,
-		"Heap":        1,
-		"CPU":         2,
+		"Unspecified":  0,
+		"Heap":         1,
+		"CPU":          2,
+		"Goroutine":    3,
+		"Mutex":        4,
+		"Block":        5,
+		"Allocs":       6,
+		"ThreadCreate": 7,
 	}
 )
 
@@ -246,7 +261,7 @@ func (x Span_Kind) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use Span_Kind.Descriptor instead.
 func (Span_Kind) EnumDescriptor() ([]byte, []int) {
-	return file_runtime_protos_runtime_proto_rawDescGZIP(), []int{38, 0}
+	return file_runtime_protos_runtime_proto_rawDescGZIP(), []int{39, 0}
 }
 
 // Type describes the type of the value.
@@ -314,7 +329,7 @@ func (x Span_Attribute_Value_Type) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use Span_Attribute_Value_Type.Descriptor instead.
 func (Span_Attribute_Value_Type) EnumDescriptor() ([]byte, []int) {
-	return file_runtime_protos_runtime_proto_rawDescGZIP(), []int{38, 0, 0, 0}
+	return file_runtime_protos_runtime_proto_rawDescGZIP(), []int{39, 0, 0, 0}
 }
 
 type Span_Status_Code int32
@@ -363,7 +378,7 @@ func (x Span_Status_Code) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use Span_Status_Code.Descriptor instead.
 func (Span_Status_Code) EnumDescriptor() ([]byte, []int) {
-	return file_runtime_protos_runtime_proto_rawDescGZIP(), []int{38, 3, 0}
+	return file_runtime_protos_runtime_proto_rawDescGZIP(), []int{39, 3, 0}
 }
 
 // WeaveletArgs is the information provided by an envelope to a weavelet when
@@ -488,7 +503,11 @@ type InitWeaveletRequest struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Sections map[string]string `protobuf:"bytes,1,rep,name=sections,proto3" json:"sections,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"` // See AppConfig.Sections.
+	Sections  map[string]string `protobuf:"bytes,1,rep,name=sections,proto3" json:"sections,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`   // See AppConfig.Sections.
+	Balancers map[string]string `protobuf:"bytes,2,rep,name=balancers,proto3" json:"balancers,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"` // See AppConfig.Balancers.
+	// See AppConfig.ConcurrencyLimits.
+	ConcurrencyLimits map[string]*ConcurrencyLimit `protobuf:"bytes,3,rep,name=concurrency_limits,json=concurrencyLimits,proto3" json:"concurrency_limits,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Uncompressed      []string                     `protobuf:"bytes,4,rep,name=uncompressed,proto3" json:"uncompressed,omitempty"` // See AppConfig.Uncompressed.
 }
 
 func (x *InitWeaveletRequest) Reset() {
@@ -530,6 +549,27 @@ func (x *InitWeaveletRequest) GetSections() map[string]string {
 	return nil
 }
 
+func (x *InitWeaveletRequest) GetBalancers() map[string]string {
+	if x != nil {
+		return x.Balancers
+	}
+	return nil
+}
+
+func (x *InitWeaveletRequest) GetConcurrencyLimits() map[string]*ConcurrencyLimit {
+	if x != nil {
+		return x.ConcurrencyLimits
+	}
+	return nil
+}
+
+func (x *InitWeaveletRequest) GetUncompressed() []string {
+	if x != nil {
+		return x.Uncompressed
+	}
+	return nil
+}
+
 // InitWeaveletReply is the information provided by a weavelet to an envelope during
 // the initial envelope-weavelet handshake.
 type InitWeaveletReply struct {
@@ -697,14 +737,16 @@ func (*GetHealthRequest) Descriptor() ([]byte, []int) {
 	return file_runtime_protos_runtime_proto_rawDescGZIP(), []int{4}
 }
 
-// GetHealthReply is a reply to a GetHealthRequest.
+// GetHealthReply is a reply to a GetHealthRequest. A weavelet is healthy iff
+// all of its components are healthy.
 type GetHealthReply struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Status            HealthStatus `protobuf:"varint,1,opt,name=status,proto3,enum=runtime.HealthStatus" json:"status,omitempty"`
-	HealthyComponents []string     `protobuf:"bytes,2,rep,name=healthy_components,json=healthyComponents,proto3" json:"healthy_components,omitempty"`
+	Status            HealthStatus       `protobuf:"varint,1,opt,name=status,proto3,enum=runtime.HealthStatus" json:"status,omitempty"`
+	HealthyComponents []string           `protobuf:"bytes,2,rep,name=healthy_components,json=healthyComponents,proto3" json:"healthy_components,omitempty"`
+	Components        []*ComponentHealth `protobuf:"bytes,3,rep,name=components,proto3" json:"components,omitempty"` // health, by component
 }
 
 func (x *GetHealthReply) Reset() {
@@ -753,6 +795,79 @@ func (x *GetHealthReply) GetHealthyComponents() []string {
 	return nil
 }
 
+func (x *GetHealthReply) GetComponents() []*ComponentHealth {
+	if x != nil {
+		return x.Components
+	}
+	return nil
+}
+
+// ComponentHealth is the health of a component hosted by a weavelet. A
+// component is healthy iff it has been initialized and the latest call to its
+// optional Health method succeeded.
+type ComponentHealth struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Component string       `protobuf:"bytes,1,opt,name=component,proto3" json:"component,omitempty"`                      // full component name
+	Status    HealthStatus `protobuf:"varint,2,opt,name=status,proto3,enum=runtime.HealthStatus" json:"status,omitempty"` // component health
+	Error     string       `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`                              // why the component is unhealthy, if it is
+}
+
+func (x *ComponentHealth) Reset() {
+	*x = ComponentHealth{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_runtime_protos_runtime_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ComponentHealth) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ComponentHealth) ProtoMessage() {}
+
+func (x *ComponentHealth) ProtoReflect() protoreflect.Message {
+	mi := &file_runtime_protos_runtime_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ComponentHealth.ProtoReflect.Descriptor instead.
+func (*ComponentHealth) Descriptor() ([]byte, []int) {
+	return file_runtime_protos_runtime_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ComponentHealth) GetComponent() string {
+	if x != nil {
+		return x.Component
+	}
+	return ""
+}
+
+func (x *ComponentHealth) GetStatus() HealthStatus {
+	if x != nil {
+		return x.Status
+	}
+	return HealthStatus_UNKNOWN
+}
+
+func (x *ComponentHealth) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
 // GetMetricsRequest is a request from an envelope for a weavelet's metrics.
 // There can only be one outstanding GetMetricsRequest at a time.
 type GetMetricsRequest struct {
@@ -764,7 +879,7 @@ type GetMetricsRequest struct {
 func (x *GetMetricsRequest) Reset() {
 	*x = GetMetricsRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_runtime_protos_runtime_proto_msgTypes[6]
+		mi := &file_runtime_protos_runtime_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -777,7 +892,7 @@ func (x *GetMetricsRequest) String() string {
 func (*GetMetricsRequest) ProtoMessage() {}
 
 func (x *GetMetricsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_runtime_protos_runtime_proto_msgTypes[6]
+	mi := &file_runtime_protos_runtime_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -790,7 +905,7 @@ func (x *GetMetricsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetMetricsRequest.ProtoReflect.Descriptor instead.
 func (*GetMetricsRequest) Descriptor() ([]byte, []int) {
-	return file_runtime_protos_runtime_proto_rawDescGZIP(), []int{6}
+	return file_runtime_protos_runtime_proto_rawDescGZIP(), []int{7}
 }
 
 // GetMetricsReply is a reply to a GetMetricsRequest. It only contains
@@ -807,7 +922,7 @@ type GetMetricsReply struct {
 func (x *GetMetricsReply) Reset() {
 	*x = GetMetricsReply{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_runtime_protos_runtime_proto_msgTypes[7]
+		mi := &file_runtime_protos_runtime_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -820,7 +935,7 @@ func (x *GetMetricsReply) String() string {
 func (*GetMetricsReply) ProtoMessage() {}
 
 func (x *GetMetricsReply) ProtoReflect() protoreflect.Message {
-	mi := &file_runtime_protos_runtime_proto_msgTypes[7]
+	mi := &file_runtime_protos_runtime_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -833,7 +948,7 @@ func (x *GetMetricsReply) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetMetricsReply.ProtoReflect.Descriptor instead.
 func (*GetMetricsReply) Descriptor() ([]byte, []int) {
-	return file_runtime_protos_runtime_proto_rawDescGZIP(), []int{7}
+	return file_runtime_protos_runtime_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *GetMetricsReply) GetUpdate() *MetricUpdate {
@@ -862,7 +977,7 @@ type MetricUpdate struct {
 func (x *MetricUpdate) Reset() {
 	*x = MetricUpdate{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_runtime_protos_runtime_proto_msgTypes[8]
+		mi := &file_runtime_protos_runtime_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -875,7 +990,7 @@ func (x *MetricUpdate) String() string {
 func (*MetricUpdate) ProtoMessage() {}
 
 func (x *MetricUpdate) ProtoReflect() protoreflect.Message {
-	mi := &file_runtime_protos_runtime_proto_msgTypes[8]
+	mi := &file_runtime_protos_runtime_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -888,7 +1003,7 @@ func (x *MetricUpdate) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MetricUpdate.ProtoReflect.Descriptor instead.
 func (*MetricUpdate) Descriptor() ([]byte, []int) {
-	return file_runtime_protos_runtime_proto_rawDescGZIP(), []int{8}
+	return file_runtime_protos_runtime_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *MetricUpdate) GetDefs() []*MetricDef {
@@ -922,7 +1037,7 @@ type MetricDef struct {
 func (x *MetricDef) Reset() {
 	*x = MetricDef{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_runtime_protos_runtime_proto_msgTypes[9]
+		mi := &file_runtime_protos_runtime_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -935,7 +1050,7 @@ func (x *MetricDef) String() string {
 func (*MetricDef) ProtoMessage() {}
 
 func (x *MetricDef) ProtoReflect() protoreflect.Message {
-	mi := &file_runtime_protos_runtime_proto_msgTypes[9]
+	mi := &file_runtime_protos_runtime_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -948,7 +1063,7 @@ func (x *MetricDef) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MetricDef.ProtoReflect.Descriptor instead.
 func (*MetricDef) Descriptor() ([]byte, []int) {
-	return file_runtime_protos_runtime_proto_rawDescGZIP(), []int{9}
+	return file_runtime_protos_runtime_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *MetricDef) GetId() uint64 {
@@ -1007,7 +1122,7 @@ type MetricValue struct {
 func (x *MetricValue) Reset() {
 	*x = MetricValue{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_runtime_protos_runtime_proto_msgTypes[10]
+		mi := &file_runtime_protos_runtime_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1020,7 +1135,7 @@ func (x *MetricValue) String() string {
 func (*MetricValue) ProtoMessage() {}
 
 func (x *MetricValue) ProtoReflect() protoreflect.Message {
-	mi := &file_runtime_protos_runtime_proto_msgTypes[10]
+	mi := &file_runtime_protos_runtime_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1033,7 +1148,7 @@ func (x *MetricValue) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MetricValue.ProtoReflect.Descriptor instead.
 func (*MetricValue) Descriptor() ([]byte, []int) {
-	return file_runtime_protos_runtime_proto_rawDescGZIP(), []int{10}
+	return file_runtime_protos_runtime_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *MetricValue) GetId() uint64 {
@@ -1079,7 +1194,7 @@ type MetricSnapshot struct {
 func (x *MetricSnapshot) Reset() {
 	*x = MetricSnapshot{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_runtime_protos_runtime_proto_msgTypes[11]
+		mi := &file_runtime_protos_runtime_proto_msgTypes[12]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1092,7 +1207,7 @@ func (x *MetricSnapshot) String() string {
 func (*MetricSnapshot) ProtoMessage() {}
 
 func (x *MetricSnapshot) ProtoReflect() protoreflect.Message {
-	mi := &file_runtime_protos_runtime_proto_msgTypes[11]
+	mi := &file_runtime_protos_runtime_proto_msgTypes[12]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1105,7 +1220,7 @@ func (x *MetricSnapshot) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MetricSnapshot.ProtoReflect.Descriptor instead.
 func (*MetricSnapshot) Descriptor() ([]byte, []int) {
-	return file_runtime_protos_runtime_proto_rawDescGZIP(), []int{11}
+	return file_runtime_protos_runtime_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *MetricSnapshot) GetId() uint64 {
@@ -1174,7 +1289,7 @@ type GetLoadRequest struct {
 func (x *GetLoadRequest) Reset() {
 	*x = GetLoadRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_runtime_protos_runtime_proto_msgTypes[12]
+		mi := &file_runtime_protos_runtime_proto_msgTypes[13]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1187,7 +1302,7 @@ func (x *GetLoadRequest) String() string {
 func (*GetLoadRequest) ProtoMessage() {}
 
 func (x *GetLoadRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_runtime_protos_runtime_proto_msgTypes[12]
+	mi := &file_runtime_protos_runtime_proto_msgTypes[13]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1200,7 +1315,7 @@ func (x *GetLoadRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetLoadRequest.ProtoReflect.Descriptor instead.
 func (*GetLoadRequest) Descriptor() ([]byte, []int) {
-	return file_runtime_protos_runtime_proto_rawDescGZIP(), []int{12}
+	return file_runtime_protos_runtime_proto_rawDescGZIP(), []int{13}
 }
 
 // GetLoadReply is a reply to a GetLoadRequest.
@@ -1215,7 +1330,7 @@ type GetLoadReply struct {
 func (x *GetLoadReply) Reset() {
 	*x = GetLoadReply{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_runtime_protos_runtime_proto_msgTypes[13]
+		mi := &file_runtime_protos_runtime_proto_msgTypes[14]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1228,7 +1343,7 @@ func (x *GetLoadReply) String() string {
 func (*GetLoadReply) ProtoMessage() {}
 
 func (x *GetLoadReply) ProtoReflect() protoreflect.Message {
-	mi := &file_runtime_protos_runtime_proto_msgTypes[13]
+	mi := &file_runtime_protos_runtime_proto_msgTypes[14]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1241,7 +1356,7 @@ func (x *GetLoadReply) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetLoadReply.ProtoReflect.Descriptor instead.
 func (*GetLoadReply) Descriptor() ([]byte, []int) {
-	return file_runtime_protos_runtime_proto_rawDescGZIP(), []int{13}
+	return file_runtime_protos_runtime_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *GetLoadReply) GetLoad() *LoadReport {
@@ -1264,7 +1379,7 @@ type LoadReport struct {
 func (x *LoadReport) Reset() {
 	*x = LoadReport{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_runtime_protos_runtime_proto_msgTypes[14]
+		mi := &file_runtime_protos_runtime_proto_msgTypes[15]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1277,7 +1392,7 @@ func (x *LoadReport) String() string {
 func (*LoadReport) ProtoMessage() {}
 
 func (x *LoadReport) ProtoReflect() protoreflect.Message {
-	mi := &file_runtime_protos_runtime_proto_msgTypes[14]
+	mi := &file_runtime_protos_runtime_proto_msgTypes[15]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1290,7 +1405,7 @@ func (x *LoadReport) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LoadReport.ProtoReflect.Descriptor instead.
 func (*LoadReport) Descriptor() ([]byte, []int) {
-	return file_runtime_protos_runtime_proto_rawDescGZIP(), []int{14}
+	return file_runtime_protos_runtime_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *LoadReport) GetLoads() map[string]*LoadReport_ComponentLoad {
@@ -1310,14 +1425,15 @@ type GetProfileRequest struct {
 
 	// Type of the profile (e.g., heap, cpu).
 	ProfileType ProfileType `protobuf:"varint,1,opt,name=profile_type,json=profileType,proto3,enum=runtime.ProfileType" json:"profile_type,omitempty"`
-	// Duration of CPU profiles, in nanoseconds.
+	// Duration of CPU, Mutex, and Block profiles, in nanoseconds. Mutex and
+	// Block profiles only report the contention observed during this duration.
 	CpuDurationNs int64 `protobuf:"varint,2,opt,name=cpu_duration_ns,json=cpuDurationNs,proto3" json:"cpu_duration_ns,omitempty"`
 }
 
 func (x *GetProfileRequest) Reset() {
 	*x = GetProfileRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_runtime_protos_runtime_proto_msgTypes[15]
+		mi := &file_runtime_protos_runtime_proto_msgTypes[16]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1330,7 +1446,7 @@ func (x *GetProfileRequest) String() string {
 func (*GetProfileRequest) ProtoMessage() {}
 
 func (x *GetProfileRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_runtime_protos_runtime_proto_msgTypes[15]
+	mi := &file_runtime_protos_runtime_proto_msgTypes[16]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1343,7 +1459,7 @@ func (x *GetProfileRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetProfileRequest.ProtoReflect.Descriptor instead.
 func (*GetProfileRequest) Descriptor() ([]byte, []int) {
-	return file_runtime_protos_runtime_proto_rawDescGZIP(), []int{15}
+	return file_runtime_protos_runtime_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *GetProfileRequest) GetProfileType() ProfileType {
@@ -1372,7 +1488,7 @@ type GetProfileReply struct {
 func (x *GetProfileReply) Reset() {
 	*x = GetProfileReply{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_runtime_protos_runtime_proto_msgTypes[16]
+		mi := &file_runtime_protos_runtime_proto_msgTypes[17]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1385,7 +1501,7 @@ func (x *GetProfileReply) String() string {
 func (*GetProfileReply) ProtoMessage() {}
 
 func (x *GetProfileReply) ProtoReflect() protoreflect.Message {
-	mi := &file_runtime_protos_runtime_proto_msgTypes[16]
+	mi := &file_runtime_protos_runtime_proto_msgTypes[17]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1398,7 +1514,7 @@ func (x *GetProfileReply) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetProfileReply.ProtoReflect.Descriptor instead.
 func (*GetProfileReply) Descriptor() ([]byte, []int) {
-	return file_runtime_protos_runtime_proto_rawDescGZIP(), []int{16}
+	return file_runtime_protos_runtime_proto_rawDescGZIP(), []int{17}
 }
 
 func (x *GetProfileReply) GetData() []byte {
@@ -1421,7 +1537,7 @@ type UpdateRoutingInfoRequest struct {
 func (x *UpdateRoutingInfoRequest) Reset() {
 	*x = UpdateRoutingInfoRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_runtime_protos_runtime_proto_msgTypes[17]
+		mi := &file_runtime_protos_runtime_proto_msgTypes[18]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1434,7 +1550,7 @@ func (x *UpdateRoutingInfoRequest) String() string {
 func (*UpdateRoutingInfoRequest) ProtoMessage() {}
 
 func (x *UpdateRoutingInfoRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_runtime_protos_runtime_proto_msgTypes[17]
+	mi := &file_runtime_protos_runtime_proto_msgTypes[18]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1447,7 +1563,7 @@ func (x *UpdateRoutingInfoRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateRoutingInfoRequest.ProtoReflect.Descriptor instead.
 func (*UpdateRoutingInfoRequest) Descriptor() ([]byte, []int) {
-	return file_runtime_protos_runtime_proto_rawDescGZIP(), []int{17}
+	return file_runtime_protos_runtime_proto_rawDescGZIP(), []int{18}
 }
 
 func (x *UpdateRoutingInfoRequest) GetRoutingInfo() *RoutingInfo {
@@ -1467,7 +1583,7 @@ type UpdateRoutingInfoReply struct {
 func (x *UpdateRoutingInfoReply) Reset() {
 	*x = UpdateRoutingInfoReply{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_runtime_protos_runtime_proto_msgTypes[18]
+		mi := &file_runtime_protos_runtime_proto_msgTypes[19]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1480,7 +1596,7 @@ func (x *UpdateRoutingInfoReply) String() string {
 func (*UpdateRoutingInfoReply) ProtoMessage() {}
 
 func (x *UpdateRoutingInfoReply) ProtoReflect() protoreflect.Message {
-	mi := &file_runtime_protos_runtime_proto_msgTypes[18]
+	mi := &file_runtime_protos_runtime_proto_msgTypes[19]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1493,7 +1609,7 @@ func (x *UpdateRoutingInfoReply) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateRoutingInfoReply.ProtoReflect.Descriptor instead.
 func (*UpdateRoutingInfoReply) Descriptor() ([]byte, []int) {
-	return file_runtime_protos_runtime_proto_rawDescGZIP(), []int{18}
+	return file_runtime_protos_runtime_proto_rawDescGZIP(), []int{19}
 }
 
 // RoutingInfo contains routing information for a component. A weavelet uses a
@@ -1520,7 +1636,7 @@ type RoutingInfo struct {
 func (x *RoutingInfo) Reset() {
 	*x = RoutingInfo{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_runtime_protos_runtime_proto_msgTypes[19]
+		mi := &file_runtime_protos_runtime_proto_msgTypes[20]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1533,7 +1649,7 @@ func (x *RoutingInfo) String() string {
 func (*RoutingInfo) ProtoMessage() {}
 
 func (x *RoutingInfo) ProtoReflect() protoreflect.Message {
-	mi := &file_runtime_protos_runtime_proto_msgTypes[19]
+	mi := &file_runtime_protos_runtime_proto_msgTypes[20]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1546,7 +1662,7 @@ func (x *RoutingInfo) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RoutingInfo.ProtoReflect.Descriptor instead.
 func (*RoutingInfo) Descriptor() ([]byte, []int) {
-	return file_runtime_protos_runtime_proto_rawDescGZIP(), []int{19}
+	return file_runtime_protos_runtime_proto_rawDescGZIP(), []int{20}
 }
 
 func (x *RoutingInfo) GetComponent() string {
@@ -1594,7 +1710,7 @@ type Assignment struct {
 func (x *Assignment) Reset() {
 	*x = Assignment{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_runtime_protos_runtime_proto_msgTypes[20]
+		mi := &file_runtime_protos_runtime_proto_msgTypes[21]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1607,7 +1723,7 @@ func (x *Assignment) String() string {
 func (*Assignment) ProtoMessage() {}
 
 func (x *Assignment) ProtoReflect() protoreflect.Message {
-	mi := &file_runtime_protos_runtime_proto_msgTypes[20]
+	mi := &file_runtime_protos_runtime_proto_msgTypes[21]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1620,7 +1736,7 @@ func (x *Assignment) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Assignment.ProtoReflect.Descriptor instead.
 func (*Assignment) Descriptor() ([]byte, []int) {
-	return file_runtime_protos_runtime_proto_rawDescGZIP(), []int{20}
+	return file_runtime_protos_runtime_proto_rawDescGZIP(), []int{21}
 }
 
 func (x *Assignment) GetSlices() []*Assignment_Slice {
@@ -1651,7 +1767,7 @@ type UpdateComponentsRequest struct {
 func (x *UpdateComponentsRequest) Reset() {
 	*x = UpdateComponentsRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_runtime_protos_runtime_proto_msgTypes[21]
+		mi := &file_runtime_protos_runtime_proto_msgTypes[22]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1664,7 +1780,7 @@ func (x *UpdateComponentsRequest) String() string {
 func (*UpdateComponentsRequest) ProtoMessage() {}
 
 func (x *UpdateComponentsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_runtime_protos_runtime_proto_msgTypes[21]
+	mi := &file_runtime_protos_runtime_proto_msgTypes[22]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1677,7 +1793,7 @@ func (x *UpdateComponentsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateComponentsRequest.ProtoReflect.Descriptor instead.
 func (*UpdateComponentsRequest) Descriptor() ([]byte, []int) {
-	return file_runtime_protos_runtime_proto_rawDescGZIP(), []int{21}
+	return file_runtime_protos_runtime_proto_rawDescGZIP(), []int{22}
 }
 
 func (x *UpdateComponentsRequest) GetComponents() []string {
@@ -1697,7 +1813,7 @@ type UpdateComponentsReply struct {
 func (x *UpdateComponentsReply) Reset() {
 	*x = UpdateComponentsReply{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_runtime_protos_runtime_proto_msgTypes[22]
+		mi := &file_runtime_protos_runtime_proto_msgTypes[23]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1710,7 +1826,7 @@ func (x *UpdateComponentsReply) String() string {
 func (*UpdateComponentsReply) ProtoMessage() {}
 
 func (x *UpdateComponentsReply) ProtoReflect() protoreflect.Message {
-	mi := &file_runtime_protos_runtime_proto_msgTypes[22]
+	mi := &file_runtime_protos_runtime_proto_msgTypes[23]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1723,7 +1839,7 @@ func (x *UpdateComponentsReply) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateComponentsReply.ProtoReflect.Descriptor instead.
 func (*UpdateComponentsReply) Descriptor() ([]byte, []int) {
-	return file_runtime_protos_runtime_proto_rawDescGZIP(), []int{22}
+	return file_runtime_protos_runtime_proto_rawDescGZIP(), []int{23}
 }
 
 // ActivateComponentRequest is a request from a weavelet to ensure that the
@@ -1742,7 +1858,7 @@ type ActivateComponentRequest struct {
 func (x *ActivateComponentRequest) Reset() {
 	*x = ActivateComponentRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_runtime_protos_runtime_proto_msgTypes[23]
+		mi := &file_runtime_protos_runtime_proto_msgTypes[24]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1755,7 +1871,7 @@ func (x *ActivateComponentRequest) String() string {
 func (*ActivateComponentRequest) ProtoMessage() {}
 
 func (x *ActivateComponentRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_runtime_protos_runtime_proto_msgTypes[23]
+	mi := &file_runtime_protos_runtime_proto_msgTypes[24]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1768,7 +1884,7 @@ func (x *ActivateComponentRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ActivateComponentRequest.ProtoReflect.Descriptor instead.
 func (*ActivateComponentRequest) Descriptor() ([]byte, []int) {
-	return file_runtime_protos_runtime_proto_rawDescGZIP(), []int{23}
+	return file_runtime_protos_runtime_proto_rawDescGZIP(), []int{24}
 }
 
 func (x *ActivateComponentRequest) GetComponent() string {
@@ -1795,7 +1911,7 @@ type ActivateComponentReply struct {
 func (x *ActivateComponentReply) Reset() {
 	*x = ActivateComponentReply{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_runtime_protos_runtime_proto_msgTypes[24]
+		mi := &file_runtime_protos_runtime_proto_msgTypes[25]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1808,7 +1924,7 @@ func (x *ActivateComponentReply) String() string {
 func (*ActivateComponentReply) ProtoMessage() {}
 
 func (x *ActivateComponentReply) ProtoReflect() protoreflect.Message {
-	mi := &file_runtime_protos_runtime_proto_msgTypes[24]
+	mi := &file_runtime_protos_runtime_proto_msgTypes[25]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1821,7 +1937,7 @@ func (x *ActivateComponentReply) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ActivateComponentReply.ProtoReflect.Descriptor instead.
 func (*ActivateComponentReply) Descriptor() ([]byte, []int) {
-	return file_runtime_protos_runtime_proto_rawDescGZIP(), []int{24}
+	return file_runtime_protos_runtime_proto_rawDescGZIP(), []int{25}
 }
 
 // GetListenerAddressRequest is a request from a weavelet for the address the
@@ -1848,7 +1964,7 @@ type GetListenerAddressRequest struct {
 func (x *GetListenerAddressRequest) Reset() {
 	*x = GetListenerAddressRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_runtime_protos_runtime_proto_msgTypes[25]
+		mi := &file_runtime_protos_runtime_proto_msgTypes[26]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1861,7 +1977,7 @@ func (x *GetListenerAddressRequest) String() string {
 func (*GetListenerAddressRequest) ProtoMessage() {}
 
 func (x *GetListenerAddressRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_runtime_protos_runtime_proto_msgTypes[25]
+	mi := &file_runtime_protos_runtime_proto_msgTypes[26]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1874,7 +1990,7 @@ func (x *GetListenerAddressRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetListenerAddressRequest.ProtoReflect.Descriptor instead.
 func (*GetListenerAddressRequest) Descriptor() ([]byte, []int) {
-	return file_runtime_protos_runtime_proto_rawDescGZIP(), []int{25}
+	return file_runtime_protos_runtime_proto_rawDescGZIP(), []int{26}
 }
 
 func (x *GetListenerAddressRequest) GetName() string {
@@ -1896,7 +2012,7 @@ type GetListenerAddressReply struct {
 func (x *GetListenerAddressReply) Reset() {
 	*x = GetListenerAddressReply{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_runtime_protos_runtime_proto_msgTypes[26]
+		mi := &file_runtime_protos_runtime_proto_msgTypes[27]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1909,7 +2025,7 @@ func (x *GetListenerAddressReply) String() string {
 func (*GetListenerAddressReply) ProtoMessage() {}
 
 func (x *GetListenerAddressReply) ProtoReflect() protoreflect.Message {
-	mi := &file_runtime_protos_runtime_proto_msgTypes[26]
+	mi := &file_runtime_protos_runtime_proto_msgTypes[27]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1922,7 +2038,7 @@ func (x *GetListenerAddressReply) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetListenerAddressReply.ProtoReflect.Descriptor instead.
 func (*GetListenerAddressReply) Descriptor() ([]byte, []int) {
-	return file_runtime_protos_runtime_proto_rawDescGZIP(), []int{26}
+	return file_runtime_protos_runtime_proto_rawDescGZIP(), []int{27}
 }
 
 func (x *GetListenerAddressReply) GetAddress() string {
@@ -1947,7 +2063,7 @@ type ExportListenerRequest struct {
 func (x *ExportListenerRequest) Reset() {
 	*x = ExportListenerRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_runtime_protos_runtime_proto_msgTypes[27]
+		mi := &file_runtime_protos_runtime_proto_msgTypes[28]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -1960,7 +2076,7 @@ func (x *ExportListenerRequest) String() string {
 func (*ExportListenerRequest) ProtoMessage() {}
 
 func (x *ExportListenerRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_runtime_protos_runtime_proto_msgTypes[27]
+	mi := &file_runtime_protos_runtime_proto_msgTypes[28]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1973,7 +2089,7 @@ func (x *ExportListenerRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ExportListenerRequest.ProtoReflect.Descriptor instead.
 func (*ExportListenerRequest) Descriptor() ([]byte, []int) {
-	return file_runtime_protos_runtime_proto_rawDescGZIP(), []int{27}
+	return file_runtime_protos_runtime_proto_rawDescGZIP(), []int{28}
 }
 
 func (x *ExportListenerRequest) GetListener() string {
@@ -2010,7 +2126,7 @@ type ExportListenerReply struct {
 func (x *ExportListenerReply) Reset() {
 	*x = ExportListenerReply{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_runtime_protos_runtime_proto_msgTypes[28]
+		mi := &file_runtime_protos_runtime_proto_msgTypes[29]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2023,7 +2139,7 @@ func (x *ExportListenerReply) String() string {
 func (*ExportListenerReply) ProtoMessage() {}
 
 func (x *ExportListenerReply) ProtoReflect() protoreflect.Message {
-	mi := &file_runtime_protos_runtime_proto_msgTypes[28]
+	mi := &file_runtime_protos_runtime_proto_msgTypes[29]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2036,7 +2152,7 @@ func (x *ExportListenerReply) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ExportListenerReply.ProtoReflect.Descriptor instead.
 func (*ExportListenerReply) Descriptor() ([]byte, []int) {
-	return file_runtime_protos_runtime_proto_rawDescGZIP(), []int{28}
+	return file_runtime_protos_runtime_proto_rawDescGZIP(), []int{29}
 }
 
 func (x *ExportListenerReply) GetProxyAddress() string {
@@ -2064,7 +2180,7 @@ type GetSelfCertificateRequest struct {
 func (x *GetSelfCertificateRequest) Reset() {
 	*x = GetSelfCertificateRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_runtime_protos_runtime_proto_msgTypes[29]
+		mi := &file_runtime_protos_runtime_proto_msgTypes[30]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2077,7 +2193,7 @@ func (x *GetSelfCertificateRequest) String() string {
 func (*GetSelfCertificateRequest) ProtoMessage() {}
 
 func (x *GetSelfCertificateRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_runtime_protos_runtime_proto_msgTypes[29]
+	mi := &file_runtime_protos_runtime_proto_msgTypes[30]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2090,7 +2206,7 @@ func (x *GetSelfCertificateRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetSelfCertificateRequest.ProtoReflect.Descriptor instead.
 func (*GetSelfCertificateRequest) Descriptor() ([]byte, []int) {
-	return file_runtime_protos_runtime_proto_rawDescGZIP(), []int{29}
+	return file_runtime_protos_runtime_proto_rawDescGZIP(), []int{30}
 }
 
 // GetSelfCertificateReply is a reply to a GetSelfCertificateRequest.
@@ -2109,7 +2225,7 @@ type GetSelfCertificateReply struct {
 func (x *GetSelfCertificateReply) Reset() {
 	*x = GetSelfCertificateReply{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_runtime_protos_runtime_proto_msgTypes[30]
+		mi := &file_runtime_protos_runtime_proto_msgTypes[31]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2122,7 +2238,7 @@ func (x *GetSelfCertificateReply) String() string {
 func (*GetSelfCertificateReply) ProtoMessage() {}
 
 func (x *GetSelfCertificateReply) ProtoReflect() protoreflect.Message {
-	mi := &file_runtime_protos_runtime_proto_msgTypes[30]
+	mi := &file_runtime_protos_runtime_proto_msgTypes[31]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2135,7 +2251,7 @@ func (x *GetSelfCertificateReply) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetSelfCertificateReply.ProtoReflect.Descriptor instead.
 func (*GetSelfCertificateReply) Descriptor() ([]byte, []int) {
-	return file_runtime_protos_runtime_proto_rawDescGZIP(), []int{30}
+	return file_runtime_protos_runtime_proto_rawDescGZIP(), []int{31}
 }
 
 func (x *GetSelfCertificateReply) GetCert() []byte {
@@ -2167,7 +2283,7 @@ type VerifyClientCertificateRequest struct {
 func (x *VerifyClientCertificateRequest) Reset() {
 	*x = VerifyClientCertificateRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_runtime_protos_runtime_proto_msgTypes[31]
+		mi := &file_runtime_protos_runtime_proto_msgTypes[32]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2180,7 +2296,7 @@ func (x *VerifyClientCertificateRequest) String() string {
 func (*VerifyClientCertificateRequest) ProtoMessage() {}
 
 func (x *VerifyClientCertificateRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_runtime_protos_runtime_proto_msgTypes[31]
+	mi := &file_runtime_protos_runtime_proto_msgTypes[32]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2193,7 +2309,7 @@ func (x *VerifyClientCertificateRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use VerifyClientCertificateRequest.ProtoReflect.Descriptor instead.
 func (*VerifyClientCertificateRequest) Descriptor() ([]byte, []int) {
-	return file_runtime_protos_runtime_proto_rawDescGZIP(), []int{31}
+	return file_runtime_protos_runtime_proto_rawDescGZIP(), []int{32}
 }
 
 func (x *VerifyClientCertificateRequest) GetCertChain() [][]byte {
@@ -2219,7 +2335,7 @@ type VerifyClientCertificateReply struct {
 func (x *VerifyClientCertificateReply) Reset() {
 	*x = VerifyClientCertificateReply{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_runtime_protos_runtime_proto_msgTypes[32]
+		mi := &file_runtime_protos_runtime_proto_msgTypes[33]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2232,7 +2348,7 @@ func (x *VerifyClientCertificateReply) String() string {
 func (*VerifyClientCertificateReply) ProtoMessage() {}
 
 func (x *VerifyClientCertificateReply) ProtoReflect() protoreflect.Message {
-	mi := &file_runtime_protos_runtime_proto_msgTypes[32]
+	mi := &file_runtime_protos_runtime_proto_msgTypes[33]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2245,7 +2361,7 @@ func (x *VerifyClientCertificateReply) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use VerifyClientCertificateReply.ProtoReflect.Descriptor instead.
 func (*VerifyClientCertificateReply) Descriptor() ([]byte, []int) {
-	return file_runtime_protos_runtime_proto_rawDescGZIP(), []int{32}
+	return file_runtime_protos_runtime_proto_rawDescGZIP(), []int{33}
 }
 
 func (x *VerifyClientCertificateReply) GetComponents() []string {
@@ -2272,7 +2388,7 @@ type VerifyServerCertificateRequest struct {
 func (x *VerifyServerCertificateRequest) Reset() {
 	*x = VerifyServerCertificateRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_runtime_protos_runtime_proto_msgTypes[33]
+		mi := &file_runtime_protos_runtime_proto_msgTypes[34]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2285,7 +2401,7 @@ func (x *VerifyServerCertificateRequest) String() string {
 func (*VerifyServerCertificateRequest) ProtoMessage() {}
 
 func (x *VerifyServerCertificateRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_runtime_protos_runtime_proto_msgTypes[33]
+	mi := &file_runtime_protos_runtime_proto_msgTypes[34]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2298,7 +2414,7 @@ func (x *VerifyServerCertificateRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use VerifyServerCertificateRequest.ProtoReflect.Descriptor instead.
 func (*VerifyServerCertificateRequest) Descriptor() ([]byte, []int) {
-	return file_runtime_protos_runtime_proto_rawDescGZIP(), []int{33}
+	return file_runtime_protos_runtime_proto_rawDescGZIP(), []int{34}
 }
 
 func (x *VerifyServerCertificateRequest) GetCertChain() [][]byte {
@@ -2325,7 +2441,7 @@ type VerifyServerCertificateReply struct {
 func (x *VerifyServerCertificateReply) Reset() {
 	*x = VerifyServerCertificateReply{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_runtime_protos_runtime_proto_msgTypes[34]
+		mi := &file_runtime_protos_runtime_proto_msgTypes[35]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2338,7 +2454,7 @@ func (x *VerifyServerCertificateReply) String() string {
 func (*VerifyServerCertificateReply) ProtoMessage() {}
 
 func (x *VerifyServerCertificateReply) ProtoReflect() protoreflect.Message {
-	mi := &file_runtime_protos_runtime_proto_msgTypes[34]
+	mi := &file_runtime_protos_runtime_proto_msgTypes[35]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2351,7 +2467,7 @@ func (x *VerifyServerCertificateReply) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use VerifyServerCertificateReply.ProtoReflect.Descriptor instead.
 func (*VerifyServerCertificateReply) Descriptor() ([]byte, []int) {
-	return file_runtime_protos_runtime_proto_rawDescGZIP(), []int{34}
+	return file_runtime_protos_runtime_proto_rawDescGZIP(), []int{35}
 }
 
 // LogEntry is a log entry. Every log entry consists of a message (the thing the
@@ -2380,7 +2496,7 @@ type LogEntry struct {
 func (x *LogEntry) Reset() {
 	*x = LogEntry{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_runtime_protos_runtime_proto_msgTypes[35]
+		mi := &file_runtime_protos_runtime_proto_msgTypes[36]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2393,7 +2509,7 @@ func (x *LogEntry) String() string {
 func (*LogEntry) ProtoMessage() {}
 
 func (x *LogEntry) ProtoReflect() protoreflect.Message {
-	mi := &file_runtime_protos_runtime_proto_msgTypes[35]
+	mi := &file_runtime_protos_runtime_proto_msgTypes[36]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2406,7 +2522,7 @@ func (x *LogEntry) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LogEntry.ProtoReflect.Descriptor instead.
 func (*LogEntry) Descriptor() ([]byte, []int) {
-	return file_runtime_protos_runtime_proto_rawDescGZIP(), []int{35}
+	return file_runtime_protos_runtime_proto_rawDescGZIP(), []int{36}
 }
 
 func (x *LogEntry) GetApp() string {
@@ -2491,7 +2607,7 @@ type LogEntryBatch struct {
 func (x *LogEntryBatch) Reset() {
 	*x = LogEntryBatch{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_runtime_protos_runtime_proto_msgTypes[36]
+		mi := &file_runtime_protos_runtime_proto_msgTypes[37]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2504,7 +2620,7 @@ func (x *LogEntryBatch) String() string {
 func (*LogEntryBatch) ProtoMessage() {}
 
 func (x *LogEntryBatch) ProtoReflect() protoreflect.Message {
-	mi := &file_runtime_protos_runtime_proto_msgTypes[36]
+	mi := &file_runtime_protos_runtime_proto_msgTypes[37]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2517,7 +2633,7 @@ func (x *LogEntryBatch) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LogEntryBatch.ProtoReflect.Descriptor instead.
 func (*LogEntryBatch) Descriptor() ([]byte, []int) {
-	return file_runtime_protos_runtime_proto_rawDescGZIP(), []int{36}
+	return file_runtime_protos_runtime_proto_rawDescGZIP(), []int{37}
 }
 
 func (x *LogEntryBatch) GetEntries() []*LogEntry {
@@ -2539,7 +2655,7 @@ type TraceSpans struct {
 func (x *TraceSpans) Reset() {
 	*x = TraceSpans{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_runtime_protos_runtime_proto_msgTypes[37]
+		mi := &file_runtime_protos_runtime_proto_msgTypes[38]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2552,7 +2668,7 @@ func (x *TraceSpans) String() string {
 func (*TraceSpans) ProtoMessage() {}
 
 func (x *TraceSpans) ProtoReflect() protoreflect.Message {
-	mi := &file_runtime_protos_runtime_proto_msgTypes[37]
+	mi := &file_runtime_protos_runtime_proto_msgTypes[38]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2565,7 +2681,7 @@ func (x *TraceSpans) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TraceSpans.ProtoReflect.Descriptor instead.
 func (*TraceSpans) Descriptor() ([]byte, []int) {
-	return file_runtime_protos_runtime_proto_rawDescGZIP(), []int{37}
+	return file_runtime_protos_runtime_proto_rawDescGZIP(), []int{38}
 }
 
 func (x *TraceSpans) GetSpan() []*Span {
@@ -2611,7 +2727,7 @@ type Span struct {
 func (x *Span) Reset() {
 	*x = Span{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_runtime_protos_runtime_proto_msgTypes[38]
+		mi := &file_runtime_protos_runtime_proto_msgTypes[39]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2624,7 +2740,7 @@ func (x *Span) String() string {
 func (*Span) ProtoMessage() {}
 
 func (x *Span) ProtoReflect() protoreflect.Message {
-	mi := &file_runtime_protos_runtime_proto_msgTypes[38]
+	mi := &file_runtime_protos_runtime_proto_msgTypes[39]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2637,7 +2753,7 @@ func (x *Span) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Span.ProtoReflect.Descriptor instead.
 func (*Span) Descriptor() ([]byte, []int) {
-	return file_runtime_protos_runtime_proto_rawDescGZIP(), []int{38}
+	return file_runtime_protos_runtime_proto_rawDescGZIP(), []int{39}
 }
 
 func (x *Span) GetName() string {
@@ -2781,7 +2897,7 @@ type WeaveletArgs_Redirect struct {
 func (x *WeaveletArgs_Redirect) Reset() {
 	*x = WeaveletArgs_Redirect{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_runtime_protos_runtime_proto_msgTypes[39]
+		mi := &file_runtime_protos_runtime_proto_msgTypes[40]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2794,7 +2910,7 @@ func (x *WeaveletArgs_Redirect) String() string {
 func (*WeaveletArgs_Redirect) ProtoMessage() {}
 
 func (x *WeaveletArgs_Redirect) ProtoReflect() protoreflect.Message {
-	mi := &file_runtime_protos_runtime_proto_msgTypes[39]
+	mi := &file_runtime_protos_runtime_proto_msgTypes[40]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2858,7 +2974,7 @@ type LoadReport_ComponentLoad struct {
 func (x *LoadReport_ComponentLoad) Reset() {
 	*x = LoadReport_ComponentLoad{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_runtime_protos_runtime_proto_msgTypes[44]
+		mi := &file_runtime_protos_runtime_proto_msgTypes[47]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2871,7 +2987,7 @@ func (x *LoadReport_ComponentLoad) String() string {
 func (*LoadReport_ComponentLoad) ProtoMessage() {}
 
 func (x *LoadReport_ComponentLoad) ProtoReflect() protoreflect.Message {
-	mi := &file_runtime_protos_runtime_proto_msgTypes[44]
+	mi := &file_runtime_protos_runtime_proto_msgTypes[47]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2884,7 +3000,7 @@ func (x *LoadReport_ComponentLoad) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LoadReport_ComponentLoad.ProtoReflect.Descriptor instead.
 func (*LoadReport_ComponentLoad) Descriptor() ([]byte, []int) {
-	return file_runtime_protos_runtime_proto_rawDescGZIP(), []int{14, 1}
+	return file_runtime_protos_runtime_proto_rawDescGZIP(), []int{15, 1}
 }
 
 func (x *LoadReport_ComponentLoad) GetLoad() []*LoadReport_SliceLoad {
@@ -2931,7 +3047,7 @@ type LoadReport_SliceLoad struct {
 func (x *LoadReport_SliceLoad) Reset() {
 	*x = LoadReport_SliceLoad{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_runtime_protos_runtime_proto_msgTypes[45]
+		mi := &file_runtime_protos_runtime_proto_msgTypes[48]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -2944,7 +3060,7 @@ func (x *LoadReport_SliceLoad) String() string {
 func (*LoadReport_SliceLoad) ProtoMessage() {}
 
 func (x *LoadReport_SliceLoad) ProtoReflect() protoreflect.Message {
-	mi := &file_runtime_protos_runtime_proto_msgTypes[45]
+	mi := &file_runtime_protos_runtime_proto_msgTypes[48]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2957,7 +3073,7 @@ func (x *LoadReport_SliceLoad) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LoadReport_SliceLoad.ProtoReflect.Descriptor instead.
 func (*LoadReport_SliceLoad) Descriptor() ([]byte, []int) {
-	return file_runtime_protos_runtime_proto_rawDescGZIP(), []int{14, 2}
+	return file_runtime_protos_runtime_proto_rawDescGZIP(), []int{15, 2}
 }
 
 func (x *LoadReport_SliceLoad) GetStart() uint64 {
@@ -3017,7 +3133,7 @@ type LoadReport_SubsliceLoad struct {
 func (x *LoadReport_SubsliceLoad) Reset() {
 	*x = LoadReport_SubsliceLoad{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_runtime_protos_runtime_proto_msgTypes[46]
+		mi := &file_runtime_protos_runtime_proto_msgTypes[49]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -3030,7 +3146,7 @@ func (x *LoadReport_SubsliceLoad) String() string {
 func (*LoadReport_SubsliceLoad) ProtoMessage() {}
 
 func (x *LoadReport_SubsliceLoad) ProtoReflect() protoreflect.Message {
-	mi := &file_runtime_protos_runtime_proto_msgTypes[46]
+	mi := &file_runtime_protos_runtime_proto_msgTypes[49]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3043,7 +3159,7 @@ func (x *LoadReport_SubsliceLoad) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LoadReport_SubsliceLoad.ProtoReflect.Descriptor instead.
 func (*LoadReport_SubsliceLoad) Descriptor() ([]byte, []int) {
-	return file_runtime_protos_runtime_proto_rawDescGZIP(), []int{14, 3}
+	return file_runtime_protos_runtime_proto_rawDescGZIP(), []int{15, 3}
 }
 
 func (x *LoadReport_SubsliceLoad) GetStart() uint64 {
@@ -3079,7 +3195,7 @@ type Assignment_Slice struct {
 func (x *Assignment_Slice) Reset() {
 	*x = Assignment_Slice{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_runtime_protos_runtime_proto_msgTypes[47]
+		mi := &file_runtime_protos_runtime_proto_msgTypes[50]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -3092,7 +3208,7 @@ func (x *Assignment_Slice) String() string {
 func (*Assignment_Slice) ProtoMessage() {}
 
 func (x *Assignment_Slice) ProtoReflect() protoreflect.Message {
-	mi := &file_runtime_protos_runtime_proto_msgTypes[47]
+	mi := &file_runtime_protos_runtime_proto_msgTypes[50]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3105,7 +3221,7 @@ func (x *Assignment_Slice) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Assignment_Slice.ProtoReflect.Descriptor instead.
 func (*Assignment_Slice) Descriptor() ([]byte, []int) {
-	return file_runtime_protos_runtime_proto_rawDescGZIP(), []int{20, 0}
+	return file_runtime_protos_runtime_proto_rawDescGZIP(), []int{21, 0}
 }
 
 func (x *Assignment_Slice) GetStart() uint64 {
@@ -3135,7 +3251,7 @@ type Span_Attribute struct {
 func (x *Span_Attribute) Reset() {
 	*x = Span_Attribute{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_runtime_protos_runtime_proto_msgTypes[48]
+		mi := &file_runtime_protos_runtime_proto_msgTypes[51]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -3148,7 +3264,7 @@ func (x *Span_Attribute) String() string {
 func (*Span_Attribute) ProtoMessage() {}
 
 func (x *Span_Attribute) ProtoReflect() protoreflect.Message {
-	mi := &file_runtime_protos_runtime_proto_msgTypes[48]
+	mi := &file_runtime_protos_runtime_proto_msgTypes[51]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3161,7 +3277,7 @@ func (x *Span_Attribute) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Span_Attribute.ProtoReflect.Descriptor instead.
 func (*Span_Attribute) Descriptor() ([]byte, []int) {
-	return file_runtime_protos_runtime_proto_rawDescGZIP(), []int{38, 0}
+	return file_runtime_protos_runtime_proto_rawDescGZIP(), []int{39, 0}
 }
 
 func (x *Span_Attribute) GetKey() string {
@@ -3194,7 +3310,7 @@ type Span_Link struct {
 func (x *Span_Link) Reset() {
 	*x = Span_Link{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_runtime_protos_runtime_proto_msgTypes[49]
+		mi := &file_runtime_protos_runtime_proto_msgTypes[52]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -3207,7 +3323,7 @@ func (x *Span_Link) String() string {
 func (*Span_Link) ProtoMessage() {}
 
 func (x *Span_Link) ProtoReflect() protoreflect.Message {
-	mi := &file_runtime_protos_runtime_proto_msgTypes[49]
+	mi := &file_runtime_protos_runtime_proto_msgTypes[52]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3220,7 +3336,7 @@ func (x *Span_Link) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Span_Link.ProtoReflect.Descriptor instead.
 func (*Span_Link) Descriptor() ([]byte, []int) {
-	return file_runtime_protos_runtime_proto_rawDescGZIP(), []int{38, 1}
+	return file_runtime_protos_runtime_proto_rawDescGZIP(), []int{39, 1}
 }
 
 func (x *Span_Link) GetTraceId() []byte {
@@ -3267,7 +3383,7 @@ type Span_Event struct {
 func (x *Span_Event) Reset() {
 	*x = Span_Event{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_runtime_protos_runtime_proto_msgTypes[50]
+		mi := &file_runtime_protos_runtime_proto_msgTypes[53]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -3280,7 +3396,7 @@ func (x *Span_Event) String() string {
 func (*Span_Event) ProtoMessage() {}
 
 func (x *Span_Event) ProtoReflect() protoreflect.Message {
-	mi := &file_runtime_protos_runtime_proto_msgTypes[50]
+	mi := &file_runtime_protos_runtime_proto_msgTypes[53]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3293,7 +3409,7 @@ func (x *Span_Event) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Span_Event.ProtoReflect.Descriptor instead.
 func (*Span_Event) Descriptor() ([]byte, []int) {
-	return file_runtime_protos_runtime_proto_rawDescGZIP(), []int{38, 2}
+	return file_runtime_protos_runtime_proto_rawDescGZIP(), []int{39, 2}
 }
 
 func (x *Span_Event) GetName() string {
@@ -3337,7 +3453,7 @@ type Span_Status struct {
 func (x *Span_Status) Reset() {
 	*x = Span_Status{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_runtime_protos_runtime_proto_msgTypes[51]
+		mi := &file_runtime_protos_runtime_proto_msgTypes[54]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -3350,7 +3466,7 @@ func (x *Span_Status) String() string {
 func (*Span_Status) ProtoMessage() {}
 
 func (x *Span_Status) ProtoReflect() protoreflect.Message {
-	mi := &file_runtime_protos_runtime_proto_msgTypes[51]
+	mi := &file_runtime_protos_runtime_proto_msgTypes[54]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3363,7 +3479,7 @@ func (x *Span_Status) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Span_Status.ProtoReflect.Descriptor instead.
 func (*Span_Status) Descriptor() ([]byte, []int) {
-	return file_runtime_protos_runtime_proto_rawDescGZIP(), []int{38, 3}
+	return file_runtime_protos_runtime_proto_rawDescGZIP(), []int{39, 3}
 }
 
 func (x *Span_Status) GetCode() Span_Status_Code {
@@ -3396,7 +3512,7 @@ type Span_Scope struct {
 func (x *Span_Scope) Reset() {
 	*x = Span_Scope{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_runtime_protos_runtime_proto_msgTypes[52]
+		mi := &file_runtime_protos_runtime_proto_msgTypes[55]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -3409,7 +3525,7 @@ func (x *Span_Scope) String() string {
 func (*Span_Scope) ProtoMessage() {}
 
 func (x *Span_Scope) ProtoReflect() protoreflect.Message {
-	mi := &file_runtime_protos_runtime_proto_msgTypes[52]
+	mi := &file_runtime_protos_runtime_proto_msgTypes[55]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3422,7 +3538,7 @@ func (x *Span_Scope) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Span_Scope.ProtoReflect.Descriptor instead.
 func (*Span_Scope) Descriptor() ([]byte, []int) {
-	return file_runtime_protos_runtime_proto_rawDescGZIP(), []int{38, 4}
+	return file_runtime_protos_runtime_proto_rawDescGZIP(), []int{39, 4}
 }
 
 func (x *Span_Scope) GetName() string {
@@ -3463,7 +3579,7 @@ type Span_Library struct {
 func (x *Span_Library) Reset() {
 	*x = Span_Library{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_runtime_protos_runtime_proto_msgTypes[53]
+		mi := &file_runtime_protos_runtime_proto_msgTypes[56]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -3476,7 +3592,7 @@ func (x *Span_Library) String() string {
 func (*Span_Library) ProtoMessage() {}
 
 func (x *Span_Library) ProtoReflect() protoreflect.Message {
-	mi := &file_runtime_protos_runtime_proto_msgTypes[53]
+	mi := &file_runtime_protos_runtime_proto_msgTypes[56]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3489,7 +3605,7 @@ func (x *Span_Library) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Span_Library.ProtoReflect.Descriptor instead.
 func (*Span_Library) Descriptor() ([]byte, []int) {
-	return file_runtime_protos_runtime_proto_rawDescGZIP(), []int{38, 5}
+	return file_runtime_protos_runtime_proto_rawDescGZIP(), []int{39, 5}
 }
 
 func (x *Span_Library) GetName() string {
@@ -3528,7 +3644,7 @@ type Span_Resource struct {
 func (x *Span_Resource) Reset() {
 	*x = Span_Resource{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_runtime_protos_runtime_proto_msgTypes[54]
+		mi := &file_runtime_protos_runtime_proto_msgTypes[57]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -3541,7 +3657,7 @@ func (x *Span_Resource) String() string {
 func (*Span_Resource) ProtoMessage() {}
 
 func (x *Span_Resource) ProtoReflect() protoreflect.Message {
-	mi := &file_runtime_protos_runtime_proto_msgTypes[54]
+	mi := &file_runtime_protos_runtime_proto_msgTypes[57]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3554,7 +3670,7 @@ func (x *Span_Resource) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Span_Resource.ProtoReflect.Descriptor instead.
 func (*Span_Resource) Descriptor() ([]byte, []int) {
-	return file_runtime_protos_runtime_proto_rawDescGZIP(), []int{38, 6}
+	return file_runtime_protos_runtime_proto_rawDescGZIP(), []int{39, 6}
 }
 
 func (x *Span_Resource) GetSchemaUrl() string {
@@ -3589,7 +3705,7 @@ type Span_Attribute_Value struct {
 func (x *Span_Attribute_Value) Reset() {
 	*x = Span_Attribute_Value{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_runtime_protos_runtime_proto_msgTypes[55]
+		mi := &file_runtime_protos_runtime_proto_msgTypes[58]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -3602,7 +3718,7 @@ func (x *Span_Attribute_Value) String() string {
 func (*Span_Attribute_Value) ProtoMessage() {}
 
 func (x *Span_Attribute_Value) ProtoReflect() protoreflect.Message {
-	mi := &file_runtime_protos_runtime_proto_msgTypes[55]
+	mi := &file_runtime_protos_runtime_proto_msgTypes[58]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3615,7 +3731,7 @@ func (x *Span_Attribute_Value) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Span_Attribute_Value.ProtoReflect.Descriptor instead.
 func (*Span_Attribute_Value) Descriptor() ([]byte, []int) {
-	return file_runtime_protos_runtime_proto_rawDescGZIP(), []int{38, 0, 0}
+	return file_runtime_protos_runtime_proto_rawDescGZIP(), []int{39, 0, 0}
 }
 
 func (x *Span_Attribute_Value) GetType() Span_Attribute_Value_Type {
@@ -3699,7 +3815,7 @@ type Span_Attribute_Value_NumberList struct {
 func (x *Span_Attribute_Value_NumberList) Reset() {
 	*x = Span_Attribute_Value_NumberList{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_runtime_protos_runtime_proto_msgTypes[56]
+		mi := &file_runtime_protos_runtime_proto_msgTypes[59]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -3712,7 +3828,7 @@ func (x *Span_Attribute_Value_NumberList) String() string {
 func (*Span_Attribute_Value_NumberList) ProtoMessage() {}
 
 func (x *Span_Attribute_Value_NumberList) ProtoReflect() protoreflect.Message {
-	mi := &file_runtime_protos_runtime_proto_msgTypes[56]
+	mi := &file_runtime_protos_runtime_proto_msgTypes[59]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3725,7 +3841,7 @@ func (x *Span_Attribute_Value_NumberList) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Span_Attribute_Value_NumberList.ProtoReflect.Descriptor instead.
 func (*Span_Attribute_Value_NumberList) Descriptor() ([]byte, []int) {
-	return file_runtime_protos_runtime_proto_rawDescGZIP(), []int{38, 0, 0, 0}
+	return file_runtime_protos_runtime_proto_rawDescGZIP(), []int{39, 0, 0, 0}
 }
 
 func (x *Span_Attribute_Value_NumberList) GetNums() []uint64 {
@@ -3746,7 +3862,7 @@ type Span_Attribute_Value_StringList struct {
 func (x *Span_Attribute_Value_StringList) Reset() {
 	*x = Span_Attribute_Value_StringList{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_runtime_protos_runtime_proto_msgTypes[57]
+		mi := &file_runtime_protos_runtime_proto_msgTypes[60]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -3759,7 +3875,7 @@ func (x *Span_Attribute_Value_StringList) String() string {
 func (*Span_Attribute_Value_StringList) ProtoMessage() {}
 
 func (x *Span_Attribute_Value_StringList) ProtoReflect() protoreflect.Message {
-	mi := &file_runtime_protos_runtime_proto_msgTypes[57]
+	mi := &file_runtime_protos_runtime_proto_msgTypes[60]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -3772,7 +3888,7 @@ func (x *Span_Attribute_Value_StringList) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Span_Attribute_Value_StringList.ProtoReflect.Descriptor instead.
 func (*Span_Attribute_Value_StringList) Descriptor() ([]byte, []int) {
-	return file_runtime_protos_runtime_proto_rawDescGZIP(), []int{38, 0, 0, 1}
+	return file_runtime_protos_runtime_proto_rawDescGZIP(), []int{39, 0, 0, 1}
 }
 
 func (x *Span_Attribute_Value_StringList) GetStrs() []string {
@@ -3787,59 +3903,95 @@ var File_runtime_protos_runtime_proto protoreflect.FileDescriptor
 var file_runtime_protos_runtime_proto_rawDesc = []byte{
 	0x0a, 0x1c, 0x72, 0x75, 0x6e, 0x74, 0x69, 0x6d, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73,
 	0x2f, 0x72, 0x75, 0x6e, 0x74, 0x69, 0x6d, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x07,
-	0x72, 0x75, 0x6e, 0x74, 0x69, 0x6d, 0x65, 0x22, 0xf6, 0x02, 0x0a, 0x0c, 0x57, 0x65, 0x61, 0x76,
-	0x65, 0x6c, 0x65, 0x74, 0x41, 0x72, 0x67, 0x73, 0x12, 0x10, 0x0a, 0x03, 0x61, 0x70, 0x70, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x61, 0x70, 0x70, 0x12, 0x23, 0x0a, 0x0d, 0x64, 0x65,
-	0x70, 0x6c, 0x6f, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x0c, 0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x49, 0x64, 0x12,
-	0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12,
-	0x19, 0x0a, 0x08, 0x72, 0x75, 0x6e, 0x5f, 0x6d, 0x61, 0x69, 0x6e, 0x18, 0x07, 0x20, 0x01, 0x28,
-	0x08, 0x52, 0x07, 0x72, 0x75, 0x6e, 0x4d, 0x61, 0x69, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x6d, 0x74,
-	0x6c, 0x73, 0x18, 0x08, 0x20, 0x01, 0x28, 0x08, 0x52, 0x04, 0x6d, 0x74, 0x6c, 0x73, 0x12, 0x29,
-	0x0a, 0x10, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x65,
-	0x73, 0x73, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e,
-	0x61, 0x6c, 0x41, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x25, 0x0a, 0x0e, 0x63, 0x6f, 0x6e,
-	0x74, 0x72, 0x6f, 0x6c, 0x5f, 0x73, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x18, 0x0d, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x0d, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x53, 0x6f, 0x63, 0x6b, 0x65, 0x74,
-	0x12, 0x3c, 0x0a, 0x09, 0x72, 0x65, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x73, 0x18, 0x0c, 0x20,
-	0x03, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x72, 0x75, 0x6e, 0x74, 0x69, 0x6d, 0x65, 0x2e, 0x57, 0x65,
-	0x61, 0x76, 0x65, 0x6c, 0x65, 0x74, 0x41, 0x72, 0x67, 0x73, 0x2e, 0x52, 0x65, 0x64, 0x69, 0x72,
-	0x65, 0x63, 0x74, 0x52, 0x09, 0x72, 0x65, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x73, 0x1a, 0x5a,
-	0x0a, 0x08, 0x52, 0x65, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x63, 0x6f,
-	0x6d, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x63,
-	0x6f, 0x6d, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x74, 0x61, 0x72, 0x67,
-	0x65, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74,
-	0x12, 0x18, 0x0a, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x4a, 0x04, 0x08, 0x04, 0x10, 0x05,
-	0x22, 0x9a, 0x01, 0x0a, 0x13, 0x49, 0x6e, 0x69, 0x74, 0x57, 0x65, 0x61, 0x76, 0x65, 0x6c, 0x65,
-	0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x46, 0x0a, 0x08, 0x73, 0x65, 0x63, 0x74,
-	0x69, 0x6f, 0x6e, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2a, 0x2e, 0x72, 0x75, 0x6e,
-	0x74, 0x69, 0x6d, 0x65, 0x2e, 0x49, 0x6e, 0x69, 0x74, 0x57, 0x65, 0x61, 0x76, 0x65, 0x6c, 0x65,
-	0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x53, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e,
-	0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x08, 0x73, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x72, 0x75, 0x6e, 0x74, 0x69, 0x6d, 0x65, 0x1a, 0x1b, 0x72, 0x75, 0x6e, 0x74, 0x69, 0x6d, 0x65,
+	0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73, 0x2f, 0x63, 0x6f, 0x6e, 0x66, 0x69, 0x67, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x22, 0xf6, 0x02, 0x0a, 0x0c, 0x57, 0x65, 0x61, 0x76, 0x65, 0x6c, 0x65,
+	0x74, 0x41, 0x72, 0x67, 0x73, 0x12, 0x10, 0x0a, 0x03, 0x61, 0x70, 0x70, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x03, 0x61, 0x70, 0x70, 0x12, 0x23, 0x0a, 0x0d, 0x64, 0x65, 0x70, 0x6c, 0x6f,
+	0x79, 0x6d, 0x65, 0x6e, 0x74, 0x5f, 0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c,
+	0x64, 0x65, 0x70, 0x6c, 0x6f, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x49, 0x64, 0x12, 0x0e, 0x0a, 0x02,
+	0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x19, 0x0a, 0x08,
+	0x72, 0x75, 0x6e, 0x5f, 0x6d, 0x61, 0x69, 0x6e, 0x18, 0x07, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07,
+	0x72, 0x75, 0x6e, 0x4d, 0x61, 0x69, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x6d, 0x74, 0x6c, 0x73, 0x18,
+	0x08, 0x20, 0x01, 0x28, 0x08, 0x52, 0x04, 0x6d, 0x74, 0x6c, 0x73, 0x12, 0x29, 0x0a, 0x10, 0x69,
+	0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18,
+	0x0a, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x69, 0x6e, 0x74, 0x65, 0x72, 0x6e, 0x61, 0x6c, 0x41,
+	0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x12, 0x25, 0x0a, 0x0e, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f,
+	0x6c, 0x5f, 0x73, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d,
+	0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x53, 0x6f, 0x63, 0x6b, 0x65, 0x74, 0x12, 0x3c, 0x0a,
+	0x09, 0x72, 0x65, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x73, 0x18, 0x0c, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x1e, 0x2e, 0x72, 0x75, 0x6e, 0x74, 0x69, 0x6d, 0x65, 0x2e, 0x57, 0x65, 0x61, 0x76, 0x65,
+	0x6c, 0x65, 0x74, 0x41, 0x72, 0x67, 0x73, 0x2e, 0x52, 0x65, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74,
+	0x52, 0x09, 0x72, 0x65, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x73, 0x1a, 0x5a, 0x0a, 0x08, 0x52,
+	0x65, 0x64, 0x69, 0x72, 0x65, 0x63, 0x74, 0x12, 0x1c, 0x0a, 0x09, 0x63, 0x6f, 0x6d, 0x70, 0x6f,
+	0x6e, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x6f, 0x6d, 0x70,
+	0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x74, 0x61, 0x72, 0x67, 0x65, 0x74, 0x12, 0x18, 0x0a,
+	0x07, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07,
+	0x61, 0x64, 0x64, 0x72, 0x65, 0x73, 0x73, 0x4a, 0x04, 0x08, 0x04, 0x10, 0x05, 0x22, 0x8c, 0x04,
+	0x0a, 0x13, 0x49, 0x6e, 0x69, 0x74, 0x57, 0x65, 0x61, 0x76, 0x65, 0x6c, 0x65, 0x74, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x46, 0x0a, 0x08, 0x73, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e,
+	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x2a, 0x2e, 0x72, 0x75, 0x6e, 0x74, 0x69, 0x6d,
+	0x65, 0x2e, 0x49, 0x6e, 0x69, 0x74, 0x57, 0x65, 0x61, 0x76, 0x65, 0x6c, 0x65, 0x74, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x53, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x45, 0x6e,
+	0x74, 0x72, 0x79, 0x52, 0x08, 0x73, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x49, 0x0a,
+	0x09, 0x62, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x72, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x2b, 0x2e, 0x72, 0x75, 0x6e, 0x74, 0x69, 0x6d, 0x65, 0x2e, 0x49, 0x6e, 0x69, 0x74, 0x57,
+	0x65, 0x61, 0x76, 0x65, 0x6c, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x42,
+	0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x72, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x09, 0x62,
+	0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x72, 0x73, 0x12, 0x62, 0x0a, 0x12, 0x63, 0x6f, 0x6e, 0x63,
+	0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x5f, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x18, 0x03,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x33, 0x2e, 0x72, 0x75, 0x6e, 0x74, 0x69, 0x6d, 0x65, 0x2e, 0x49,
+	0x6e, 0x69, 0x74, 0x57, 0x65, 0x61, 0x76, 0x65, 0x6c, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x2e, 0x43, 0x6f, 0x6e, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x4c, 0x69,
+	0x6d, 0x69, 0x74, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x11, 0x63, 0x6f, 0x6e, 0x63, 0x75,
+	0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x12, 0x22, 0x0a, 0x0c,
+	0x75, 0x6e, 0x63, 0x6f, 0x6d, 0x70, 0x72, 0x65, 0x73, 0x73, 0x65, 0x64, 0x18, 0x04, 0x20, 0x03,
+	0x28, 0x09, 0x52, 0x0c, 0x75, 0x6e, 0x63, 0x6f, 0x6d, 0x70, 0x72, 0x65, 0x73, 0x73, 0x65, 0x64,
 	0x1a, 0x3b, 0x0a, 0x0d, 0x53, 0x65, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x45, 0x6e, 0x74, 0x72,
 	0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03,
 	0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x5b, 0x0a,
-	0x11, 0x49, 0x6e, 0x69, 0x74, 0x57, 0x65, 0x61, 0x76, 0x65, 0x6c, 0x65, 0x74, 0x52, 0x65, 0x70,
-	0x6c, 0x79, 0x12, 0x1b, 0x0a, 0x09, 0x64, 0x69, 0x61, 0x6c, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x18,
-	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x64, 0x69, 0x61, 0x6c, 0x41, 0x64, 0x64, 0x72, 0x12,
-	0x29, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x0f, 0x2e, 0x72, 0x75, 0x6e, 0x74, 0x69, 0x6d, 0x65, 0x2e, 0x53, 0x65, 0x6d, 0x56, 0x65,
-	0x72, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x4a, 0x0a, 0x06, 0x53, 0x65,
-	0x6d, 0x56, 0x65, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x6d, 0x61, 0x6a, 0x6f, 0x72, 0x18, 0x01, 0x20,
-	0x01, 0x28, 0x03, 0x52, 0x05, 0x6d, 0x61, 0x6a, 0x6f, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x6d, 0x69,
-	0x6e, 0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x6d, 0x69, 0x6e, 0x6f, 0x72,
-	0x12, 0x14, 0x0a, 0x05, 0x70, 0x61, 0x74, 0x63, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52,
-	0x05, 0x70, 0x61, 0x74, 0x63, 0x68, 0x22, 0x12, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x48, 0x65, 0x61,
-	0x6c, 0x74, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x6e, 0x0a, 0x0e, 0x47, 0x65,
+	0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x3c, 0x0a,
+	0x0e, 0x42, 0x61, 0x6c, 0x61, 0x6e, 0x63, 0x65, 0x72, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12,
+	0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65,
+	0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x5f, 0x0a, 0x16, 0x43,
+	0x6f, 0x6e, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x73,
+	0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x2f, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x72, 0x75, 0x6e, 0x74, 0x69, 0x6d, 0x65,
+	0x2e, 0x43, 0x6f, 0x6e, 0x63, 0x75, 0x72, 0x72, 0x65, 0x6e, 0x63, 0x79, 0x4c, 0x69, 0x6d, 0x69,
+	0x74, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x5b, 0x0a, 0x11,
+	0x49, 0x6e, 0x69, 0x74, 0x57, 0x65, 0x61, 0x76, 0x65, 0x6c, 0x65, 0x74, 0x52, 0x65, 0x70, 0x6c,
+	0x79, 0x12, 0x1b, 0x0a, 0x09, 0x64, 0x69, 0x61, 0x6c, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x64, 0x69, 0x61, 0x6c, 0x41, 0x64, 0x64, 0x72, 0x12, 0x29,
+	0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x0f, 0x2e, 0x72, 0x75, 0x6e, 0x74, 0x69, 0x6d, 0x65, 0x2e, 0x53, 0x65, 0x6d, 0x56, 0x65, 0x72,
+	0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x4a, 0x0a, 0x06, 0x53, 0x65, 0x6d,
+	0x56, 0x65, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x6d, 0x61, 0x6a, 0x6f, 0x72, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x03, 0x52, 0x05, 0x6d, 0x61, 0x6a, 0x6f, 0x72, 0x12, 0x14, 0x0a, 0x05, 0x6d, 0x69, 0x6e,
+	0x6f, 0x72, 0x18, 0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05, 0x6d, 0x69, 0x6e, 0x6f, 0x72, 0x12,
+	0x14, 0x0a, 0x05, 0x70, 0x61, 0x74, 0x63, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x05,
+	0x70, 0x61, 0x74, 0x63, 0x68, 0x22, 0x12, 0x0a, 0x10, 0x47, 0x65, 0x74, 0x48, 0x65, 0x61, 0x6c,
+	0x74, 0x68, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0xa8, 0x01, 0x0a, 0x0e, 0x47, 0x65,
 	0x74, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x52, 0x65, 0x70, 0x6c, 0x79, 0x12, 0x2d, 0x0a, 0x06,
 	0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x15, 0x2e, 0x72,
 	0x75, 0x6e, 0x74, 0x69, 0x6d, 0x65, 0x2e, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x53, 0x74, 0x61,
 	0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x2d, 0x0a, 0x12, 0x68,
 	0x65, 0x61, 0x6c, 0x74, 0x68, 0x79, 0x5f, 0x63, 0x6f, 0x6d, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74,
 	0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x11, 0x68, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x79,
-	0x43, 0x6f, 0x6d, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x73, 0x22, 0x13, 0x0a, 0x11, 0x47, 0x65,
+	0x43, 0x6f, 0x6d, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x73, 0x12, 0x38, 0x0a, 0x0a, 0x63, 0x6f,
+	0x6d, 0x70, 0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x18,
+	0x2e, 0x72, 0x75, 0x6e, 0x74, 0x69, 0x6d, 0x65, 0x2e, 0x43, 0x6f, 0x6d, 0x70, 0x6f, 0x6e, 0x65,
+	0x6e, 0x74, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x52, 0x0a, 0x63, 0x6f, 0x6d, 0x70, 0x6f, 0x6e,
+	0x65, 0x6e, 0x74, 0x73, 0x22, 0x74, 0x0a, 0x0f, 0x43, 0x6f, 0x6d, 0x70, 0x6f, 0x6e, 0x65, 0x6e,
+	0x74, 0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x12, 0x1c, 0x0a, 0x09, 0x63, 0x6f, 0x6d, 0x70, 0x6f,
+	0x6e, 0x65, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x63, 0x6f, 0x6d, 0x70,
+	0x6f, 0x6e, 0x65, 0x6e, 0x74, 0x12, 0x2d, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x15, 0x2e, 0x72, 0x75, 0x6e, 0x74, 0x69, 0x6d, 0x65, 0x2e,
+	0x48, 0x65, 0x61, 0x6c, 0x74, 0x68, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x05, 0x65, 0x72, 0x72, 0x6f, 0x72, 0x22, 0x13, 0x0a, 0x11, 0x47, 0x65,
 	0x74, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22,
 	0x40, 0x0a, 0x0f, 0x47, 0x65, 0x74, 0x4d, 0x65, 0x74, 0x72, 0x69, 0x63, 0x73, 0x52, 0x65, 0x70,
 	0x6c, 0x79, 0x12, 0x2d, 0x0a, 0x06, 0x75, 0x70, 0x64, 0x61, 0x74, 0x65, 0x18, 0x01, 0x20, 0x01,
@@ -4173,14 +4325,18 @@ var file_runtime_protos_runtime_proto_rawDesc = []byte{
 	0x72, 0x69, 0x63, 0x54, 0x79, 0x70, 0x65, 0x12, 0x0b, 0x0a, 0x07, 0x49, 0x4e, 0x56, 0x41, 0x4c,
 	0x49, 0x44, 0x10, 0x00, 0x12, 0x0b, 0x0a, 0x07, 0x43, 0x4f, 0x55, 0x4e, 0x54, 0x45, 0x52, 0x10,
 	0x01, 0x12, 0x09, 0x0a, 0x05, 0x47, 0x41, 0x55, 0x47, 0x45, 0x10, 0x02, 0x12, 0x0d, 0x0a, 0x09,
-	0x48, 0x49, 0x53, 0x54, 0x4f, 0x47, 0x52, 0x41, 0x4d, 0x10, 0x03, 0x2a, 0x31, 0x0a, 0x0b, 0x50,
+	0x48, 0x49, 0x53, 0x54, 0x4f, 0x47, 0x52, 0x41, 0x4d, 0x10, 0x03, 0x2a, 0x74, 0x0a, 0x0b, 0x50,
 	0x72, 0x6f, 0x66, 0x69, 0x6c, 0x65, 0x54, 0x79, 0x70, 0x65, 0x12, 0x0f, 0x0a, 0x0b, 0x55, 0x6e,
 	0x73, 0x70, 0x65, 0x63, 0x69, 0x66, 0x69, 0x65, 0x64, 0x10, 0x00, 0x12, 0x08, 0x0a, 0x04, 0x48,
-	0x65, 0x61, 0x70, 0x10, 0x01, 0x12, 0x07, 0x0a, 0x03, 0x43, 0x50, 0x55, 0x10, 0x02, 0x42, 0x30,
-	0x5a, 0x2e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x53, 0x65, 0x72,
-	0x76, 0x69, 0x63, 0x65, 0x57, 0x65, 0x61, 0x76, 0x65, 0x72, 0x2f, 0x77, 0x65, 0x61, 0x76, 0x65,
-	0x72, 0x2f, 0x72, 0x75, 0x6e, 0x74, 0x69, 0x6d, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x73,
-	0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x65, 0x61, 0x70, 0x10, 0x01, 0x12, 0x07, 0x0a, 0x03, 0x43, 0x50, 0x55, 0x10, 0x02, 0x12, 0x0d,
+	0x0a, 0x09, 0x47, 0x6f, 0x72, 0x6f, 0x75, 0x74, 0x69, 0x6e, 0x65, 0x10, 0x03, 0x12, 0x09, 0x0a,
+	0x05, 0x4d, 0x75, 0x74, 0x65, 0x78, 0x10, 0x04, 0x12, 0x09, 0x0a, 0x05, 0x42, 0x6c, 0x6f, 0x63,
+	0x6b, 0x10, 0x05, 0x12, 0x0a, 0x0a, 0x06, 0x41, 0x6c, 0x6c, 0x6f, 0x63, 0x73, 0x10, 0x06, 0x12,
+	0x10, 0x0a, 0x0c, 0x54, 0x68, 0x72, 0x65, 0x61, 0x64, 0x43, 0x72, 0x65, 0x61, 0x74, 0x65, 0x10,
+	0x07, 0x42, 0x30, 0x5a, 0x2e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f,
+	0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x57, 0x65, 0x61, 0x76, 0x65, 0x72, 0x2f, 0x77, 0x65,
+	0x61, 0x76, 0x65, 0x72, 0x2f, 0x72, 0x75, 0x6e, 0x74, 0x69, 0x6d, 0x65, 0x2f, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x73, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -4196,7 +4352,7 @@ func file_runtime_protos_runtime_proto_rawDescGZIP() []byte {
 }
 
 var file_runtime_protos_runtime_proto_enumTypes = make([]protoimpl.EnumInfo, 6)
-var file_runtime_protos_runtime_proto_msgTypes = make([]protoimpl.MessageInfo, 58)
+var file_runtime_protos_runtime_proto_msgTypes = make([]protoimpl.MessageInfo, 61)
 var file_runtime_protos_runtime_proto_goTypes = []interface{}{
 	(HealthStatus)(0),                       // 0: runtime.HealthStatus
 	(MetricType)(0),                         // 1: runtime.MetricType
@@ -4210,103 +4366,112 @@ var file_runtime_protos_runtime_proto_goTypes = []interface{}{
 	(*SemVer)(nil),                          // 9: runtime.SemVer
 	(*GetHealthRequest)(nil),                // 10: runtime.GetHealthRequest
 	(*GetHealthReply)(nil),                  // 11: runtime.GetHealthReply
-	(*GetMetricsRequest)(nil),               // 12: runtime.GetMetricsRequest
-	(*GetMetricsReply)(nil),                 // 13: runtime.GetMetricsReply
-	(*MetricUpdate)(nil),                    // 14: runtime.MetricUpdate
-	(*MetricDef)(nil),                       // 15: runtime.MetricDef
-	(*MetricValue)(nil),                     // 16: runtime.MetricValue
-	(*MetricSnapshot)(nil),                  // 17: runtime.MetricSnapshot
-	(*GetLoadRequest)(nil),                  // 18: runtime.GetLoadRequest
-	(*GetLoadReply)(nil),                    // 19: runtime.GetLoadReply
-	(*LoadReport)(nil),                      // 20: runtime.LoadReport
-	(*GetProfileRequest)(nil),               // 21: runtime.GetProfileRequest
-	(*GetProfileReply)(nil),                 // 22: runtime.GetProfileReply
-	(*UpdateRoutingInfoRequest)(nil),        // 23: runtime.UpdateRoutingInfoRequest
-	(*UpdateRoutingInfoReply)(nil),          // 24: runtime.UpdateRoutingInfoReply
-	(*RoutingInfo)(nil),                     // 25: runtime.RoutingInfo
-	(*Assignment)(nil),                      // 26: runtime.Assignment
-	(*UpdateComponentsRequest)(nil),         // 27: runtime.UpdateComponentsRequest
-	(*UpdateComponentsReply)(nil),           // 28: runtime.UpdateComponentsReply
-	(*ActivateComponentRequest)(nil),        // 29: runtime.ActivateComponentRequest
-	(*ActivateComponentReply)(nil),          // 30: runtime.ActivateComponentReply
-	(*GetListenerAddressRequest)(nil),       // 31: runtime.GetListenerAddressRequest
-	(*GetListenerAddressReply)(nil),         // 32: runtime.GetListenerAddressReply
-	(*ExportListenerRequest)(nil),           // 33: runtime.ExportListenerRequest
-	(*ExportListenerReply)(nil),             // 34: runtime.ExportListenerReply
-	(*GetSelfCertificateRequest)(nil),       // 35: runtime.GetSelfCertificateRequest
-	(*GetSelfCertificateReply)(nil),         // 36: runtime.GetSelfCertificateReply
-	(*VerifyClientCertificateRequest)(nil),  // 37: runtime.VerifyClientCertificateRequest
-	(*VerifyClientCertificateReply)(nil),    // 38: runtime.VerifyClientCertificateReply
-	(*VerifyServerCertificateRequest)(nil),  // 39: runtime.VerifyServerCertificateRequest
-	(*VerifyServerCertificateReply)(nil),    // 40: runtime.VerifyServerCertificateReply
-	(*LogEntry)(nil),                        // 41: runtime.LogEntry
-	(*LogEntryBatch)(nil),                   // 42: runtime.LogEntryBatch
-	(*TraceSpans)(nil),                      // 43: runtime.TraceSpans
-	(*Span)(nil),                            // 44: runtime.Span
-	(*WeaveletArgs_Redirect)(nil),           // 45: runtime.WeaveletArgs.Redirect
-	nil,                                     // 46: runtime.InitWeaveletRequest.SectionsEntry
-	nil,                                     // 47: runtime.MetricDef.LabelsEntry
-	nil,                                     // 48: runtime.MetricSnapshot.LabelsEntry
-	nil,                                     // 49: runtime.LoadReport.LoadsEntry
-	(*LoadReport_ComponentLoad)(nil),        // 50: runtime.LoadReport.ComponentLoad
-	(*LoadReport_SliceLoad)(nil),            // 51: runtime.LoadReport.SliceLoad
-	(*LoadReport_SubsliceLoad)(nil),         // 52: runtime.LoadReport.SubsliceLoad
-	(*Assignment_Slice)(nil),                // 53: runtime.Assignment.Slice
-	(*Span_Attribute)(nil),                  // 54: runtime.Span.Attribute
-	(*Span_Link)(nil),                       // 55: runtime.Span.Link
-	(*Span_Event)(nil),                      // 56: runtime.Span.Event
-	(*Span_Status)(nil),                     // 57: runtime.Span.Status
-	(*Span_Scope)(nil),                      // 58: runtime.Span.Scope
-	(*Span_Library)(nil),                    // 59: runtime.Span.Library
-	(*Span_Resource)(nil),                   // 60: runtime.Span.Resource
-	(*Span_Attribute_Value)(nil),            // 61: runtime.Span.Attribute.Value
-	(*Span_Attribute_Value_NumberList)(nil), // 62: runtime.Span.Attribute.Value.NumberList
-	(*Span_Attribute_Value_StringList)(nil), // 63: runtime.Span.Attribute.Value.StringList
+	(*ComponentHealth)(nil),                 // 12: runtime.ComponentHealth
+	(*GetMetricsRequest)(nil),               // 13: runtime.GetMetricsRequest
+	(*GetMetricsReply)(nil),                 // 14: runtime.GetMetricsReply
+	(*MetricUpdate)(nil),                    // 15: runtime.MetricUpdate
+	(*MetricDef)(nil),                       // 16: runtime.MetricDef
+	(*MetricValue)(nil),                     // 17: runtime.MetricValue
+	(*MetricSnapshot)(nil),                  // 18: runtime.MetricSnapshot
+	(*GetLoadRequest)(nil),                  // 19: runtime.GetLoadRequest
+	(*GetLoadReply)(nil),                    // 20: runtime.GetLoadReply
+	(*LoadReport)(nil),                      // 21: runtime.LoadReport
+	(*GetProfileRequest)(nil),               // 22: runtime.GetProfileRequest
+	(*GetProfileReply)(nil),                 // 23: runtime.GetProfileReply
+	(*UpdateRoutingInfoRequest)(nil),        // 24: runtime.UpdateRoutingInfoRequest
+	(*UpdateRoutingInfoReply)(nil),          // 25: runtime.UpdateRoutingInfoReply
+	(*RoutingInfo)(nil),                     // 26: runtime.RoutingInfo
+	(*Assignment)(nil),                      // 27: runtime.Assignment
+	(*UpdateComponentsRequest)(nil),         // 28: runtime.UpdateComponentsRequest
+	(*UpdateComponentsReply)(nil),           // 29: runtime.UpdateComponentsReply
+	(*ActivateComponentRequest)(nil),        // 30: runtime.ActivateComponentRequest
+	(*ActivateComponentReply)(nil),          // 31: runtime.ActivateComponentReply
+	(*GetListenerAddressRequest)(nil),       // 32: runtime.GetListenerAddressRequest
+	(*GetListenerAddressReply)(nil),         // 33: runtime.GetListenerAddressReply
+	(*ExportListenerRequest)(nil),           // 34: runtime.ExportListenerRequest
+	(*ExportListenerReply)(nil),             // 35: runtime.ExportListenerReply
+	(*GetSelfCertificateRequest)(nil),       // 36: runtime.GetSelfCertificateRequest
+	(*GetSelfCertificateReply)(nil),         // 37: runtime.GetSelfCertificateReply
+	(*VerifyClientCertificateRequest)(nil),  // 38: runtime.VerifyClientCertificateRequest
+	(*VerifyClientCertificateReply)(nil),    // 39: runtime.VerifyClientCertificateReply
+	(*VerifyServerCertificateRequest)(nil),  // 40: runtime.VerifyServerCertificateRequest
+	(*VerifyServerCertificateReply)(nil),    // 41: runtime.VerifyServerCertificateReply
+	(*LogEntry)(nil),                        // 42: runtime.LogEntry
+	(*LogEntryBatch)(nil),                   // 43: runtime.LogEntryBatch
+	(*TraceSpans)(nil),                      // 44: runtime.TraceSpans
+	(*Span)(nil),                            // 45: runtime.Span
+	(*WeaveletArgs_Redirect)(nil),           // 46: runtime.WeaveletArgs.Redirect
+	nil,                                     // 47: runtime.InitWeaveletRequest.SectionsEntry
+	nil,                                     // 48: runtime.InitWeaveletRequest.BalancersEntry
+	nil,                                     // 49: runtime.InitWeaveletRequest.ConcurrencyLimitsEntry
+	nil,                                     // 50: runtime.MetricDef.LabelsEntry
+	nil,                                     // 51: runtime.MetricSnapshot.LabelsEntry
+	nil,                                     // 52: runtime.LoadReport.LoadsEntry
+	(*LoadReport_ComponentLoad)(nil),        // 53: runtime.LoadReport.ComponentLoad
+	(*LoadReport_SliceLoad)(nil),            // 54: runtime.LoadReport.SliceLoad
+	(*LoadReport_SubsliceLoad)(nil),         // 55: runtime.LoadReport.SubsliceLoad
+	(*Assignment_Slice)(nil),                // 56: runtime.Assignment.Slice
+	(*Span_Attribute)(nil),                  // 57: runtime.Span.Attribute
+	(*Span_Link)(nil),                       // 58: runtime.Span.Link
+	(*Span_Event)(nil),                      // 59: runtime.Span.Event
+	(*Span_Status)(nil),                     // 60: runtime.Span.Status
+	(*Span_Scope)(nil),                      // 61: runtime.Span.Scope
+	(*Span_Library)(nil),                    // 62: runtime.Span.Library
+	(*Span_Resource)(nil),                   // 63: runtime.Span.Resource
+	(*Span_Attribute_Value)(nil),            // 64: runtime.Span.Attribute.Value
+	(*Span_Attribute_Value_NumberList)(nil), // 65: runtime.Span.Attribute.Value.NumberList
+	(*Span_Attribute_Value_StringList)(nil), // 66: runtime.Span.Attribute.Value.StringList
+	(*ConcurrencyLimit)(nil),                // 67: runtime.ConcurrencyLimit
 }
 var file_runtime_protos_runtime_proto_depIdxs = []int32{
-	45, // 0: runtime.WeaveletArgs.redirects:type_name -> runtime.WeaveletArgs.Redirect
-	46, // 1: runtime.InitWeaveletRequest.sections:type_name -> runtime.InitWeaveletRequest.SectionsEntry
-	9,  // 2: runtime.InitWeaveletReply.version:type_name -> runtime.SemVer
-	0,  // 3: runtime.GetHealthReply.status:type_name -> runtime.HealthStatus
-	14, // 4: runtime.GetMetricsReply.update:type_name -> runtime.MetricUpdate
-	15, // 5: runtime.MetricUpdate.defs:type_name -> runtime.MetricDef
-	16, // 6: runtime.MetricUpdate.values:type_name -> runtime.MetricValue
-	1,  // 7: runtime.MetricDef.typ:type_name -> runtime.MetricType
-	47, // 8: runtime.MetricDef.labels:type_name -> runtime.MetricDef.LabelsEntry
-	1,  // 9: runtime.MetricSnapshot.typ:type_name -> runtime.MetricType
-	48, // 10: runtime.MetricSnapshot.labels:type_name -> runtime.MetricSnapshot.LabelsEntry
-	20, // 11: runtime.GetLoadReply.load:type_name -> runtime.LoadReport
-	49, // 12: runtime.LoadReport.loads:type_name -> runtime.LoadReport.LoadsEntry
-	2,  // 13: runtime.GetProfileRequest.profile_type:type_name -> runtime.ProfileType
-	25, // 14: runtime.UpdateRoutingInfoRequest.routing_info:type_name -> runtime.RoutingInfo
-	26, // 15: runtime.RoutingInfo.assignment:type_name -> runtime.Assignment
-	53, // 16: runtime.Assignment.slices:type_name -> runtime.Assignment.Slice
-	41, // 17: runtime.LogEntryBatch.entries:type_name -> runtime.LogEntry
-	44, // 18: runtime.TraceSpans.span:type_name -> runtime.Span
-	3,  // 19: runtime.Span.kind:type_name -> runtime.Span.Kind
-	54, // 20: runtime.Span.attributes:type_name -> runtime.Span.Attribute
-	55, // 21: runtime.Span.links:type_name -> runtime.Span.Link
-	56, // 22: runtime.Span.events:type_name -> runtime.Span.Event
-	57, // 23: runtime.Span.status:type_name -> runtime.Span.Status
-	58, // 24: runtime.Span.scope:type_name -> runtime.Span.Scope
-	59, // 25: runtime.Span.library:type_name -> runtime.Span.Library
-	60, // 26: runtime.Span.resource:type_name -> runtime.Span.Resource
-	50, // 27: runtime.LoadReport.LoadsEntry.value:type_name -> runtime.LoadReport.ComponentLoad
-	51, // 28: runtime.LoadReport.ComponentLoad.load:type_name -> runtime.LoadReport.SliceLoad
-	52, // 29: runtime.LoadReport.SliceLoad.splits:type_name -> runtime.LoadReport.SubsliceLoad
-	61, // 30: runtime.Span.Attribute.value:type_name -> runtime.Span.Attribute.Value
-	54, // 31: runtime.Span.Link.attributes:type_name -> runtime.Span.Attribute
-	54, // 32: runtime.Span.Event.attributes:type_name -> runtime.Span.Attribute
-	5,  // 33: runtime.Span.Status.code:type_name -> runtime.Span.Status.Code
-	54, // 34: runtime.Span.Resource.attributes:type_name -> runtime.Span.Attribute
-	4,  // 35: runtime.Span.Attribute.Value.type:type_name -> runtime.Span.Attribute.Value.Type
-	62, // 36: runtime.Span.Attribute.Value.nums:type_name -> runtime.Span.Attribute.Value.NumberList
-	63, // 37: runtime.Span.Attribute.Value.strs:type_name -> runtime.Span.Attribute.Value.StringList
-	38, // [38:38] is the sub-list for method output_type
-	38, // [38:38] is the sub-list for method input_type
-	38, // [38:38] is the sub-list for extension type_name
-	38, // [38:38] is the sub-list for extension extendee
-	0,  // [0:38] is the sub-list for field type_name
+	46, // 0: runtime.WeaveletArgs.redirects:type_name -> runtime.WeaveletArgs.Redirect
+	47, // 1: runtime.InitWeaveletRequest.sections:type_name -> runtime.InitWeaveletRequest.SectionsEntry
+	48, // 2: runtime.InitWeaveletRequest.balancers:type_name -> runtime.InitWeaveletRequest.BalancersEntry
+	49, // 3: runtime.InitWeaveletRequest.concurrency_limits:type_name -> runtime.InitWeaveletRequest.ConcurrencyLimitsEntry
+	9,  // 4: runtime.InitWeaveletReply.version:type_name -> runtime.SemVer
+	0,  // 5: runtime.GetHealthReply.status:type_name -> runtime.HealthStatus
+	12, // 6: runtime.GetHealthReply.components:type_name -> runtime.ComponentHealth
+	0,  // 7: runtime.ComponentHealth.status:type_name -> runtime.HealthStatus
+	15, // 8: runtime.GetMetricsReply.update:type_name -> runtime.MetricUpdate
+	16, // 9: runtime.MetricUpdate.defs:type_name -> runtime.MetricDef
+	17, // 10: runtime.MetricUpdate.values:type_name -> runtime.MetricValue
+	1,  // 11: runtime.MetricDef.typ:type_name -> runtime.MetricType
+	50, // 12: runtime.MetricDef.labels:type_name -> runtime.MetricDef.LabelsEntry
+	1,  // 13: runtime.MetricSnapshot.typ:type_name -> runtime.MetricType
+	51, // 14: runtime.MetricSnapshot.labels:type_name -> runtime.MetricSnapshot.LabelsEntry
+	21, // 15: runtime.GetLoadReply.load:type_name -> runtime.LoadReport
+	52, // 16: runtime.LoadReport.loads:type_name -> runtime.LoadReport.LoadsEntry
+	2,  // 17: runtime.GetProfileRequest.profile_type:type_name -> runtime.ProfileType
+	26, // 18: runtime.UpdateRoutingInfoRequest.routing_info:type_name -> runtime.RoutingInfo
+	27, // 19: runtime.RoutingInfo.assignment:type_name -> runtime.Assignment
+	56, // 20: runtime.Assignment.slices:type_name -> runtime.Assignment.Slice
+	42, // 21: runtime.LogEntryBatch.entries:type_name -> runtime.LogEntry
+	45, // 22: runtime.TraceSpans.span:type_name -> runtime.Span
+	3,  // 23: runtime.Span.kind:type_name -> runtime.Span.Kind
+	57, // 24: runtime.Span.attributes:type_name -> runtime.Span.Attribute
+	58, // 25: runtime.Span.links:type_name -> runtime.Span.Link
+	59, // 26: runtime.Span.events:type_name -> runtime.Span.Event
+	60, // 27: runtime.Span.status:type_name -> runtime.Span.Status
+	61, // 28: runtime.Span.scope:type_name -> runtime.Span.Scope
+	62, // 29: runtime.Span.library:type_name -> runtime.Span.Library
+	63, // 30: runtime.Span.resource:type_name -> runtime.Span.Resource
+	67, // 31: runtime.InitWeaveletRequest.ConcurrencyLimitsEntry.value:type_name -> runtime.ConcurrencyLimit
+	53, // 32: runtime.LoadReport.LoadsEntry.value:type_name -> runtime.LoadReport.ComponentLoad
+	54, // 33: runtime.LoadReport.ComponentLoad.load:type_name -> runtime.LoadReport.SliceLoad
+	55, // 34: runtime.LoadReport.SliceLoad.splits:type_name -> runtime.LoadReport.SubsliceLoad
+	64, // 35: runtime.Span.Attribute.value:type_name -> runtime.Span.Attribute.Value
+	57, // 36: runtime.Span.Link.attributes:type_name -> runtime.Span.Attribute
+	57, // 37: runtime.Span.Event.attributes:type_name -> runtime.Span.Attribute
+	5,  // 38: runtime.Span.Status.code:type_name -> runtime.Span.Status.Code
+	57, // 39: runtime.Span.Resource.attributes:type_name -> runtime.Span.Attribute
+	4,  // 40: runtime.Span.Attribute.Value.type:type_name -> runtime.Span.Attribute.Value.Type
+	65, // 41: runtime.Span.Attribute.Value.nums:type_name -> runtime.Span.Attribute.Value.NumberList
+	66, // 42: runtime.Span.Attribute.Value.strs:type_name -> runtime.Span.Attribute.Value.StringList
+	43, // [43:43] is the sub-list for method output_type
+	43, // [43:43] is the sub-list for method input_type
+	43, // [43:43] is the sub-list for extension type_name
+	43, // [43:43] is the sub-list for extension extendee
+	0,  // [0:43] is the sub-list for field type_name
 }
 
 func init() { file_runtime_protos_runtime_proto_init() }
@@ -4314,6 +4479,7 @@ func file_runtime_protos_runtime_proto_init() {
 	if File_runtime_protos_runtime_proto != nil {
 		return
 	}
+	file_runtime_protos_config_proto_init()
 	if !protoimpl.UnsafeEnabled {
 		file_runtime_protos_runtime_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*WeaveletArgs); i {
@@ -4388,7 +4554,7 @@ func file_runtime_protos_runtime_proto_init() {
 			}
 		}
 		file_runtime_protos_runtime_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetMetricsRequest); i {
+			switch v := v.(*ComponentHealth); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4400,7 +4566,7 @@ func file_runtime_protos_runtime_proto_init() {
 			}
 		}
 		file_runtime_protos_runtime_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetMetricsReply); i {
+			switch v := v.(*GetMetricsRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4412,7 +4578,7 @@ func file_runtime_protos_runtime_proto_init() {
 			}
 		}
 		file_runtime_protos_runtime_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*MetricUpdate); i {
+			switch v := v.(*GetMetricsReply); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4424,7 +4590,7 @@ func file_runtime_protos_runtime_proto_init() {
 			}
 		}
 		file_runtime_protos_runtime_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*MetricDef); i {
+			switch v := v.(*MetricUpdate); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4436,7 +4602,7 @@ func file_runtime_protos_runtime_proto_init() {
 			}
 		}
 		file_runtime_protos_runtime_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*MetricValue); i {
+			switch v := v.(*MetricDef); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4448,7 +4614,7 @@ func file_runtime_protos_runtime_proto_init() {
 			}
 		}
 		file_runtime_protos_runtime_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*MetricSnapshot); i {
+			switch v := v.(*MetricValue); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4460,7 +4626,7 @@ func file_runtime_protos_runtime_proto_init() {
 			}
 		}
 		file_runtime_protos_runtime_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetLoadRequest); i {
+			switch v := v.(*MetricSnapshot); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4472,7 +4638,7 @@ func file_runtime_protos_runtime_proto_init() {
 			}
 		}
 		file_runtime_protos_runtime_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetLoadReply); i {
+			switch v := v.(*GetLoadRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4484,7 +4650,7 @@ func file_runtime_protos_runtime_proto_init() {
 			}
 		}
 		file_runtime_protos_runtime_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*LoadReport); i {
+			switch v := v.(*GetLoadReply); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4496,7 +4662,7 @@ func file_runtime_protos_runtime_proto_init() {
 			}
 		}
 		file_runtime_protos_runtime_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetProfileRequest); i {
+			switch v := v.(*LoadReport); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4508,7 +4674,7 @@ func file_runtime_protos_runtime_proto_init() {
 			}
 		}
 		file_runtime_protos_runtime_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetProfileReply); i {
+			switch v := v.(*GetProfileRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4520,7 +4686,7 @@ func file_runtime_protos_runtime_proto_init() {
 			}
 		}
 		file_runtime_protos_runtime_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*UpdateRoutingInfoRequest); i {
+			switch v := v.(*GetProfileReply); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4532,7 +4698,7 @@ func file_runtime_protos_runtime_proto_init() {
 			}
 		}
 		file_runtime_protos_runtime_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*UpdateRoutingInfoReply); i {
+			switch v := v.(*UpdateRoutingInfoRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4544,7 +4710,7 @@ func file_runtime_protos_runtime_proto_init() {
 			}
 		}
 		file_runtime_protos_runtime_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RoutingInfo); i {
+			switch v := v.(*UpdateRoutingInfoReply); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4556,7 +4722,7 @@ func file_runtime_protos_runtime_proto_init() {
 			}
 		}
 		file_runtime_protos_runtime_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Assignment); i {
+			switch v := v.(*RoutingInfo); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4568,7 +4734,7 @@ func file_runtime_protos_runtime_proto_init() {
 			}
 		}
 		file_runtime_protos_runtime_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*UpdateComponentsRequest); i {
+			switch v := v.(*Assignment); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4580,7 +4746,7 @@ func file_runtime_protos_runtime_proto_init() {
 			}
 		}
 		file_runtime_protos_runtime_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*UpdateComponentsReply); i {
+			switch v := v.(*UpdateComponentsRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4592,7 +4758,7 @@ func file_runtime_protos_runtime_proto_init() {
 			}
 		}
 		file_runtime_protos_runtime_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ActivateComponentRequest); i {
+			switch v := v.(*UpdateComponentsReply); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4604,7 +4770,7 @@ func file_runtime_protos_runtime_proto_init() {
 			}
 		}
 		file_runtime_protos_runtime_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ActivateComponentReply); i {
+			switch v := v.(*ActivateComponentRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4616,7 +4782,7 @@ func file_runtime_protos_runtime_proto_init() {
 			}
 		}
 		file_runtime_protos_runtime_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetListenerAddressRequest); i {
+			switch v := v.(*ActivateComponentReply); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4628,7 +4794,7 @@ func file_runtime_protos_runtime_proto_init() {
 			}
 		}
 		file_runtime_protos_runtime_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetListenerAddressReply); i {
+			switch v := v.(*GetListenerAddressRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4640,7 +4806,7 @@ func file_runtime_protos_runtime_proto_init() {
 			}
 		}
 		file_runtime_protos_runtime_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ExportListenerRequest); i {
+			switch v := v.(*GetListenerAddressReply); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4652,7 +4818,7 @@ func file_runtime_protos_runtime_proto_init() {
 			}
 		}
 		file_runtime_protos_runtime_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ExportListenerReply); i {
+			switch v := v.(*ExportListenerRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4664,7 +4830,7 @@ func file_runtime_protos_runtime_proto_init() {
 			}
 		}
 		file_runtime_protos_runtime_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetSelfCertificateRequest); i {
+			switch v := v.(*ExportListenerReply); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4676,7 +4842,7 @@ func file_runtime_protos_runtime_proto_init() {
 			}
 		}
 		file_runtime_protos_runtime_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*GetSelfCertificateReply); i {
+			switch v := v.(*GetSelfCertificateRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4688,7 +4854,7 @@ func file_runtime_protos_runtime_proto_init() {
 			}
 		}
 		file_runtime_protos_runtime_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*VerifyClientCertificateRequest); i {
+			switch v := v.(*GetSelfCertificateReply); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4700,7 +4866,7 @@ func file_runtime_protos_runtime_proto_init() {
 			}
 		}
 		file_runtime_protos_runtime_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*VerifyClientCertificateReply); i {
+			switch v := v.(*VerifyClientCertificateRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4712,7 +4878,7 @@ func file_runtime_protos_runtime_proto_init() {
 			}
 		}
 		file_runtime_protos_runtime_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*VerifyServerCertificateRequest); i {
+			switch v := v.(*VerifyClientCertificateReply); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4724,7 +4890,7 @@ func file_runtime_protos_runtime_proto_init() {
 			}
 		}
 		file_runtime_protos_runtime_proto_msgTypes[34].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*VerifyServerCertificateReply); i {
+			switch v := v.(*VerifyServerCertificateRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4736,7 +4902,7 @@ func file_runtime_protos_runtime_proto_init() {
 			}
 		}
 		file_runtime_protos_runtime_proto_msgTypes[35].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*LogEntry); i {
+			switch v := v.(*VerifyServerCertificateReply); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4748,7 +4914,7 @@ func file_runtime_protos_runtime_proto_init() {
 			}
 		}
 		file_runtime_protos_runtime_proto_msgTypes[36].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*LogEntryBatch); i {
+			switch v := v.(*LogEntry); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4760,7 +4926,7 @@ func file_runtime_protos_runtime_proto_init() {
 			}
 		}
 		file_runtime_protos_runtime_proto_msgTypes[37].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*TraceSpans); i {
+			switch v := v.(*LogEntryBatch); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4772,7 +4938,7 @@ func file_runtime_protos_runtime_proto_init() {
 			}
 		}
 		file_runtime_protos_runtime_proto_msgTypes[38].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*Span); i {
+			switch v := v.(*TraceSpans); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -4784,6 +4950,18 @@ func file_runtime_protos_runtime_proto_init() {
 			}
 		}
 		file_runtime_protos_runtime_proto_msgTypes[39].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Span); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_runtime_protos_runtime_proto_msgTypes[40].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*WeaveletArgs_Redirect); i {
 			case 0:
 				return &v.state
@@ -4795,7 +4973,7 @@ func file_runtime_protos_runtime_proto_init() {
 				return nil
 			}
 		}
-		file_runtime_protos_runtime_proto_msgTypes[44].Exporter = func(v interface{}, i int) interface{} {
+		file_runtime_protos_runtime_proto_msgTypes[47].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*LoadReport_ComponentLoad); i {
 			case 0:
 				return &v.state
@@ -4807,7 +4985,7 @@ func file_runtime_protos_runtime_proto_init() {
 				return nil
 			}
 		}
-		file_runtime_protos_runtime_proto_msgTypes[45].Exporter = func(v interface{}, i int) interface{} {
+		file_runtime_protos_runtime_proto_msgTypes[48].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*LoadReport_SliceLoad); i {
 			case 0:
 				return &v.state
@@ -4819,7 +4997,7 @@ func file_runtime_protos_runtime_proto_init() {
 				return nil
 			}
 		}
-		file_runtime_protos_runtime_proto_msgTypes[46].Exporter = func(v interface{}, i int) interface{} {
+		file_runtime_protos_runtime_proto_msgTypes[49].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*LoadReport_SubsliceLoad); i {
 			case 0:
 				return &v.state
@@ -4831,7 +5009,7 @@ func file_runtime_protos_runtime_proto_init() {
 				return nil
 			}
 		}
-		file_runtime_protos_runtime_proto_msgTypes[47].Exporter = func(v interface{}, i int) interface{} {
+		file_runtime_protos_runtime_proto_msgTypes[50].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Assignment_Slice); i {
 			case 0:
 				return &v.state
@@ -4843,7 +5021,7 @@ func file_runtime_protos_runtime_proto_init() {
 				return nil
 			}
 		}
-		file_runtime_protos_runtime_proto_msgTypes[48].Exporter = func(v interface{}, i int) interface{} {
+		file_runtime_protos_runtime_proto_msgTypes[51].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Span_Attribute); i {
 			case 0:
 				return &v.state
@@ -4855,7 +5033,7 @@ func file_runtime_protos_runtime_proto_init() {
 				return nil
 			}
 		}
-		file_runtime_protos_runtime_proto_msgTypes[49].Exporter = func(v interface{}, i int) interface{} {
+		file_runtime_protos_runtime_proto_msgTypes[52].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Span_Link); i {
 			case 0:
 				return &v.state
@@ -4867,7 +5045,7 @@ func file_runtime_protos_runtime_proto_init() {
 				return nil
 			}
 		}
-		file_runtime_protos_runtime_proto_msgTypes[50].Exporter = func(v interface{}, i int) interface{} {
+		file_runtime_protos_runtime_proto_msgTypes[53].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Span_Event); i {
 			case 0:
 				return &v.state
@@ -4879,7 +5057,7 @@ func file_runtime_protos_runtime_proto_init() {
 				return nil
 			}
 		}
-		file_runtime_protos_runtime_proto_msgTypes[51].Exporter = func(v interface{}, i int) interface{} {
+		file_runtime_protos_runtime_proto_msgTypes[54].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Span_Status); i {
 			case 0:
 				return &v.state
@@ -4891,7 +5069,7 @@ func file_runtime_protos_runtime_proto_init() {
 				return nil
 			}
 		}
-		file_runtime_protos_runtime_proto_msgTypes[52].Exporter = func(v interface{}, i int) interface{} {
+		file_runtime_protos_runtime_proto_msgTypes[55].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Span_Scope); i {
 			case 0:
 				return &v.state
@@ -4903,7 +5081,7 @@ func file_runtime_protos_runtime_proto_init() {
 				return nil
 			}
 		}
-		file_runtime_protos_runtime_proto_msgTypes[53].Exporter = func(v interface{}, i int) interface{} {
+		file_runtime_protos_runtime_proto_msgTypes[56].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Span_Library); i {
 			case 0:
 				return &v.state
@@ -4915,7 +5093,7 @@ func file_runtime_protos_runtime_proto_init() {
 				return nil
 			}
 		}
-		file_runtime_protos_runtime_proto_msgTypes[54].Exporter = func(v interface{}, i int) interface{} {
+		file_runtime_protos_runtime_proto_msgTypes[57].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Span_Resource); i {
 			case 0:
 				return &v.state
@@ -4927,7 +5105,7 @@ func file_runtime_protos_runtime_proto_init() {
 				return nil
 			}
 		}
-		file_runtime_protos_runtime_proto_msgTypes[55].Exporter = func(v interface{}, i int) interface{} {
+		file_runtime_protos_runtime_proto_msgTypes[58].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Span_Attribute_Value); i {
 			case 0:
 				return &v.state
@@ -4939,7 +5117,7 @@ func file_runtime_protos_runtime_proto_init() {
 				return nil
 			}
 		}
-		file_runtime_protos_runtime_proto_msgTypes[56].Exporter = func(v interface{}, i int) interface{} {
+		file_runtime_protos_runtime_proto_msgTypes[59].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Span_Attribute_Value_NumberList); i {
 			case 0:
 				return &v.state
@@ -4951,7 +5129,7 @@ func file_runtime_protos_runtime_proto_init() {
 				return nil
 			}
 		}
-		file_runtime_protos_runtime_proto_msgTypes[57].Exporter = func(v interface{}, i int) interface{} {
+		file_runtime_protos_runtime_proto_msgTypes[60].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*Span_Attribute_Value_StringList); i {
 			case 0:
 				return &v.state
@@ -4964,7 +5142,7 @@ func file_runtime_protos_runtime_proto_init() {
 			}
 		}
 	}
-	file_runtime_protos_runtime_proto_msgTypes[55].OneofWrappers = []interface{}{
+	file_runtime_protos_runtime_proto_msgTypes[58].OneofWrappers = []interface{}{
 		(*Span_Attribute_Value_Num)(nil),
 		(*Span_Attribute_Value_Str)(nil),
 		(*Span_Attribute_Value_Nums)(nil),
@@ -4976,7 +5154,7 @@ func file_runtime_protos_runtime_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_runtime_protos_runtime_proto_rawDesc,
 			NumEnums:      6,
-			NumMessages:   58,
+			NumMessages:   61,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
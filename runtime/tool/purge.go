@@ -32,6 +32,10 @@ type PurgeSpec struct {
 	Kill  string   // regex of processes to kill, or empty
 	Paths []string // paths to delete
 
+	// Remote, if not nil, purges processes and data on remote machines. It
+	// is called before any local processes are killed or paths deleted.
+	Remote func(context.Context) error
+
 	force bool // the --force flag
 }
 
@@ -66,7 +70,7 @@ Description:
 	}
 }
 
-func (spec *PurgeSpec) purge(context.Context, []string) error {
+func (spec *PurgeSpec) purge(ctx context.Context, _ []string) error {
 	if !spec.force {
 		// Gather the set of processes to kill.
 		tokill := ""
@@ -89,6 +93,9 @@ func (spec *PurgeSpec) purge(context.Context, []string) error {
 		for _, path := range spec.Paths {
 			fmt.Fprintf(&paths, "    - %s\n", path)
 		}
+		if spec.Remote != nil {
+			fmt.Fprintf(&paths, "\nYou will also kill the processes and delete the data of all %q\ndeployments on remote machines.\n", spec.Tool)
+		}
 
 		fmt.Printf(`WARNING: You are about to kill all processes which match the following regex:
 
@@ -120,6 +127,13 @@ Enter (y)es to continue: `, spec.Kill, indent(tokill, 4), spec.Tool, paths.Strin
 		fmt.Println("")
 	}
 
+	// Purge the remote machines.
+	if spec.Remote != nil {
+		if err := spec.Remote(ctx); err != nil {
+			return err
+		}
+	}
+
 	// Kill the processes.
 	if spec.Kill != "" {
 		killed, err := pkill(spec.Kill)
@@ -0,0 +1,183 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tool
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"text/template"
+	"time"
+
+	"github.com/ServiceWeaver/weaver/runtime/traces"
+)
+
+// TracesSpec configures the command returned by TracesCmd.
+type TracesSpec struct {
+	Tool   string        // tool name, e.g., "weaver multi"
+	Flags  *flag.FlagSet // optional additional flags
+	DBFile string        // trace database file
+
+	// Flags.
+	app    string
+	limit  int64
+	errs   bool
+	format string
+}
+
+// traceEntry is the JSON representation of a trace summary.
+type traceEntry struct {
+	TraceID   string `json:"trace_id"`
+	StartTime string `json:"start_time"`
+	EndTime   string `json:"end_time"`
+	Duration  string `json:"duration"`
+	Status    string `json:"status"`
+}
+
+// TracesCmd returns a command to query traces.
+func TracesCmd(spec *TracesSpec) *Command {
+	if spec.Flags == nil {
+		spec.Flags = flag.NewFlagSet("traces", flag.ContinueOnError)
+	}
+	spec.Flags.StringVar(&spec.app, "app", "", "Only show traces for this application")
+	spec.Flags.Int64Var(&spec.limit, "limit", 100, "Maximum number of traces to show")
+	spec.Flags.BoolVar(&spec.errs, "errors", false, "Only show traces with errors")
+	spec.Flags.StringVar(&spec.format, "format", "pretty", "Output format (pretty or json)")
+	const help = `Usage:
+  {{.Tool}} traces [--app=<app>] [--limit=<n>] [--errors] [--format=<format>] [query]
+
+Flags:
+  -h, --help	Print this help message.
+{{.Flags}}
+
+Queries:
+  Traces are made of spans, and every span has the following fields:
+
+      * app          : the application name
+      * version      : the abbreviated application version
+      * full_version : the unabbreviated application version
+      * name         : the span name
+      * component    : the abbreviated Service Weaver component name
+      * method       : the component method name
+      * time         : the time the span started
+      * duration     : the span duration
+      * status       : "OK", or the error message of a failed span
+      * attrs        : the span attributes
+
+  component and method are only set for the spans of component method calls.
+  A trace matches a query if at least one of its spans matches the query.
+  Queries use the same language as the queries passed to "{{.Tool}} logs"; see
+  "{{.Tool}} logs --help" for a complete description of the query language.
+  Note that all of the conditions in a query apply to the same span.
+
+Examples:
+  # Display the 100 most recent traces.
+  {{.Tool}} traces
+
+  # Display the traces for version "cf575354" of the "todo" app.
+  {{.Tool}} traces --app=todo 'version == "cf575354"'
+
+  # Display the traces that call any method of component "store.Store".
+  {{.Tool}} traces 'component == "store.Store"'
+
+  # Display the traces that call method "store.Store.Get" and take more than
+  # 100 milliseconds to do so.
+  {{.Tool}} traces 'name == "store.Store.Get" && duration > duration("100ms")'
+
+  # Display the traces with a failed call to a "store.Store" method.
+  {{.Tool}} traces 'component == "store.Store" && status != "OK"'
+
+  # Display the traces with a span that has attribute "user" equal to "alice".
+  {{.Tool}} traces 'attrs["user"] == "alice"'
+
+  # Display the traces that started on or after Jan 1, 2023 (in UTC+0).
+  {{.Tool}} traces 'time >= timestamp("2023-01-01T00:00:00Z")'
+
+  # Display the traces with errors, in JSON format.
+  {{.Tool}} traces --errors --format=json`
+	var b strings.Builder
+	t := template.Must(template.New(spec.Tool).Parse(help))
+	content := struct{ Tool, Flags string }{spec.Tool, FlagsHelp(spec.Flags)}
+	if err := t.Execute(&b, content); err != nil {
+		panic(err)
+	}
+
+	return &Command{
+		Name:        "traces",
+		Flags:       spec.Flags,
+		Description: "Query Service Weaver traces",
+		Help:        b.String(),
+		Fn:          spec.tracesFn,
+	}
+}
+
+func (s *TracesSpec) tracesFn(ctx context.Context, args []string) error {
+	// Parse command line arguments.
+	if len(args) > 1 {
+		return fmt.Errorf("too many arguments")
+	}
+	var query traces.Query
+	if len(args) == 1 {
+		query = args[0]
+	}
+	if s.format != "pretty" && s.format != "json" {
+		return fmt.Errorf("invalid format %q; must be %q or %q", s.format, "pretty", "json")
+	}
+	if s.limit <= 0 {
+		return fmt.Errorf("invalid limit %d; must be positive", s.limit)
+	}
+
+	// Query the traces.
+	db, err := traces.OpenDB(ctx, s.DBFile)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+	summaries, err := db.QueryFilteredTraces(ctx, s.app, "" /*version*/, query, time.Time{}, time.Time{}, 0, 0, s.errs, s.limit)
+	if err != nil {
+		return err
+	}
+
+	switch s.format {
+	case "pretty":
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "TRACE ID\tSTART TIME\tDURATION\tSTATUS")
+		for _, t := range summaries {
+			fmt.Fprintf(w, "%s\t%s\t%v\t%s\n", t.TraceID, t.StartTime.Format(time.RFC3339Nano), t.EndTime.Sub(t.StartTime), t.Status)
+		}
+		return w.Flush()
+	case "json":
+		for _, t := range summaries {
+			bytes, err := json.MarshalIndent(traceEntry{
+				TraceID:   t.TraceID,
+				StartTime: t.StartTime.Format(time.RFC3339Nano),
+				EndTime:   t.EndTime.Format(time.RFC3339Nano),
+				Duration:  t.EndTime.Sub(t.StartTime).String(),
+				Status:    t.Status,
+			}, "", "    ")
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(bytes))
+		}
+		return nil
+	default:
+		panic(fmt.Sprintf("unexpected format %q", s.format))
+	}
+}
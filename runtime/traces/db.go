@@ -27,8 +27,10 @@ import (
 	"net/http"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
+	"github.com/ServiceWeaver/weaver/internal/traceio"
 	"github.com/ServiceWeaver/weaver/runtime/protos"
 	"github.com/ServiceWeaver/weaver/runtime/retry"
 	"google.golang.org/protobuf/proto"
@@ -38,10 +40,12 @@ import (
 
 // DB is a trace database that stores traces on the local file system.
 type DB struct {
-	// Trace data is stored in a sqlite DB spread across two tables:
-	// (1) traces:         serialized trace data, used for querying.
-	// (2) encoded_spans:  full encoded span data, used for fetching all of the
-	//                     spans that belong to a given trace.
+	// Trace data is stored in a sqlite DB spread across four tables:
+	// (1) traces:           serialized trace data, used for querying.
+	// (2) encoded_spans:    full encoded span data, used for fetching all of
+	//                       the spans that belong to a given trace.
+	// (3) spans:            serialized span data, used for querying.
+	// (4) span_attributes:  span attributes, used for querying.
 	fname string
 	db    *sql.DB
 }
@@ -94,6 +98,31 @@ CREATE TABLE IF NOT EXISTS encoded_spans (
 	FOREIGN KEY (trace_id) REFERENCES traces (trace_id)
 );
 
+-- Queryable span data.
+CREATE TABLE IF NOT EXISTS spans (
+	trace_id TEXT NOT NULL,
+	span_id TEXT NOT NULL,
+	name TEXT,
+	component TEXT,
+	method TEXT,
+	start_time_unix_us INTEGER,
+	end_time_unix_us INTEGER,
+	status TEXT
+);
+CREATE INDEX IF NOT EXISTS spans_by_trace ON spans (trace_id);
+CREATE INDEX IF NOT EXISTS spans_by_method ON spans (component, method);
+
+-- Queryable span attributes.
+CREATE TABLE IF NOT EXISTS span_attributes (
+	trace_id TEXT NOT NULL,
+	span_id TEXT NOT NULL,
+	start_time_unix_us INTEGER,
+	key TEXT NOT NULL,
+	value TEXT
+);
+CREATE INDEX IF NOT EXISTS span_attributes_by_span ON span_attributes (trace_id, span_id);
+CREATE INDEX IF NOT EXISTS span_attributes_by_key ON span_attributes (key, value);
+
 -- Garbage-collect traces older than 30 days.
 CREATE TRIGGER IF NOT EXISTS expire_traces AFTER INSERT ON traces
 BEGIN
@@ -107,6 +136,15 @@ BEGIN
 	DELETE FROM encoded_spans
 	WHERE start_time_unix_us < (1000000 * unixepoch('now', '-30 days'));
 END;
+
+-- Garbage-collect span data older than 30 days.
+CREATE TRIGGER IF NOT EXISTS expire_span_data AFTER INSERT ON spans
+BEGIN
+	DELETE FROM spans
+	WHERE start_time_unix_us < (1000000 * unixepoch('now', '-30 days'));
+	DELETE FROM span_attributes
+	WHERE start_time_unix_us < (1000000 * unixepoch('now', '-30 days'));
+END;
 `
 	if _, err := t.execDB(ctx, initDB); err != nil {
 		return nil, fmt.Errorf("open trace DB %s: %w", fname, err)
@@ -159,9 +197,26 @@ func (d *DB) storeSpan(ctx context.Context, tx *sql.Tx, span *protos.Span) error
 	if err != nil {
 		return err
 	}
+	traceID := hex.EncodeToString(span.TraceId)
 	const stmt = `INSERT INTO encoded_spans VALUES (?,?,?)`
-	_, err = tx.ExecContext(ctx, stmt, hex.EncodeToString(span.TraceId), span.StartMicros, encoded)
-	return err
+	if _, err := tx.ExecContext(ctx, stmt, traceID, span.StartMicros, encoded); err != nil {
+		return err
+	}
+
+	// Index the span.
+	spanID := hex.EncodeToString(span.SpanId)
+	component, method := componentMethod(span)
+	const spanStmt = `INSERT INTO spans VALUES (?,?,?,?,?,?,?,?)`
+	if _, err := tx.ExecContext(ctx, spanStmt, traceID, spanID, span.Name, component, method, span.StartMicros, span.EndMicros, spanStatus(span)); err != nil {
+		return err
+	}
+	const attrStmt = `INSERT INTO span_attributes VALUES (?,?,?,?,?)`
+	for _, kv := range fromProtoAttrs(span.Attributes) {
+		if _, err := tx.ExecContext(ctx, attrStmt, traceID, spanID, span.StartMicros, string(kv.Key), kv.Value.Emit()); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 // TraceSummary stores summary information about a trace.
@@ -182,18 +237,35 @@ type TraceSummary struct {
 // Any query argument that has a zero value (e.g., empty app or version,
 // zero endTime) is ignored, i.e., it matches all spans.
 func (d *DB) QueryTraces(ctx context.Context, app, version string, startTime, endTime time.Time, durationLower, durationUpper time.Duration, onlyErrors bool, limit int64) ([]TraceSummary, error) {
-	const query = `
+	return d.QueryFilteredTraces(ctx, app, version, "" /*query*/, startTime, endTime, durationLower, durationUpper, onlyErrors, limit)
+}
+
+// QueryFilteredTraces is like QueryTraces, but it only returns the summaries
+// of the traces that have at least one span that matches the given query. An
+// empty query matches all spans.
+func (d *DB) QueryFilteredTraces(ctx context.Context, app, version string, query Query, startTime, endTime time.Time, durationLower, durationUpper time.Duration, onlyErrors bool, limit int64) ([]TraceSummary, error) {
+	spanFilter := "TRUE"
+	var spanArgs []any
+	if query != "" {
+		var err error
+		spanFilter, spanArgs, err = compileQuery(query)
+		if err != nil {
+			return nil, err
+		}
+	}
+	sqlQuery := fmt.Sprintf(`
 SELECT trace_id, start_time_unix_us, end_time_unix_us, status
-FROM traces
+FROM traces t
 WHERE
 	(app=? OR ?="") AND (version=? OR ?="") AND
 	(start_time_unix_us>=? OR ?=0) AND (end_time_unix_us<=? OR ?=0) AND
 	((end_time_unix_us - start_time_unix_us)>=? OR ?=0) AND
 	((end_time_unix_us - start_time_unix_us)<? OR ?=0) AND
-	(status != "" OR ?=0)
+	(status != "" OR ?=0) AND
+	(?="" OR EXISTS (SELECT 1 FROM spans s WHERE s.trace_id=t.trace_id AND (%s)))
 ORDER BY end_time_unix_us DESC
 LIMIT ?
-`
+`, spanFilter)
 	var startTimeUs int64
 	if !startTime.IsZero() {
 		startTimeUs = startTime.UnixMicro()
@@ -207,7 +279,10 @@ LIMIT ?
 	if limit <= 0 {
 		limit = math.MaxInt64
 	}
-	rows, err := d.queryDB(ctx, query, app, app, version, version, startTimeUs, startTimeUs, endTimeUs, endTimeUs, durationLowerUs, durationLowerUs, durationUpperUs, durationUpperUs, onlyErrors, limit)
+	args := []any{app, app, version, version, startTimeUs, startTimeUs, endTimeUs, endTimeUs, durationLowerUs, durationLowerUs, durationUpperUs, durationUpperUs, onlyErrors, query}
+	args = append(args, spanArgs...)
+	args = append(args, limit)
+	rows, err := d.queryDB(ctx, sqlQuery, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -293,6 +368,19 @@ func isLocked(err error) bool {
 	return ok && (sqlError.Code() == sqlite3.SQLITE_BUSY || sqlError.Code() == sqlite3.SQLITE_LOCKED)
 }
 
+// componentMethod returns the component and method of a span that traces a
+// component method call, or empty strings for other spans.
+func componentMethod(span *protos.Span) (string, string) {
+	if span.Scope.GetName() != traceio.InstrumentationLibrary {
+		return "", ""
+	}
+	i := strings.LastIndex(span.Name, ".")
+	if i < 0 {
+		return "", ""
+	}
+	return span.Name[:i], span.Name[i+1:]
+}
+
 // isRootSpan returns true iff the given span is a root span.
 func isRootSpan(span *protos.Span) bool {
 	var nilSpanID [8]byte
@@ -24,6 +24,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/ServiceWeaver/weaver/internal/traceio"
 	"github.com/ServiceWeaver/weaver/runtime/protos"
 	"github.com/ServiceWeaver/weaver/runtime/traces"
 	"github.com/google/go-cmp/cmp"
@@ -232,6 +233,105 @@ func TestQueryTraces(t *testing.T) {
 	}
 }
 
+func TestQueryTracesByQuery(t *testing.T) {
+	ctx := context.Background()
+	fname := filepath.Join(t.TempDir(), "tracedb.db_test.db")
+	db, err := traces.OpenDB(ctx, fname)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	// componentSpan creates a test span for a call to a component method.
+	componentSpan := func(name string, tid, sid, pid string, start, end time.Time, attrs ...string) *protos.Span {
+		span := makeSpan(name, tid, sid, pid, start, end, attrs...)
+		span.Scope = &protos.Span_Scope{Name: traceio.InstrumentationLibrary}
+		return span
+	}
+
+	// Store a bunch of spans.
+	s1 := makeSpan("main", tid(1), sid(1), sid(0), tick(1), tick(10))
+	s2 := componentSpan("store.Store.Get", tid(1), sid(2), sid(1), tick(2), tick(3), "user=alice")
+	s3 := componentSpan("store.Store.Put", tid(1), sid(3), sid(1), tick(4), tick(9), "user=bob")
+	s4 := makeSpan("main", tid(2), sid(4), sid(0), tick(1), tick(4))
+	s5 := componentSpan("store.Store.Get", tid(2), sid(5), sid(4), tick(2), tick(3), "user=bob")
+	s6 := makeSpan("main", tid(3), sid(6), sid(0), tick(1), tick(2), "http.status_code=400")
+	s7 := componentSpan("cache.Cache.Get", tid(3), sid(7), sid(6), tick(1), tick(2), "hit=1")
+	storeSpans(ctx, t, db, "app", "v1", s1, s2, s3)
+	storeSpans(ctx, t, db, "app", "v1", s4, s5)
+	storeSpans(ctx, t, db, "app", "v2", s6, s7)
+
+	for _, tc := range []struct {
+		query string
+		want  []string // trace ids
+	}{
+		{`name == "main"`, []string{tid(1), tid(2), tid(3)}},
+		{`component == "store.Store"`, []string{tid(1), tid(2)}},
+		{`method == "Get"`, []string{tid(1), tid(2), tid(3)}},
+		{`component == "store.Store" && method == "Put"`, []string{tid(1)}},
+		{`component.contains("Cache")`, []string{tid(3)}},
+		{`name.matches("^store.*Put$")`, []string{tid(1)}},
+		{`duration >= duration("3s")`, []string{tid(1), tid(2)}},
+		{`duration < duration("2s") && component != ""`, []string{tid(1), tid(2), tid(3)}},
+		{`time < timestamp("2000-01-01T00:00:00Z")`, nil},
+		{`attrs["user"] == "alice"`, []string{tid(1)}},
+		{`attrs["user"] == "bob"`, []string{tid(1), tid(2)}},
+		{`"hit" in attrs`, []string{tid(3)}},
+		{`!("user" in attrs) && component != ""`, []string{tid(3)}},
+		{`status != "OK"`, []string{tid(3)}},
+		{`status == "Bad Request"`, []string{tid(3)}},
+		{`version == "v2"`, []string{tid(3)}},
+		// All conditions must hold for the same span.
+		{`method == "Get" && attrs["user"] == "bob"`, []string{tid(2)}},
+		{`method == "Put" || attrs["user"] == "alice"`, []string{tid(1)}},
+	} {
+		t.Run(tc.query, func(t *testing.T) {
+			summaries, err := db.QueryFilteredTraces(ctx, "app", "", tc.query, time.Time{}, time.Time{}, 0, 0, false, 0)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []string
+			for _, summary := range summaries {
+				s, err := hex.DecodeString(summary.TraceID)
+				if err != nil {
+					t.Fatal(err)
+				}
+				got = append(got, string(s))
+			}
+			if diff := cmp.Diff(tc.want, got, cmpopts.SortSlices(func(a, b string) bool { return a < b })); diff != "" {
+				t.Errorf("unexpected traces: (-want +got): %s", diff)
+			}
+		})
+	}
+}
+
+func TestBadTraceQueries(t *testing.T) {
+	ctx := context.Background()
+	fname := filepath.Join(t.TempDir(), "tracedb.db_test.db")
+	db, err := traces.OpenDB(ctx, fname)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	for _, query := range []string{
+		`name`,
+		`foo == "bar"`,
+		`"bar" == name`,
+		`name == component`,
+		`attrs[name] == "bar"`,
+		`duration > duration("nope")`,
+		`name.startsWith("s")`,
+	} {
+		t.Run(query, func(t *testing.T) {
+			_, err := db.QueryFilteredTraces(ctx, "", "", query, time.Time{}, time.Time{}, 0, 0, false, 0)
+			if err == nil {
+				t.Fatalf("QueryFilteredTraces(%q): unexpected success", query)
+			}
+		})
+	}
+}
+
 func BenchmarkStore(b *testing.B) {
 	ctx := context.Background()
 	s := makeSpan("s1", tid(1), sid(1), sid(1), tick(3), tick(10))
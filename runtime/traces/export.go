@@ -0,0 +1,342 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package traces
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/ServiceWeaver/weaver/runtime/protos"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	sdk "go.opentelemetry.io/otel/sdk/trace"
+)
+
+const (
+	// Default values for the batch and buffer sizes of an exporter. See
+	// protos.TraceExporter.
+	defaultBatchSize  = 512
+	defaultBufferSize = 2048
+
+	// flushInterval is how often buffered spans are exported, if fewer than a
+	// batch worth of spans are buffered.
+	flushInterval = 5 * time.Second
+
+	// exportTimeout bounds the time it takes to export a batch of spans.
+	exportTimeout = 30 * time.Second
+)
+
+// An Exporter exports trace spans to a tracing backend.
+type Exporter interface {
+	// Export exports the provided spans.
+	Export(ctx context.Context, spans *protos.TraceSpans) error
+
+	// Shutdown exports any buffered spans and releases the resources held by
+	// the exporter.
+	Shutdown(ctx context.Context) error
+}
+
+// ExporterOptions are the options used to create exporters.
+type ExporterOptions struct {
+	App          string       // application name
+	DeploymentId string       // deployment id
+	DBFile       string       // database file used by the "sqlite" exporter
+	Logger       *slog.Logger // logger for export errors; if nil, slog.Default()
+}
+
+// An ExporterFactory creates an exporter with the provided configuration.
+type ExporterFactory func(ctx context.Context, config *protos.TraceExporter, opts ExporterOptions) (Exporter, error)
+
+var (
+	factoriesMu sync.Mutex
+	factories   = map[string]ExporterFactory{
+		"sqlite":    newSQLiteExporter,
+		"otlp-http": newOTLPHTTPExporter,
+		"otlp-grpc": newOTLPGRPCExporter,
+		"stdout":    newStdoutExporter,
+	}
+)
+
+// RegisterExporter registers a factory for exporters of the provided kind,
+// which can then be used in the traces section of an application config. It
+// panics if an exporter of the same kind is already registered.
+func RegisterExporter(kind string, factory ExporterFactory) {
+	factoriesMu.Lock()
+	defer factoriesMu.Unlock()
+	if _, ok := factories[kind]; ok {
+		panic(fmt.Sprintf("trace exporter %q already registered", kind))
+	}
+	factories[kind] = factory
+}
+
+// NewExporter returns an exporter that exports spans to every one of the
+// provided exporters. If no exporters are provided, spans are stored in the
+// trace database in opts.DBFile.
+//
+// Spans are buffered and exported in batches, separately for every exporter,
+// so that a slow exporter doesn't hold back the others. If the buffer of an
+// exporter is full, new spans are dropped.
+func NewExporter(ctx context.Context, configs []*protos.TraceExporter, opts ExporterOptions) (Exporter, error) {
+	if len(configs) == 0 {
+		configs = []*protos.TraceExporter{{Kind: "sqlite"}}
+	}
+	if opts.Logger == nil {
+		opts.Logger = slog.Default()
+	}
+
+	var exporters multiExporter
+	for _, config := range configs {
+		factoriesMu.Lock()
+		factory, ok := factories[config.Kind]
+		factoriesMu.Unlock()
+		if !ok {
+			exporters.Shutdown(ctx)
+			return nil, fmt.Errorf("unknown trace exporter %q", config.Kind)
+		}
+		exporter, err := factory(ctx, config, opts)
+		if err != nil {
+			exporters.Shutdown(ctx)
+			return nil, fmt.Errorf("create %q trace exporter: %w", config.Kind, err)
+		}
+		exporters = append(exporters, newBatcher(exporter, config, opts.Logger))
+	}
+	return exporters, nil
+}
+
+// multiExporter exports spans to multiple exporters.
+type multiExporter []Exporter
+
+// Export implements the Exporter interface.
+func (m multiExporter) Export(ctx context.Context, spans *protos.TraceSpans) error {
+	var errs []error
+	for _, e := range m {
+		errs = append(errs, e.Export(ctx, spans))
+	}
+	return errors.Join(errs...)
+}
+
+// Shutdown implements the Exporter interface.
+func (m multiExporter) Shutdown(ctx context.Context) error {
+	var errs []error
+	for _, e := range m {
+		errs = append(errs, e.Shutdown(ctx))
+	}
+	return errors.Join(errs...)
+}
+
+// batcher buffers spans and exports them in batches.
+type batcher struct {
+	exporter   Exporter
+	kind       string
+	batchSize  int
+	bufferSize int
+	logger     *slog.Logger
+	full       chan struct{} // signaled when a batch worth of spans is buffered
+	done       chan struct{} // closed when the batcher is shut down
+	stopped    chan struct{} // closed when the export loop returns
+	shutdown   sync.Once
+
+	mu     sync.Mutex
+	buffer []*protos.Span
+}
+
+func newBatcher(exporter Exporter, config *protos.TraceExporter, logger *slog.Logger) *batcher {
+	b := &batcher{
+		exporter:   exporter,
+		kind:       config.Kind,
+		batchSize:  int(config.BatchSize),
+		bufferSize: int(config.BufferSize),
+		logger:     logger,
+		full:       make(chan struct{}, 1),
+		done:       make(chan struct{}),
+		stopped:    make(chan struct{}),
+	}
+	if b.batchSize == 0 {
+		b.batchSize = defaultBatchSize
+	}
+	if b.bufferSize == 0 {
+		b.bufferSize = defaultBufferSize
+	}
+	go b.run()
+	return b
+}
+
+// Export implements the Exporter interface. It returns an error if some of the
+// spans were dropped because the buffer is full.
+func (b *batcher) Export(_ context.Context, spans *protos.TraceSpans) error {
+	b.mu.Lock()
+	n := min(len(spans.Span), b.bufferSize-len(b.buffer))
+	b.buffer = append(b.buffer, spans.Span[:n]...)
+	full := len(b.buffer) >= b.batchSize
+	b.mu.Unlock()
+
+	if full {
+		select {
+		case b.full <- struct{}{}:
+		default:
+		}
+	}
+	if dropped := len(spans.Span) - n; dropped > 0 {
+		return fmt.Errorf("%q trace exporter: buffer full, dropped %d spans", b.kind, dropped)
+	}
+	return nil
+}
+
+// Shutdown implements the Exporter interface.
+func (b *batcher) Shutdown(ctx context.Context) error {
+	b.shutdown.Do(func() { close(b.done) })
+	select {
+	case <-b.stopped:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	return errors.Join(b.flush(ctx, true), b.exporter.Shutdown(ctx))
+}
+
+// run exports buffered spans until the batcher is shut down.
+func (b *batcher) run() {
+	defer close(b.stopped)
+	ticker := time.NewTicker(flushInterval)
+	defer ticker.Stop()
+	for {
+		var all bool
+		select {
+		case <-b.full:
+		case <-ticker.C:
+			all = true
+		case <-b.done:
+			return
+		}
+		ctx, cancel := context.WithTimeout(context.Background(), exportTimeout)
+		if err := b.flush(ctx, all); err != nil {
+			b.logger.Error("Cannot export trace spans", "exporter", b.kind, "err", err)
+		}
+		cancel()
+	}
+}
+
+// flush exports the buffered spans in batches. If all is false, a partial
+// batch is left in the buffer.
+func (b *batcher) flush(ctx context.Context, all bool) error {
+	for {
+		b.mu.Lock()
+		n := min(len(b.buffer), b.batchSize)
+		if n == 0 || (!all && n < b.batchSize) {
+			b.mu.Unlock()
+			return nil
+		}
+		batch := b.buffer[:n:n]
+		b.buffer = b.buffer[n:]
+		b.mu.Unlock()
+
+		if err := b.exporter.Export(ctx, &protos.TraceSpans{Span: batch}); err != nil {
+			return err
+		}
+	}
+}
+
+// sqliteExporter stores spans in a trace database.
+type sqliteExporter struct {
+	db                *DB
+	app, deploymentId string
+}
+
+func newSQLiteExporter(ctx context.Context, _ *protos.TraceExporter, opts ExporterOptions) (Exporter, error) {
+	db, err := OpenDB(ctx, opts.DBFile)
+	if err != nil {
+		return nil, err
+	}
+	return &sqliteExporter{db: db, app: opts.App, deploymentId: opts.DeploymentId}, nil
+}
+
+// Export implements the Exporter interface.
+func (s *sqliteExporter) Export(ctx context.Context, spans *protos.TraceSpans) error {
+	return s.db.Store(ctx, s.app, s.deploymentId, spans)
+}
+
+// Shutdown implements the Exporter interface.
+func (s *sqliteExporter) Shutdown(context.Context) error {
+	return s.db.Close()
+}
+
+// spanExporter adapts an Open Telemetry span exporter to the Exporter
+// interface.
+type spanExporter struct {
+	exporter sdk.SpanExporter
+}
+
+// Export implements the Exporter interface.
+func (s spanExporter) Export(ctx context.Context, spans *protos.TraceSpans) error {
+	readSpans := make([]sdk.ReadOnlySpan, len(spans.Span))
+	for i, span := range spans.Span {
+		readSpans[i] = &ReadSpan{Span: span}
+	}
+	return s.exporter.ExportSpans(ctx, readSpans)
+}
+
+// Shutdown implements the Exporter interface.
+func (s spanExporter) Shutdown(ctx context.Context) error {
+	return s.exporter.Shutdown(ctx)
+}
+
+func newOTLPHTTPExporter(ctx context.Context, config *protos.TraceExporter, _ ExporterOptions) (Exporter, error) {
+	var opts []otlptracehttp.Option
+	if config.Endpoint != "" {
+		opts = append(opts, otlptracehttp.WithEndpoint(config.Endpoint))
+	}
+	if config.Insecure {
+		opts = append(opts, otlptracehttp.WithInsecure())
+	}
+	if len(config.Headers) > 0 {
+		opts = append(opts, otlptracehttp.WithHeaders(config.Headers))
+	}
+	exporter, err := otlptracehttp.New(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return spanExporter{exporter}, nil
+}
+
+func newOTLPGRPCExporter(ctx context.Context, config *protos.TraceExporter, _ ExporterOptions) (Exporter, error) {
+	var opts []otlptracegrpc.Option
+	if config.Endpoint != "" {
+		opts = append(opts, otlptracegrpc.WithEndpoint(config.Endpoint))
+	}
+	if config.Insecure {
+		opts = append(opts, otlptracegrpc.WithInsecure())
+	}
+	if len(config.Headers) > 0 {
+		opts = append(opts, otlptracegrpc.WithHeaders(config.Headers))
+	}
+	exporter, err := otlptracegrpc.New(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return spanExporter{exporter}, nil
+}
+
+func newStdoutExporter(context.Context, *protos.TraceExporter, ExporterOptions) (Exporter, error) {
+	exporter, err := stdouttrace.New(stdouttrace.WithWriter(os.Stdout))
+	if err != nil {
+		return nil, err
+	}
+	return spanExporter{exporter}, nil
+}
@@ -0,0 +1,223 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package traces_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/ServiceWeaver/weaver/runtime/protos"
+	"github.com/ServiceWeaver/weaver/runtime/traces"
+	"github.com/google/go-cmp/cmp"
+	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	"google.golang.org/protobuf/proto"
+)
+
+// fakeExporter is an exporter that records the batches it exports.
+type fakeExporter struct {
+	mu      sync.Mutex
+	batches [][]string // span names, by batch
+}
+
+func (f *fakeExporter) Export(_ context.Context, spans *protos.TraceSpans) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var names []string
+	for _, span := range spans.Span {
+		names = append(names, span.Name)
+	}
+	f.batches = append(f.batches, names)
+	return nil
+}
+
+func (f *fakeExporter) Shutdown(context.Context) error { return nil }
+
+// registerFake registers a fake exporter with a unique kind.
+func registerFake(t *testing.T) (string, *fakeExporter) {
+	kind := "fake-" + t.Name()
+	fake := &fakeExporter{}
+	traces.RegisterExporter(kind, func(context.Context, *protos.TraceExporter, traces.ExporterOptions) (traces.Exporter, error) {
+		return fake, nil
+	})
+	return kind, fake
+}
+
+// spans returns n test spans named "1", "2", ..., "n".
+func spans(n int) *protos.TraceSpans {
+	spans := &protos.TraceSpans{}
+	for i := 0; i < n; i++ {
+		spans.Span = append(spans.Span, makeSpan(strconv.Itoa(i+1), tid(1), sid(i+1), sid(0), tick(1), tick(2)))
+	}
+	return spans
+}
+
+func TestExporterBatches(t *testing.T) {
+	ctx := context.Background()
+	kind, fake := registerFake(t)
+	configs := []*protos.TraceExporter{{Kind: kind, BatchSize: 2}}
+	exporter, err := traces.NewExporter(ctx, configs, traces.ExporterOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := exporter.Export(ctx, spans(5)); err != nil {
+		t.Fatal(err)
+	}
+	if err := exporter.Shutdown(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	var got []string
+	for _, batch := range fake.batches {
+		if len(batch) > 2 {
+			t.Errorf("batch %v larger than batch size 2", batch)
+		}
+		got = append(got, batch...)
+	}
+	if diff := cmp.Diff([]string{"1", "2", "3", "4", "5"}, got); diff != "" {
+		t.Fatalf("exported spans (-want +got):\n%s", diff)
+	}
+}
+
+func TestExporterDropsSpans(t *testing.T) {
+	ctx := context.Background()
+	kind, fake := registerFake(t)
+	configs := []*protos.TraceExporter{{Kind: kind, BatchSize: 10, BufferSize: 3}}
+	exporter, err := traces.NewExporter(ctx, configs, traces.ExporterOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = exporter.Export(ctx, spans(5))
+	if err == nil || !strings.Contains(err.Error(), "dropped 2 spans") {
+		t.Fatalf("Export: got %v, want dropped spans error", err)
+	}
+	if err := exporter.Shutdown(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([][]string{{"1", "2", "3"}}, fake.batches); diff != "" {
+		t.Fatalf("exported batches (-want +got):\n%s", diff)
+	}
+}
+
+func TestExporterUnknownKind(t *testing.T) {
+	ctx := context.Background()
+	configs := []*protos.TraceExporter{{Kind: "unknown"}}
+	if _, err := traces.NewExporter(ctx, configs, traces.ExporterOptions{}); err == nil {
+		t.Fatal("NewExporter: unexpected success")
+	}
+}
+
+func TestSQLiteExporter(t *testing.T) {
+	ctx := context.Background()
+	fname := filepath.Join(t.TempDir(), "tracedb.db")
+	opts := traces.ExporterOptions{App: "app", DeploymentId: "v1", DBFile: fname}
+	exporter, err := traces.NewExporter(ctx, nil, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := exporter.Export(ctx, spans(1)); err != nil {
+		t.Fatal(err)
+	}
+	if err := exporter.Shutdown(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	db, err := traces.OpenDB(ctx, fname)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	got, err := db.QueryTraces(ctx, "app", "v1", tick(0), tick(0), 0, 0, false, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 1 {
+		t.Fatalf("QueryTraces: got %d traces, want 1", len(got))
+	}
+}
+
+func TestOTLPHTTPExporter(t *testing.T) {
+	// Start a fake OTLP collector.
+	var mu sync.Mutex
+	var names []string
+	var headers []string
+	collector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/traces" {
+			http.NotFound(w, r)
+			return
+		}
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		req := &coltracepb.ExportTraceServiceRequest{}
+		if err := proto.Unmarshal(body, req); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		headers = append(headers, r.Header.Get("api-key"))
+		for _, rs := range req.ResourceSpans {
+			for _, ss := range rs.ScopeSpans {
+				for _, span := range ss.Spans {
+					names = append(names, span.Name)
+				}
+			}
+		}
+		w.Header().Set("Content-Type", "application/x-protobuf")
+		resp, _ := proto.Marshal(&coltracepb.ExportTraceServiceResponse{})
+		w.Write(resp)
+	}))
+	defer collector.Close()
+
+	ctx := context.Background()
+	configs := []*protos.TraceExporter{{
+		Kind:     "otlp-http",
+		Endpoint: strings.TrimPrefix(collector.URL, "http://"),
+		Insecure: true,
+		Headers:  map[string]string{"api-key": "secret"},
+	}}
+	exporter, err := traces.NewExporter(ctx, configs, traces.ExporterOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := exporter.Export(ctx, spans(3)); err != nil {
+		t.Fatal(err)
+	}
+	if err := exporter.Shutdown(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	sort.Strings(names)
+	if diff := cmp.Diff([]string{"1", "2", "3"}, names); diff != "" {
+		t.Errorf("collected spans (-want +got):\n%s", diff)
+	}
+	for _, h := range headers {
+		if h != "secret" {
+			t.Errorf("api-key header: got %q, want %q", h, "secret")
+		}
+	}
+}
@@ -0,0 +1,336 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package traces
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/checker/decls"
+	"github.com/google/cel-go/common/operators"
+	exprpb "google.golang.org/genproto/googleapis/api/expr/v1alpha1"
+	"modernc.org/sqlite"
+)
+
+// Query is a filter for trace spans. A trace matches a query if at least one
+// of its spans matches the query.
+//
+// # Syntax
+//
+// Queries are written using the same subset of the CEL language [1] as log
+// queries (see logging.Query). Specifically, a query is a CEL program over the
+// following span fields:
+//
+//   - app: string
+//   - version: string
+//   - full_version: string
+//   - name: string
+//   - component: string
+//   - method: string
+//   - time: timestamp
+//   - duration: duration
+//   - status: string
+//   - attrs: map[string]string
+//
+// component and method are set only for the spans of component method calls,
+// and are empty otherwise. component is the shortened component name (e.g.,
+// "store.Store"). time is the start time of a span. status is "OK" for
+// successful spans, and the error message otherwise. attrs holds the span
+// attributes, formatted as strings.
+//
+// A query is restricted to:
+//
+//   - boolean algebra (!, &&, ||),
+//   - equalities and inequalities (==, !=, <, <=, >, >=),
+//   - the string operations "contains" and "matches",
+//   - map indexing (attrs["foo"]) and membership ("foo" in attrs), and
+//   - constant strings, timestamps, durations, and ints.
+//
+// All equalities and inequalities must look like `component == "store.Store"`
+// or `attrs["foo"] == "bar"`; i.e. a field or attribute on the left and a
+// constant on the right.
+//
+// # Semantics
+//
+// Like for log queries, an attribute expression like `attrs["foo"]` has an
+// implicit membership test `"foo" in attrs`. Note that all the conditions in
+// a query apply to the same span. For example, the query
+//
+//	method == "Get" && attrs["user"] == "alice"
+//
+// matches the traces with a span of a Get method that has a user attribute
+// equal to alice, not the traces with a span of a Get method and some other
+// span with a user attribute equal to alice.
+//
+// [1]: https://opensource.google/projects/cel
+type Query = string
+
+// queryEnv returns the cel.Env needed to compile a query.
+func queryEnv() (*cel.Env, error) {
+	return cel.NewEnv(cel.Declarations(
+		decls.NewVar("app", decls.String),
+		decls.NewVar("version", decls.String),
+		decls.NewVar("full_version", decls.String),
+		decls.NewVar("name", decls.String),
+		decls.NewVar("component", decls.String),
+		decls.NewVar("method", decls.String),
+		decls.NewVar("time", decls.Timestamp),
+		decls.NewVar("duration", decls.Duration),
+		decls.NewVar("status", decls.String),
+		decls.NewVar("attrs", decls.NewMapType(decls.String, decls.String)),
+	))
+}
+
+// columns maps every query field, other than attrs, to the corresponding SQL
+// expression. The expressions refer to the traces table as t and to the
+// spans table as s.
+var columns = map[string]string{
+	"app":          "t.app",
+	"version":      "substr(t.version, 1, 8)",
+	"full_version": "t.version",
+	"name":         "s.name",
+	"component":    "s.component",
+	"method":       "s.method",
+	"time":         "s.start_time_unix_us",
+	"duration":     "(s.end_time_unix_us - s.start_time_unix_us)",
+	"status":       `(CASE s.status WHEN '' THEN 'OK' ELSE s.status END)`,
+}
+
+// sqlOps maps CEL comparison operators to SQL comparison operators.
+var sqlOps = map[string]string{
+	operators.Equals:        "=",
+	operators.NotEquals:     "!=",
+	operators.Less:          "<",
+	operators.LessEquals:    "<=",
+	operators.Greater:       ">",
+	operators.GreaterEquals: ">=",
+}
+
+// compileQuery compiles a query into an SQL condition on a span s of a trace
+// t, along with the condition's arguments.
+func compileQuery(query Query) (string, []any, error) {
+	env, err := queryEnv()
+	if err != nil {
+		return "", nil, fmt.Errorf("query %s: environment error: %w", query, err)
+	}
+	ast, issues := env.Compile(query)
+	if issues != nil && issues.Err() != nil {
+		return "", nil, fmt.Errorf("query %s: compilation error: %w", query, issues.Err())
+	}
+	if ast.OutputType() != cel.BoolType {
+		return "", nil, fmt.Errorf("query %s: type error: got %v, want %v", query, ast.OutputType(), "bool")
+	}
+	var c sqlCompiler
+	if err := c.expr(ast.Expr()); err != nil {
+		return "", nil, fmt.Errorf("query %s: %w", query, err)
+	}
+	return c.b.String(), c.args, nil
+}
+
+// sqlCompiler translates a query into an SQL condition.
+type sqlCompiler struct {
+	b    strings.Builder
+	args []any
+}
+
+func (c *sqlCompiler) expr(e *exprpb.Expr) error {
+	call := e.GetCallExpr()
+	if call == nil {
+		return fmt.Errorf("unsupported expression: %v", e)
+	}
+
+	// Note that CEL represents operators like || and ! as calls.
+	switch f := call.GetFunction(); f {
+	// !
+	case operators.LogicalNot:
+		c.b.WriteString("NOT (")
+		if err := c.expr(call.Args[0]); err != nil {
+			return err
+		}
+		c.b.WriteString(")")
+		return nil
+
+	// &&, ||
+	case operators.LogicalAnd, operators.LogicalOr:
+		op := " AND "
+		if f == operators.LogicalOr {
+			op = " OR "
+		}
+		c.b.WriteString("(")
+		if err := c.expr(call.Args[0]); err != nil {
+			return err
+		}
+		c.b.WriteString(")" + op + "(")
+		if err := c.expr(call.Args[1]); err != nil {
+			return err
+		}
+		c.b.WriteString(")")
+		return nil
+
+	// ==, !=, <, <=, >, >=
+	case operators.Equals, operators.NotEquals,
+		operators.Less, operators.LessEquals,
+		operators.Greater, operators.GreaterEquals:
+		lit, err := literal(call.Args[1])
+		if err != nil {
+			return err
+		}
+		return c.field(call.Args[0], func(col string) {
+			c.b.WriteString(fmt.Sprintf("%s %s ?", col, sqlOps[f]))
+			c.args = append(c.args, lit)
+		})
+
+	// contains, matches
+	case "contains", "matches":
+		lit, err := literal(call.Args[0])
+		if err != nil {
+			return err
+		}
+		if _, ok := lit.(string); !ok {
+			return fmt.Errorf("unsupported %s argument: %v", f, call.Args[0])
+		}
+		return c.field(call.Target, func(col string) {
+			if f == "contains" {
+				c.b.WriteString(fmt.Sprintf("instr(%s, ?) > 0", col))
+			} else {
+				c.b.WriteString(fmt.Sprintf("%s(?, %s)", matchesFunc, col))
+			}
+			c.args = append(c.args, lit)
+		})
+
+	// in
+	case operators.In:
+		key := call.Args[0].GetConstExpr().GetStringValue()
+		if key == "" {
+			return fmt.Errorf("unsupported attribute, want a non-empty string constant, got %v", call.Args[0])
+		}
+		if call.Args[1].GetIdentExpr().GetName() != "attrs" {
+			return fmt.Errorf(`unsupported map, want "attrs", got %v`, call.Args[1])
+		}
+		c.b.WriteString(attrExists + ")")
+		c.args = append(c.args, key)
+		return nil
+
+	default:
+		return fmt.Errorf("unsupported call: %v", call)
+	}
+}
+
+// attrExists is the start of an SQL condition that checks for the existence
+// of the attribute of span s with the key in the first argument. It must be
+// followed by a closing parenthesis, possibly preceded by more conditions on
+// the attribute's value a.value.
+const attrExists = `EXISTS (SELECT 1 FROM span_attributes a WHERE a.trace_id = s.trace_id AND a.span_id = s.span_id AND a.key = ?`
+
+// field writes the SQL condition cond on the provided field, which is either
+// an identifier like `component` or an attribute expression like
+// `attrs["foo"]`. The cond function is passed the SQL expression for the
+// field.
+func (c *sqlCompiler) field(e *exprpb.Expr, cond func(col string)) error {
+	if ident := e.GetIdentExpr(); ident != nil {
+		col, ok := columns[ident.GetName()]
+		if !ok {
+			return fmt.Errorf("unsupported field: %v", ident.GetName())
+		}
+		cond(col)
+		return nil
+	}
+
+	call := e.GetCallExpr()
+	if call == nil || call.GetFunction() != operators.Index {
+		return fmt.Errorf("unsupported field: %v", e)
+	}
+	if call.Args[0].GetIdentExpr().GetName() != "attrs" {
+		return fmt.Errorf(`unsupported map target, want "attrs", got %v`, call.Args[0])
+	}
+	key := call.Args[1].GetConstExpr().GetStringValue()
+	if key == "" {
+		return fmt.Errorf("unsupported map index, want a non-empty string constant, got %v", call.Args[1])
+	}
+	// Note that attrs["foo"] has an implicit "foo" in attrs check.
+	c.b.WriteString(attrExists + " AND ")
+	c.args = append(c.args, key)
+	cond("a.value")
+	c.b.WriteString(")")
+	return nil
+}
+
+// literal returns the SQL value of the provided literal expression (e.g., 42,
+// "foo", timestamp("2023-01-01T00:00:00Z"), duration("1s")). Timestamps and
+// durations are represented in microseconds.
+func literal(e *exprpb.Expr) (any, error) {
+	if c := e.GetConstExpr(); c != nil {
+		switch v := c.GetConstantKind().(type) {
+		case *exprpb.Constant_StringValue:
+			return v.StringValue, nil
+		case *exprpb.Constant_Int64Value:
+			return v.Int64Value, nil
+		}
+		return nil, fmt.Errorf("unsupported literal: %v", e)
+	}
+	call := e.GetCallExpr()
+	if call == nil || len(call.Args) != 1 {
+		return nil, fmt.Errorf("unsupported literal: %v", e)
+	}
+	arg := call.Args[0].GetConstExpr().GetStringValue()
+	switch call.GetFunction() {
+	case "timestamp":
+		t, err := time.Parse(time.RFC3339, arg)
+		if err != nil {
+			return nil, fmt.Errorf("invalid timestamp %q: %w", arg, err)
+		}
+		return t.UnixMicro(), nil
+	case "duration":
+		d, err := time.ParseDuration(arg)
+		if err != nil {
+			return nil, fmt.Errorf("invalid duration %q: %w", arg, err)
+		}
+		return d.Microseconds(), nil
+	default:
+		return nil, fmt.Errorf("unsupported literal: %v", e)
+	}
+}
+
+// matchesFunc is the name of the SQL function that implements the CEL
+// "matches" operation. matchesFunc(pattern, s) returns whether s contains a
+// match of the regular expression pattern.
+const matchesFunc = "weaver_matches"
+
+// regexps caches compiled regular expressions, by pattern.
+var regexps sync.Map
+
+func init() {
+	sqlite.MustRegisterDeterministicScalarFunction(matchesFunc, 2, func(_ *sqlite.FunctionContext, args []driver.Value) (driver.Value, error) {
+		pattern, ok1 := args[0].(string)
+		s, ok2 := args[1].(string)
+		if !ok1 || !ok2 {
+			return false, nil
+		}
+		re, ok := regexps.Load(pattern)
+		if !ok {
+			compiled, err := regexp.Compile(pattern)
+			if err != nil {
+				return nil, err
+			}
+			re, _ = regexps.LoadOrStore(pattern, compiled)
+		}
+		return re.(*regexp.Regexp).MatchString(s), nil
+	})
+}
@@ -17,6 +17,8 @@ package sim
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"strconv"
 
 	"github.com/ServiceWeaver/weaver"
 )
@@ -58,6 +60,9 @@ type panicker interface {
 	Panic(context.Context, bool) error
 }
 
+// frontend serves DivMod over HTTP. GET /divmod?n=<n>&d=<d> returns "n/d n%d".
+type frontend interface{}
+
 // Component implementation structs.
 
 type divModImpl struct {
@@ -88,6 +93,12 @@ type panickerImpl struct {
 	weaver.Implements[panicker]
 }
 
+type frontendImpl struct {
+	weaver.Implements[frontend]
+	divmod weaver.Ref[divMod]
+	lis    weaver.Listener `weaver:"simfrontend"`
+}
+
 // Component implementations.
 
 func (i *divModImpl) DivMod(ctx context.Context, n, d int) (int, int, error) {
@@ -148,6 +159,25 @@ func (*panickerImpl) Panic(_ context.Context, b bool) error {
 	return nil
 }
 
+func (f *frontendImpl) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	n, err := strconv.Atoi(r.URL.Query().Get("n"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	d, err := strconv.Atoi(r.URL.Query().Get("d"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	div, mod, err := f.divmod.Get().DivMod(r.Context(), n, d)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	fmt.Fprintf(w, "%d %d", div, mod)
+}
+
 // Errors.
 
 type zeroError struct {
@@ -32,6 +32,7 @@ type EventOpFinish struct {
 	TraceID int    // trace id
 	SpanID  int    // span id
 	Error   string // returned error message
+	Output  string // output recorded with Observe, if any
 }
 
 // EventCall represents a component method call.
@@ -84,6 +85,43 @@ type EventPanic struct {
 	Stack    string // stack trace
 }
 
+// EventAbort represents a method call failing while it is still executing.
+// The method keeps executing, but its remaining method calls fail, and its
+// caller receives an error.
+type EventAbort struct {
+	TraceID   int    // trace id
+	SpanID    int    // span id
+	Component string // component executing the call
+	Replica   int    // component replica executing the call
+}
+
+// EventCrash represents a component replica crashing and restarting. The
+// restarted replica loses all of its in-memory state, and the method calls
+// executing on the crashed replica are aborted.
+type EventCrash struct {
+	Component string // crashing component
+	Replica   int    // crashing component replica
+}
+
+// EventDelay represents the delay of a pending method call or return, letting
+// other ops run before it is delivered.
+type EventDelay struct {
+	TraceID int // trace id
+	SpanID  int // span id
+}
+
+// EventPartition represents a network partition that splits component
+// replicas into two sides. Replicas on different sides cannot communicate
+// until the partition heals. Ops and fakes can communicate with every
+// replica.
+type EventPartition struct {
+	Left  []string // replicas on one side, as "<component> <replica>"
+	Right []string // replicas on the other side, as "<component> <replica>"
+}
+
+// EventHeal represents a network partition healing.
+type EventHeal struct{}
+
 func (EventOpStart) isEvent()       {}
 func (EventOpFinish) isEvent()      {}
 func (EventCall) isEvent()          {}
@@ -92,6 +130,11 @@ func (EventReturn) isEvent()        {}
 func (EventDeliverReturn) isEvent() {}
 func (EventDeliverError) isEvent()  {}
 func (EventPanic) isEvent()         {}
+func (EventAbort) isEvent()         {}
+func (EventCrash) isEvent()         {}
+func (EventDelay) isEvent()         {}
+func (EventPartition) isEvent()     {}
+func (EventHeal) isEvent()          {}
 
 var _ Event = EventOpStart{}
 var _ Event = EventOpFinish{}
@@ -101,3 +144,8 @@ var _ Event = EventReturn{}
 var _ Event = EventDeliverReturn{}
 var _ Event = EventDeliverError{}
 var _ Event = EventPanic{}
+var _ Event = EventAbort{}
+var _ Event = EventCrash{}
+var _ Event = EventDelay{}
+var _ Event = EventPartition{}
+var _ Event = EventHeal{}
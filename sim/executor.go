@@ -20,8 +20,10 @@ import (
 	"log/slog"
 	"math/rand"
 	"net"
+	"net/http"
 	"reflect"
 	"runtime/debug"
+	"sort"
 	"sync"
 	"testing"
 
@@ -31,6 +33,7 @@ import (
 	"github.com/ServiceWeaver/weaver/runtime/codegen"
 	"github.com/ServiceWeaver/weaver/runtime/protos"
 	"github.com/google/uuid"
+	"golang.org/x/exp/maps"
 	"golang.org/x/sync/errgroup"
 )
 
@@ -61,11 +64,15 @@ type componentInfo struct {
 
 // hyperparameters configure an execution.
 type hyperparameters struct {
-	Seed        int64   // the executor's seed
-	NumReplicas int     // the number of replicas of every component
-	NumOps      int     // the number of ops to run
-	FailureRate float64 // the fraction of calls to artificially fail
-	YieldRate   float64 // the probability that an op yields after a step
+	Seed          int64   // the executor's seed
+	NumReplicas   int     // the number of replicas of every component
+	NumOps        int     // the number of ops to run
+	FailureRate   float64 // the fraction of calls to artificially fail
+	YieldRate     float64 // the probability that an op yields after a step
+	CrashRate     float64 // the probability that a replica crashes before a step
+	PartitionRate float64 // the probability that the network partitions (or heals) before a step
+	DelayRate     float64 // the probability that a delivered message is delayed
+	SimplifyRate  float64 // the probability that a random value used to generate op arguments is zero
 }
 
 // generator is an untyped Generator[T].
@@ -89,30 +96,39 @@ type op struct {
 //	}
 type executor struct {
 	// Immutable fields.
-	w          reflect.Type                           // workload type
-	regsByIntf map[reflect.Type]*codegen.Registration // registrations, by component interface
-	info       componentInfo                          // component information
-	config     *protos.AppConfig                      // application config
-
-	registrar  *registrar       // registrar
-	params     hyperparameters  // hyperparameters
-	workload   reflect.Value    // workload instance
-	ops        []*op            // registered ops
-	components map[string][]any // component replicas
+	w            reflect.Type                           // workload type
+	regsByIntf   map[reflect.Type]*codegen.Registration // registrations, by component interface
+	listenerRegs map[string]*codegen.Registration       // registrations, by listener name
+	info         componentInfo                          // component information
+	config       *protos.AppConfig                      // application config
+
+	registrar  *registrar              // registrar
+	params     hyperparameters         // hyperparameters
+	workload   reflect.Value           // workload instance
+	ops        []*op                   // registered ops
+	weaverInfo *weaver.WeaverInfo      // application runtime information
+	replicated []*codegen.Registration // non-faked components, sorted by name
 
 	ctx   context.Context // execution context
 	group *errgroup.Group // group with all running goroutines
 
-	mu          sync.Mutex       // guards the following fields
-	rand        *rand.Rand       // random number generator
-	current     int              // currently running op
-	numStarted  int              // number of started ops
-	notFinished ints             // not finished op trace ids, optimized for removal and sampling
-	calls       map[int][]*call  // pending calls, by trace id
-	replies     map[int][]*reply // pending replies, by trace id
-	history     []Event          // history of events
-	nextTraceID int              // next trace id
-	nextSpanID  int              // next span id
+	mu          sync.Mutex             // guards the following fields
+	rand        *rand.Rand             // random number generator
+	simplifier  *simplifier            // source of argRand
+	argRand     *rand.Rand             // random number generator for op arguments, if simplifying
+	current     int                    // currently running op
+	numStarted  int                    // number of started ops
+	notFinished ints                   // not finished op trace ids, optimized for removal and sampling
+	components  map[string][]any       // component replicas
+	calls       map[int][]*call        // pending calls, by trace id
+	replies     map[int][]*reply       // pending replies, by trace id
+	running     map[int]*call          // delivered calls that haven't returned, by span id
+	partition   map[string][]bool      // the side of every replica, or nil if there is no partition
+	listeners   map[string][]*listener // listener replicas, by listener name
+	history     []Event                // history of events
+	operations  []timedOperation       // completed ops, if a model is registered
+	nextTraceID int                    // next trace id
+	nextSpanID  int                    // next span id
 }
 
 // result is the result of an execution.
@@ -137,19 +153,29 @@ const (
 	// this case, the method call will fail after fully executing.
 	failAfterDelivery
 
-	// TODO(mwhittaker): Deliver a method call, and then fail it while it is
-	// still executing.
+	// Fail the call while it is still executing on a component replica. In
+	// this case, the method call is aborted either right before one of its
+	// own method calls or when it returns. The method keeps executing, but
+	// the rest of its method calls fail, and the call fails.
+	failDuringExecution
 )
 
+// failures are the fates of failing calls.
+var failures = []fate{failBeforeDelivery, failAfterDelivery, failDuringExecution}
+
 // call is a pending method call.
 type call struct {
-	traceID   int
-	spanID    int
-	fate      fate            // whether to fail the operation
-	component reflect.Type    // the component being called
-	method    string          // the method being called
-	args      []reflect.Value // the call's arguments
-	reply     chan *reply     // a channel to receive the call's reply
+	traceID       int
+	spanID        int
+	fate          fate                  // whether to fail the operation
+	caller        string                // the calling component (or "op")
+	callerReplica int                   // the calling component replica (or op number)
+	reg           *codegen.Registration // the component being called
+	method        string                // the method being called
+	args          []reflect.Value       // the call's arguments
+	reply         chan *reply           // a channel to receive the call's reply
+	replica       int                   // the replica executing the call, once delivered
+	aborted       bool                  // has the call been aborted?
 }
 
 // reply is a pending method reply.
@@ -181,13 +207,17 @@ func withIDs(ctx context.Context, traceID, spanID int) context.Context {
 }
 
 // extractIDs returns the trace and span id embedded in the provided context.
-// If the provided context does not have embedded trace and span ids,
-// extractIDs returns 0, 0.
+// The context of an HTTP request served on a simulated listener carries the
+// ids of the request (see address). If the provided context does not have
+// embedded trace and span ids, extractIDs returns 0, 0.
 func extractIDs(ctx context.Context) (int, int) {
 	var traceID, spanID int
 	if x := ctx.Value(traceContextKey); x != nil {
 		traceID = x.(traceContext).traceID
 		spanID = x.(traceContext).spanID
+	} else if addr, ok := ctx.Value(http.LocalAddrContextKey).(address); ok {
+		traceID = addr.trace.traceID
+		spanID = addr.trace.spanID
 	}
 	return traceID, spanID
 }
@@ -198,16 +228,29 @@ func newExecutor(t testing.TB, w reflect.Type, regsByIntf map[reflect.Type]*code
 	for intf := range regsByIntf {
 		registered[intf] = struct{}{}
 	}
+	listenerRegs := map[string]*codegen.Registration{}
+	for _, reg := range regsByIntf {
+		for _, name := range reg.Listeners {
+			listenerRegs[name] = reg
+		}
+	}
+	source := &wyrand{0}
+	simplifier := &simplifier{src: source}
 	return &executor{
-		w:          w,
-		regsByIntf: regsByIntf,
-		info:       info,
-		config:     app,
-		registrar:  newRegistrar(t, w, registered),
-		components: make(map[string][]any, len(regsByIntf)),
-		rand:       rand.New(&wyrand{0}),
-		calls:      map[int][]*call{},
-		replies:    map[int][]*reply{},
+		w:            w,
+		regsByIntf:   regsByIntf,
+		listenerRegs: listenerRegs,
+		info:         info,
+		config:       app,
+		registrar:    newRegistrar(t, w, registered),
+		components:   make(map[string][]any, len(regsByIntf)),
+		rand:         rand.New(source),
+		simplifier:   simplifier,
+		argRand:      rand.New(simplifier),
+		calls:        map[int][]*call{},
+		replies:      map[int][]*reply{},
+		running:      map[int]*call{},
+		listeners:    map[string][]*listener{},
 	}
 }
 
@@ -238,6 +281,19 @@ func (e *executor) execute(ctx context.Context, params hyperparameters) (result,
 	if params.YieldRate < 0 || params.YieldRate > 1 {
 		return result{}, fmt.Errorf("YieldRate (%f) out of range [0, 1]", params.YieldRate)
 	}
+	if params.CrashRate < 0 || params.CrashRate > 1 {
+		return result{}, fmt.Errorf("CrashRate (%f) out of range [0, 1]", params.CrashRate)
+	}
+	if params.PartitionRate < 0 || params.PartitionRate > 1 {
+		return result{}, fmt.Errorf("PartitionRate (%f) out of range [0, 1]", params.PartitionRate)
+	}
+	if params.DelayRate < 0 || params.DelayRate > 1 {
+		return result{}, fmt.Errorf("DelayRate (%f) out of range [0, 1]", params.DelayRate)
+	}
+	if params.SimplifyRate < 0 || params.SimplifyRate >= 1 {
+		// A SimplifyRate of 1 would make Filter generators loop forever.
+		return result{}, fmt.Errorf("SimplifyRate (%f) out of range [0, 1)", params.SimplifyRate)
+	}
 
 	// Construct an instance of the workload struct.
 	workload := reflect.New(e.w.Elem()).Interface().(Workload)
@@ -262,18 +318,43 @@ func (e *executor) execute(ctx context.Context, params hyperparameters) (result,
 	e.group, e.ctx = errgroup.WithContext(ctx)
 	e.step()
 	err := e.group.Wait()
+	e.closeListeners()
 	if err != nil && err == ctx.Err() {
 		return result{}, err
 	}
+	if err == nil {
+		err = e.check()
+	}
 	return result{params, err, e.history}, nil
 }
 
+// check checks the registered invariants and model against a finished
+// execution.
+func (e *executor) check() error {
+	if len(e.registrar.invariants) > 0 {
+		snapshot := Snapshot{components: make(map[reflect.Type][]any, len(e.regsByIntf))}
+		for intf, reg := range e.regsByIntf {
+			snapshot.components[intf] = e.components[reg.Name]
+		}
+		for _, check := range e.registrar.invariants {
+			if err := check(snapshot); err != nil {
+				return fmt.Errorf("invariant violated: %w", err)
+			}
+		}
+	}
+	if model := e.registrar.model; model != nil {
+		return checkLinearizable(model, e.operations)
+	}
+	return nil
+}
+
 // reset resets the state of an executor, preparing it for the next execution.
 func (e *executor) reset(workload Workload, fakes map[reflect.Type]any, ops []*op, params hyperparameters) error {
 	e.workload = reflect.ValueOf(workload)
 	e.params = params
 	e.ops = ops
 	e.rand.Seed(params.Seed)
+	e.simplifier.rate = params.SimplifyRate
 	e.current = 1
 	e.numStarted = 0
 	e.notFinished.reset(1, 1+params.NumOps)
@@ -283,7 +364,11 @@ func (e *executor) reset(workload Workload, fakes map[reflect.Type]any, ops []*o
 	for k, v := range e.replies {
 		e.replies[k] = v[:0]
 	}
+	clear(e.running)
+	e.partition = nil
+	e.closeListeners()
 	e.history = []Event{}
+	e.operations = e.operations[:0]
 	e.nextTraceID = 1
 	e.nextSpanID = 1
 
@@ -292,7 +377,7 @@ func (e *executor) reset(workload Workload, fakes map[reflect.Type]any, ops []*o
 	if err != nil {
 		return err
 	}
-	weaverInfo := &weaver.WeaverInfo{
+	e.weaverInfo = &weaver.WeaverInfo{
 		DeploymentID: depID.String(),
 	}
 
@@ -303,7 +388,19 @@ func (e *executor) reset(workload Workload, fakes map[reflect.Type]any, ops []*o
 		return err
 	}
 
+	// Validate the registered servers.
+	for impl := range e.registrar.servers {
+		found := false
+		for _, reg := range e.regsByIntf {
+			found = found || reg.Impl == impl
+		}
+		if !found {
+			return fmt.Errorf("server registered for %v, which is not a component implementation", impl)
+		}
+	}
+
 	// Create component replicas.
+	e.replicated = e.replicated[:0]
 	for _, reg := range e.regsByIntf {
 		components := e.components[reg.Name]
 		if components != nil {
@@ -316,64 +413,114 @@ func (e *executor) reset(workload Workload, fakes map[reflect.Type]any, ops []*o
 		}
 
 		for i := 0; i < params.NumReplicas; i++ {
-			// Create the component implementation.
-			v := reflect.New(reg.Impl)
-			obj := v.Interface()
-
-			// Fill config.
-			if e.info.hasConfig[reg.Iface] {
-				if cfg := weaver.GetConfig(obj); cfg != nil {
-					if err := runtime.ParseConfigSection(reg.Name, "", e.config.Sections, cfg); err != nil {
-						return err
-					}
-				}
-			}
-
-			// Set logger.
-			//
-			// TODO(mwhittaker): Use custom logger.
-			if err := weaver.SetLogger(obj, slog.Default()); err != nil {
+			obj, err := e.newReplica(reg, i)
+			if err != nil {
 				return err
 			}
+			components = append(components, obj)
+		}
+		e.components[reg.Name] = components
+		e.replicated = append(e.replicated, reg)
+	}
 
-			// Set application runtime information.
-			if err := weaver.SetWeaverInfo(obj, weaverInfo); err != nil {
-				return err
-			}
+	// Sort the replicated components, so that crashes and partitions are
+	// deterministic.
+	sort.Slice(e.replicated, func(i, j int) bool {
+		return e.replicated[i].Name < e.replicated[j].Name
+	})
+	return nil
+}
 
-			// Fill ref fields.
-			if e.info.hasRefs[reg.Iface] {
-				if err := weaver.FillRefs(obj, func(t reflect.Type) (any, error) {
-					return e.getIntf(t, reg.Name, i)
-				}); err != nil {
-					return err
-				}
+// newReplica creates and initializes the provided replica of the provided
+// component.
+func (e *executor) newReplica(reg *codegen.Registration, replica int) (any, error) {
+	// Create the component implementation.
+	v := reflect.New(reg.Impl)
+	obj := v.Interface()
+
+	// Fill config.
+	if e.info.hasConfig[reg.Iface] {
+		if cfg := weaver.GetConfig(obj); cfg != nil {
+			if err := runtime.ParseConfigSection(reg.Name, "", e.config.Sections, cfg); err != nil {
+				return nil, err
 			}
+		}
+	}
 
-			// Fill listener fields.
-			if e.info.hasListeners[reg.Iface] {
-				if err := weaver.FillListeners(obj, func(name string) (net.Listener, string, error) {
-					lis, err := net.Listen("tcp", ":0")
-					return lis, "", err
-				}); err != nil {
-					return err
-				}
-			}
+	// Set logger.
+	//
+	// TODO(mwhittaker): Use custom logger.
+	if err := weaver.SetLogger(obj, slog.Default()); err != nil {
+		return nil, err
+	}
+
+	// Set application runtime information.
+	if err := weaver.SetWeaverInfo(obj, e.weaverInfo); err != nil {
+		return nil, err
+	}
+
+	// Fill ref fields.
+	if e.info.hasRefs[reg.Iface] {
+		if err := weaver.FillRefs(obj, func(t reflect.Type) (any, error) {
+			return e.getIntf(t, reg.Name, replica)
+		}); err != nil {
+			return nil, err
+		}
+	}
 
-			// Call Init if available.
-			if i, ok := obj.(interface{ Init(context.Context) error }); ok {
-				// TODO(mwhittaker): Use better context.
-				if err := i.Init(context.Background()); err != nil {
-					return fmt.Errorf("component %q initialization failed: %w", reg.Name, err)
-				}
+	// Fill listener fields with in-memory listeners. The listeners of a
+	// crashed replica are closed and replaced.
+	var listeners []*listener
+	if e.info.hasListeners[reg.Iface] {
+		if err := weaver.FillListeners(obj, func(name string) (net.Listener, string, error) {
+			lis := newListener(name, replica)
+			if replica < len(e.listeners[name]) {
+				e.listeners[name][replica].Close()
+				e.listeners[name][replica] = lis
+			} else {
+				e.listeners[name] = append(e.listeners[name], lis)
 			}
+			listeners = append(listeners, lis)
+			return lis, "", nil
+		}); err != nil {
+			return nil, err
+		}
+	}
 
-			components = append(components, obj)
+	// Call Init if available.
+	if i, ok := obj.(interface{ Init(context.Context) error }); ok {
+		// TODO(mwhittaker): Use better context.
+		if err := i.Init(context.Background()); err != nil {
+			return nil, fmt.Errorf("component %q initialization failed: %w", reg.Name, err)
 		}
-		e.components[reg.Name] = components
 	}
 
-	return nil
+	// Start the registered server, if any. If the server returns before its
+	// listeners are closed, requests sent to the replica fail with the
+	// server's error.
+	if server, ok := e.registrar.servers[reg.Impl]; ok {
+		go func() {
+			err := server.serve(context.Background(), obj)
+			if err == nil {
+				err = fmt.Errorf("server returned")
+			}
+			for _, lis := range listeners {
+				lis.close(fmt.Errorf("component %q replica %d: %w", reg.Name, replica, err))
+			}
+		}()
+	}
+	return obj, nil
+}
+
+// closeListeners closes the listeners of every component replica, stopping
+// their servers.
+func (e *executor) closeListeners() {
+	for name, listeners := range e.listeners {
+		for _, lis := range listeners {
+			lis.Close()
+		}
+		e.listeners[name] = listeners[:0]
+	}
 }
 
 // getIntf returns a handle to the component of the provided type.
@@ -390,17 +537,17 @@ func (e *executor) getIntf(t reflect.Type, caller string, replica int) (any, err
 
 // call executes a component method call against a random replica.
 func (e *executor) call(caller string, replica int, reg *codegen.Registration, method string, ctx context.Context, args []any, returns []any) error {
-	// Convert the arguments to reflect.Values.
+	// Convert the arguments to reflect.Values. The context is filled in
+	// below, once the call has a span id.
 	in := make([]reflect.Value, 1+len(args))
-	in[0] = reflect.ValueOf(ctx)
 	strings := make([]string, len(args))
 	for i, arg := range args {
 		in[i+1] = reflect.ValueOf(arg)
 		strings[i] = fmt.Sprint(arg)
 	}
 
-	// Extract the trace id.
-	traceID, _ := extractIDs(ctx)
+	// Extract the trace and span id.
+	traceID, parentSpanID := extractIDs(ctx)
 	if traceID == 0 {
 		// TODO(mwhittaker): Link to online documentation with better
 		// explanation of this error.
@@ -412,29 +559,7 @@ func (e *executor) call(caller string, replica int, reg *codegen.Registration, m
 	e.mu.Lock()
 	spanID := e.nextSpanID
 	e.nextSpanID++
-
-	// Determine the fate of the call.
-	fate := dontFail
-	if flip(e.rand, e.params.FailureRate) {
-		// TODO(mwhittaker): Have two parameters to control the rate of failing
-		// before and after delivery? This level of control might be
-		// unnecessary. For now, we pick between them equiprobably.
-		if flip(e.rand, 0.5) {
-			fate = failBeforeDelivery
-		} else {
-			fate = failAfterDelivery
-		}
-	}
-
-	e.calls[traceID] = append(e.calls[traceID], &call{
-		traceID:   traceID,
-		spanID:    spanID,
-		fate:      fate,
-		component: reg.Iface,
-		method:    method,
-		args:      in,
-		reply:     reply,
-	})
+	in[0] = reflect.ValueOf(withIDs(ctx, traceID, spanID))
 
 	if caller == "op" {
 		replica = traceID
@@ -448,6 +573,43 @@ func (e *executor) call(caller string, replica int, reg *codegen.Registration, m
 		Method:    method,
 		Args:      strings,
 	})
+
+	// If the caller is a method call that is failing while executing, abort
+	// it right before this call, or on any of its later calls.
+	parent := e.running[parentSpanID]
+	if parent != nil && parent.fate == failDuringExecution && !parent.aborted && flip(e.rand, 0.5) {
+		e.abort(parent)
+	}
+	if parent != nil && parent.aborted {
+		// The calls of an aborted method call fail immediately.
+		e.history = append(e.history, EventDeliverError{
+			TraceID: traceID,
+			SpanID:  spanID,
+		})
+		e.mu.Unlock()
+		return core.RemoteCallError
+	}
+
+	// Determine the fate of the call.
+	fate := dontFail
+	if flip(e.rand, e.params.FailureRate) {
+		// TODO(mwhittaker): Have separate parameters to control the rate of
+		// failing before, during, and after delivery? This level of control
+		// might be unnecessary. For now, we pick between them equiprobably.
+		fate = pick(e.rand, failures)
+	}
+
+	e.calls[traceID] = append(e.calls[traceID], &call{
+		traceID:       traceID,
+		spanID:        spanID,
+		fate:          fate,
+		caller:        caller,
+		callerReplica: replica,
+		reg:           reg,
+		method:        method,
+		args:          in,
+		reply:         reply,
+	})
 	e.mu.Unlock()
 
 	// Take a step and wait for the call to finish.
@@ -490,91 +652,247 @@ func (e *executor) step() {
 		return
 	}
 
-	if !e.notFinished.has(e.current) || flip(e.rand, e.params.YieldRate) {
-		// Yield execution to a (potentially) different op.
-		e.current = e.notFinished.pick(e.rand)
+	// Inject replica crashes and network partitions.
+	if len(e.replicated) > 0 && e.params.CrashRate > 0 && flip(e.rand, e.params.CrashRate) {
+		if err := e.crash(); err != nil {
+			// Failing to restart a replica fails the execution.
+			e.group.Go(func() error { return err })
+			return
+		}
+	}
+	if len(e.replicated) > 0 && e.params.PartitionRate > 0 && flip(e.rand, e.params.PartitionRate) {
+		e.togglePartition()
 	}
 
-	if e.current > e.numStarted {
-		// Make sure to start ops in increasing order. Op 1 starts first, then
-		// Op 2, and so on.
-		e.current = e.numStarted + 1
-		e.numStarted++
+	// delayed is true if a message was delayed during this step. At most one
+	// message is delayed per step.
+	delayed := false
+	for {
+		if delayed {
+			// Yield execution to a different op.
+			for current := e.current; e.current == current; {
+				e.current = e.notFinished.pick(e.rand)
+			}
+		} else if !e.notFinished.has(e.current) || flip(e.rand, e.params.YieldRate) {
+			// Yield execution to a (potentially) different op.
+			e.current = e.notFinished.pick(e.rand)
+		}
 
-		// Start the op.
-		o := pick(e.rand, e.ops)
-		e.group.Go(func() error {
-			return e.runOp(e.ctx, o)
-		})
-		return
-	}
+		if e.current > e.numStarted {
+			// Make sure to start ops in increasing order. Op 1 starts first,
+			// then Op 2, and so on.
+			e.current = e.numStarted + 1
+			e.numStarted++
 
-	if len(e.calls[e.current]) == 0 && len(e.replies[e.current]) == 0 {
-		// This should be impossible. If it ever happens, there's a bug.
-		panic(fmt.Errorf("op %d has no pending calls or replies", e.current))
-	}
+			// Start the op.
+			o := pick(e.rand, e.ops)
+			e.group.Go(func() error {
+				return e.runOp(e.ctx, o)
+			})
+			return
+		}
 
-	hasCalls := len(e.calls[e.current]) > 0
-	hasReplies := len(e.replies[e.current]) > 0
-	deliverCall := false
-	switch {
-	case hasCalls && hasReplies:
-		deliverCall = flip(e.rand, 0.5)
-	case hasCalls && !hasReplies:
-		deliverCall = true
-	case !hasCalls && hasReplies:
-		deliverCall = false
-	case !hasCalls && !hasReplies:
-		return
-	}
+		if len(e.calls[e.current]) == 0 && len(e.replies[e.current]) == 0 {
+			// This should be impossible. If it ever happens, there's a bug.
+			panic(fmt.Errorf("op %d has no pending calls or replies", e.current))
+		}
 
-	// Randomly execute a step.
-	if deliverCall {
-		var call *call
-		call, e.calls[e.current] = pop(e.rand, e.calls[e.current])
+		hasCalls := len(e.calls[e.current]) > 0
+		hasReplies := len(e.replies[e.current]) > 0
+		deliverCall := false
+		switch {
+		case hasCalls && hasReplies:
+			deliverCall = flip(e.rand, 0.5)
+		case hasCalls && !hasReplies:
+			deliverCall = true
+		case !hasCalls && hasReplies:
+			deliverCall = false
+		case !hasCalls && !hasReplies:
+			return
+		}
 
-		if call.fate == failBeforeDelivery {
-			// Fail the call before delivering it.
-			e.history = append(e.history, EventDeliverError{
-				TraceID: call.traceID,
-				SpanID:  call.spanID,
-			})
-			call.reply <- &reply{
-				call:    call,
-				returns: returnError(call.component, call.method, core.RemoteCallError),
+		// Randomly execute a step.
+		if deliverCall {
+			var call *call
+			call, e.calls[e.current] = pop(e.rand, e.calls[e.current])
+
+			if !delayed && e.delay(call) {
+				// Delay the call, letting another op run first.
+				e.calls[e.current] = append(e.calls[e.current], call)
+				delayed = true
+				continue
+			}
+
+			if call.fate == failBeforeDelivery {
+				// Fail the call before delivering it.
+				e.failCall(call)
+				return
+			}
+
+			// Pick a replica to execute the call.
+			index := e.rand.Intn(len(e.components[call.reg.Name]))
+			if !e.reachable(call.caller, call.callerReplica, call.reg.Name, index) {
+				// Fail the call because the replica is partitioned away from
+				// the caller.
+				e.failCall(call)
+				return
 			}
-			close(call.reply)
+
+			// Deliver the call.
+			e.group.Go(func() error {
+				return e.deliverCall(call, index)
+			})
 			return
 		}
 
-		// Deliver the call.
-		e.group.Go(func() error {
-			return e.deliverCall(call)
-		})
-	} else {
 		var reply *reply
 		reply, e.replies[e.current] = pop(e.rand, e.replies[e.current])
 
-		if reply.call.fate == failAfterDelivery {
+		if !delayed && e.delay(reply.call) {
+			// Delay the reply, letting another op run first.
+			e.replies[e.current] = append(e.replies[e.current], reply)
+			delayed = true
+			continue
+		}
+
+		c := reply.call
+		if c.fate == failAfterDelivery || c.aborted || !e.reachable(c.reg.Name, c.replica, c.caller, c.callerReplica) {
 			// Fail the call after delivering it.
 			e.history = append(e.history, EventDeliverError{
-				TraceID: reply.call.traceID,
-				SpanID:  reply.call.spanID,
+				TraceID: c.traceID,
+				SpanID:  c.spanID,
 			})
-			reply.returns = returnError(reply.call.component, reply.call.method, core.RemoteCallError)
-			reply.call.reply <- reply
-			close(reply.call.reply)
+			reply.returns = returnError(c.reg.Iface, c.method, core.RemoteCallError)
+			c.reply <- reply
+			close(c.reply)
 			return
 		}
 
 		// Return successfully.
 		e.history = append(e.history, EventDeliverReturn{
-			TraceID: reply.call.traceID,
-			SpanID:  reply.call.spanID,
+			TraceID: c.traceID,
+			SpanID:  c.spanID,
 		})
-		reply.call.reply <- reply
-		close(reply.call.reply)
+		c.reply <- reply
+		close(c.reply)
+		return
+	}
+}
+
+// delay returns whether to delay delivering a message of the provided call,
+// recording an EventDelay if so. A message is only delayed if there is
+// another op that can run in the meantime. REQUIRES: e.mu is held.
+func (e *executor) delay(call *call) bool {
+	if e.params.DelayRate == 0 || e.notFinished.size() < 2 || !flip(e.rand, e.params.DelayRate) {
+		return false
+	}
+	e.history = append(e.history, EventDelay{
+		TraceID: call.traceID,
+		SpanID:  call.spanID,
+	})
+	return true
+}
+
+// failCall fails the provided call without delivering it. REQUIRES: e.mu is
+// held.
+func (e *executor) failCall(call *call) {
+	e.history = append(e.history, EventDeliverError{
+		TraceID: call.traceID,
+		SpanID:  call.spanID,
+	})
+	call.reply <- &reply{
+		call:    call,
+		returns: returnError(call.reg.Iface, call.method, core.RemoteCallError),
 	}
+	close(call.reply)
+}
+
+// abort aborts the provided running call. The call keeps executing, but its
+// later method calls fail, and it fails when it returns. REQUIRES: e.mu is
+// held.
+func (e *executor) abort(call *call) {
+	call.aborted = true
+	e.history = append(e.history, EventAbort{
+		TraceID:   call.traceID,
+		SpanID:    call.spanID,
+		Component: call.reg.Name,
+		Replica:   call.replica,
+	})
+}
+
+// crash crashes a random component replica and restarts it with a fresh
+// instance, losing all of the replica's in-memory state. Every call running
+// on the crashed replica is aborted. REQUIRES: e.mu is held.
+func (e *executor) crash() error {
+	reg := pick(e.rand, e.replicated)
+	index := e.rand.Intn(len(e.components[reg.Name]))
+	e.history = append(e.history, EventCrash{
+		Component: reg.Name,
+		Replica:   index,
+	})
+
+	// Abort running calls in span order, to keep the history deterministic.
+	spans := maps.Keys(e.running)
+	sort.Ints(spans)
+	for _, span := range spans {
+		call := e.running[span]
+		if call.reg == reg && call.replica == index && !call.aborted {
+			e.abort(call)
+		}
+	}
+
+	obj, err := e.newReplica(reg, index)
+	if err != nil {
+		return fmt.Errorf("restart component %q replica %d: %w", reg.Name, index, err)
+	}
+	e.components[reg.Name][index] = obj
+	return nil
+}
+
+// togglePartition partitions the replicas of every component into two random
+// sides, or heals the current partition if there is one. REQUIRES: e.mu is
+// held.
+func (e *executor) togglePartition() {
+	if e.partition != nil {
+		e.partition = nil
+		e.history = append(e.history, EventHeal{})
+		return
+	}
+
+	e.partition = map[string][]bool{}
+	var left, right []string
+	for _, reg := range e.replicated {
+		sides := make([]bool, len(e.components[reg.Name]))
+		for i := range sides {
+			sides[i] = flip(e.rand, 0.5)
+			replica := fmt.Sprintf("%s %d", reg.Name, i)
+			if sides[i] {
+				left = append(left, replica)
+			} else {
+				right = append(right, replica)
+			}
+		}
+		e.partition[reg.Name] = sides
+	}
+	e.history = append(e.history, EventPartition{Left: left, Right: right})
+}
+
+// reachable returns whether the provided source replica can currently send a
+// message to the provided destination replica. Ops and fakes are never
+// partitioned. REQUIRES: e.mu is held.
+func (e *executor) reachable(src string, srcReplica int, dst string, dstReplica int) bool {
+	if e.partition == nil {
+		return true
+	}
+	srcSides, ok := e.partition[src]
+	if !ok {
+		return true
+	}
+	dstSides, ok := e.partition[dst]
+	if !ok {
+		return true
+	}
+	return srcSides[srcReplica] == dstSides[dstReplica]
 }
 
 // runOp runs the provided operation.
@@ -614,15 +932,29 @@ func (e *executor) runOp(ctx context.Context, o *op) (err error) {
 
 	// Generate random op inputs. Lock s.mu because s.rand is not safe for
 	// concurrent use by multiple goroutines.
+	r := e.rand
+	if e.params.SimplifyRate > 0 {
+		r = e.argRand
+	}
+	var output any
+	checked := e.registrar.model != nil
+	var values []any
+	if checked {
+		values = make([]any, len(o.generators))
+	}
 	args[0] = e.workload
-	args[1] = reflect.ValueOf(withIDs(ctx, traceID, spanID))
+	args[1] = reflect.ValueOf(withExecutor(withOutput(withIDs(ctx, traceID, spanID), &output), e))
 	for i, generator := range o.generators {
-		x := generator(e.rand)
+		x := generator(r)
 		args[i+2] = x
 		formatted[i] = fmt.Sprint(x.Interface())
+		if checked {
+			values[i] = x.Interface()
+		}
 	}
 
 	// Record an OpStart event.
+	start := len(e.history)
 	e.history = append(e.history, EventOpStart{
 		TraceID: traceID,
 		SpanID:  spanID,
@@ -646,11 +978,23 @@ func (e *executor) runOp(ctx context.Context, o *op) (err error) {
 	if err != nil {
 		msg = err.Error()
 	}
+	observed := ""
+	if output != nil {
+		observed = fmt.Sprint(output)
+	}
 	e.mu.Lock()
+	if checked && err == nil {
+		e.operations = append(e.operations, timedOperation{
+			op:     Operation{Name: o.m.Name, Args: values, Output: output},
+			start:  start,
+			finish: len(e.history),
+		})
+	}
 	e.history = append(e.history, EventOpFinish{
 		TraceID: traceID,
 		SpanID:  spanID,
 		Error:   msg,
+		Output:  observed,
 	})
 	e.notFinished.remove(traceID)
 	e.mu.Unlock()
@@ -667,10 +1011,10 @@ func (e *executor) runOp(ctx context.Context, o *op) (err error) {
 	return nil
 }
 
-// deliverCall delivers the provided pending method call.
-func (e *executor) deliverCall(call *call) (err error) {
-	var component string
-	var index int
+// deliverCall delivers the provided pending method call to the provided
+// replica.
+func (e *executor) deliverCall(call *call, index int) (err error) {
+	component := call.reg.Name
 	defer func() {
 		if x := recover(); x != nil {
 			err = fmt.Errorf("panic: %v", x)
@@ -687,23 +1031,15 @@ func (e *executor) deliverCall(call *call) (err error) {
 		}
 	}()
 
-	reg, ok := e.regsByIntf[call.component]
-	if !ok {
-		return fmt.Errorf("component %v not found", call.component)
-	}
-
-	// Pick a replica to execute the call.
-	component = reg.Name
-	replicas := e.components[component]
-	e.mu.Lock()
-	index = e.rand.Intn(len(replicas))
-	replica := replicas[index]
-
 	// Record a DeliverCall event.
+	e.mu.Lock()
+	replica := e.components[component][index]
+	call.replica = index
+	e.running[call.spanID] = call
 	e.history = append(e.history, EventDeliverCall{
 		TraceID:   call.traceID,
 		SpanID:    call.spanID,
-		Component: reg.Name,
+		Component: component,
 		Replica:   index,
 	})
 	e.mu.Unlock()
@@ -722,6 +1058,12 @@ func (e *executor) deliverCall(call *call) (err error) {
 
 	// Record the reply and take a step.
 	e.mu.Lock()
+	delete(e.running, call.spanID)
+	if call.fate == failDuringExecution && !call.aborted {
+		// The call wasn't aborted during any of its method calls, so abort
+		// it right before it returns.
+		e.abort(call)
+	}
 	e.replies[call.traceID] = append(e.replies[call.traceID], &reply{
 		call:    call,
 		returns: returns,
@@ -730,7 +1072,7 @@ func (e *executor) deliverCall(call *call) (err error) {
 	e.history = append(e.history, EventReturn{
 		TraceID:   call.traceID,
 		SpanID:    call.spanID,
-		Component: reg.Name,
+		Component: component,
 		Replica:   index,
 		Returns:   strings,
 	})
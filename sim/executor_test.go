@@ -110,11 +110,14 @@ func TestExecuteGraveyardEntries(t *testing.T) {
 	}
 	for _, entry := range graveyard {
 		params := hyperparameters{
-			Seed:        entry.Seed,
-			NumReplicas: entry.NumReplicas,
-			NumOps:      entry.NumOps,
-			FailureRate: entry.FailureRate,
-			YieldRate:   entry.YieldRate,
+			Seed:          entry.Seed,
+			NumReplicas:   entry.NumReplicas,
+			NumOps:        entry.NumOps,
+			FailureRate:   entry.FailureRate,
+			YieldRate:     entry.YieldRate,
+			CrashRate:     entry.CrashRate,
+			PartitionRate: entry.PartitionRate,
+			DelayRate:     entry.DelayRate,
 		}
 		s := New(t, &failingWorkload{}, Options{})
 		result, err := s.newExecutor().execute(context.Background(), params)
@@ -380,6 +383,128 @@ func TestInjectedErrors(t *testing.T) {
 	}
 }
 
+// count returns the number of events of type T in the provided history.
+func count[T Event](history []Event) int {
+	n := 0
+	for _, event := range history {
+		if _, ok := event.(T); ok {
+			n++
+		}
+	}
+	return n
+}
+
+func TestInjectedFaults(t *testing.T) {
+	// Inject each kind of fault into an execution that ignores errors. The
+	// execution should pass and record the injected faults in its history.
+	for _, test := range []struct {
+		name   string
+		params hyperparameters
+		count  func([]Event) int
+	}{
+		{"FailDuringExecution", hyperparameters{FailureRate: 1}, count[EventAbort]},
+		{"Crash", hyperparameters{CrashRate: 0.1}, count[EventCrash]},
+		{"Partition", hyperparameters{PartitionRate: 0.1}, count[EventPartition]},
+		{"Heal", hyperparameters{PartitionRate: 0.1}, count[EventHeal]},
+		{"Unreachable", hyperparameters{PartitionRate: 0.1}, count[EventDeliverError]},
+		{"Delay", hyperparameters{DelayRate: 0.5}, count[EventDelay]},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			params := test.params
+			params.NumReplicas = 3
+			params.NumOps = 1000
+			params.YieldRate = 0.5
+			s := New(t, &passingWorkload{}, Options{})
+			result, err := s.newExecutor().execute(context.Background(), params)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if result.err != nil {
+				t.Fatal(result.err)
+			}
+			if test.count(result.history) == 0 {
+				t.Fatalf("no %s events in history", test.name)
+			}
+		})
+	}
+}
+
+func TestCrashAbortsRunningCalls(t *testing.T) {
+	// Crash a replica before every step. Calls are frequently aborted by
+	// crashes, and the aborted calls always fail.
+	params := hyperparameters{
+		NumReplicas: 1,
+		NumOps:      1000,
+		YieldRate:   0.5,
+		CrashRate:   1,
+	}
+	s := New(t, &passingWorkload{}, Options{})
+	result, err := s.newExecutor().execute(context.Background(), params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.err != nil {
+		t.Fatal(result.err)
+	}
+
+	aborted := map[int]bool{}
+	for _, event := range result.history {
+		switch x := event.(type) {
+		case EventAbort:
+			aborted[x.SpanID] = true
+		case EventDeliverReturn:
+			if aborted[x.SpanID] {
+				t.Fatalf("aborted call %d returned successfully", x.SpanID)
+			}
+		}
+	}
+	if len(aborted) == 0 {
+		t.Fatal("no calls aborted")
+	}
+}
+
+func TestFaultsAreDeterministic(t *testing.T) {
+	// Executions with the same hyperparameters have the same history.
+	params := hyperparameters{
+		Seed:          42,
+		NumReplicas:   3,
+		NumOps:        100,
+		FailureRate:   0.1,
+		YieldRate:     0.5,
+		CrashRate:     0.05,
+		PartitionRate: 0.05,
+		DelayRate:     0.1,
+	}
+	s := New(t, &passingWorkload{}, Options{})
+	var histories [2][]Event
+	for i := range histories {
+		result, err := s.newExecutor().execute(context.Background(), params)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if result.err != nil {
+			t.Fatal(result.err)
+		}
+		histories[i] = result.history
+	}
+	if !reflect.DeepEqual(histories[0], histories[1]) {
+		t.Fatal("executions with the same hyperparameters have different histories")
+	}
+}
+
+func TestInvalidFaultRates(t *testing.T) {
+	for _, params := range []hyperparameters{
+		{NumReplicas: 1, NumOps: 1, CrashRate: -0.1},
+		{NumReplicas: 1, NumOps: 1, PartitionRate: 1.1},
+		{NumReplicas: 1, NumOps: 1, DelayRate: 2},
+	} {
+		s := New(t, &passingWorkload{}, Options{})
+		if _, err := s.newExecutor().execute(context.Background(), params); err == nil {
+			t.Errorf("execute(%+v): unexpected success", params)
+		}
+	}
+}
+
 // See TestFakes.
 type fakeDivMod struct{}
 
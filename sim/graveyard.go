@@ -87,13 +87,20 @@ import (
 const version = 1
 
 // graveyardEntry is a set of failing inputs persisted for later execution.
+//
+// Entries written before crashes, partitions, delays, and shrinking were
+// introduced don't have the corresponding rates, which default to zero.
 type graveyardEntry struct {
-	Version     int     `json:"version"`
-	Seed        int64   `json:"seed"`
-	NumReplicas int     `json:"num_replicas"`
-	NumOps      int     `json:"num_ops"`
-	FailureRate float64 `json:"failure_rate"`
-	YieldRate   float64 `json:"yield_rate"`
+	Version       int     `json:"version"`
+	Seed          int64   `json:"seed"`
+	NumReplicas   int     `json:"num_replicas"`
+	NumOps        int     `json:"num_ops"`
+	FailureRate   float64 `json:"failure_rate"`
+	YieldRate     float64 `json:"yield_rate"`
+	CrashRate     float64 `json:"crash_rate"`
+	PartitionRate float64 `json:"partition_rate"`
+	DelayRate     float64 `json:"delay_rate"`
+	SimplifyRate  float64 `json:"simplify_rate"`
 }
 
 // readGraveyard reads all the graveyard entries stored in the provided directory.
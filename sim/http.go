@@ -0,0 +1,364 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sim
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"reflect"
+	"sync"
+
+	core "github.com/ServiceWeaver/weaver"
+	"github.com/ServiceWeaver/weaver/internal/reflection"
+)
+
+// A Server runs the HTTP server of a component. See [Serve].
+type Server struct {
+	impl  reflect.Type                     // component implementation struct
+	serve func(context.Context, any) error // serves the component
+}
+
+// Serve returns a Server that runs the provided function on every replica of
+// the component implemented by *T, typically to serve HTTP requests on the
+// component's weaver.Listeners. For example, a workload that simulates an
+// application whose main function is passed to weaver.Run can register the
+// main function:
+//
+//	func serve(ctx context.Context, app *app) error {
+//	    return http.Serve(app.lis, app)
+//	}
+//
+//	func (w *workload) Init(r sim.Registrar) error {
+//	    r.RegisterServer(sim.Serve(serve))
+//	    ...
+//	}
+//
+// Every component whose listeners receive requests needs a Server, even if it
+// starts serving in its Init method. Requests sent to the listeners of a
+// component without a Server fail.
+func Serve[T any](serve func(context.Context, *T) error) Server {
+	return Server{
+		impl:  reflection.Type[T](),
+		serve: func(ctx context.Context, impl any) error { return serve(ctx, impl.(*T)) },
+	}
+}
+
+// HTTPClient returns an HTTP client that sends requests to the weaver.Listeners
+// of a simulated application. The host of a request's URL is the name of a
+// listener (e.g., "http://frontend/index.html" is sent to the listener named
+// "frontend"), and the request is delivered to a random replica of the
+// component that owns the listener.
+//
+// Requests must be sent from a simulated op with the op's context (see
+// [http.NewRequestWithContext]). The component method calls performed by the
+// HTTP handler are interleaved and fault-injected like any other call, and
+// the request itself can fail with weaver.RemoteCallError.
+func HTTPClient() *http.Client {
+	return &http.Client{Transport: transport{}}
+}
+
+// transport is the http.RoundTripper used by HTTPClient.
+type transport struct{}
+
+// RoundTrip implements the http.RoundTripper interface.
+func (transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	e, ok := req.Context().Value(executorKey).(*executor)
+	if !ok {
+		return nil, fmt.Errorf("sim: HTTP request to %q not sent with the context of a simulated op", req.URL)
+	}
+	return e.roundTrip(req)
+}
+
+// We store the executor running an op in the op's context using the following
+// key. See traceContextKey for why it is not a pointer to a zero-sized
+// variable.
+var executorKey = &struct{ int }{}
+
+// withExecutor returns a context embedded with the provided executor.
+func withExecutor(ctx context.Context, e *executor) context.Context {
+	return context.WithValue(ctx, executorKey, e)
+}
+
+// An address is the address of a simulated listener or connection. The
+// address of a connection carries the trace and span id of the HTTP request
+// sent on it. net/http stores the address in the context of every request it
+// serves (see http.LocalAddrContextKey), which is how the trace context of
+// an op reaches the component method calls of an HTTP handler.
+type address struct {
+	listener string       // listener name
+	replica  int          // component replica
+	trace    traceContext // trace context, for connections
+}
+
+var _ net.Addr = address{}
+
+// Network implements the net.Addr interface.
+func (address) Network() string { return "sim" }
+
+// String implements the net.Addr interface.
+func (a address) String() string { return fmt.Sprintf("%s.%d", a.listener, a.replica) }
+
+// A listener is an in-memory net.Listener. Connections are delivered to a
+// listener directly, without going through the network.
+type listener struct {
+	addr  address       // listener address
+	conns chan net.Conn // delivered connections
+	once  sync.Once     // closes done
+	done  chan struct{} // closed when the listener is closed
+	err   error         // why the listener was closed, set before done is closed
+}
+
+var _ net.Listener = &listener{}
+
+// newListener returns a new listener with the provided name.
+func newListener(name string, replica int) *listener {
+	return &listener{
+		addr:  address{listener: name, replica: replica},
+		conns: make(chan net.Conn),
+		done:  make(chan struct{}),
+	}
+}
+
+// Accept implements the net.Listener interface.
+func (l *listener) Accept() (net.Conn, error) {
+	select {
+	case conn := <-l.conns:
+		return conn, nil
+	case <-l.done:
+		return nil, net.ErrClosed
+	}
+}
+
+// Close implements the net.Listener interface.
+func (l *listener) Close() error {
+	l.close(net.ErrClosed)
+	return nil
+}
+
+// close closes the listener with the provided error. Only the first call to
+// close has an effect.
+func (l *listener) close(err error) {
+	l.once.Do(func() {
+		l.err = err
+		close(l.done)
+	})
+}
+
+// Addr implements the net.Listener interface.
+func (l *listener) Addr() net.Addr {
+	return l.addr
+}
+
+// deliver delivers a connection to the listener, blocking until the
+// connection is accepted. If the listener is closed first, deliver returns the
+// reason it was closed.
+func (l *listener) deliver(ctx context.Context, conn net.Conn) error {
+	select {
+	case l.conns <- conn:
+		return nil
+	case <-l.done:
+		return l.err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// conn is the server end of a simulated connection.
+type conn struct {
+	net.Conn
+	addr address
+}
+
+// LocalAddr implements the net.Conn interface.
+func (c conn) LocalAddr() net.Addr {
+	return c.addr
+}
+
+// roundTrip delivers an HTTP request to a random replica of the component
+// that owns the listener named by the request's host.
+func (e *executor) roundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	name := req.URL.Hostname()
+
+	traceID, parentSpanID := extractIDs(ctx)
+	if traceID == 0 {
+		panic(fmt.Errorf("missing simulation trace context. Make sure that every HTTP request is sent with the context the caller was invoked with."))
+	}
+
+	// Record the call.
+	e.mu.Lock()
+	reg, ok := e.listenerRegs[name]
+	if !ok {
+		e.mu.Unlock()
+		return nil, fmt.Errorf("sim: listener %q not found", name)
+	}
+	if len(e.listeners[name]) == 0 {
+		e.mu.Unlock()
+		return nil, fmt.Errorf("sim: listener %q of component %q has no replicas", name, reg.Name)
+	}
+	if _, ok := e.registrar.servers[reg.Impl]; !ok {
+		e.mu.Unlock()
+		return nil, fmt.Errorf("sim: listener %q of component %q is not served; register a Server with Registrar.RegisterServer", name, reg.Name)
+	}
+	spanID := e.nextSpanID
+	e.nextSpanID++
+
+	caller, callerReplica := "op", traceID
+	parent := e.running[parentSpanID]
+	if parent != nil {
+		caller, callerReplica = parent.reg.Name, parent.replica
+	}
+	e.history = append(e.history, EventCall{
+		TraceID:   traceID,
+		SpanID:    spanID,
+		Caller:    caller,
+		Replica:   callerReplica,
+		Component: reg.Name,
+		Method:    req.Method,
+		Args:      []string{req.URL.RequestURI()},
+	})
+
+	// The requests of an aborted method call fail immediately.
+	if parent != nil && parent.fate == failDuringExecution && !parent.aborted && flip(e.rand, 0.5) {
+		e.abort(parent)
+	}
+	if parent != nil && parent.aborted {
+		e.history = append(e.history, EventDeliverError{TraceID: traceID, SpanID: spanID})
+		e.mu.Unlock()
+		return nil, core.RemoteCallError
+	}
+
+	// Determine the fate of the request, and deliver it.
+	fate := dontFail
+	if flip(e.rand, e.params.FailureRate) {
+		fate = pick(e.rand, failures)
+	}
+	c := &call{
+		traceID:       traceID,
+		spanID:        spanID,
+		fate:          fate,
+		caller:        caller,
+		callerReplica: callerReplica,
+		reg:           reg,
+		method:        req.Method,
+	}
+	index := e.rand.Intn(len(e.listeners[name]))
+	if fate == failBeforeDelivery || !e.reachable(caller, callerReplica, reg.Name, index) {
+		e.history = append(e.history, EventDeliverError{TraceID: traceID, SpanID: spanID})
+		e.mu.Unlock()
+		return nil, core.RemoteCallError
+	}
+	lis := e.listeners[name][index]
+	c.replica = index
+	e.running[spanID] = c
+	e.history = append(e.history, EventDeliverCall{
+		TraceID:   traceID,
+		SpanID:    spanID,
+		Component: reg.Name,
+		Replica:   index,
+	})
+	e.mu.Unlock()
+
+	// Send the request and read the entire response. Reading the entire
+	// response ensures that the handler has finished before the op continues,
+	// which keeps the execution deterministic.
+	client, server := net.Pipe()
+	addr := address{listener: name, replica: index, trace: traceContext{traceID, spanID}}
+	var resp *http.Response
+	err := lis.deliver(e.ctx, conn{server, addr})
+	switch {
+	case e.ctx.Err() != nil:
+		// The simulation was cancelled. Abort.
+		return nil, e.ctx.Err()
+	case err == nil:
+		resp, err = exchange(e.ctx, client, req)
+		if e.ctx.Err() != nil {
+			return nil, e.ctx.Err()
+		}
+	case errors.Is(err, net.ErrClosed):
+		// The replica crashed before accepting the connection. The request
+		// fails below.
+		client.Close()
+		server.Close()
+	default:
+		// The replica's server returned without being stopped.
+		client.Close()
+		server.Close()
+		return nil, err
+	}
+
+	// Record the reply.
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	delete(e.running, spanID)
+	if c.fate == failDuringExecution && !c.aborted {
+		e.abort(c)
+	}
+	returns := []string{fmt.Sprint(err)}
+	if err == nil {
+		returns = []string{resp.Status}
+	}
+	e.history = append(e.history, EventReturn{
+		TraceID:   traceID,
+		SpanID:    spanID,
+		Component: reg.Name,
+		Replica:   index,
+		Returns:   returns,
+	})
+	if err != nil || c.fate == failAfterDelivery || c.aborted || !e.reachable(reg.Name, index, caller, callerReplica) {
+		e.history = append(e.history, EventDeliverError{TraceID: traceID, SpanID: spanID})
+		return nil, core.RemoteCallError
+	}
+	e.history = append(e.history, EventDeliverReturn{TraceID: traceID, SpanID: spanID})
+	return resp, nil
+}
+
+// exchange writes an HTTP request to the provided connection and reads the
+// response. The connection is closed when exchange returns.
+func exchange(ctx context.Context, client net.Conn, req *http.Request) (*http.Response, error) {
+	defer client.Close()
+	stop := context.AfterFunc(ctx, func() { client.Close() })
+	defer stop()
+
+	// Ask the server to close the connection after replying, so that every
+	// request gets a fresh connection with its own trace context.
+	req = req.Clone(req.Context())
+	req.Close = true
+
+	// Write the request concurrently with reading the response, as a handler
+	// may reply before reading the entire request body.
+	errs := make(chan error, 1)
+	go func() { errs <- req.Write(client) }()
+
+	resp, err := http.ReadResponse(bufio.NewReader(client), req)
+	if err != nil {
+		return nil, err
+	}
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	client.Close()
+	<-errs
+	return resp, nil
+}
@@ -0,0 +1,260 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sim
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ServiceWeaver/weaver"
+)
+
+// serveFrontend serves HTTP requests on a frontend replica.
+func serveFrontend(ctx context.Context, f *frontendImpl) error {
+	return http.Serve(f.lis, f)
+}
+
+// See TestHTTP*. Failed requests are ignored.
+type httpWorkload struct{}
+
+func (h *httpWorkload) Init(r Registrar) error {
+	r.RegisterGenerators("DivMod", Range(0, 100), Range(1, 100))
+	r.RegisterServer(Serve(serveFrontend))
+	return nil
+}
+
+func (h *httpWorkload) DivMod(ctx context.Context, n, d int) error {
+	return divmod(ctx, n, d, false)
+}
+
+// strictHTTPWorkload is like httpWorkload, but every request must succeed.
+//
+// Note that the simulator constructs a new workload for every execution, so
+// strictness is a property of the workload type rather than a field.
+type strictHTTPWorkload struct {
+	httpWorkload
+}
+
+func (h *strictHTTPWorkload) DivMod(ctx context.Context, n, d int) error {
+	return divmod(ctx, n, d, true)
+}
+
+// divmod requests n/d and n%d from the frontend. If strict is false, failed
+// requests are ignored.
+func divmod(ctx context.Context, n, d int, strict bool) error {
+	url := fmt.Sprintf("http://simfrontend/divmod?n=%d&d=%d", n, d)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := HTTPClient().Do(req)
+	if err != nil {
+		if !strict && errors.Is(err, weaver.RemoteCallError) {
+			return nil
+		}
+		return err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		if !strict {
+			return nil
+		}
+		return fmt.Errorf("%s: %s", resp.Status, body)
+	}
+	if got, want := string(body), fmt.Sprintf("%d %d", n/d, n%d); got != want {
+		return fmt.Errorf("divmod(%d, %d): got %q, want %q", n, d, got, want)
+	}
+	return nil
+}
+
+func TestHTTPPassingExecution(t *testing.T) {
+	// Send HTTP requests with failures injected, ignoring any errors. The
+	// execution should pass.
+	params := hyperparameters{
+		NumReplicas:   3,
+		NumOps:        200,
+		FailureRate:   0.1,
+		YieldRate:     0.5,
+		CrashRate:     0.05,
+		PartitionRate: 0.05,
+		DelayRate:     0.05,
+	}
+	s := New(t, &httpWorkload{}, Options{})
+	result, err := s.newExecutor().execute(context.Background(), params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.err != nil {
+		t.Fatal(result.err)
+	}
+}
+
+func TestHTTPFailureRateZero(t *testing.T) {
+	// Without injected failures, every HTTP request should succeed.
+	params := hyperparameters{
+		NumReplicas: 3,
+		NumOps:      200,
+		YieldRate:   0.5,
+	}
+	s := New(t, &strictHTTPWorkload{}, Options{})
+	result, err := s.newExecutor().execute(context.Background(), params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.err != nil {
+		t.Fatal(result.err)
+	}
+}
+
+func TestHTTPHandlerCallsAreSimulated(t *testing.T) {
+	// The component method calls of an HTTP handler should be recorded in the
+	// trace of the op that sent the request.
+	params := hyperparameters{NumReplicas: 2, NumOps: 10, YieldRate: 0.5}
+	s := New(t, &strictHTTPWorkload{}, Options{})
+	result, err := s.newExecutor().execute(context.Background(), params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.err != nil {
+		t.Fatal(result.err)
+	}
+
+	requests := map[int]int{} // span id -> trace id
+	handled := map[int]bool{} // trace ids with a handler call
+	for _, event := range result.history {
+		call, ok := event.(EventCall)
+		if !ok {
+			continue
+		}
+		switch {
+		case call.Caller == "op" && call.Method == http.MethodGet:
+			requests[call.SpanID] = call.TraceID
+		case strings.HasSuffix(call.Caller, "/frontend") && call.Method == "DivMod":
+			handled[call.TraceID] = true
+		}
+	}
+	if len(requests) != params.NumOps {
+		t.Fatalf("got %d HTTP requests, want %d", len(requests), params.NumOps)
+	}
+	for span, trace := range requests {
+		if !handled[trace] {
+			t.Errorf("HTTP request [%d:%d] has no DivMod call", trace, span)
+		}
+	}
+}
+
+func TestHTTPDeterministic(t *testing.T) {
+	// Executions with the same seed should have the same history.
+	params := hyperparameters{
+		Seed:          42,
+		NumReplicas:   3,
+		NumOps:        100,
+		FailureRate:   0.1,
+		YieldRate:     0.5,
+		CrashRate:     0.05,
+		PartitionRate: 0.05,
+		DelayRate:     0.05,
+	}
+	s := New(t, &httpWorkload{}, Options{})
+	exec := s.newExecutor()
+	want, err := exec.execute(context.Background(), params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 10; i++ {
+		got, err := exec.execute(context.Background(), params)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(got.history, want.history) {
+			t.Fatalf("execution %d: history differs from first execution", i)
+		}
+	}
+}
+
+// See TestHTTPUnknownListener.
+type unknownListenerWorkload struct{}
+
+func (*unknownListenerWorkload) Init(r Registrar) error {
+	r.RegisterGenerators("Get")
+	return nil
+}
+
+func (*unknownListenerWorkload) Get(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "http://unknown/", nil)
+	if err != nil {
+		return err
+	}
+	resp, err := HTTPClient().Do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
+
+func TestHTTPUnknownListener(t *testing.T) {
+	params := hyperparameters{NumReplicas: 1, NumOps: 1}
+	s := New(t, &unknownListenerWorkload{}, Options{})
+	result, err := s.newExecutor().execute(context.Background(), params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	const want = `listener "unknown" not found`
+	if result.err == nil || !strings.Contains(result.err.Error(), want) {
+		t.Fatalf("error: got %v, want %q", result.err, want)
+	}
+}
+
+// See TestHTTPUnservedListener.
+type unservedListenerWorkload struct{}
+
+func (*unservedListenerWorkload) Init(r Registrar) error {
+	// The frontend's listener is never served.
+	r.RegisterGenerators("DivMod", Range(0, 100), Range(1, 100))
+	return nil
+}
+
+func (*unservedListenerWorkload) DivMod(ctx context.Context, n, d int) error {
+	return divmod(ctx, n, d, true)
+}
+
+func TestHTTPUnservedListener(t *testing.T) {
+	// A request to a listener that nobody serves should fail the execution
+	// rather than block until the simulation times out.
+	params := hyperparameters{NumReplicas: 1, NumOps: 1}
+	s := New(t, &unservedListenerWorkload{}, Options{})
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	result, err := s.newExecutor().execute(ctx, params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	const want = `listener "simfrontend" of component "github.com/ServiceWeaver/weaver/sim/frontend" is not served`
+	if result.err == nil || !strings.Contains(result.err.Error(), want) {
+		t.Fatalf("error: got %v, want %q", result.err, want)
+	}
+}
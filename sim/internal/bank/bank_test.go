@@ -17,6 +17,7 @@ package bank_test
 import (
 	"context"
 	"fmt"
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -104,3 +105,152 @@ func TestBank(t *testing.T) {
 	}
 	t.Log(r.Mermaid())
 }
+
+// bankModel is a sequential specification of a bank with $100 in alice and
+// bob's accounts initially. Its state is a map[string]int of balances.
+type bankModel struct{}
+
+// Init implements the sim.Model interface.
+func (bankModel) Init() any {
+	return map[string]int{"alice": 100, "bob": 100}
+}
+
+// Step implements the sim.Model interface.
+func (bankModel) Step(state any, op sim.Operation) []any {
+	balances := state.(map[string]int)
+	user, amount := op.Args[0].(string), op.Args[1].(int)
+	if op.Name == "Withdraw" {
+		amount = -amount
+	}
+	if balances[user]+amount < 0 {
+		// The operation fails without taking effect.
+		if op.Output != nil {
+			return nil
+		}
+		return []any{state}
+	}
+	next := map[string]int{}
+	for k, v := range balances {
+		next[k] = v
+	}
+	next[user] += amount
+	if op.Output == nil {
+		// The operation failed, so it may or may not have taken effect.
+		return []any{state, next}
+	}
+	if op.Output != next[user] {
+		return nil
+	}
+	return []any{next}
+}
+
+// registerUncheckedOps registers generators for the Deposit and Withdraw ops
+// of a workload, along with a fake store with $100 in alice and bob's
+// accounts initially. The ops never return errors, so that a failing
+// execution can only be caused by a registered check.
+func registerUncheckedOps(r sim.Registrar) {
+	user := sim.OneOf("alice", "bob")
+	amount := sim.Range(0, 100)
+	r.RegisterGenerators("Deposit", user, amount)
+	r.RegisterGenerators("Withdraw", user, amount)
+
+	store := &fakestore{values: map[string]int{"alice": 100, "bob": 100}}
+	r.RegisterFake(sim.Fake[bank.Store](store))
+}
+
+// CheckedBankWorkload is a workload that performs random deposits and
+// withdrawals. Rather than checking balances itself, it registers a model of
+// the bank.
+type CheckedBankWorkload struct {
+	bank weaver.Ref[bank.Bank]
+}
+
+// Init implements the sim.Workload interface.
+func (c *CheckedBankWorkload) Init(r sim.Registrar) error {
+	registerUncheckedOps(r)
+
+	// Check that the history of deposits and withdrawals is linearizable.
+	r.RegisterModel(bankModel{})
+	return nil
+}
+
+// Deposit is an operation that deposits the provided amount in the provided
+// user's bank account balance.
+func (c *CheckedBankWorkload) Deposit(ctx context.Context, user string, amount int) error {
+	if balance, err := c.bank.Get().Deposit(ctx, user, amount); err == nil {
+		sim.Observe(ctx, balance)
+	}
+	return nil
+}
+
+// Withdraw is an operation that withdraws the provided amount from the
+// provided user's bank account balance.
+func (c *CheckedBankWorkload) Withdraw(ctx context.Context, user string, amount int) error {
+	if balance, err := c.bank.Get().Withdraw(ctx, user, amount); err == nil {
+		sim.Observe(ctx, balance)
+	}
+	return nil
+}
+
+func TestCheckedBank(t *testing.T) {
+	s := sim.New(t, &CheckedBankWorkload{}, sim.Options{})
+	r := s.Run(60 * time.Second)
+	if r.Err == nil {
+		t.Fatal("Unexpected success")
+	}
+	if !strings.Contains(r.Err.Error(), "not linearizable") {
+		t.Fatalf("got error %v, want a linearizability error", r.Err)
+	}
+	t.Log(r.Err)
+}
+
+// InvariantBankWorkload is a workload that performs random deposits and
+// withdrawals. Rather than checking balances itself, it registers an
+// invariant over the store's state.
+type InvariantBankWorkload struct {
+	bank weaver.Ref[bank.Bank]
+}
+
+// Init implements the sim.Workload interface.
+func (c *InvariantBankWorkload) Init(r sim.Registrar) error {
+	registerUncheckedOps(r)
+
+	// Check that the store never ends up with a negative balance.
+	r.RegisterInvariant(func(s sim.Snapshot) error {
+		for _, store := range sim.Replicas[bank.Store](s) {
+			for user, balance := range store.(*fakestore).values {
+				if balance < 0 {
+					return fmt.Errorf("user %s has negative balance %d", user, balance)
+				}
+			}
+		}
+		return nil
+	})
+	return nil
+}
+
+// Deposit is an operation that deposits the provided amount in the provided
+// user's bank account balance.
+func (c *InvariantBankWorkload) Deposit(ctx context.Context, user string, amount int) error {
+	c.bank.Get().Deposit(ctx, user, amount)
+	return nil
+}
+
+// Withdraw is an operation that withdraws the provided amount from the
+// provided user's bank account balance.
+func (c *InvariantBankWorkload) Withdraw(ctx context.Context, user string, amount int) error {
+	c.bank.Get().Withdraw(ctx, user, amount)
+	return nil
+}
+
+func TestBankInvariant(t *testing.T) {
+	s := sim.New(t, &InvariantBankWorkload{}, sim.Options{})
+	r := s.Run(60 * time.Second)
+	if r.Err == nil {
+		t.Fatal("Unexpected success")
+	}
+	if !strings.Contains(r.Err.Error(), "invariant violated") {
+		t.Fatalf("got error %v, want an invariant violation", r.Err)
+	}
+	t.Log(r.Err)
+}
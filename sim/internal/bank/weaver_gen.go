@@ -30,7 +30,7 @@ func init() {
 		ReflectStubFn: func(caller func(string, context.Context, []any, []any) error) any {
 			return bank_reflect_stub{caller: caller}
 		},
-		RefData: "⟦dab0c530:wEaVeReDgE:github.com/ServiceWeaver/weaver/sim/internal/bank/Bank→github.com/ServiceWeaver/weaver/sim/internal/bank/Store⟧\n",
+		RefData: "⟦dab0c530:wEaVeReDgE:github.com/ServiceWeaver/weaver/sim/internal/bank/Bank→github.com/ServiceWeaver/weaver/sim/internal/bank/Store⟧\n⟦879731b7:wEaVeRfInGeRpRiNt:github.com/ServiceWeaver/weaver/sim/internal/bank/Bank→Deposit:7c3801a184170488⟧\n⟦ffa3c615:wEaVeRfInGeRpRiNt:github.com/ServiceWeaver/weaver/sim/internal/bank/Bank→Withdraw:7c3801a184170488⟧\n",
 	})
 	codegen.Register(codegen.Registration{
 		Name:  "github.com/ServiceWeaver/weaver/sim/internal/bank/Store",
@@ -48,7 +48,7 @@ func init() {
 		ReflectStubFn: func(caller func(string, context.Context, []any, []any) error) any {
 			return store_reflect_stub{caller: caller}
 		},
-		RefData: "",
+		RefData: "⟦c71eeb80:wEaVeRfInGeRpRiNt:github.com/ServiceWeaver/weaver/sim/internal/bank/Store→Add:7c3801a184170488⟧\n⟦2c4b11f1:wEaVeRfInGeRpRiNt:github.com/ServiceWeaver/weaver/sim/internal/bank/Store→Get:01cc51fba820dd37⟧\n",
 	})
 }
 
@@ -415,10 +415,10 @@ func (s store_client_stub) Get(ctx context.Context, a0 string) (r0 int, err erro
 // Note that "weaver generate" will always generate the error message below.
 // Everything is okay. The error message is only relevant if you see it when
 // you run "go build" or "go run".
-var _ codegen.LatestVersion = codegen.Version[[0][24]struct{}](`
+var _ codegen.LatestVersion = codegen.Version[[0][25]struct{}](`
 
 ERROR: You generated this file with 'weaver generate' (devel) (codegen
-version v0.24.0). The generated code is incompatible with the version of the
+version v0.25.0). The generated code is incompatible with the version of the
 github.com/ServiceWeaver/weaver module that you're using. The weaver module
 version can be found in your go.mod file or by running the following command.
 
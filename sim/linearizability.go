@@ -0,0 +1,332 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sim
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// A Model is a sequential specification of a workload. When a workload
+// registers a Model, the simulator checks that the history of every execution
+// is linearizable with respect to the model. That is, every op appears to
+// take effect atomically at some point between its start and its finish, in an
+// order that the model allows.
+//
+// For example, the following model specifies a register that supports Read
+// and Write ops. The Read op records the value it read with [Observe].
+//
+//	type registerModel struct{}
+//
+//	func (registerModel) Init() any {
+//		return 0
+//	}
+//
+//	func (registerModel) Step(state any, op sim.Operation) []any {
+//		switch op.Name {
+//		case "Write":
+//			return []any{op.Args[0]}
+//		case "Read":
+//			if op.Output != state {
+//				return nil
+//			}
+//			return []any{state}
+//		}
+//		return nil
+//	}
+type Model interface {
+	// Init returns the initial state of the model.
+	Init() any
+
+	// Step returns the states the model can be in after performing the
+	// provided op in the provided state. If the op is not legal in the
+	// provided state (e.g., its output is wrong), Step returns no states. An
+	// op that may or may not have taken effect (e.g., an op that failed with
+	// weaver.RemoteCallError) can return the states with and without the op.
+	//
+	// States are compared with reflect.DeepEqual.
+	Step(state any, op Operation) []any
+}
+
+// An Operation is an op executed during a simulation, as checked against a
+// [Model].
+type Operation struct {
+	Name   string // op name
+	Args   []any  // op arguments, excluding the context
+	Output any    // output recorded with Observe, or nil
+}
+
+// String returns a human readable representation of an Operation.
+func (o Operation) String() string {
+	args := make([]string, len(o.Args))
+	for i, arg := range o.Args {
+		args[i] = fmt.Sprint(arg)
+	}
+	s := fmt.Sprintf("%s(%s)", o.Name, strings.Join(args, ", "))
+	if o.Output != nil {
+		s = fmt.Sprintf("%s -> %v", s, o.Output)
+	}
+	return s
+}
+
+// We store a pointer to the output of an op in the op's context using the
+// following key. See traceContextKey for why it is not a pointer to a
+// zero-sized variable.
+var outputKey = &struct{ int }{}
+
+// Observe records the output of the op executing with the provided context.
+// The output is checked against the workload's [Model], if it registered one.
+// If an op calls Observe more than once, the last output is recorded. Observe
+// is a noop when called outside of a simulated op.
+func Observe(ctx context.Context, output any) {
+	if p, ok := ctx.Value(outputKey).(*any); ok {
+		*p = output
+	}
+}
+
+// withOutput returns a context that records the output of an op in the
+// provided pointer.
+func withOutput(ctx context.Context, output *any) context.Context {
+	return context.WithValue(ctx, outputKey, output)
+}
+
+// A Snapshot is the state of an application at the end of an execution. It
+// is passed to the invariants registered with [Registrar.RegisterInvariant].
+type Snapshot struct {
+	components map[reflect.Type][]any // component replicas, by interface
+}
+
+// Replicas returns the replicas of the component with interface T in the
+// provided snapshot. Every replica is the component implementation (or fake),
+// which can be type asserted to inspect its state.
+func Replicas[T any](s Snapshot) []T {
+	var t T
+	replicas := s.components[reflect.TypeOf(&t).Elem()]
+	ts := make([]T, len(replicas))
+	for i, replica := range replicas {
+		ts[i] = replica.(T)
+	}
+	return ts
+}
+
+// A timedOperation is an Operation along with when it started and finished.
+// Times are indices into an execution's history.
+type timedOperation struct {
+	op     Operation
+	start  int
+	finish int
+}
+
+// An entry is the start or finish of an op in the doubly linked list of
+// entries checked by the linearizability checker.
+type entry struct {
+	id         int    // index of the op
+	isStart    bool   // start or finish?
+	match      *entry // the finish of a start entry
+	prev, next *entry // neighbors in the list
+}
+
+// lift removes a start entry and its matching finish entry from the list.
+func (e *entry) lift() {
+	e.prev.next = e.next
+	e.next.prev = e.prev
+	m := e.match
+	m.prev.next = m.next
+	if m.next != nil {
+		m.next.prev = m.prev
+	}
+}
+
+// unlift reverts a lift.
+func (e *entry) unlift() {
+	m := e.match
+	m.prev.next = m
+	if m.next != nil {
+		m.next.prev = m
+	}
+	e.prev.next = e
+	e.next.prev = e
+}
+
+// bitset is a set of op indices.
+type bitset []uint64
+
+func newBitset(n int) bitset { return make(bitset, (n+63)/64) }
+func (b bitset) set(i int)   { b[i/64] |= 1 << (i % 64) }
+func (b bitset) clear(i int) { b[i/64] &^= 1 << (i % 64) }
+func (b bitset) key() string {
+	buf := make([]byte, 8*len(b))
+	for i, x := range b {
+		binary.LittleEndian.PutUint64(buf[8*i:], x)
+	}
+	return string(buf)
+}
+
+// checkLinearizable returns an error if the provided ops are not linearizable
+// with respect to the provided model. It implements the algorithm of Wing and
+// Gong [1], with the memoization of Lowe [2], as popularized by Porcupine [3].
+//
+// [1]: https://doi.org/10.1016/0743-7315(93)90003-A
+// [2]: https://doi.org/10.1002/cpe.3928
+// [3]: https://github.com/anishathalye/porcupine
+func checkLinearizable(model Model, ops []timedOperation) error {
+	if len(ops) == 0 {
+		return nil
+	}
+
+	// Build a doubly linked list of op starts and finishes, ordered by time.
+	entries := make([]*entry, 0, 2*len(ops))
+	for i := range ops {
+		start := &entry{id: i, isStart: true}
+		finish := &entry{id: i}
+		start.match = finish
+		entries = append(entries, start, finish)
+	}
+	time := func(e *entry) int {
+		if e.isStart {
+			return ops[e.id].start
+		}
+		return ops[e.id].finish
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		return time(entries[i]) < time(entries[j])
+	})
+	head := &entry{}
+	prev := head
+	for _, e := range entries {
+		prev.next = e
+		e.prev = prev
+		prev = e
+	}
+
+	// Search for a linearization. The model's states are tracked as a set to
+	// handle ops with multiple possible outcomes.
+	type frame struct {
+		e      *entry
+		states []any
+	}
+	var stack []frame
+	var longest []int // the longest linearizable sequence of ops found
+	states := []any{model.Init()}
+	linearized := newBitset(len(ops))
+	cache := map[string][][]any{}
+	e := head.next
+	for head.next != nil {
+		if !e.isStart {
+			// We reached the finish of an op that we couldn't linearize.
+			// Backtrack.
+			if len(stack) == 0 {
+				return notLinearizable(ops, longest)
+			}
+			f := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			states = f.states
+			linearized.clear(f.e.id)
+			f.e.unlift()
+			e = f.e.next
+			continue
+		}
+
+		next := step(model, states, ops[e.id].op)
+		if len(next) > 0 {
+			linearized.set(e.id)
+			key := linearized.key()
+			if !cached(cache[key], next) {
+				cache[key] = append(cache[key], next)
+				stack = append(stack, frame{e, states})
+				if len(stack) > len(longest) {
+					longest = longest[:0]
+					for _, f := range stack {
+						longest = append(longest, f.e.id)
+					}
+				}
+				states = next
+				e.lift()
+				e = head.next
+				continue
+			}
+			linearized.clear(e.id)
+		}
+		e = e.next
+	}
+	return nil
+}
+
+// step returns the set of states reachable by performing the provided op in
+// any of the provided states.
+func step(model Model, states []any, op Operation) []any {
+	var next []any
+	for _, state := range states {
+		for _, s := range model.Step(state, op) {
+			if !contains(next, s) {
+				next = append(next, s)
+			}
+		}
+	}
+	return next
+}
+
+// cached returns whether the provided set of states is in the provided cache
+// entries.
+func cached(entries [][]any, states []any) bool {
+	for _, other := range entries {
+		if len(other) != len(states) {
+			continue
+		}
+		equal := true
+		for _, s := range states {
+			if !contains(other, s) {
+				equal = false
+				break
+			}
+		}
+		if equal {
+			return true
+		}
+	}
+	return false
+}
+
+// contains returns whether the provided state is in the provided states.
+func contains(states []any, state any) bool {
+	for _, s := range states {
+		if reflect.DeepEqual(s, state) {
+			return true
+		}
+	}
+	return false
+}
+
+// notLinearizable returns an error explaining that the provided ops are not
+// linearizable, given the longest linearizable sequence of ops found.
+func notLinearizable(ops []timedOperation, longest []int) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "history of %d ops is not linearizable", len(ops))
+	if len(longest) == 0 {
+		fmt.Fprintf(&b, "; no op can be linearized first")
+		return errors.New(b.String())
+	}
+	fmt.Fprintf(&b, "; the longest linearizable sequence of ops is:")
+	for _, id := range longest {
+		fmt.Fprintf(&b, "\n    %v", ops[id].op)
+	}
+	fmt.Fprintf(&b, "\nand no remaining op can be linearized after it")
+	return errors.New(b.String())
+}
@@ -0,0 +1,269 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sim
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/ServiceWeaver/weaver"
+)
+
+// registerModel is a model of a register with Read and Write ops. A Write
+// that may or may not have taken effect outputs "?".
+type registerModel struct{}
+
+func (registerModel) Init() any {
+	return 0
+}
+
+func (registerModel) Step(state any, op Operation) []any {
+	switch op.Name {
+	case "Write":
+		if op.Output == "?" {
+			return []any{state, op.Args[0]}
+		}
+		return []any{op.Args[0]}
+	case "Read":
+		if op.Output != state {
+			return nil
+		}
+		return []any{state}
+	}
+	return nil
+}
+
+func write(x, start, finish int) timedOperation {
+	return timedOperation{Operation{Name: "Write", Args: []any{x}}, start, finish}
+}
+
+func maybeWrite(x, start, finish int) timedOperation {
+	return timedOperation{Operation{Name: "Write", Args: []any{x}, Output: "?"}, start, finish}
+}
+
+func read(x, start, finish int) timedOperation {
+	return timedOperation{Operation{Name: "Read", Output: x}, start, finish}
+}
+
+func TestLinearizable(t *testing.T) {
+	for _, test := range []struct {
+		name string
+		ops  []timedOperation
+	}{
+		{"Empty", nil},
+		{"Sequential", []timedOperation{write(1, 0, 1), read(1, 2, 3), write(2, 4, 5), read(2, 6, 7)}},
+		{"ConcurrentReadOld", []timedOperation{write(1, 0, 3), read(0, 1, 2)}},
+		{"ConcurrentReadNew", []timedOperation{write(1, 0, 3), read(1, 1, 2)}},
+		{"ConcurrentWrites", []timedOperation{write(1, 0, 3), write(2, 1, 4), read(1, 5, 6)}},
+		{"MaybeWriteTookEffect", []timedOperation{maybeWrite(1, 0, 1), read(1, 2, 3)}},
+		{"MaybeWriteDidNot", []timedOperation{maybeWrite(1, 0, 1), read(0, 2, 3)}},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			if err := checkLinearizable(registerModel{}, test.ops); err != nil {
+				t.Fatal(err)
+			}
+		})
+	}
+}
+
+func TestNotLinearizable(t *testing.T) {
+	for _, test := range []struct {
+		name string
+		ops  []timedOperation
+	}{
+		{"StaleRead", []timedOperation{write(1, 0, 1), read(0, 2, 3)}},
+		{"ReadFromFuture", []timedOperation{read(1, 0, 1), write(1, 2, 3)}},
+		{"NonMonotonicReads", []timedOperation{write(1, 0, 5), read(1, 1, 2), read(0, 3, 4)}},
+		{"MaybeWriteUnknownValue", []timedOperation{maybeWrite(1, 0, 1), read(2, 2, 3)}},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			err := checkLinearizable(registerModel{}, test.ops)
+			if err == nil {
+				t.Fatal("unexpected success")
+			}
+			if !strings.Contains(err.Error(), "not linearizable") {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestObserveOutsideOp(t *testing.T) {
+	// Observe is a noop outside of an op.
+	Observe(context.Background(), 42)
+}
+
+// See TestLinearizabilityViolation and TestLinearizableExecution.
+type counterModel struct{}
+
+func (counterModel) Init() any {
+	return 0
+}
+
+func (counterModel) Step(state any, op Operation) []any {
+	next := state.(int) + 1
+	if op.Output != next {
+		return nil
+	}
+	return []any{next}
+}
+
+// inc increments *x, making a component method call between reading and
+// writing *x, and observes the incremented value.
+func inc(ctx context.Context, id identity, x *int) {
+	old := *x
+	id.Identity(ctx, old) // Yield to other ops, ignoring errors.
+	*x = old + 1
+	Observe(ctx, *x)
+}
+
+// racyCounterWorkload increments a counter non-atomically, racing with
+// concurrent increments.
+type racyCounterWorkload struct {
+	id weaver.Ref[identity]
+	x  int
+}
+
+func (r *racyCounterWorkload) Init(reg Registrar) error {
+	reg.RegisterGenerators("Inc")
+	reg.RegisterModel(counterModel{})
+	return nil
+}
+
+func (r *racyCounterWorkload) Inc(ctx context.Context) error {
+	inc(ctx, r.id.Get(), &r.x)
+	return nil
+}
+
+// atomicCounterWorkload increments a counter atomically, but observes the
+// incremented value after a component method call, so that increments
+// overlap.
+type atomicCounterWorkload struct {
+	id weaver.Ref[identity]
+	x  int
+}
+
+func (a *atomicCounterWorkload) Init(r Registrar) error {
+	r.RegisterGenerators("Inc")
+	r.RegisterModel(counterModel{})
+	return nil
+}
+
+func (a *atomicCounterWorkload) Inc(ctx context.Context) error {
+	a.x++
+	x := a.x
+	a.id.Get().Identity(ctx, x) // Yield to other ops, ignoring errors.
+	Observe(ctx, x)
+	return nil
+}
+
+func TestLinearizabilityViolation(t *testing.T) {
+	params := hyperparameters{NumReplicas: 1, NumOps: 100, YieldRate: 0.5}
+	s := New(t, &racyCounterWorkload{}, Options{})
+	result, err := s.newExecutor().execute(context.Background(), params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.err == nil {
+		t.Fatal("unexpected success")
+	}
+	if !strings.Contains(result.err.Error(), "not linearizable") {
+		t.Fatalf("unexpected error: %v", result.err)
+	}
+}
+
+func TestLinearizableExecution(t *testing.T) {
+	params := hyperparameters{NumReplicas: 1, NumOps: 100, YieldRate: 0.5}
+	s := New(t, &atomicCounterWorkload{}, Options{})
+	result, err := s.newExecutor().execute(context.Background(), params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.err != nil {
+		t.Fatal(result.err)
+	}
+}
+
+// numInvariantReplicas is the number of replicas used in TestInvariants.
+const numInvariantReplicas = 3
+
+// errViolated is returned by violatedInvariantWorkload's invariant.
+var errViolated = errors.New("violated")
+
+// See TestInvariants.
+type heldInvariantWorkload struct {
+	id weaver.Ref[identity]
+}
+
+func (h *heldInvariantWorkload) Init(r Registrar) error {
+	r.RegisterGenerators("Identity")
+	r.RegisterInvariant(func(s Snapshot) error {
+		replicas := Replicas[identity](s)
+		if len(replicas) != numInvariantReplicas {
+			return fmt.Errorf("got %d replicas, want %d", len(replicas), numInvariantReplicas)
+		}
+		for _, replica := range replicas {
+			if _, ok := replica.(*identityImpl); !ok {
+				return fmt.Errorf("replica %T is not an *identityImpl", replica)
+			}
+		}
+		return nil
+	})
+	return nil
+}
+
+func (h *heldInvariantWorkload) Identity(ctx context.Context) error {
+	h.id.Get().Identity(ctx, 42)
+	return nil
+}
+
+// See TestInvariants.
+type violatedInvariantWorkload struct{}
+
+func (v *violatedInvariantWorkload) Init(r Registrar) error {
+	r.RegisterGenerators("Foo")
+	r.RegisterInvariant(func(Snapshot) error { return errViolated })
+	return nil
+}
+
+func (v *violatedInvariantWorkload) Foo(context.Context) error {
+	return nil
+}
+
+func TestInvariants(t *testing.T) {
+	for _, test := range []struct {
+		name     string
+		workload Workload
+		want     error
+	}{
+		{"Held", &heldInvariantWorkload{}, nil},
+		{"Violated", &violatedInvariantWorkload{}, errViolated},
+	} {
+		t.Run(test.name, func(t *testing.T) {
+			params := hyperparameters{NumReplicas: numInvariantReplicas, NumOps: 10, YieldRate: 0.5}
+			s := New(t, test.workload, Options{})
+			result, err := s.newExecutor().execute(context.Background(), params)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !errors.Is(result.err, test.want) {
+				t.Fatalf("got error %v, want %v", result.err, test.want)
+			}
+		})
+	}
+}
@@ -150,6 +150,38 @@ func (w *wyrand) Uint64() uint64 {
 
 }
 
+// simplifier is a random number generator that returns zero with probability
+// rate and otherwise returns the values of an underlying wyrand. Generators
+// that draw from a simplifier are biased towards simple values, like 0, false,
+// "", and empty slices, which is used to simplify the arguments of a failing
+// execution.
+type simplifier struct {
+	src  *wyrand
+	rate float64
+}
+
+var _ rand.Source = &simplifier{}
+var _ rand.Source64 = &simplifier{}
+
+// Seed implements the rand.Source interface.
+func (s *simplifier) Seed(seed int64) {
+	s.src.Seed(seed)
+}
+
+// Int63 implements the rand.Source interface.
+func (s *simplifier) Int63() int64 {
+	return int64(s.Uint64() >> 1)
+}
+
+// Uint64 implements the rand.Source64 interface.
+func (s *simplifier) Uint64() uint64 {
+	x := s.src.Uint64()
+	if s.rate > 0 && float64(s.src.Uint64()>>11)/(1<<53) < s.rate {
+		return 0
+	}
+	return x
+}
+
 // See https://github.com/wangyi-fudan/wyhash for explanation.
 func wymix(x uint64, y uint64) uint64 {
 	hi, lo := bits.Mul64(x, y)
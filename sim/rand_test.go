@@ -128,3 +128,36 @@ func TestWyRandDeterministic(t *testing.T) {
 		}
 	}
 }
+
+func TestSimplifier(t *testing.T) {
+	for _, test := range []struct {
+		rate     float64
+		min, max int // bounds on the number of zeros in 1000 values
+	}{
+		{0, 0, 0},
+		{0.5, 400, 600},
+		{1, 1000, 1000},
+	} {
+		s := &simplifier{src: &wyrand{42}, rate: test.rate}
+		zeros := 0
+		for i := 0; i < 1000; i++ {
+			if s.Uint64() == 0 {
+				zeros++
+			}
+		}
+		if zeros < test.min || zeros > test.max {
+			t.Errorf("rate %v: got %d zeros, want [%d, %d]", test.rate, zeros, test.min, test.max)
+		}
+	}
+}
+
+func TestSimplifierWithZeroRate(t *testing.T) {
+	// A simplifier with rate zero returns the values of the underlying source.
+	a := &wyrand{42}
+	b := &simplifier{src: &wyrand{42}}
+	for i := 0; i < 100; i++ {
+		if x, y := a.Uint64(), b.Uint64(); x != y {
+			t.Fatalf("Uint64: %d != %d", x, y)
+		}
+	}
+}
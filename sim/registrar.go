@@ -45,8 +45,11 @@ type registrar struct {
 	typeInfo map[string][]generatorTypeInfo // generator type info
 
 	// Updated for every execution.
-	fakes map[reflect.Type]any // fakes, by component interface
-	ops   []*op                // operations
+	fakes      map[reflect.Type]any    // fakes, by component interface
+	ops        []*op                   // operations
+	model      Model                   // registered model, if any
+	invariants []func(Snapshot) error  // registered invariants
+	servers    map[reflect.Type]Server // registered servers, by component implementation
 }
 
 var _ Registrar = &registrar{}
@@ -76,6 +79,7 @@ func newRegistrar(t testing.TB, w reflect.Type, registered map[reflect.Type]stru
 		t:          t,
 		registered: registered,
 		fakes:      map[reflect.Type]any{},
+		servers:    map[reflect.Type]Server{},
 		typeInfo:   map[string][]generatorTypeInfo{},
 		ops:        ops,
 		opsByName:  opsByName,
@@ -90,6 +94,9 @@ func (r *registrar) reset() {
 	for _, op := range r.ops {
 		op.generators = op.generators[:0]
 	}
+	r.model = nil
+	r.invariants = r.invariants[:0]
+	clear(r.servers)
 }
 
 // RegisterFake implements the Registrar interface.
@@ -108,6 +115,55 @@ func (r *registrar) RegisterGenerators(method string, generators ...any) {
 	}
 }
 
+// RegisterModel implements the Registrar interface.
+func (r *registrar) RegisterModel(model Model) {
+	r.t.Helper()
+	if err := r.registerModel(model); err != nil {
+		r.t.Fatalf("RegisterModel: %v", err)
+	}
+}
+
+// RegisterInvariant implements the Registrar interface.
+func (r *registrar) RegisterInvariant(check func(Snapshot) error) {
+	r.t.Helper()
+	if check == nil {
+		r.t.Fatalf("RegisterInvariant: nil check")
+	}
+	r.invariants = append(r.invariants, check)
+}
+
+// RegisterServer implements the Registrar interface.
+func (r *registrar) RegisterServer(server Server) {
+	r.t.Helper()
+	if err := r.registerServer(server); err != nil {
+		r.t.Fatalf("RegisterServer: %v", err)
+	}
+}
+
+// registerServer implements RegisterServer.
+func (r *registrar) registerServer(server Server) error {
+	if server.serve == nil {
+		return fmt.Errorf("nil server")
+	}
+	if _, ok := r.servers[server.impl]; ok {
+		return fmt.Errorf("server for %v already registered", server.impl)
+	}
+	r.servers[server.impl] = server
+	return nil
+}
+
+// registerModel implements RegisterModel.
+func (r *registrar) registerModel(model Model) error {
+	if model == nil {
+		return fmt.Errorf("nil model")
+	}
+	if r.model != nil {
+		return fmt.Errorf("model already registered")
+	}
+	r.model = model
+	return nil
+}
+
 // registerFakes implements RegisterFakes.
 func (r *registrar) registerFakes(fake FakeComponent) error {
 	if _, ok := r.fakes[fake.intf]; ok {
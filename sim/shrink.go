@@ -0,0 +1,147 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sim
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+)
+
+// # Shrinking
+//
+// A failing execution found by the simulator often has hundreds of ops and
+// injected failures that have nothing to do with the bug. Before reporting a
+// failing execution, the simulator shrinks it, in the style of delta
+// debugging: it repeatedly re-executes the workload with simpler
+// hyperparameters (fewer ops, fewer replicas, lower failure rates, and
+// simpler op arguments) and keeps the simpler hyperparameters whenever the
+// execution still fails. Shrinking stops when no simpler hyperparameters fail
+// or when the shrinking budget runs out.
+//
+// Changing the hyperparameters of an execution changes the whole execution,
+// so every candidate is executed with a handful of seeds. Any failure counts,
+// even if it differs from the original one.
+//
+// Failing graveyard entries are not shrunk, as they were shrunk before being
+// written to the graveyard.
+
+const (
+	// shrinkSeeds is the number of seeds tried for every candidate.
+	shrinkSeeds = 10
+
+	// maxShrinkExecutions is the maximum number of executions performed
+	// while shrinking a failing execution.
+	maxShrinkExecutions = 2000
+
+	// shrinkTimeout is the maximum amount of time spent shrinking a failing
+	// execution.
+	shrinkTimeout = 10 * time.Second
+
+	// minShrinkRate is the smallest non-zero rate tried while shrinking.
+	minShrinkRate = 0.001
+)
+
+// shrink returns the simplest failing execution it can find that is simpler
+// than the provided failing execution.
+func (s *Simulator) shrink(failing result, stats *stats) result {
+	ctx, cancel := context.WithTimeout(context.Background(), shrinkTimeout)
+	defer cancel()
+
+	before := failing.params
+	exec := s.newExecutor()
+	executions := 0
+	for progress := true; progress; {
+		progress = false
+	candidates:
+		for _, p := range shrinkCandidates(failing.params) {
+			for i := int64(0); i < shrinkSeeds; i++ {
+				if executions == maxShrinkExecutions {
+					break candidates
+				}
+				p.Seed = failing.params.Seed + i
+				r, err := exec.execute(ctx, p)
+				if err != nil {
+					// Either shrinking timed out, or the execution failed to
+					// run properly. Either way, stop shrinking.
+					break candidates
+				}
+				executions++
+				atomic.AddInt64(&stats.numExecutions, 1)
+				atomic.AddInt64(&stats.numOps, int64(p.NumOps))
+				if r.err != nil {
+					failing = r
+					progress = true
+					break candidates
+				}
+			}
+		}
+	}
+
+	after := failing.params
+	s.t.Logf("Shrunk failing execution from %d ops and %d replicas to %d ops and %d replicas in %d executions.",
+		before.NumOps, before.NumReplicas, after.NumOps, after.NumReplicas, executions)
+	return failing
+}
+
+// shrinkCandidates returns hyperparameters that are strictly simpler than the
+// provided hyperparameters, most aggressive first. The seeds of the returned
+// hyperparameters are unspecified.
+func shrinkCandidates(p hyperparameters) []hyperparameters {
+	var candidates []hyperparameters
+	seen := map[hyperparameters]bool{}
+	add := func(f func(*hyperparameters)) {
+		q := p
+		f(&q)
+		if q != p && !seen[q] {
+			seen[q] = true
+			candidates = append(candidates, q)
+		}
+	}
+
+	// Fewer ops and replicas.
+	for _, n := range []int{1, p.NumOps / 2, p.NumOps - 1} {
+		if n >= 1 {
+			add(func(q *hyperparameters) { q.NumOps = min(n, p.NumOps) })
+		}
+	}
+	for _, n := range []int{1, p.NumReplicas - 1} {
+		if n >= 1 {
+			add(func(q *hyperparameters) { q.NumReplicas = min(n, p.NumReplicas) })
+		}
+	}
+
+	// Lower rates.
+	for _, rate := range []func(*hyperparameters) *float64{
+		func(q *hyperparameters) *float64 { return &q.FailureRate },
+		func(q *hyperparameters) *float64 { return &q.CrashRate },
+		func(q *hyperparameters) *float64 { return &q.PartitionRate },
+		func(q *hyperparameters) *float64 { return &q.DelayRate },
+		func(q *hyperparameters) *float64 { return &q.YieldRate },
+	} {
+		add(func(q *hyperparameters) { *rate(q) = 0 })
+		if half := *rate(&p) / 2; half >= minShrinkRate {
+			add(func(q *hyperparameters) { *rate(q) = half })
+		}
+	}
+
+	// Simpler op arguments.
+	for _, simplify := range []float64{0.9, 0.5} {
+		if simplify > p.SimplifyRate {
+			add(func(q *hyperparameters) { q.SimplifyRate = simplify })
+		}
+	}
+	return candidates
+}
@@ -0,0 +1,102 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sim
+
+import (
+	"context"
+	"testing"
+)
+
+// simpler returns whether p is strictly simpler than q.
+func simpler(p, q hyperparameters) bool {
+	notHarder := p.NumOps <= q.NumOps &&
+		p.NumReplicas <= q.NumReplicas &&
+		p.FailureRate <= q.FailureRate &&
+		p.YieldRate <= q.YieldRate &&
+		p.CrashRate <= q.CrashRate &&
+		p.PartitionRate <= q.PartitionRate &&
+		p.DelayRate <= q.DelayRate &&
+		p.SimplifyRate >= q.SimplifyRate
+	p.Seed = q.Seed
+	return notHarder && p != q
+}
+
+func TestShrinkCandidates(t *testing.T) {
+	p := hyperparameters{
+		NumReplicas:   3,
+		NumOps:        100,
+		FailureRate:   0.1,
+		YieldRate:     0.5,
+		CrashRate:     0.05,
+		PartitionRate: 0.05,
+		DelayRate:     0.01,
+	}
+	candidates := shrinkCandidates(p)
+	if len(candidates) == 0 {
+		t.Fatal("no candidates")
+	}
+	for _, c := range candidates {
+		if !simpler(c, p) {
+			t.Errorf("candidate %+v is not simpler than %+v", c, p)
+		}
+	}
+}
+
+func TestShrinkCandidatesOfSimplestHyperparameters(t *testing.T) {
+	p := hyperparameters{NumReplicas: 1, NumOps: 1, SimplifyRate: 0.9}
+	if candidates := shrinkCandidates(p); len(candidates) != 0 {
+		t.Fatalf("unexpected candidates: %v", candidates)
+	}
+}
+
+func TestShrink(t *testing.T) {
+	// Find a large failing execution.
+	s := New(t, &failingWorkload{}, Options{})
+	exec := s.newExecutor()
+	var failing result
+	for seed := int64(0); failing.err == nil; seed++ {
+		params := hyperparameters{
+			Seed:          seed,
+			NumReplicas:   3,
+			NumOps:        100,
+			FailureRate:   0.1,
+			YieldRate:     0.5,
+			CrashRate:     0.05,
+			PartitionRate: 0.05,
+			DelayRate:     0.05,
+		}
+		r, err := exec.execute(context.Background(), params)
+		if err != nil {
+			t.Fatal(err)
+		}
+		failing = r
+	}
+
+	// Shrink it. Every op of a failingWorkload fails with probability 1/2, so
+	// a single op on a single replica without any injected failures suffices.
+	shrunk := s.shrink(failing, &stats{})
+	if shrunk.err == nil {
+		t.Fatal("shrunk execution passed")
+	}
+	want := hyperparameters{NumReplicas: 1, NumOps: 1, SimplifyRate: 0.9}
+	got := shrunk.params
+	got.Seed = 0
+	if got != want {
+		t.Fatalf("shrunk hyperparameters: got %+v, want %+v", got, want)
+	}
+	if got, want := count[EventOpStart](shrunk.history), 1; got != want {
+		t.Fatalf("shrunk history: got %d ops, want %d", got, want)
+	}
+}
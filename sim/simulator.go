@@ -120,6 +120,66 @@
 // to a component using weaver.Ref. See serviceweaver.dev/blog/testing.html for
 // a complete example.
 //
+// # Models and Invariants
+//
+// Checking invariants inside ops only catches bugs that a single op can
+// observe. A workload's Init method can also register checks over the whole
+// execution. [Registrar.RegisterModel] registers a [Model], a sequential
+// specification of the workload, and the simulator checks that the history of
+// every execution is linearizable with respect to it. Ops record their
+// outputs for the model with [Observe]. [Registrar.RegisterInvariant]
+// registers a check that runs at the end of every execution and can inspect
+// the state of every component replica with [Replicas]. See the
+// sim/internal/bank package for an example.
+//
+// # HTTP
+//
+// The simulator fills a component's weaver.Listeners with in-memory
+// listeners. Ops send HTTP requests to them with the client returned by
+// [HTTPClient], using the listener's name as the host of the request URL:
+//
+//	func (w *workload) Get(ctx context.Context, path string) error {
+//		req, err := http.NewRequestWithContext(ctx, "GET", "http://frontend"+path, nil)
+//		if err != nil {
+//			return err
+//		}
+//		resp, err := sim.HTTPClient().Do(req)
+//		...
+//	}
+//
+// Every request is delivered to a random replica of the component that owns
+// the listener, and the component method calls performed by the handler are
+// simulated like any other call. Every component whose listeners receive
+// requests must register the function that serves them (e.g., the function
+// passed to weaver.Run) with [Registrar.RegisterServer].
+//
+// # Failures
+//
+// While executing a workload, the simulator deterministically injects the
+// kinds of failures that occur in a real deployment:
+//
+//   - Method calls fail before they are delivered, after they execute, or
+//     while they are still executing. A call that fails while executing keeps
+//     running, but its remaining method calls fail.
+//   - Component replicas crash and restart, losing all of their in-memory
+//     state. The method calls running on a crashed replica fail.
+//   - Method calls and returns are delayed, letting other operations run
+//     first.
+//   - The network partitions the component replicas into two sides that
+//     can't communicate until the partition heals.
+//
+// Every injected failure is recorded in the history of a failing execution
+// (e.g., [EventAbort], [EventCrash], [EventDelay], [EventPartition]).
+//
+// # Shrinking
+//
+// When the simulator finds a failing execution, it shrinks it before
+// reporting it. It re-executes the workload with fewer ops, fewer replicas,
+// fewer injected failures, and simpler op arguments until it finds the
+// simplest execution that still fails. The history of this minimized
+// execution is returned in [Results] and logged as a [mermaid] diagram (see
+// [Results.Mermaid]).
+//
 // # Graveyard
 //
 // When the simulator runs a failed execution, it persists the failing inputs
@@ -153,6 +213,7 @@
 //
 // [1]: https://asatarin.github.io/testing-distributed-systems/#deterministic-simulation
 // [2]: https://go.dev/security/fuzz
+// [mermaid]: https://mermaid.js.org/
 package sim
 
 import (
@@ -233,6 +294,22 @@ type Registrar interface {
 	// TODO(mwhittaker): Allow people to register a func(*rand.Rand) T instead
 	// of a Generator[T] for convenience.
 	RegisterGenerators(method string, generators ...any)
+
+	// RegisterModel registers a sequential specification of the workload.
+	// After every execution, the simulator checks that the history of ops is
+	// linearizable with respect to the model. See [Model] for details.
+	RegisterModel(Model)
+
+	// RegisterInvariant registers a check that runs after every execution
+	// that finishes without error. The check receives a snapshot of the
+	// application's component replicas (see [Replicas]). If the check returns
+	// a non-nil error, the execution fails.
+	RegisterInvariant(check func(Snapshot) error)
+
+	// RegisterServer registers a function that serves the weaver.Listeners of
+	// every replica of a component, like the main function passed to
+	// weaver.Run. See [Serve] and [HTTPClient] for details.
+	RegisterServer(Server)
 }
 
 // A Workload defines the set of operations to run as part of a simulation.
@@ -449,16 +526,21 @@ func (s *Simulator) Run(duration time.Duration) Results {
 		s.t.Log(results.summary())
 
 		entry := graveyardEntry{
-			Version:     version,
-			Seed:        result.params.Seed,
-			NumReplicas: result.params.NumReplicas,
-			NumOps:      result.params.NumOps,
-			FailureRate: result.params.FailureRate,
-			YieldRate:   result.params.YieldRate,
+			Version:       version,
+			Seed:          result.params.Seed,
+			NumReplicas:   result.params.NumReplicas,
+			NumOps:        result.params.NumOps,
+			FailureRate:   result.params.FailureRate,
+			YieldRate:     result.params.YieldRate,
+			CrashRate:     result.params.CrashRate,
+			PartitionRate: result.params.PartitionRate,
+			DelayRate:     result.params.DelayRate,
+			SimplifyRate:  result.params.SimplifyRate,
 		}
 		if filename, err := writeGraveyardEntry(s.graveyardDir(), entry); err == nil {
 			s.t.Logf("Failing input written to %s.", filename)
 		}
+		s.t.Logf("Failing execution history (see https://mermaid.live):\n%s", results.Mermaid())
 		return results
 
 	default:
@@ -541,19 +623,26 @@ func (s *Simulator) run(ctx context.Context, stats *stats) (result, error) {
 			for _, numReplicas := range []int{1, 2, 3} {
 				for _, failureRate := range []float64{0.0, 0.01, 0.05, 0.1} {
 					for _, yieldRate := range []float64{0.0, 0.1, 0.2, 0.3, 0.4, 0.5, 0.6, 0.7, 0.8, 0.9, 1.0} {
-						for i := 0; i < 1000; i++ {
-							seed++
-							p := hyperparameters{
-								Seed:        seed,
-								NumOps:      numOps,
-								NumReplicas: numReplicas,
-								FailureRate: failureRate,
-								YieldRate:   yieldRate,
-							}
-							select {
-							case <-ctx.Done():
-								return
-							case params <- p:
+						// faultRate is the rate of replica crashes, network
+						// partitions, and message delays.
+						for _, faultRate := range []float64{0.0, 0.01, 0.05} {
+							for i := 0; i < 1000; i++ {
+								seed++
+								p := hyperparameters{
+									Seed:          seed,
+									NumOps:        numOps,
+									NumReplicas:   numReplicas,
+									FailureRate:   failureRate,
+									YieldRate:     yieldRate,
+									CrashRate:     faultRate,
+									PartitionRate: faultRate,
+									DelayRate:     faultRate,
+								}
+								select {
+								case <-ctx.Done():
+									return
+								case params <- p:
+								}
 							}
 						}
 					}
@@ -574,7 +663,7 @@ func (s *Simulator) run(ctx context.Context, stats *stats) (result, error) {
 	case r := <-failing:
 		cancel()
 		done.Wait()
-		return r, nil
+		return s.shrink(r, stats), nil
 	}
 }
 
@@ -613,11 +702,15 @@ func (s *Simulator) executeGraveyard(ctx context.Context, stats *stats) (result,
 	exec := s.newExecutor()
 	for _, entry := range graveyard {
 		p := hyperparameters{
-			Seed:        entry.Seed,
-			NumReplicas: entry.NumReplicas,
-			NumOps:      entry.NumOps,
-			FailureRate: entry.FailureRate,
-			YieldRate:   entry.YieldRate,
+			Seed:          entry.Seed,
+			NumReplicas:   entry.NumReplicas,
+			NumOps:        entry.NumOps,
+			FailureRate:   entry.FailureRate,
+			YieldRate:     entry.YieldRate,
+			CrashRate:     entry.CrashRate,
+			PartitionRate: entry.PartitionRate,
+			DelayRate:     entry.DelayRate,
+			SimplifyRate:  entry.SimplifyRate,
 		}
 		r, err := exec.execute(ctx, p)
 		if err != nil {
@@ -700,6 +793,8 @@ func (r *Results) Mermaid() string {
 			replicas[replica{call.Component, x.Replica}] = struct{}{}
 		case EventReturn:
 			returns[x.SpanID] = x
+		case EventCrash:
+			replicas[replica{x.Component, x.Replica}] = struct{}{}
 		}
 	}
 
@@ -724,13 +819,29 @@ func (r *Results) Mermaid() string {
 		fmt.Fprintf(&b, "    participant %s%d as %s %d\n", replica.component, replica.replica, shorten(replica.component), replica.replica)
 	}
 
+	// Notes about the whole system, like partitions, span every participant.
+	var everyone []string
+	for _, op := range ops {
+		everyone = append(everyone, fmt.Sprintf("op%d", op.TraceID))
+	}
+	for _, replica := range sorted {
+		everyone = append(everyone, fmt.Sprintf("%s%d", replica.component, replica.replica))
+	}
+	if len(everyone) > 1 {
+		everyone = []string{everyone[0], everyone[len(everyone)-1]}
+	}
+
 	// Create events.
 	for _, event := range r.History {
 		switch x := event.(type) {
 		case EventOpStart:
 			fmt.Fprintf(&b, "    note right of op%d: [%d:%d] %s(%s)\n", x.TraceID, x.TraceID, x.SpanID, x.Name, commas(x.Args))
 		case EventOpFinish:
-			fmt.Fprintf(&b, "    note right of op%d: [%d:%d] return %s\n", x.TraceID, x.TraceID, x.SpanID, x.Error)
+			if x.Output != "" {
+				fmt.Fprintf(&b, "    note right of op%d: [%d:%d] observe %s, return %s\n", x.TraceID, x.TraceID, x.SpanID, x.Output, x.Error)
+			} else {
+				fmt.Fprintf(&b, "    note right of op%d: [%d:%d] return %s\n", x.TraceID, x.TraceID, x.SpanID, x.Error)
+			}
 		case EventDeliverCall:
 			call := calls[x.SpanID]
 			fmt.Fprintf(&b, "    %s%d->>%s%d: [%d:%d] %s.%s(%s)\n", call.Caller, call.Replica, call.Component, x.Replica, x.TraceID, x.SpanID, shorten(call.Component), call.Method, commas(call.Args))
@@ -744,6 +855,21 @@ func (r *Results) Mermaid() string {
 		case EventPanic:
 			stack := strings.ReplaceAll(x.Stack, "\n", "<br>")
 			fmt.Fprintf(&b, "    note right of %s%d: [%d:%d] %s<br>%s\n", x.Panicker, x.Replica, x.TraceID, x.SpanID, x.Error, stack)
+		case EventAbort:
+			fmt.Fprintf(&b, "    note right of %s%d: [%d:%d] abort\n", x.Component, x.Replica, x.TraceID, x.SpanID)
+		case EventCrash:
+			fmt.Fprintf(&b, "    note right of %s%d: crash and restart\n", x.Component, x.Replica)
+		case EventDelay:
+			call := calls[x.SpanID]
+			fmt.Fprintf(&b, "    note right of %s%d: [%d:%d] delay\n", call.Caller, call.Replica, x.TraceID, x.SpanID)
+		case EventPartition:
+			if len(everyone) > 0 {
+				fmt.Fprintf(&b, "    note over %s: partition (%s) | (%s)\n", strings.Join(everyone, ","), commas(x.Left), commas(x.Right))
+			}
+		case EventHeal:
+			if len(everyone) > 0 {
+				fmt.Fprintf(&b, "    note over %s: heal partition\n", strings.Join(everyone, ","))
+			}
 		}
 	}
 	return b.String()
@@ -31,7 +31,7 @@ func init() {
 		ReflectStubFn: func(caller func(string, context.Context, []any, []any) error) any {
 			return blocker_reflect_stub{caller: caller}
 		},
-		RefData: "",
+		RefData: "⟦a0d5cbf6:wEaVeRfInGeRpRiNt:github.com/ServiceWeaver/weaver/sim/blocker→Block:ef273203fe44267b⟧\n",
 	})
 	codegen.Register(codegen.Registration{
 		Name:  "github.com/ServiceWeaver/weaver/sim/div",
@@ -49,7 +49,7 @@ func init() {
 		ReflectStubFn: func(caller func(string, context.Context, []any, []any) error) any {
 			return div_reflect_stub{caller: caller}
 		},
-		RefData: "⟦6ddebe91:wEaVeReDgE:github.com/ServiceWeaver/weaver/sim/div→github.com/ServiceWeaver/weaver/sim/identity⟧\n",
+		RefData: "⟦6ddebe91:wEaVeReDgE:github.com/ServiceWeaver/weaver/sim/div→github.com/ServiceWeaver/weaver/sim/identity⟧\n⟦7113cf59:wEaVeRfInGeRpRiNt:github.com/ServiceWeaver/weaver/sim/div→Div:da291c07184c703e⟧\n",
 	})
 	codegen.Register(codegen.Registration{
 		Name:  "github.com/ServiceWeaver/weaver/sim/divMod",
@@ -67,7 +67,24 @@ func init() {
 		ReflectStubFn: func(caller func(string, context.Context, []any, []any) error) any {
 			return divMod_reflect_stub{caller: caller}
 		},
-		RefData: "⟦df3a80a0:wEaVeReDgE:github.com/ServiceWeaver/weaver/sim/divMod→github.com/ServiceWeaver/weaver/sim/div⟧\n⟦b28314dd:wEaVeReDgE:github.com/ServiceWeaver/weaver/sim/divMod→github.com/ServiceWeaver/weaver/sim/mod⟧\n",
+		RefData: "⟦df3a80a0:wEaVeReDgE:github.com/ServiceWeaver/weaver/sim/divMod→github.com/ServiceWeaver/weaver/sim/div⟧\n⟦b28314dd:wEaVeReDgE:github.com/ServiceWeaver/weaver/sim/divMod→github.com/ServiceWeaver/weaver/sim/mod⟧\n⟦f487a1e7:wEaVeRfInGeRpRiNt:github.com/ServiceWeaver/weaver/sim/divMod→DivMod:9487165c9d244a41⟧\n",
+	})
+	codegen.Register(codegen.Registration{
+		Name:      "github.com/ServiceWeaver/weaver/sim/frontend",
+		Iface:     reflect.TypeOf((*frontend)(nil)).Elem(),
+		Impl:      reflect.TypeOf(frontendImpl{}),
+		Listeners: []string{"simfrontend"},
+		LocalStubFn: func(impl any, caller string, tracer trace.Tracer) any {
+			return frontend_local_stub{impl: impl.(frontend), tracer: tracer}
+		},
+		ClientStubFn: func(stub codegen.Stub, caller string) any { return frontend_client_stub{stub: stub} },
+		ServerStubFn: func(impl any, addLoad func(uint64, float64)) codegen.Server {
+			return frontend_server_stub{impl: impl.(frontend), addLoad: addLoad}
+		},
+		ReflectStubFn: func(caller func(string, context.Context, []any, []any) error) any {
+			return frontend_reflect_stub{caller: caller}
+		},
+		RefData: "⟦4e4a643d:wEaVeReDgE:github.com/ServiceWeaver/weaver/sim/frontend→github.com/ServiceWeaver/weaver/sim/divMod⟧\n⟦c9ea7837:wEaVeRlIsTeNeRs:github.com/ServiceWeaver/weaver/sim/frontend→simfrontend⟧\n",
 	})
 	codegen.Register(codegen.Registration{
 		Name:  "github.com/ServiceWeaver/weaver/sim/identity",
@@ -85,7 +102,7 @@ func init() {
 		ReflectStubFn: func(caller func(string, context.Context, []any, []any) error) any {
 			return identity_reflect_stub{caller: caller}
 		},
-		RefData: "",
+		RefData: "⟦86cd7e28:wEaVeRfInGeRpRiNt:github.com/ServiceWeaver/weaver/sim/identity→Identity:c9de9364ec133bcd⟧\n",
 	})
 	codegen.Register(codegen.Registration{
 		Name:  "github.com/ServiceWeaver/weaver/sim/mod",
@@ -103,7 +120,7 @@ func init() {
 		ReflectStubFn: func(caller func(string, context.Context, []any, []any) error) any {
 			return mod_reflect_stub{caller: caller}
 		},
-		RefData: "⟦5bf2dcf2:wEaVeReDgE:github.com/ServiceWeaver/weaver/sim/mod→github.com/ServiceWeaver/weaver/sim/identity⟧\n",
+		RefData: "⟦5bf2dcf2:wEaVeReDgE:github.com/ServiceWeaver/weaver/sim/mod→github.com/ServiceWeaver/weaver/sim/identity⟧\n⟦a1128957:wEaVeRfInGeRpRiNt:github.com/ServiceWeaver/weaver/sim/mod→Mod:da291c07184c703e⟧\n",
 	})
 	codegen.Register(codegen.Registration{
 		Name:  "github.com/ServiceWeaver/weaver/sim/panicker",
@@ -121,7 +138,7 @@ func init() {
 		ReflectStubFn: func(caller func(string, context.Context, []any, []any) error) any {
 			return panicker_reflect_stub{caller: caller}
 		},
-		RefData: "",
+		RefData: "⟦1466773c:wEaVeRfInGeRpRiNt:github.com/ServiceWeaver/weaver/sim/panicker→Panic:66ef3b37fce334df⟧\n",
 	})
 }
 
@@ -129,6 +146,7 @@ func init() {
 var _ weaver.InstanceOf[blocker] = (*blockerImpl)(nil)
 var _ weaver.InstanceOf[div] = (*divImpl)(nil)
 var _ weaver.InstanceOf[divMod] = (*divModImpl)(nil)
+var _ weaver.InstanceOf[frontend] = (*frontendImpl)(nil)
 var _ weaver.InstanceOf[identity] = (*identityImpl)(nil)
 var _ weaver.InstanceOf[mod] = (*modImpl)(nil)
 var _ weaver.InstanceOf[panicker] = (*panickerImpl)(nil)
@@ -137,6 +155,7 @@ var _ weaver.InstanceOf[panicker] = (*panickerImpl)(nil)
 var _ weaver.Unrouted = (*blockerImpl)(nil)
 var _ weaver.Unrouted = (*divImpl)(nil)
 var _ weaver.Unrouted = (*divModImpl)(nil)
+var _ weaver.Unrouted = (*frontendImpl)(nil)
 var _ weaver.Unrouted = (*identityImpl)(nil)
 var _ weaver.Unrouted = (*modImpl)(nil)
 var _ weaver.Unrouted = (*panickerImpl)(nil)
@@ -230,6 +249,14 @@ func (s divMod_local_stub) DivMod(ctx context.Context, a0 int, a1 int) (r0 int,
 	return s.impl.DivMod(ctx, a0, a1)
 }
 
+type frontend_local_stub struct {
+	impl   frontend
+	tracer trace.Tracer
+}
+
+// Check that frontend_local_stub implements the frontend interface.
+var _ frontend = (*frontend_local_stub)(nil)
+
 type identity_local_stub struct {
 	impl            identity
 	tracer          trace.Tracer
@@ -506,6 +533,13 @@ func (s divMod_client_stub) DivMod(ctx context.Context, a0 int, a1 int) (r0 int,
 	return
 }
 
+type frontend_client_stub struct {
+	stub codegen.Stub
+}
+
+// Check that frontend_client_stub implements the frontend interface.
+var _ frontend = (*frontend_client_stub)(nil)
+
 type identity_client_stub struct {
 	stub            codegen.Stub
 	identityMetrics *codegen.MethodMetrics
@@ -702,10 +736,10 @@ func (s panicker_client_stub) Panic(ctx context.Context, a0 bool) (err error) {
 // Note that "weaver generate" will always generate the error message below.
 // Everything is okay. The error message is only relevant if you see it when
 // you run "go build" or "go run".
-var _ codegen.LatestVersion = codegen.Version[[0][24]struct{}](`
+var _ codegen.LatestVersion = codegen.Version[[0][25]struct{}](`
 
 ERROR: You generated this file with 'weaver generate' (devel) (codegen
-version v0.24.0). The generated code is incompatible with the version of the
+version v0.25.0). The generated code is incompatible with the version of the
 github.com/ServiceWeaver/weaver module that you're using. The weaver module
 version can be found in your go.mod file or by running the following command.
 
@@ -852,6 +886,22 @@ func (s divMod_server_stub) divMod(ctx context.Context, args []byte) (res []byte
 	return enc.Data(), nil
 }
 
+type frontend_server_stub struct {
+	impl    frontend
+	addLoad func(key uint64, load float64)
+}
+
+// Check that frontend_server_stub implements the codegen.Server interface.
+var _ codegen.Server = (*frontend_server_stub)(nil)
+
+// GetStubFn implements the codegen.Server interface.
+func (s frontend_server_stub) GetStubFn(method string) func(ctx context.Context, args []byte) ([]byte, error) {
+	switch method {
+	default:
+		return nil
+	}
+}
+
 type identity_server_stub struct {
 	impl    identity
 	addLoad func(key uint64, load float64)
@@ -1020,6 +1070,13 @@ func (s divMod_reflect_stub) DivMod(ctx context.Context, a0 int, a1 int) (r0 int
 	return
 }
 
+type frontend_reflect_stub struct {
+	caller func(string, context.Context, []any, []any) error
+}
+
+// Check that frontend_reflect_stub implements the frontend interface.
+var _ frontend = (*frontend_reflect_stub)(nil)
+
 type identity_reflect_stub struct {
 	caller func(string, context.Context, []any, []any) error
 }
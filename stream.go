@@ -0,0 +1,240 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package weaver
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// Stream[T] is the receiving end of a stream of values of type T. Component
+// methods can take a Stream as an argument and return a Stream as a result.
+// For example:
+//
+//	type Indexer interface {
+//	    // Index streams documents to the component and returns the number of
+//	    // documents indexed.
+//	    Index(context.Context, weaver.Stream[Doc]) (int, error)
+//
+//	    // Search streams back the results matching a query.
+//	    Search(context.Context, string) (weaver.Stream[Result], error)
+//
+//	    // Translate translates a stream of sentences into a stream of
+//	    // translated sentences.
+//	    Translate(context.Context, weaver.Stream[string]) (weaver.Stream[string], error)
+//	}
+//
+// A method can have at most one Stream argument and at most one Stream result,
+// and T must be serializable. Streams are created using NewStream.
+//
+// Streams are flow controlled: a sender blocks when the receiver falls too far
+// behind. A receiver that is not interested in the remaining values of a
+// stream should Close it, which causes subsequent sends to fail. Note that a
+// streaming method call is not finished until the streams it returns have
+// been fully received or closed, and calls to streaming methods are never
+// retried.
+type Stream[T any] interface {
+	// Recv returns the next value in the stream. It returns io.EOF after the
+	// last value has been received, or the error passed to the sender's
+	// CloseWithError.
+	Recv(ctx context.Context) (T, error)
+
+	// Close stops receiving values from the stream.
+	Close() error
+}
+
+// StreamWriter[T] is the sending end of a Stream[T], returned by NewStream.
+type StreamWriter[T any] interface {
+	// Send sends a value on the stream. Send blocks until the receiver has
+	// room for the value, and returns an error if the receiver has closed
+	// the stream.
+	Send(ctx context.Context, value T) error
+
+	// Close ends the stream. The receiver receives io.EOF after receiving all
+	// of the sent values.
+	Close() error
+
+	// CloseWithError ends the stream with the provided error, which is
+	// returned to the receiver after it receives all of the sent values. If
+	// err is nil, CloseWithError is equivalent to Close. Concurrent calls to
+	// Send that are blocked waiting for room fail without sending their
+	// values.
+	CloseWithError(err error) error
+}
+
+// streamBuffer is the number of values a stream created by NewStream buffers
+// before Send blocks.
+const streamBuffer = 16
+
+// NewStream returns the two ends of a new stream. Values sent on the returned
+// StreamWriter are received from the returned Stream. Typically, one end of
+// the stream is passed to (or returned from) a component method, while the
+// other is used by a goroutine of the caller (or callee). For example:
+//
+//	func (s *search) Search(ctx context.Context, query string) (weaver.Stream[Result], error) {
+//	    results, w := weaver.NewStream[Result]()
+//	    go func() {
+//	        for _, r := range s.lookup(query) {
+//	            if err := w.Send(ctx, r); err != nil {
+//	                return
+//	            }
+//	        }
+//	        w.Close()
+//	    }()
+//	    return results, nil
+//	}
+func NewStream[T any]() (Stream[T], StreamWriter[T]) {
+	p := &pipe[T]{
+		values: make(chan T, streamBuffer),
+		closed: make(chan struct{}),
+		ending: make(chan struct{}),
+		ended:  make(chan struct{}),
+	}
+	p.sendsDone = sync.NewCond(&p.mu)
+	return pipeReader[T]{p}, pipeWriter[T]{p}
+}
+
+// pipe is an in-memory stream.
+type pipe[T any] struct {
+	values chan T        // buffered values
+	closed chan struct{} // closed when the receiver closes the stream
+	ending chan struct{} // closed when the sender starts closing the stream
+	ended  chan struct{} // closed when the sender closes the stream
+
+	mu        sync.Mutex
+	sendsDone *sync.Cond // signaled when sendCount drops to zero
+	isClosed  bool       // has closed been closed?
+	isEnded   bool       // has ended been closed?
+	err       error      // error passed to CloseWithError
+	sendCount int        // number of in-progress sends
+}
+
+type pipeReader[T any] struct{ p *pipe[T] }
+type pipeWriter[T any] struct{ p *pipe[T] }
+
+// Recv implements the Stream interface.
+func (r pipeReader[T]) Recv(ctx context.Context) (T, error) {
+	p := r.p
+	var zero T
+
+	// Prefer buffered values over the end of the stream.
+	select {
+	case v := <-p.values:
+		return v, nil
+	default:
+	}
+
+	select {
+	case v := <-p.values:
+		return v, nil
+	case <-p.ended:
+		// The sender doesn't send after ending the stream, so any values in
+		// the buffer are the last ones.
+		select {
+		case v := <-p.values:
+			return v, nil
+		default:
+		}
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		if p.err != nil {
+			return zero, p.err
+		}
+		return zero, io.EOF
+	case <-p.closed:
+		return zero, fmt.Errorf("receive on closed stream: %w", io.ErrClosedPipe)
+	case <-ctx.Done():
+		return zero, ctx.Err()
+	}
+}
+
+// Close implements the Stream interface.
+func (r pipeReader[T]) Close() error {
+	p := r.p
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if !p.isClosed {
+		p.isClosed = true
+		close(p.closed)
+	}
+	return nil
+}
+
+// Send implements the StreamWriter interface.
+func (w pipeWriter[T]) Send(ctx context.Context, value T) error {
+	p := w.p
+	p.mu.Lock()
+	if p.isEnded {
+		p.mu.Unlock()
+		return fmt.Errorf("send on closed stream: %w", io.ErrClosedPipe)
+	}
+	p.sendCount++
+	p.mu.Unlock()
+
+	defer func() {
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		p.sendCount--
+		if p.sendCount == 0 {
+			p.sendsDone.Broadcast()
+		}
+	}()
+
+	select {
+	case <-p.closed:
+		return fmt.Errorf("stream closed by receiver: %w", io.ErrClosedPipe)
+	default:
+	}
+	select {
+	case p.values <- value:
+		return nil
+	case <-p.closed:
+		return fmt.Errorf("stream closed by receiver: %w", io.ErrClosedPipe)
+	case <-p.ending:
+		return fmt.Errorf("send on closed stream: %w", io.ErrClosedPipe)
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Close implements the StreamWriter interface.
+func (w pipeWriter[T]) Close() error {
+	return w.CloseWithError(nil)
+}
+
+// CloseWithError implements the StreamWriter interface.
+func (w pipeWriter[T]) CloseWithError(err error) error {
+	p := w.p
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.isEnded {
+		return nil
+	}
+	p.isEnded = true
+	p.err = err
+
+	// Fail the in-progress sends that are blocked on a full buffer, and wait
+	// for all in-progress sends to finish, so that the receiver observes
+	// every sent value before it observes the end of the stream. Note that
+	// Wait releases p.mu while waiting.
+	close(p.ending)
+	for p.sendCount > 0 {
+		p.sendsDone.Wait()
+	}
+	close(p.ended)
+	return nil
+}
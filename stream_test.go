@@ -0,0 +1,89 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package weaver
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+)
+
+func TestStreamCloseWithBlockedSend(t *testing.T) {
+	// Fill the stream's buffer, so that the next Send blocks.
+	ctx := context.Background()
+	values, w := NewStream[int]()
+	for i := 0; i < streamBuffer; i++ {
+		if err := w.Send(ctx, i); err != nil {
+			t.Fatal(err)
+		}
+	}
+	blocked := make(chan error)
+	go func() { blocked <- w.Send(ctx, streamBuffer) }()
+	p := w.(pipeWriter[int]).p
+	for {
+		p.mu.Lock()
+		sending := p.sendCount > 0
+		p.mu.Unlock()
+		if sending {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	// Closing the stream should fail the blocked Send rather than wait for it.
+	closed := make(chan error)
+	go func() { closed <- w.CloseWithError(errors.New("done")) }()
+	for _, c := range []chan error{closed, blocked} {
+		select {
+		case <-c:
+		case <-time.After(10 * time.Second):
+			t.Fatal("CloseWithError deadlocked with a blocked Send")
+		}
+	}
+
+	// The receiver should receive the buffered values, followed by the error.
+	// The value of the failed Send may or may not have been sent.
+	for i := 0; ; i++ {
+		v, err := values.Recv(ctx)
+		if err != nil {
+			if err.Error() != "done" {
+				t.Fatalf("Recv: got %v, want %q", err, "done")
+			}
+			if i < streamBuffer {
+				t.Fatalf("received %d values, want at least %d", i, streamBuffer)
+			}
+			break
+		}
+		if v != i {
+			t.Fatalf("Recv: got %d, want %d", v, i)
+		}
+	}
+}
+
+func TestStreamSendAfterClose(t *testing.T) {
+	ctx := context.Background()
+	values, w := NewStream[int]()
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Send(ctx, 42); !errors.Is(err, io.ErrClosedPipe) {
+		t.Fatalf("Send: got %v, want %v", err, io.ErrClosedPipe)
+	}
+	if _, err := values.Recv(ctx); err != io.EOF {
+		t.Fatalf("Recv: got %v, want %v", err, io.EOF)
+	}
+}
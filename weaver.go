@@ -33,7 +33,9 @@ import (
 	"net/http"
 	"os"
 	"sync"
+	"time"
 
+	"github.com/ServiceWeaver/weaver/internal/net/call"
 	"github.com/ServiceWeaver/weaver/internal/reflection"
 	"github.com/ServiceWeaver/weaver/internal/weaver"
 	"github.com/ServiceWeaver/weaver/runtime"
@@ -71,6 +73,13 @@ import (
 // example.
 var RemoteCallError = errors.New("Service Weaver remote call error")
 
+// CircuitOpenError indicates that a remote component method call failed fast,
+// without being sent, because every replica of the component was recently
+// ejected for failing or being much slower than its peers. Replicas are
+// ejected for a short while, so the call may succeed if retried later. An
+// error that embeds CircuitOpenError also embeds RemoteCallError.
+var CircuitOpenError error = call.CircuitOpen
+
 // HealthzHandler is a health-check handler that returns an OK status for all
 // incoming HTTP requests.
 var HealthzHandler = func(w http.ResponseWriter, _ *http.Request) {
@@ -166,6 +175,12 @@ func runLocal[T any, _ PointerToMain[T]](ctx context.Context, app func(context.C
 	if err != nil {
 		return err
 	}
+	defer func() {
+		// Export the remaining trace spans.
+		if err := wlet.Shutdown(context.Background()); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+		}
+	}()
 
 	go func() {
 		if err := wlet.ServeStatus(ctx); err != nil {
@@ -582,3 +597,65 @@ func (AutoMarshal) WeaverMarshal(*codegen.Encoder)   {}
 func (AutoMarshal) WeaverUnmarshal(*codegen.Decoder) {}
 
 type NotRetriable interface{}
+
+// Idempotent marks a component method as idempotent: executing a call to the
+// method more than once has the same effect as executing it once. Only
+// idempotent methods can be hedged (see MethodOptions.Hedge). To mark a
+// method as idempotent, declare a package-level variable of type Idempotent
+// holding the method, in the package that implements the component:
+//
+//	var _ weaver.Idempotent = Cache.Get
+//
+// A method can't be both Idempotent and NotRetriable.
+type Idempotent interface{}
+
+// MethodOptions configures the calls to a component method. To configure a
+// method, declare a package-level variable holding a MethodOptions literal,
+// with Method set to the method, in the package that implements the
+// component. For example:
+//
+//	// Give up on Cache.Get after 100ms or three attempts.
+//	var _ = weaver.MethodOptions{
+//	    Method:   Cache.Get,
+//	    Timeout:  100 * time.Millisecond,
+//	    Attempts: 3,
+//	}
+//
+// The options are read by "weaver generate", so all fields other than Method
+// must be constant expressions. Timeout applies to every call; the other
+// options only affect remote calls, since local calls never fail due to
+// communication errors. MethodOptions cannot be used with methods that take
+// or return a Stream.
+type MethodOptions struct {
+	// Method is the configured method, written as a method expression of the
+	// component interface (e.g., Cache.Get).
+	Method any
+
+	// Timeout, if positive, bounds the duration of a call, including all of
+	// its attempts. A call's context deadline is kept if it is earlier.
+	Timeout time.Duration
+
+	// Attempts, if positive, is the maximum number of times a call that fails
+	// due to communication errors is attempted. By default, such a call is
+	// retried until its context is done. Attempts can't be larger than one
+	// for a NotRetriable method.
+	Attempts int
+
+	// Backoff, if positive, is the delay before the first retry of a call.
+	// Subsequent retries back off exponentially.
+	Backoff time.Duration
+
+	// Hedge, if positive, is how long to wait for a reply before sending the
+	// call a second time, to a different replica if there is one. The first
+	// reply to arrive is returned, and the other call is canceled. As hedging
+	// may execute a call twice, only Idempotent methods can be hedged.
+	Hedge time.Duration
+
+	// HedgePercentile, if positive, hedges calls that haven't received a
+	// reply after the given percentile (e.g., 95) of the latencies of recent
+	// calls to the method, as observed by the caller. Until enough calls have
+	// finished to estimate the percentile, Hedge is used instead. Like Hedge,
+	// HedgePercentile can only be set for an Idempotent method, and it must
+	// be smaller than 100.
+	HedgePercentile int
+}
@@ -30,7 +30,7 @@ func init() {
 		ReflectStubFn: func(caller func(string, context.Context, []any, []any) error) any {
 			return deployerControl_reflect_stub{caller: caller}
 		},
-		RefData: "",
+		RefData: "⟦0d92dc7b:wEaVeRfInGeRpRiNt:github.com/ServiceWeaver/weaver/deployerControl→ActivateComponent:064077fc9a1846af⟧\n⟦894a7631:wEaVeRfInGeRpRiNt:github.com/ServiceWeaver/weaver/deployerControl→ExportListener:740b22f267340a91⟧\n⟦18fe0007:wEaVeRfInGeRpRiNt:github.com/ServiceWeaver/weaver/deployerControl→GetListenerAddress:08d7d349e951f492⟧\n⟦4a7376b0:wEaVeRfInGeRpRiNt:github.com/ServiceWeaver/weaver/deployerControl→GetSelfCertificate:4526da76cd849db6⟧\n⟦06c75476:wEaVeRfInGeRpRiNt:github.com/ServiceWeaver/weaver/deployerControl→HandleTraceSpans:86b2d85618b94161⟧\n⟦471cebad:wEaVeRfInGeRpRiNt:github.com/ServiceWeaver/weaver/deployerControl→LogBatch:ef05ea31bb8d6f09⟧\n⟦f77a08d9:wEaVeRfInGeRpRiNt:github.com/ServiceWeaver/weaver/deployerControl→VerifyClientCertificate:65a41a331fced3ae⟧\n⟦8fd463ad:wEaVeRfInGeRpRiNt:github.com/ServiceWeaver/weaver/deployerControl→VerifyServerCertificate:bafe4fa6b136a72a⟧\n",
 	})
 	codegen.Register(codegen.Registration{
 		Name:  "github.com/ServiceWeaver/weaver/weaveletControl",
@@ -48,7 +48,7 @@ func init() {
 		ReflectStubFn: func(caller func(string, context.Context, []any, []any) error) any {
 			return weaveletControl_reflect_stub{caller: caller}
 		},
-		RefData: "",
+		RefData: "⟦7ee7ef85:wEaVeRfInGeRpRiNt:github.com/ServiceWeaver/weaver/weaveletControl→GetHealth:47999d56de8c7336⟧\n⟦33ea9a91:wEaVeRfInGeRpRiNt:github.com/ServiceWeaver/weaver/weaveletControl→GetLoad:38bb212298ae8ed7⟧\n⟦92b145f7:wEaVeRfInGeRpRiNt:github.com/ServiceWeaver/weaver/weaveletControl→GetMetrics:c6240a5697b13d00⟧\n⟦f2089e66:wEaVeRfInGeRpRiNt:github.com/ServiceWeaver/weaver/weaveletControl→GetProfile:ef85249ea5005c38⟧\n⟦24c3297d:wEaVeRfInGeRpRiNt:github.com/ServiceWeaver/weaver/weaveletControl→InitWeavelet:da6e7e0b8e172c71⟧\n⟦018423bd:wEaVeRfInGeRpRiNt:github.com/ServiceWeaver/weaver/weaveletControl→UpdateComponents:c6cf709cdccd4754⟧\n⟦67a2b707:wEaVeRfInGeRpRiNt:github.com/ServiceWeaver/weaver/weaveletControl→UpdateRoutingInfo:1ea07e31c4765030⟧\n",
 	})
 }
 
@@ -1190,10 +1190,10 @@ func (s weaveletControl_client_stub) UpdateRoutingInfo(ctx context.Context, a0 *
 // Note that "weaver generate" will always generate the error message below.
 // Everything is okay. The error message is only relevant if you see it when
 // you run "go build" or "go run".
-var _ codegen.LatestVersion = codegen.Version[[0][24]struct{}](`
+var _ codegen.LatestVersion = codegen.Version[[0][25]struct{}](`
 
 ERROR: You generated this file with 'weaver generate' (devel) (codegen
-version v0.24.0). The generated code is incompatible with the version of the
+version v0.25.0). The generated code is incompatible with the version of the
 github.com/ServiceWeaver/weaver module that you're using. The weaver module
 version can be found in your go.mod file or by running the following command.
 
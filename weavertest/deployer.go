@@ -20,6 +20,7 @@ import (
 	"fmt"
 	"log/slog"
 	"reflect"
+	"slices"
 	"sync"
 
 	"github.com/ServiceWeaver/weaver/internal/control"
@@ -77,6 +78,15 @@ type group struct {
 	components  map[string]bool                      // started components
 	addresses   map[string]bool                      // weavelet addresses
 	subscribers map[string][]control.WeaveletControl // routing info subscribers, by component
+	replicas    []*replica                           // replicas running in subprocesses
+}
+
+// A replica is a weavelet running in a subprocess.
+type replica struct {
+	addr       string                  // weavelet address
+	controller control.WeaveletControl // weavelet controller
+	cancel     context.CancelFunc      // stops the weavelet
+	restarted  bool                    // stopped by restart?
 }
 
 // handler handles a connection to a weavelet.
@@ -354,48 +364,119 @@ func (d *deployer) startGroup(g *group) error {
 		return nil
 	}
 
-	update := &protos.UpdateComponentsRequest{Components: maps.Keys(g.components)}
 	for r := 0; r < DefaultReplication; r++ {
-		// Start the weavelet.
-		wlet := &protos.WeaveletArgs{
-			App:             d.wlet.App,
-			DeploymentId:    d.wlet.DeploymentId,
-			Id:              uuid.New().String(),
-			InternalAddress: "localhost:0",
-		}
-		handler := &handler{
-			deployer:   d,
-			group:      g,
-			subscribed: map[string]bool{},
-		}
-		logger := slog.New(&logging.LogHandler{
-			Opts:  logging.Options{Component: "envelope", Weavelet: wlet.Id},
-			Write: d.log,
-		})
-		e, err := envelope.NewEnvelope(d.ctx, wlet, d.config, envelope.Options{
-			Logger: logger,
-		})
-		if err != nil {
+		if err := d.startReplica(g); err != nil {
 			return err
 		}
-		d.running.Go(func() error {
-			err := e.Serve(handler)
-			d.stop(err)
-			return err
-		})
-		if err := d.registerReplica(g, e.WeaveletAddress()); err != nil {
-			return err
-		}
-		wc := e.WeaveletControl()
-		if _, err := wc.UpdateComponents(d.ctx, update); err != nil {
-			return err
+	}
+	return nil
+}
+
+// startReplica starts a replica of the provided co-location group in a
+// subprocess.
+//
+// REQUIRES: d.mu is held.
+func (d *deployer) startReplica(g *group) error {
+	// Start the weavelet.
+	wlet := &protos.WeaveletArgs{
+		App:             d.wlet.App,
+		DeploymentId:    d.wlet.DeploymentId,
+		Id:              uuid.New().String(),
+		InternalAddress: "localhost:0",
+	}
+	handler := &handler{
+		deployer:   d,
+		group:      g,
+		subscribed: map[string]bool{},
+	}
+	logger := slog.New(&logging.LogHandler{
+		Opts:  logging.Options{Component: "envelope", Weavelet: wlet.Id},
+		Write: d.log,
+	})
+	ctx, cancel := context.WithCancel(d.ctx)
+	e, err := envelope.NewEnvelope(ctx, wlet, d.config, envelope.Options{
+		Logger: logger,
+	})
+	if err != nil {
+		cancel()
+		return err
+	}
+	r := &replica{addr: e.WeaveletAddress(), cancel: cancel}
+	d.running.Go(func() error {
+		err := e.Serve(handler)
+		d.mu.Lock()
+		defer d.mu.Unlock()
+		if r.restarted {
+			// The replica was stopped on purpose.
+			return nil
 		}
-		handler.controller = wc
-		g.controllers = append(g.controllers, wc)
+		d.stopLocked(err)
+		return err
+	})
+	if err := d.registerReplica(g, r.addr); err != nil {
+		return err
+	}
+	wc := e.WeaveletControl()
+	update := &protos.UpdateComponentsRequest{Components: maps.Keys(g.components)}
+	if _, err := wc.UpdateComponents(d.ctx, update); err != nil {
+		return err
 	}
+	handler.controller = wc
+	r.controller = wc
+	g.controllers = append(g.controllers, wc)
+	g.replicas = append(g.replicas, r)
 	return nil
 }
 
+// restart stops a replica of the co-location group that hosts the provided
+// component and starts a new replica in its place. pick is used to pick the
+// replica. restart returns false if the group has no replicas running in
+// subprocesses (e.g., the main group).
+//
+// REQUIRES: d.mu is not held.
+func (d *deployer) restart(component string, pick int) (bool, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.ctx.Err() != nil {
+		return false, nil
+	}
+	g := d.group(component)
+	if len(g.replicas) == 0 {
+		return false, nil
+	}
+
+	// Stop the replica.
+	i := pick % len(g.replicas)
+	r := g.replicas[i]
+	r.restarted = true
+	r.cancel()
+	g.replicas = slices.Delete(g.replicas, i, i+1)
+	g.controllers = slices.DeleteFunc(g.controllers, func(c control.WeaveletControl) bool {
+		return c == r.controller
+	})
+	for _, other := range d.groups {
+		for c, subs := range other.subscribers {
+			other.subscribers[c] = slices.DeleteFunc(subs, func(sub control.WeaveletControl) bool {
+				return sub == r.controller
+			})
+		}
+	}
+
+	// Notify subscribers that the replica is gone.
+	delete(g.addresses, r.addr)
+	for component := range g.components {
+		update := &protos.UpdateRoutingInfoRequest{RoutingInfo: g.routing(component)}
+		for _, sub := range g.subscribers[component] {
+			if _, err := sub.UpdateRoutingInfo(d.ctx, update); err != nil {
+				return true, err
+			}
+		}
+	}
+
+	// Start a new replica in its place.
+	return true, d.startReplica(g)
+}
+
 // group returns the group that corresponds to the given component.
 //
 // REQUIRES: d.mu is held.
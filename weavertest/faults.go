@@ -0,0 +1,251 @@
+// Copyright 2023 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package weavertest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"math/rand"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/ServiceWeaver/weaver/internal/reflection"
+	"github.com/ServiceWeaver/weaver/internal/weaver"
+	"github.com/ServiceWeaver/weaver/runtime/logging"
+)
+
+// faultSeedKey is the environment variable used to pass the fault injection
+// seed to the subprocesses of a multiprocess test.
+const faultSeedKey = "SERVICEWEAVER_WEAVERTEST_FAULT_SEED"
+
+// faultPrefix prefixes every fault reported by a weavertest, so that the
+// faults reported by subprocesses can be recognized and reported in the test
+// log.
+const faultPrefix = "weavertest: fault: "
+
+// errInjected is the error returned by a call that failed because of an
+// injected error. Component method calls wrap it in weaver.RemoteCallError.
+var errInjected = errors.New("error injected by weavertest")
+
+// Faults configures the faults that a Runner injects into remote method calls.
+// For example, the following test fails 10% of the calls to the Foo
+// component and delays another 10% by up to 100 milliseconds:
+//
+//	func TestFoo(t *testing.T) {
+//		runner := weavertest.Multi
+//		runner.Faults = &weavertest.Faults{
+//			Components:  []reflect.Type{weavertest.ComponentType[Foo]()},
+//			ErrorRate:   0.1,
+//			LatencyRate: 0.1,
+//			MaxLatency:  100 * time.Millisecond,
+//		}
+//		runner.Test(t, func(t *testing.T, foo Foo) {
+//			// Test foo ...
+//		})
+//	}
+//
+// Faults can only be injected by runners that perform remote calls (i.e., RPC
+// and Multi); a test that sets Faults on the Local runner fails. Every
+// injected fault is reported in the test log.
+type Faults struct {
+	// Seed seeds the random number generators that decide which calls are
+	// faulty. The faults injected into the sequence of calls to a component
+	// are determined by the seed, so a test that calls components
+	// sequentially can be re-run with the same faults by re-using the seed of
+	// a failing run. If Seed is zero, a seed is picked at random. The seed is
+	// reported in the test log.
+	Seed int64
+
+	// Components are the interface types of the components whose methods are
+	// faulty (see ComponentType). If Components is empty, faults are injected
+	// into calls to every component.
+	Components []reflect.Type
+
+	// LatencyRate is the probability that a call is delayed by a random
+	// duration less than MaxLatency before it is sent.
+	LatencyRate float64
+	MaxLatency  time.Duration
+
+	// ErrorRate is the probability that a call fails with a
+	// weaver.RemoteCallError without being executed.
+	ErrorRate float64
+
+	// DropRate is the probability that a call is executed, but its reply is
+	// dropped, failing the call with a weaver.RemoteCallError.
+	DropRate float64
+
+	// RestartRate is the probability that a replica of the called component
+	// is restarted before a call is sent, losing its in-memory state. Calls
+	// running on the restarted replica fail. Replicas are only restarted by
+	// the Multi runner, before the calls sent by the test and the components
+	// co-located with it.
+	RestartRate float64
+}
+
+// ComponentType returns the interface type of component T, for use in
+// Faults.Components.
+func ComponentType[T any]() reflect.Type {
+	return reflection.Type[T]()
+}
+
+// validate validates Faults.
+func (f *Faults) validate() error {
+	var errs []error
+	for _, x := range []struct {
+		name string
+		rate float64
+	}{
+		{"LatencyRate", f.LatencyRate},
+		{"ErrorRate", f.ErrorRate},
+		{"DropRate", f.DropRate},
+		{"RestartRate", f.RestartRate},
+	} {
+		if x.rate < 0 || x.rate > 1 {
+			errs = append(errs, fmt.Errorf("%s (%f) out of range [0, 1]", x.name, x.rate))
+		}
+	}
+	if f.MaxLatency < 0 {
+		errs = append(errs, fmt.Errorf("MaxLatency (%v) < 0", f.MaxLatency))
+	}
+	for _, c := range f.Components {
+		if c == nil || c.Kind() != reflect.Interface {
+			errs = append(errs, fmt.Errorf("component %v is not an interface type", c))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// injector is the weaver.FaultInjector used by weavertests.
+type injector struct {
+	faults Faults                           // faults to inject, with a non-zero seed
+	faulty map[string]bool                  // faulty components, or nil if all are faulty
+	logf   func(format string, args ...any) // reports faults
+
+	// restart, if not nil, restarts a replica of the provided component,
+	// returning false if the component has no replicas that can be
+	// restarted. pick is used to pick the replica.
+	restart func(component string, pick int) (bool, error)
+
+	mu    sync.Mutex            // guards rands
+	rands map[string]*rand.Rand // random number generators, by component
+}
+
+var _ weaver.FaultInjector = &injector{}
+
+// newInjector returns a new injector that injects the provided faults,
+// reporting them with logf. faults.Seed must be non-zero.
+func newInjector(faults Faults, logf func(format string, args ...any)) *injector {
+	var faulty map[string]bool
+	if len(faults.Components) > 0 {
+		faulty = map[string]bool{}
+		for _, c := range faults.Components {
+			faulty[fmt.Sprintf("%s/%s", c.PkgPath(), c.Name())] = true
+		}
+	}
+	return &injector{
+		faults: faults,
+		faulty: faulty,
+		logf:   logf,
+		rands:  map[string]*rand.Rand{},
+	}
+}
+
+// Inject implements the weaver.FaultInjector interface.
+func (i *injector) Inject(ctx context.Context, component, method string) (bool, error) {
+	if i.faulty != nil && !i.faulty[component] {
+		return false, nil
+	}
+
+	// Decide which faults to inject. Every decision is made for every call,
+	// so that the faults injected into a call don't depend on the faults
+	// injected into earlier calls.
+	i.mu.Lock()
+	r, ok := i.rands[component]
+	if !ok {
+		h := fnv.New64a()
+		h.Write([]byte(component))
+		r = rand.New(rand.NewSource(i.faults.Seed ^ int64(h.Sum64())))
+		i.rands[component] = r
+	}
+	restart := flip(r, i.faults.RestartRate)
+	pick := r.Int()
+	delay := flip(r, i.faults.LatencyRate)
+	latency := time.Duration(0)
+	if i.faults.MaxLatency > 0 {
+		latency = time.Duration(r.Int63n(int64(i.faults.MaxLatency)))
+	}
+	fail := flip(r, i.faults.ErrorRate)
+	drop := flip(r, i.faults.DropRate)
+	i.mu.Unlock()
+
+	name := fmt.Sprintf("%s.%s", logging.ShortenComponent(component), method)
+	if restart && i.restart != nil {
+		switch restarted, err := i.restart(component, pick); {
+		case err != nil:
+			i.logf("%sfailed to restart a replica before calling %s: %v", faultPrefix, name, err)
+		case restarted:
+			i.logf("%srestarted a replica before calling %s", faultPrefix, name)
+		}
+	}
+	if delay && latency > 0 {
+		i.logf("%sdelaying call to %s by %v", faultPrefix, name, latency)
+		timer := time.NewTimer(latency)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return false, ctx.Err()
+		}
+	}
+	if fail {
+		i.logf("%sfailing call to %s", faultPrefix, name)
+		return false, errInjected
+	}
+	if drop {
+		i.logf("%sdropping reply of call to %s", faultPrefix, name)
+	}
+	return drop, nil
+}
+
+// flip returns true with probability p.
+func flip(r *rand.Rand, p float64) bool {
+	return r.Float64() < p
+}
+
+// testLogf returns a function that logs to t until t finishes, and drops logs
+// afterwards. Faults can be injected into calls that are still running when a
+// test finishes, and t.Logf panics if called after the test finishes.
+func testLogf(t interface {
+	Logf(format string, args ...any)
+	Cleanup(func())
+}) func(format string, args ...any) {
+	var mu sync.Mutex
+	finished := false
+	t.Cleanup(func() {
+		mu.Lock()
+		defer mu.Unlock()
+		finished = true
+	})
+	return func(format string, args ...any) {
+		mu.Lock()
+		defer mu.Unlock()
+		if !finished {
+			t.Logf(format, args...)
+		}
+	}
+}
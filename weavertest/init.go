@@ -21,6 +21,8 @@ import (
 	"reflect"
 	"runtime"
 	"slices"
+	"strconv"
+	"strings"
 	"testing"
 	"time"
 
@@ -28,6 +30,7 @@ import (
 	"github.com/ServiceWeaver/weaver/internal/weaver"
 	"github.com/ServiceWeaver/weaver/runtime/codegen"
 	"github.com/ServiceWeaver/weaver/runtime/logging"
+	"github.com/ServiceWeaver/weaver/runtime/protos"
 )
 
 // Runner runs user-supplied testing code as a weaver application.
@@ -53,6 +56,10 @@ type Runner struct {
 	// The typical use is to override some subset of the application
 	// code being tested with test-specific component implementations.
 	Fakes []FakeComponent
+
+	// Faults, if not nil, configures the faults injected into remote method
+	// calls. See Faults for details.
+	Faults *Faults
 }
 
 var (
@@ -195,6 +202,15 @@ func (r Runner) sub(t testing.TB, isBench bool, testBody any) {
 		}
 	}()
 
+	if r.Faults != nil {
+		if !r.multi && !r.forceRPC {
+			t.Fatalf("weavertest.Runner.Faults: runner %q makes no remote calls to inject faults into", r.Name)
+		}
+		if err := r.Faults.validate(); err != nil {
+			t.Fatalf("weavertest.Runner.Faults: %v", err)
+		}
+	}
+
 	fakes := map[reflect.Type]any{}
 	for _, f := range r.Fakes {
 		fakes[f.intf] = f.impl
@@ -207,15 +223,49 @@ func (r Runner) sub(t testing.TB, isBench bool, testBody any) {
 			Config: r.Config,
 			Quiet:  !testing.Verbose(),
 		}
-		var err error
-		runner, err = weaver.NewSingleWeavelet(ctx, codegen.Registered(), opts)
+		wlet, err := weaver.NewSingleWeavelet(ctx, codegen.Registered(), opts)
 		if err != nil {
 			t.Fatal(err)
 		}
+		cleanup = func() error {
+			return wlet.Shutdown(context.Background())
+		}
+		runner = wlet
 	} else {
 		opts := weaver.RemoteWeaveletOptions{Fakes: fakes, InjectRetries: r.injectRetries}
 		logger := logging.NewTestLogger(t, testing.Verbose())
-		wlet, multiCleanup, err := initMultiProcess(ctx, t, isBench, r, intfs, logger.Log, opts)
+		logWriter := logger.Log
+		if r.Faults != nil {
+			// Subprocesses use the seed picked by the test process and report
+			// faults on stderr, which the test process forwards to the test
+			// log. See initMultiProcess.
+			faults := *r.Faults
+			logf := testLogf(t)
+			if seed, ok := os.LookupEnv(faultSeedKey); ok {
+				faults.Seed, err = strconv.ParseInt(seed, 10, 64)
+				if err != nil {
+					t.Fatalf("invalid %s: %v", faultSeedKey, err)
+				}
+				logf = func(format string, args ...any) {
+					fmt.Fprintf(os.Stderr, format+"\n", args...)
+				}
+			} else {
+				if faults.Seed == 0 {
+					faults.Seed = time.Now().UnixNano()
+				}
+				t.Logf("weavertest: injecting faults with seed %d", faults.Seed)
+				logWriter = func(entry *protos.LogEntry) {
+					if strings.HasPrefix(entry.Msg, faultPrefix) {
+						logf("%s", entry.Msg)
+						return
+					}
+					logger.Log(entry)
+				}
+			}
+			r.Faults = &faults
+			opts.Faults = newInjector(faults, logf)
+		}
+		wlet, multiCleanup, err := initMultiProcess(ctx, t, isBench, r, intfs, logWriter, opts)
 		if err != nil {
 			t.Fatal(err)
 		}
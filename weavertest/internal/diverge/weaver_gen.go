@@ -31,7 +31,7 @@ func init() {
 		ReflectStubFn: func(caller func(string, context.Context, []any, []any) error) any {
 			return errer_reflect_stub{caller: caller}
 		},
-		RefData: "",
+		RefData: "⟦21927038:wEaVeRfInGeRpRiNt:github.com/ServiceWeaver/weaver/weavertest/internal/diverge/Errer→Err:4c893dca9638f17f⟧\n",
 	})
 	codegen.Register(codegen.Registration{
 		Name:  "github.com/ServiceWeaver/weaver/weavertest/internal/diverge/Pointer",
@@ -49,7 +49,7 @@ func init() {
 		ReflectStubFn: func(caller func(string, context.Context, []any, []any) error) any {
 			return pointer_reflect_stub{caller: caller}
 		},
-		RefData: "",
+		RefData: "⟦8d06f603:wEaVeRfInGeRpRiNt:github.com/ServiceWeaver/weaver/weavertest/internal/diverge/Pointer→Get:be798cf5b63397f6⟧\n",
 	})
 }
 
@@ -244,10 +244,10 @@ func (s pointer_client_stub) Get(ctx context.Context) (r0 Pair, err error) {
 // Note that "weaver generate" will always generate the error message below.
 // Everything is okay. The error message is only relevant if you see it when
 // you run "go build" or "go run".
-var _ codegen.LatestVersion = codegen.Version[[0][24]struct{}](`
+var _ codegen.LatestVersion = codegen.Version[[0][25]struct{}](`
 
 ERROR: You generated this file with 'weaver generate' (devel) (codegen
-version v0.24.0). The generated code is incompatible with the version of the
+version v0.25.0). The generated code is incompatible with the version of the
 github.com/ServiceWeaver/weaver module that you're using. The weaver module
 version can be found in your go.mod file or by running the following command.
 
@@ -42,10 +42,38 @@ type customErrorValue struct {
 
 func (c customErrorValue) Error() string { return fmt.Sprintf("customError(%s)", c.key) }
 
+// tree is a recursive type.
+type tree struct {
+	weaver.AutoMarshal
+	Value    int
+	Children []*tree
+}
+
+// shape is an interface whose implementations are serialized.
+type shape interface {
+	area() float64
+}
+
+type square struct {
+	weaver.AutoMarshal
+	Side float64
+}
+
+func (s square) area() float64 { return s.Side * s.Side }
+
+type rectangle struct {
+	weaver.AutoMarshal
+	Width, Height float64
+}
+
+func (r *rectangle) area() float64 { return r.Width * r.Height }
+
 type testApp interface {
 	Get(_ context.Context, key string, behavior behaviorType) (int, error)
 	IncPointer(_ context.Context, arg *int) (*int, error)
 	DivMod(_ context.Context, numerator int, denominator int) (int, int, error)
+	Sum(_ context.Context, t *tree) (int, error)
+	Area(_ context.Context, s shape) (float64, error)
 }
 
 type impl struct {
@@ -84,3 +112,27 @@ func (p *impl) DivMod(_ context.Context, n, d int) (int, int, error) {
 	}
 	return n / d, n % d, nil
 }
+
+// Sum returns the sum of the values in a tree.
+func (p *impl) Sum(ctx context.Context, t *tree) (int, error) {
+	if t == nil {
+		return 0, nil
+	}
+	sum := t.Value
+	for _, child := range t.Children {
+		n, err := p.Sum(ctx, child)
+		if err != nil {
+			return 0, err
+		}
+		sum += n
+	}
+	return sum, nil
+}
+
+// Area returns the area of a shape.
+func (p *impl) Area(_ context.Context, s shape) (float64, error) {
+	if s == nil {
+		return 0, fmt.Errorf("nil shape")
+	}
+	return s.area(), nil
+}
@@ -115,6 +115,54 @@ func TestPointers(t *testing.T) {
 	}
 }
 
+func TestRecursiveTypes(t *testing.T) {
+	for _, runner := range weavertest.AllRunners() {
+		ctx := context.Background()
+		runner.Test(t, func(t *testing.T, client testApp) {
+			// Note that leaf is shared by two subtrees.
+			leaf := &tree{Value: 1}
+			root := &tree{Value: 2, Children: []*tree{
+				{Value: 3, Children: []*tree{leaf}},
+				leaf,
+			}}
+			got, err := client.Sum(ctx, root)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if want := 7; got != want {
+				t.Fatalf("client.Sum: got %d, want %d", got, want)
+			}
+		})
+	}
+}
+
+func TestInterfaces(t *testing.T) {
+	for _, runner := range weavertest.AllRunners() {
+		ctx := context.Background()
+		runner.Test(t, func(t *testing.T, client testApp) {
+			for _, test := range []struct {
+				s    shape
+				want float64
+			}{
+				{square{Side: 3}, 9},
+				{&rectangle{Width: 2, Height: 5}, 10},
+			} {
+				got, err := client.Area(ctx, test.s)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if got != test.want {
+					t.Errorf("client.Area(%v): got %v, want %v", test.s, got, test.want)
+				}
+			}
+
+			if _, err := client.Area(ctx, nil); err == nil {
+				t.Error("client.Area(nil): unexpected success")
+			}
+		})
+	}
+}
+
 func TestReflectStubs(t *testing.T) {
 	fakeErr := fmt.Errorf("fake error")
 	call := func(method string, _ context.Context, args, returns []any) error {
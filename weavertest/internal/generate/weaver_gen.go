@@ -20,10 +20,10 @@ func init() {
 		Iface: reflect.TypeOf((*testApp)(nil)).Elem(),
 		Impl:  reflect.TypeOf(impl{}),
 		LocalStubFn: func(impl any, caller string, tracer trace.Tracer) any {
-			return testApp_local_stub{impl: impl.(testApp), tracer: tracer, divModMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/weavertest/internal/generate/testApp", Method: "DivMod", Remote: false, Generated: true}), getMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/weavertest/internal/generate/testApp", Method: "Get", Remote: false, Generated: true}), incPointerMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/weavertest/internal/generate/testApp", Method: "IncPointer", Remote: false, Generated: true})}
+			return testApp_local_stub{impl: impl.(testApp), tracer: tracer, areaMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/weavertest/internal/generate/testApp", Method: "Area", Remote: false, Generated: true}), divModMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/weavertest/internal/generate/testApp", Method: "DivMod", Remote: false, Generated: true}), getMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/weavertest/internal/generate/testApp", Method: "Get", Remote: false, Generated: true}), incPointerMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/weavertest/internal/generate/testApp", Method: "IncPointer", Remote: false, Generated: true}), sumMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/weavertest/internal/generate/testApp", Method: "Sum", Remote: false, Generated: true})}
 		},
 		ClientStubFn: func(stub codegen.Stub, caller string) any {
-			return testApp_client_stub{stub: stub, divModMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/weavertest/internal/generate/testApp", Method: "DivMod", Remote: true, Generated: true}), getMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/weavertest/internal/generate/testApp", Method: "Get", Remote: true, Generated: true}), incPointerMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/weavertest/internal/generate/testApp", Method: "IncPointer", Remote: true, Generated: true})}
+			return testApp_client_stub{stub: stub, areaMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/weavertest/internal/generate/testApp", Method: "Area", Remote: true, Generated: true}), divModMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/weavertest/internal/generate/testApp", Method: "DivMod", Remote: true, Generated: true}), getMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/weavertest/internal/generate/testApp", Method: "Get", Remote: true, Generated: true}), incPointerMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/weavertest/internal/generate/testApp", Method: "IncPointer", Remote: true, Generated: true}), sumMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/weavertest/internal/generate/testApp", Method: "Sum", Remote: true, Generated: true})}
 		},
 		ServerStubFn: func(impl any, addLoad func(uint64, float64)) codegen.Server {
 			return testApp_server_stub{impl: impl.(testApp), addLoad: addLoad}
@@ -31,7 +31,7 @@ func init() {
 		ReflectStubFn: func(caller func(string, context.Context, []any, []any) error) any {
 			return testApp_reflect_stub{caller: caller}
 		},
-		RefData: "",
+		RefData: "⟦aaadd18c:wEaVeRfInGeRpRiNt:github.com/ServiceWeaver/weaver/weavertest/internal/generate/testApp→Area:57c2c3ed577d69ab⟧\n⟦56036b57:wEaVeRfInGeRpRiNt:github.com/ServiceWeaver/weaver/weavertest/internal/generate/testApp→DivMod:9487165c9d244a41⟧\n⟦920d90c6:wEaVeRfInGeRpRiNt:github.com/ServiceWeaver/weaver/weavertest/internal/generate/testApp→Get:7c3801a184170488⟧\n⟦e4df06b5:wEaVeRfInGeRpRiNt:github.com/ServiceWeaver/weaver/weavertest/internal/generate/testApp→IncPointer:2376efddc4d2857b⟧\n⟦f8ccf020:wEaVeRfInGeRpRiNt:github.com/ServiceWeaver/weaver/weavertest/internal/generate/testApp→Sum:c9984b970d3b08f0⟧\n",
 	})
 }
 
@@ -46,14 +46,36 @@ var _ weaver.Unrouted = (*impl)(nil)
 type testApp_local_stub struct {
 	impl              testApp
 	tracer            trace.Tracer
+	areaMetrics       *codegen.MethodMetrics
 	divModMetrics     *codegen.MethodMetrics
 	getMetrics        *codegen.MethodMetrics
 	incPointerMetrics *codegen.MethodMetrics
+	sumMetrics        *codegen.MethodMetrics
 }
 
 // Check that testApp_local_stub implements the testApp interface.
 var _ testApp = (*testApp_local_stub)(nil)
 
+func (s testApp_local_stub) Area(ctx context.Context, a0 shape) (r0 float64, err error) {
+	// Update metrics.
+	begin := s.areaMetrics.Begin()
+	defer func() { s.areaMetrics.End(begin, err != nil, 0, 0) }()
+	span := trace.SpanFromContext(ctx)
+	if span.SpanContext().IsValid() {
+		// Create a child span for this method.
+		ctx, span = s.tracer.Start(ctx, "generate.testApp.Area", trace.WithSpanKind(trace.SpanKindInternal))
+		defer func() {
+			if err != nil {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+			}
+			span.End()
+		}()
+	}
+
+	return s.impl.Area(ctx, a0)
+}
+
 func (s testApp_local_stub) DivMod(ctx context.Context, a0 int, a1 int) (r0 int, r1 int, err error) {
 	// Update metrics.
 	begin := s.divModMetrics.Begin()
@@ -114,18 +136,91 @@ func (s testApp_local_stub) IncPointer(ctx context.Context, a0 *int) (r0 *int, e
 	return s.impl.IncPointer(ctx, a0)
 }
 
+func (s testApp_local_stub) Sum(ctx context.Context, a0 *tree) (r0 int, err error) {
+	// Update metrics.
+	begin := s.sumMetrics.Begin()
+	defer func() { s.sumMetrics.End(begin, err != nil, 0, 0) }()
+	span := trace.SpanFromContext(ctx)
+	if span.SpanContext().IsValid() {
+		// Create a child span for this method.
+		ctx, span = s.tracer.Start(ctx, "generate.testApp.Sum", trace.WithSpanKind(trace.SpanKindInternal))
+		defer func() {
+			if err != nil {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+			}
+			span.End()
+		}()
+	}
+
+	return s.impl.Sum(ctx, a0)
+}
+
 // Client stub implementations.
 
 type testApp_client_stub struct {
 	stub              codegen.Stub
+	areaMetrics       *codegen.MethodMetrics
 	divModMetrics     *codegen.MethodMetrics
 	getMetrics        *codegen.MethodMetrics
 	incPointerMetrics *codegen.MethodMetrics
+	sumMetrics        *codegen.MethodMetrics
 }
 
 // Check that testApp_client_stub implements the testApp interface.
 var _ testApp = (*testApp_client_stub)(nil)
 
+func (s testApp_client_stub) Area(ctx context.Context, a0 shape) (r0 float64, err error) {
+	// Update metrics.
+	var requestBytes, replyBytes int
+	begin := s.areaMetrics.Begin()
+	defer func() { s.areaMetrics.End(begin, err != nil, requestBytes, replyBytes) }()
+
+	span := trace.SpanFromContext(ctx)
+	if span.SpanContext().IsValid() {
+		// Create a child span for this method.
+		ctx, span = s.stub.Tracer().Start(ctx, "generate.testApp.Area", trace.WithSpanKind(trace.SpanKindClient))
+	}
+
+	defer func() {
+		// Catch and return any panics detected during encoding/decoding/rpc.
+		if err == nil {
+			err = codegen.CatchPanics(recover())
+			if err != nil {
+				err = errors.Join(weaver.RemoteCallError, err)
+			}
+		}
+
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+
+	}()
+
+	// Encode arguments.
+	enc := codegen.NewEncoder()
+	enc.EncodeInterface(a0)
+	var shardKey uint64
+
+	// Call the remote method.
+	requestBytes = len(enc.Data())
+	var results []byte
+	results, err = s.stub.Run(ctx, 0, enc.Data(), shardKey)
+	replyBytes = len(results)
+	if err != nil {
+		err = errors.Join(weaver.RemoteCallError, err)
+		return
+	}
+
+	// Decode the results.
+	dec := codegen.NewDecoder(results)
+	r0 = dec.Float64()
+	err = dec.Error()
+	return
+}
+
 func (s testApp_client_stub) DivMod(ctx context.Context, a0 int, a1 int) (r0 int, r1 int, err error) {
 	// Update metrics.
 	var requestBytes, replyBytes int
@@ -170,7 +265,7 @@ func (s testApp_client_stub) DivMod(ctx context.Context, a0 int, a1 int) (r0 int
 	// Call the remote method.
 	requestBytes = len(enc.Data())
 	var results []byte
-	results, err = s.stub.Run(ctx, 0, enc.Data(), shardKey)
+	results, err = s.stub.Run(ctx, 1, enc.Data(), shardKey)
 	replyBytes = len(results)
 	if err != nil {
 		err = errors.Join(weaver.RemoteCallError, err)
@@ -229,7 +324,7 @@ func (s testApp_client_stub) Get(ctx context.Context, a0 string, a1 behaviorType
 	// Call the remote method.
 	requestBytes = len(enc.Data())
 	var results []byte
-	results, err = s.stub.Run(ctx, 1, enc.Data(), shardKey)
+	results, err = s.stub.Run(ctx, 2, enc.Data(), shardKey)
 	replyBytes = len(results)
 	if err != nil {
 		err = errors.Join(weaver.RemoteCallError, err)
@@ -285,7 +380,7 @@ func (s testApp_client_stub) IncPointer(ctx context.Context, a0 *int) (r0 *int,
 	// Call the remote method.
 	requestBytes = len(enc.Data())
 	var results []byte
-	results, err = s.stub.Run(ctx, 2, enc.Data(), shardKey)
+	results, err = s.stub.Run(ctx, 3, enc.Data(), shardKey)
 	replyBytes = len(results)
 	if err != nil {
 		err = errors.Join(weaver.RemoteCallError, err)
@@ -299,13 +394,64 @@ func (s testApp_client_stub) IncPointer(ctx context.Context, a0 *int) (r0 *int,
 	return
 }
 
+func (s testApp_client_stub) Sum(ctx context.Context, a0 *tree) (r0 int, err error) {
+	// Update metrics.
+	var requestBytes, replyBytes int
+	begin := s.sumMetrics.Begin()
+	defer func() { s.sumMetrics.End(begin, err != nil, requestBytes, replyBytes) }()
+
+	span := trace.SpanFromContext(ctx)
+	if span.SpanContext().IsValid() {
+		// Create a child span for this method.
+		ctx, span = s.stub.Tracer().Start(ctx, "generate.testApp.Sum", trace.WithSpanKind(trace.SpanKindClient))
+	}
+
+	defer func() {
+		// Catch and return any panics detected during encoding/decoding/rpc.
+		if err == nil {
+			err = codegen.CatchPanics(recover())
+			if err != nil {
+				err = errors.Join(weaver.RemoteCallError, err)
+			}
+		}
+
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+
+	}()
+
+	// Encode arguments.
+	enc := codegen.NewEncoder()
+	serviceweaver_enc_ptr_tree_ce2e44aa(enc, a0)
+	var shardKey uint64
+
+	// Call the remote method.
+	requestBytes = len(enc.Data())
+	var results []byte
+	results, err = s.stub.Run(ctx, 4, enc.Data(), shardKey)
+	replyBytes = len(results)
+	if err != nil {
+		err = errors.Join(weaver.RemoteCallError, err)
+		return
+	}
+
+	// Decode the results.
+	dec := codegen.NewDecoder(results)
+	r0 = dec.Int()
+	err = dec.Error()
+	return
+}
+
 // Note that "weaver generate" will always generate the error message below.
 // Everything is okay. The error message is only relevant if you see it when
 // you run "go build" or "go run".
-var _ codegen.LatestVersion = codegen.Version[[0][24]struct{}](`
+var _ codegen.LatestVersion = codegen.Version[[0][25]struct{}](`
 
 ERROR: You generated this file with 'weaver generate' (devel) (codegen
-version v0.24.0). The generated code is incompatible with the version of the
+version v0.25.0). The generated code is incompatible with the version of the
 github.com/ServiceWeaver/weaver module that you're using. The weaver module
 version can be found in your go.mod file or by running the following command.
 
@@ -335,17 +481,46 @@ var _ codegen.Server = (*testApp_server_stub)(nil)
 // GetStubFn implements the codegen.Server interface.
 func (s testApp_server_stub) GetStubFn(method string) func(ctx context.Context, args []byte) ([]byte, error) {
 	switch method {
+	case "Area":
+		return s.area
 	case "DivMod":
 		return s.divMod
 	case "Get":
 		return s.get
 	case "IncPointer":
 		return s.incPointer
+	case "Sum":
+		return s.sum
 	default:
 		return nil
 	}
 }
 
+func (s testApp_server_stub) area(ctx context.Context, args []byte) (res []byte, err error) {
+	// Catch and return any panics detected during encoding/decoding/rpc.
+	defer func() {
+		if err == nil {
+			err = codegen.CatchPanics(recover())
+		}
+	}()
+
+	// Decode arguments.
+	dec := codegen.NewDecoder(args)
+	var a0 shape
+	a0 = codegen.DecodeInterface[shape](dec)
+
+	// TODO(rgrandl): The deferred function above will recover from panics in the
+	// user code: fix this.
+	// Call the local method.
+	r0, appErr := s.impl.Area(ctx, a0)
+
+	// Encode the results.
+	enc := codegen.NewEncoder()
+	enc.Float64(r0)
+	enc.Error(appErr)
+	return enc.Data(), nil
+}
+
 func (s testApp_server_stub) divMod(ctx context.Context, args []byte) (res []byte, err error) {
 	// Catch and return any panics detected during encoding/decoding/rpc.
 	defer func() {
@@ -426,6 +601,31 @@ func (s testApp_server_stub) incPointer(ctx context.Context, args []byte) (res [
 	return enc.Data(), nil
 }
 
+func (s testApp_server_stub) sum(ctx context.Context, args []byte) (res []byte, err error) {
+	// Catch and return any panics detected during encoding/decoding/rpc.
+	defer func() {
+		if err == nil {
+			err = codegen.CatchPanics(recover())
+		}
+	}()
+
+	// Decode arguments.
+	dec := codegen.NewDecoder(args)
+	var a0 *tree
+	a0 = serviceweaver_dec_ptr_tree_ce2e44aa(dec)
+
+	// TODO(rgrandl): The deferred function above will recover from panics in the
+	// user code: fix this.
+	// Call the local method.
+	r0, appErr := s.impl.Sum(ctx, a0)
+
+	// Encode the results.
+	enc := codegen.NewEncoder()
+	enc.Int(r0)
+	enc.Error(appErr)
+	return enc.Data(), nil
+}
+
 // Reflect stub implementations.
 
 type testApp_reflect_stub struct {
@@ -435,6 +635,11 @@ type testApp_reflect_stub struct {
 // Check that testApp_reflect_stub implements the testApp interface.
 var _ testApp = (*testApp_reflect_stub)(nil)
 
+func (s testApp_reflect_stub) Area(ctx context.Context, a0 shape) (r0 float64, err error) {
+	err = s.caller("Area", ctx, []any{a0}, []any{&r0})
+	return
+}
+
 func (s testApp_reflect_stub) DivMod(ctx context.Context, a0 int, a1 int) (r0 int, r1 int, err error) {
 	err = s.caller("DivMod", ctx, []any{a0, a1}, []any{&r0, &r1})
 	return
@@ -450,6 +655,11 @@ func (s testApp_reflect_stub) IncPointer(ctx context.Context, a0 *int) (r0 *int,
 	return
 }
 
+func (s testApp_reflect_stub) Sum(ctx context.Context, a0 *tree) (r0 int, err error) {
+	err = s.caller("Sum", ctx, []any{a0}, []any{&r0})
+	return
+}
+
 // AutoMarshal implementations.
 
 var _ codegen.AutoMarshal = (*customErrorValue)(nil)
@@ -476,6 +686,120 @@ func (x *customErrorValue) WeaverUnmarshal(dec *codegen.Decoder) {
 }
 func init() { codegen.RegisterSerializable[*customErrorValue]() }
 
+var _ codegen.AutoMarshal = (*rectangle)(nil)
+
+type __is_rectangle[T ~struct {
+	weaver.AutoMarshal
+	Width  float64
+	Height float64
+}] struct{}
+
+var _ __is_rectangle[rectangle]
+
+func (x *rectangle) WeaverMarshal(enc *codegen.Encoder) {
+	if x == nil {
+		panic(fmt.Errorf("rectangle.WeaverMarshal: nil receiver"))
+	}
+	enc.Float64(x.Width)
+	enc.Float64(x.Height)
+}
+
+func (x *rectangle) WeaverUnmarshal(dec *codegen.Decoder) {
+	if x == nil {
+		panic(fmt.Errorf("rectangle.WeaverUnmarshal: nil receiver"))
+	}
+	x.Width = dec.Float64()
+	x.Height = dec.Float64()
+}
+func init() { codegen.RegisterSerializable[*rectangle]() }
+
+var _ codegen.AutoMarshal = (*square)(nil)
+
+type __is_square[T ~struct {
+	weaver.AutoMarshal
+	Side float64
+}] struct{}
+
+var _ __is_square[square]
+
+func (x *square) WeaverMarshal(enc *codegen.Encoder) {
+	if x == nil {
+		panic(fmt.Errorf("square.WeaverMarshal: nil receiver"))
+	}
+	enc.Float64(x.Side)
+}
+
+func (x *square) WeaverUnmarshal(dec *codegen.Decoder) {
+	if x == nil {
+		panic(fmt.Errorf("square.WeaverUnmarshal: nil receiver"))
+	}
+	x.Side = dec.Float64()
+}
+func init() { codegen.RegisterSerializable[*square]() }
+
+var _ codegen.AutoMarshal = (*tree)(nil)
+
+type __is_tree[T ~struct {
+	weaver.AutoMarshal
+	Value    int
+	Children []*tree
+}] struct{}
+
+var _ __is_tree[tree]
+
+func (x *tree) WeaverMarshal(enc *codegen.Encoder) {
+	if x == nil {
+		panic(fmt.Errorf("tree.WeaverMarshal: nil receiver"))
+	}
+	enc.Int(x.Value)
+	serviceweaver_enc_slice_ptr_tree_6a52a96b(enc, x.Children)
+}
+
+func (x *tree) WeaverUnmarshal(dec *codegen.Decoder) {
+	if x == nil {
+		panic(fmt.Errorf("tree.WeaverUnmarshal: nil receiver"))
+	}
+	x.Value = dec.Int()
+	x.Children = serviceweaver_dec_slice_ptr_tree_6a52a96b(dec)
+}
+
+func serviceweaver_enc_ptr_tree_ce2e44aa(enc *codegen.Encoder, arg *tree) {
+	if codegen.EncodeRef(enc, arg) {
+		(*arg).WeaverMarshal(enc)
+	}
+}
+
+func serviceweaver_dec_ptr_tree_ce2e44aa(dec *codegen.Decoder) *tree {
+	res, ok := codegen.DecodeRef[tree](dec)
+	if ok {
+		(res).WeaverUnmarshal(dec)
+	}
+	return res
+}
+
+func serviceweaver_enc_slice_ptr_tree_6a52a96b(enc *codegen.Encoder, arg []*tree) {
+	if arg == nil {
+		enc.Len(-1)
+		return
+	}
+	enc.Len(len(arg))
+	for i := 0; i < len(arg); i++ {
+		serviceweaver_enc_ptr_tree_ce2e44aa(enc, arg[i])
+	}
+}
+
+func serviceweaver_dec_slice_ptr_tree_6a52a96b(dec *codegen.Decoder) []*tree {
+	n := dec.Len()
+	if n == -1 {
+		return nil
+	}
+	res := make([]*tree, n)
+	for i := 0; i < n; i++ {
+		res[i] = serviceweaver_dec_ptr_tree_ce2e44aa(dec)
+	}
+	return res
+}
+
 // Encoding/decoding implementations.
 
 func serviceweaver_enc_ptr_int_98a2a745(enc *codegen.Encoder, arg *int) {
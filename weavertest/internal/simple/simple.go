@@ -22,6 +22,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"maps"
 	"net/http"
 	"os"
@@ -175,3 +176,70 @@ func (s *server) Init(ctx context.Context) error {
 func (s *server) Address(ctx context.Context) (string, error)      { return s.addr, nil }
 func (s *server) ProxyAddress(ctx context.Context) (string, error) { return s.proxy, nil }
 func (s *server) Shutdown(ctx context.Context) error               { return s.srv.Shutdown(ctx) }
+
+// Streamer is a component used to test streaming methods.
+type Streamer interface {
+	// Count returns the number of values received on the provided stream.
+	Count(context.Context, weaver.Stream[string]) (int, error)
+
+	// Ints returns a stream of the integers in the range [0, n). If n is
+	// negative, the returned stream is unbounded.
+	Ints(_ context.Context, n int) (weaver.Stream[int], error)
+
+	// Upper returns a stream of the upper-cased values of the provided stream.
+	Upper(context.Context, weaver.Stream[string]) (weaver.Stream[string], error)
+}
+
+type streamer struct {
+	weaver.Implements[Streamer]
+}
+
+func (s *streamer) Count(ctx context.Context, values weaver.Stream[string]) (int, error) {
+	n := 0
+	for {
+		_, err := values.Recv(ctx)
+		if errors.Is(err, io.EOF) {
+			return n, nil
+		} else if err != nil {
+			return 0, err
+		}
+		n++
+	}
+}
+
+func (s *streamer) Ints(ctx context.Context, n int) (weaver.Stream[int], error) {
+	if n == 0 {
+		return nil, fmt.Errorf("empty range")
+	}
+	results, w := weaver.NewStream[int]()
+	go func() {
+		for i := 0; n < 0 || i < n; i++ {
+			if err := w.Send(ctx, i); err != nil {
+				return
+			}
+		}
+		w.Close()
+	}()
+	return results, nil
+}
+
+func (s *streamer) Upper(ctx context.Context, values weaver.Stream[string]) (weaver.Stream[string], error) {
+	results, w := weaver.NewStream[string]()
+	go func() {
+		defer values.Close()
+		for {
+			v, err := values.Recv(ctx)
+			if errors.Is(err, io.EOF) {
+				w.Close()
+				return
+			} else if err != nil {
+				w.CloseWithError(err)
+				return
+			}
+			if err := w.Send(ctx, strings.ToUpper(v)); err != nil {
+				return
+			}
+		}
+	}()
+	return results, nil
+}
@@ -16,6 +16,7 @@ package simple_test
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -26,6 +27,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/ServiceWeaver/weaver"
 	"github.com/ServiceWeaver/weaver/internal/traceio"
 	"github.com/ServiceWeaver/weaver/metadata"
 	"github.com/ServiceWeaver/weaver/weavertest"
@@ -279,3 +281,170 @@ func BenchmarkTracedCall(b *testing.B) {
 		}
 	})
 }
+
+func TestStreams(t *testing.T) {
+	ctx := context.Background()
+	for _, runner := range weavertest.AllRunners() {
+		runner.Test(t, func(t *testing.T, s simple.Streamer) {
+			t.Run("Count", func(t *testing.T) {
+				values, w := weaver.NewStream[string]()
+				go func() {
+					for i := 0; i < 100; i++ {
+						if err := w.Send(ctx, fmt.Sprint(i)); err != nil {
+							t.Error(err)
+							return
+						}
+					}
+					w.Close()
+				}()
+				n, err := s.Count(ctx, values)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if n != 100 {
+					t.Fatalf("Count: got %d, want 100", n)
+				}
+			})
+
+			t.Run("Ints", func(t *testing.T) {
+				results, err := s.Ints(ctx, 100)
+				if err != nil {
+					t.Fatal(err)
+				}
+				for i := 0; i < 100; i++ {
+					got, err := results.Recv(ctx)
+					if err != nil {
+						t.Fatal(err)
+					}
+					if got != i {
+						t.Fatalf("Recv: got %d, want %d", got, i)
+					}
+				}
+				if _, err := results.Recv(ctx); !errors.Is(err, io.EOF) {
+					t.Fatalf("Recv: got %v, want io.EOF", err)
+				}
+			})
+
+			t.Run("IntsError", func(t *testing.T) {
+				if _, err := s.Ints(ctx, 0); err == nil {
+					t.Fatal("Ints: unexpected success")
+				}
+			})
+
+			t.Run("IntsClose", func(t *testing.T) {
+				// Stop receiving from an unbounded stream.
+				results, err := s.Ints(ctx, -1)
+				if err != nil {
+					t.Fatal(err)
+				}
+				for i := 0; i < 10; i++ {
+					if _, err := results.Recv(ctx); err != nil {
+						t.Fatal(err)
+					}
+				}
+				if err := results.Close(); err != nil {
+					t.Fatal(err)
+				}
+			})
+
+			t.Run("Upper", func(t *testing.T) {
+				values, w := weaver.NewStream[string]()
+				results, err := s.Upper(ctx, values)
+				if err != nil {
+					t.Fatal(err)
+				}
+				for _, v := range []string{"a", "b", "c"} {
+					if err := w.Send(ctx, v); err != nil {
+						t.Fatal(err)
+					}
+					got, err := results.Recv(ctx)
+					if err != nil {
+						t.Fatal(err)
+					}
+					if want := strings.ToUpper(v); got != want {
+						t.Fatalf("Recv: got %q, want %q", got, want)
+					}
+				}
+				w.CloseWithError(errors.New("done"))
+				if _, err := results.Recv(ctx); err == nil || !strings.Contains(err.Error(), "done") {
+					t.Fatalf("Recv: got %v, want error containing %q", err, "done")
+				}
+			})
+		})
+	}
+}
+
+func TestFaults(t *testing.T) {
+	// getpids calls dst.Getpid n times, returning the results of the calls
+	// that succeed and the indices of the calls that fail.
+	getpids := func(t *testing.T, dst simple.Destination, n int) (map[int]bool, []int) {
+		pids := map[int]bool{}
+		var failed []int
+		for i := 0; i < n; i++ {
+			pid, err := dst.Getpid(context.Background())
+			if err != nil {
+				if !errors.Is(err, weaver.RemoteCallError) {
+					t.Fatalf("Getpid: got %v, want weaver.RemoteCallError", err)
+				}
+				failed = append(failed, i)
+				continue
+			}
+			pids[pid] = true
+		}
+		return pids, failed
+	}
+
+	for _, runner := range []weavertest.Runner{weavertest.RPC, weavertest.Multi} {
+		runner.Faults = &weavertest.Faults{
+			Seed:        42,
+			Components:  []reflect.Type{weavertest.ComponentType[simple.Destination]()},
+			LatencyRate: 0.2,
+			MaxLatency:  time.Millisecond,
+			ErrorRate:   0.2,
+			DropRate:    0.2,
+		}
+
+		var want []int
+		for i := 0; i < 2; i++ {
+			runner.Test(t, func(t *testing.T, dst simple.Destination) {
+				const n = 50
+				_, failed := getpids(t, dst, n)
+				if len(failed) == 0 || len(failed) == n {
+					t.Fatalf("%d/%d calls failed, want some calls to fail", len(failed), n)
+				}
+
+				// Calls are sent sequentially, so the faults are determined
+				// by the seed.
+				if i == 0 {
+					want = failed
+				} else if !reflect.DeepEqual(failed, want) {
+					t.Fatalf("failed calls: got %v, want %v", failed, want)
+				}
+			})
+		}
+	}
+
+	t.Run("OtherComponents", func(t *testing.T) {
+		runner := weavertest.RPC
+		runner.Faults = &weavertest.Faults{
+			Components: []reflect.Type{weavertest.ComponentType[simple.Source]()},
+			ErrorRate:  1,
+		}
+		runner.Test(t, func(t *testing.T, dst simple.Destination) {
+			if _, failed := getpids(t, dst, 10); len(failed) > 0 {
+				t.Fatalf("calls %v failed, want no failures", failed)
+			}
+		})
+	})
+
+	t.Run("Restarts", func(t *testing.T) {
+		runner := weavertest.Multi
+		runner.Faults = &weavertest.Faults{Seed: 42, RestartRate: 0.5}
+		runner.Test(t, func(t *testing.T, dst simple.Destination) {
+			// Every restart starts a new process.
+			if pids, _ := getpids(t, dst, 50); len(pids) <= weavertest.DefaultReplication {
+				t.Fatalf("got %d pids, want more than %d", len(pids), weavertest.DefaultReplication)
+			}
+		})
+	})
+}
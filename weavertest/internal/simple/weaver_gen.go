@@ -32,7 +32,7 @@ func init() {
 		ReflectStubFn: func(caller func(string, context.Context, []any, []any) error) any {
 			return destination_reflect_stub{caller: caller}
 		},
-		RefData: "",
+		RefData: "⟦40f15dec:wEaVeRfInGeRpRiNt:github.com/ServiceWeaver/weaver/weavertest/internal/simple/Destination→GetAll:f14251e93463504c⟧\n⟦ac068476:wEaVeRfInGeRpRiNt:github.com/ServiceWeaver/weaver/weavertest/internal/simple/Destination→GetMetadata:42275fb578e7a678⟧\n⟦ede6d319:wEaVeRfInGeRpRiNt:github.com/ServiceWeaver/weaver/weavertest/internal/simple/Destination→Getpid:2ade0b0fddbc7b38⟧\n⟦4ab4f045:wEaVeRfInGeRpRiNt:github.com/ServiceWeaver/weaver/weavertest/internal/simple/Destination→Record:2405d96ec0681160⟧\n⟦e6e1250a:wEaVeRfInGeRpRiNt:github.com/ServiceWeaver/weaver/weavertest/internal/simple/Destination→RoutedRecord:2405d96ec0681160⟧\n⟦94552d09:wEaVeRfInGeRpRiNt:github.com/ServiceWeaver/weaver/weavertest/internal/simple/Destination→UpdateMetadata:ef273203fe44267b⟧\n",
 	})
 	codegen.Register(codegen.Registration{
 		Name:      "github.com/ServiceWeaver/weaver/weavertest/internal/simple/Server",
@@ -51,7 +51,7 @@ func init() {
 		ReflectStubFn: func(caller func(string, context.Context, []any, []any) error) any {
 			return server_reflect_stub{caller: caller}
 		},
-		RefData: "⟦1e2dce71:wEaVeRlIsTeNeRs:github.com/ServiceWeaver/weaver/weavertest/internal/simple/Server→hello⟧\n",
+		RefData: "⟦1e2dce71:wEaVeRlIsTeNeRs:github.com/ServiceWeaver/weaver/weavertest/internal/simple/Server→hello⟧\n⟦3a596b3c:wEaVeRfInGeRpRiNt:github.com/ServiceWeaver/weaver/weavertest/internal/simple/Server→Address:991af47b1b9c8ae0⟧\n⟦83057598:wEaVeRfInGeRpRiNt:github.com/ServiceWeaver/weaver/weavertest/internal/simple/Server→ProxyAddress:991af47b1b9c8ae0⟧\n⟦2b30ed70:wEaVeRfInGeRpRiNt:github.com/ServiceWeaver/weaver/weavertest/internal/simple/Server→Shutdown:ef273203fe44267b⟧\n",
 	})
 	codegen.Register(codegen.Registration{
 		Name:    "github.com/ServiceWeaver/weaver/weavertest/internal/simple/Source",
@@ -70,7 +70,25 @@ func init() {
 		ReflectStubFn: func(caller func(string, context.Context, []any, []any) error) any {
 			return source_reflect_stub{caller: caller}
 		},
-		RefData: "⟦bf914175:wEaVeReDgE:github.com/ServiceWeaver/weaver/weavertest/internal/simple/Source→github.com/ServiceWeaver/weaver/weavertest/internal/simple/Destination⟧\n",
+		RefData: "⟦bf914175:wEaVeReDgE:github.com/ServiceWeaver/weaver/weavertest/internal/simple/Source→github.com/ServiceWeaver/weaver/weavertest/internal/simple/Destination⟧\n⟦5dc12ce4:wEaVeRfInGeRpRiNt:github.com/ServiceWeaver/weaver/weavertest/internal/simple/Source→Emit:2405d96ec0681160⟧\n",
+	})
+	codegen.Register(codegen.Registration{
+		Name:  "github.com/ServiceWeaver/weaver/weavertest/internal/simple/Streamer",
+		Iface: reflect.TypeOf((*Streamer)(nil)).Elem(),
+		Impl:  reflect.TypeOf(streamer{}),
+		LocalStubFn: func(impl any, caller string, tracer trace.Tracer) any {
+			return streamer_local_stub{impl: impl.(Streamer), tracer: tracer, countMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/weavertest/internal/simple/Streamer", Method: "Count", Remote: false, Generated: true}), intsMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/weavertest/internal/simple/Streamer", Method: "Ints", Remote: false, Generated: true}), upperMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/weavertest/internal/simple/Streamer", Method: "Upper", Remote: false, Generated: true})}
+		},
+		ClientStubFn: func(stub codegen.Stub, caller string) any {
+			return streamer_client_stub{stub: stub, countMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/weavertest/internal/simple/Streamer", Method: "Count", Remote: true, Generated: true}), intsMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/weavertest/internal/simple/Streamer", Method: "Ints", Remote: true, Generated: true}), upperMetrics: codegen.MethodMetricsFor(codegen.MethodLabels{Caller: caller, Component: "github.com/ServiceWeaver/weaver/weavertest/internal/simple/Streamer", Method: "Upper", Remote: true, Generated: true})}
+		},
+		ServerStubFn: func(impl any, addLoad func(uint64, float64)) codegen.Server {
+			return streamer_server_stub{impl: impl.(Streamer), addLoad: addLoad}
+		},
+		ReflectStubFn: func(caller func(string, context.Context, []any, []any) error) any {
+			return streamer_reflect_stub{caller: caller}
+		},
+		RefData: "⟦5a7a01e0:wEaVeRfInGeRpRiNt:github.com/ServiceWeaver/weaver/weavertest/internal/simple/Streamer→Count:0ae2561f0b33f2ac⟧\n⟦5568a714:wEaVeRfInGeRpRiNt:github.com/ServiceWeaver/weaver/weavertest/internal/simple/Streamer→Ints:1e2ab40a48a884ae⟧\n⟦74147663:wEaVeRfInGeRpRiNt:github.com/ServiceWeaver/weaver/weavertest/internal/simple/Streamer→Upper:5e296219d356adb6⟧\n",
 	})
 }
 
@@ -78,11 +96,13 @@ func init() {
 var _ weaver.InstanceOf[Destination] = (*destination)(nil)
 var _ weaver.InstanceOf[Server] = (*server)(nil)
 var _ weaver.InstanceOf[Source] = (*source)(nil)
+var _ weaver.InstanceOf[Streamer] = (*streamer)(nil)
 
 // weaver.Router checks.
 var _ weaver.RoutedBy[destRouter] = (*destination)(nil)
 var _ weaver.Unrouted = (*server)(nil)
 var _ weaver.Unrouted = (*source)(nil)
+var _ weaver.Unrouted = (*streamer)(nil)
 
 // Component "destination", router "destRouter" checks.
 type __destination_destRouter_if_youre_seeing_this_you_probably_forgot_to_run_weaver_generate struct {
@@ -341,6 +361,77 @@ func (s source_local_stub) Emit(ctx context.Context, a0 string, a1 string) (err
 	return s.impl.Emit(ctx, a0, a1)
 }
 
+type streamer_local_stub struct {
+	impl         Streamer
+	tracer       trace.Tracer
+	countMetrics *codegen.MethodMetrics
+	intsMetrics  *codegen.MethodMetrics
+	upperMetrics *codegen.MethodMetrics
+}
+
+// Check that streamer_local_stub implements the Streamer interface.
+var _ Streamer = (*streamer_local_stub)(nil)
+
+func (s streamer_local_stub) Count(ctx context.Context, a0 weaver.Stream[string]) (r0 int, err error) {
+	// Update metrics.
+	begin := s.countMetrics.Begin()
+	defer func() { s.countMetrics.End(begin, err != nil, 0, 0) }()
+	span := trace.SpanFromContext(ctx)
+	if span.SpanContext().IsValid() {
+		// Create a child span for this method.
+		ctx, span = s.tracer.Start(ctx, "simple.Streamer.Count", trace.WithSpanKind(trace.SpanKindInternal))
+		defer func() {
+			if err != nil {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+			}
+			span.End()
+		}()
+	}
+
+	return s.impl.Count(ctx, a0)
+}
+
+func (s streamer_local_stub) Ints(ctx context.Context, a0 int) (r0 weaver.Stream[int], err error) {
+	// Update metrics.
+	begin := s.intsMetrics.Begin()
+	defer func() { s.intsMetrics.End(begin, err != nil, 0, 0) }()
+	span := trace.SpanFromContext(ctx)
+	if span.SpanContext().IsValid() {
+		// Create a child span for this method.
+		ctx, span = s.tracer.Start(ctx, "simple.Streamer.Ints", trace.WithSpanKind(trace.SpanKindInternal))
+		defer func() {
+			if err != nil {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+			}
+			span.End()
+		}()
+	}
+
+	return s.impl.Ints(ctx, a0)
+}
+
+func (s streamer_local_stub) Upper(ctx context.Context, a0 weaver.Stream[string]) (r0 weaver.Stream[string], err error) {
+	// Update metrics.
+	begin := s.upperMetrics.Begin()
+	defer func() { s.upperMetrics.End(begin, err != nil, 0, 0) }()
+	span := trace.SpanFromContext(ctx)
+	if span.SpanContext().IsValid() {
+		// Create a child span for this method.
+		ctx, span = s.tracer.Start(ctx, "simple.Streamer.Upper", trace.WithSpanKind(trace.SpanKindInternal))
+		defer func() {
+			if err != nil {
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+			}
+			span.End()
+		}()
+	}
+
+	return s.impl.Upper(ctx, a0)
+}
+
 // Client stub implementations.
 
 type destination_client_stub struct {
@@ -884,13 +975,212 @@ func (s source_client_stub) Emit(ctx context.Context, a0 string, a1 string) (err
 	return
 }
 
+type streamer_client_stub struct {
+	stub         codegen.Stub
+	countMetrics *codegen.MethodMetrics
+	intsMetrics  *codegen.MethodMetrics
+	upperMetrics *codegen.MethodMetrics
+}
+
+// Check that streamer_client_stub implements the Streamer interface.
+var _ Streamer = (*streamer_client_stub)(nil)
+
+func (s streamer_client_stub) Count(ctx context.Context, a0 weaver.Stream[string]) (r0 int, err error) {
+	// Update metrics.
+	var requestBytes, replyBytes int
+	begin := s.countMetrics.Begin()
+	defer func() { s.countMetrics.End(begin, err != nil, requestBytes, replyBytes) }()
+
+	span := trace.SpanFromContext(ctx)
+	if span.SpanContext().IsValid() {
+		// Create a child span for this method.
+		ctx, span = s.stub.Tracer().Start(ctx, "simple.Streamer.Count", trace.WithSpanKind(trace.SpanKindClient))
+	}
+
+	defer func() {
+		// Catch and return any panics detected during encoding/decoding/rpc.
+		if err == nil {
+			err = codegen.CatchPanics(recover())
+			if err != nil {
+				err = errors.Join(weaver.RemoteCallError, err)
+			}
+		}
+
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+
+	}()
+
+	var shardKey uint64
+
+	// Start the remote streaming call.
+	var conn codegen.ClientStream
+	conn, err = s.stub.Stream(ctx, 0, nil, shardKey)
+	if err != nil {
+		err = errors.Join(weaver.RemoteCallError, err)
+		return
+	}
+
+	// Send the argument stream.
+	go codegen.SendStream(ctx, conn, a0, func(enc *codegen.Encoder, x string) { enc.String(x) })
+
+	// Wait for the results.
+	var results []byte
+	results, err = conn.Reply()
+	replyBytes = len(results)
+	if err != nil {
+		err = errors.Join(weaver.RemoteCallError, err)
+		return
+	}
+
+	// Decode the results.
+	dec := codegen.NewDecoder(results)
+	r0 = dec.Int()
+	err = dec.Error()
+	return
+}
+
+func (s streamer_client_stub) Ints(ctx context.Context, a0 int) (r0 weaver.Stream[int], err error) {
+	// Update metrics.
+	var requestBytes, replyBytes int
+	begin := s.intsMetrics.Begin()
+	defer func() { s.intsMetrics.End(begin, err != nil, requestBytes, replyBytes) }()
+
+	span := trace.SpanFromContext(ctx)
+	if span.SpanContext().IsValid() {
+		// Create a child span for this method.
+		ctx, span = s.stub.Tracer().Start(ctx, "simple.Streamer.Ints", trace.WithSpanKind(trace.SpanKindClient))
+	}
+
+	defer func() {
+		// Catch and return any panics detected during encoding/decoding/rpc.
+		if err == nil {
+			err = codegen.CatchPanics(recover())
+			if err != nil {
+				err = errors.Join(weaver.RemoteCallError, err)
+			}
+		}
+
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+
+	}()
+
+	// Encode arguments.
+	enc := codegen.NewEncoder()
+	enc.Int(a0)
+	var shardKey uint64
+
+	// Start the remote streaming call.
+	requestBytes = len(enc.Data())
+	var conn codegen.ClientStream
+	conn, err = s.stub.Stream(ctx, 1, enc.Data(), shardKey)
+	if err != nil {
+		err = errors.Join(weaver.RemoteCallError, err)
+		return
+	}
+
+	// Wait for the results.
+	var results []byte
+	results, err = conn.Reply()
+	replyBytes = len(results)
+	if err != nil {
+		err = errors.Join(weaver.RemoteCallError, err)
+		return
+	}
+
+	// Decode the results.
+	dec := codegen.NewDecoder(results)
+	err = dec.Error()
+	if err != nil {
+		conn.CloseRecv()
+		return
+	}
+	r0 = codegen.NewStreamReader(conn, func(dec *codegen.Decoder) (x int) {
+		x = dec.Int()
+		return
+	})
+	return
+}
+
+func (s streamer_client_stub) Upper(ctx context.Context, a0 weaver.Stream[string]) (r0 weaver.Stream[string], err error) {
+	// Update metrics.
+	var requestBytes, replyBytes int
+	begin := s.upperMetrics.Begin()
+	defer func() { s.upperMetrics.End(begin, err != nil, requestBytes, replyBytes) }()
+
+	span := trace.SpanFromContext(ctx)
+	if span.SpanContext().IsValid() {
+		// Create a child span for this method.
+		ctx, span = s.stub.Tracer().Start(ctx, "simple.Streamer.Upper", trace.WithSpanKind(trace.SpanKindClient))
+	}
+
+	defer func() {
+		// Catch and return any panics detected during encoding/decoding/rpc.
+		if err == nil {
+			err = codegen.CatchPanics(recover())
+			if err != nil {
+				err = errors.Join(weaver.RemoteCallError, err)
+			}
+		}
+
+		if err != nil {
+			span.RecordError(err)
+			span.SetStatus(codes.Error, err.Error())
+		}
+		span.End()
+
+	}()
+
+	var shardKey uint64
+
+	// Start the remote streaming call.
+	var conn codegen.ClientStream
+	conn, err = s.stub.Stream(ctx, 2, nil, shardKey)
+	if err != nil {
+		err = errors.Join(weaver.RemoteCallError, err)
+		return
+	}
+
+	// Send the argument stream.
+	go codegen.SendStream(ctx, conn, a0, func(enc *codegen.Encoder, x string) { enc.String(x) })
+
+	// Wait for the results.
+	var results []byte
+	results, err = conn.Reply()
+	replyBytes = len(results)
+	if err != nil {
+		err = errors.Join(weaver.RemoteCallError, err)
+		return
+	}
+
+	// Decode the results.
+	dec := codegen.NewDecoder(results)
+	err = dec.Error()
+	if err != nil {
+		conn.CloseRecv()
+		return
+	}
+	r0 = codegen.NewStreamReader(conn, func(dec *codegen.Decoder) (x string) {
+		x = dec.String()
+		return
+	})
+	return
+}
+
 // Note that "weaver generate" will always generate the error message below.
 // Everything is okay. The error message is only relevant if you see it when
 // you run "go build" or "go run".
-var _ codegen.LatestVersion = codegen.Version[[0][24]struct{}](`
+var _ codegen.LatestVersion = codegen.Version[[0][25]struct{}](`
 
 ERROR: You generated this file with 'weaver generate' (devel) (codegen
-version v0.24.0). The generated code is incompatible with the version of the
+version v0.25.0). The generated code is incompatible with the version of the
 github.com/ServiceWeaver/weaver module that you're using. The weaver module
 version can be found in your go.mod file or by running the following command.
 
@@ -1200,6 +1490,134 @@ func (s source_server_stub) emit(ctx context.Context, args []byte) (res []byte,
 	return enc.Data(), nil
 }
 
+type streamer_server_stub struct {
+	impl    Streamer
+	addLoad func(key uint64, load float64)
+}
+
+// Check that streamer_server_stub implements the codegen.Server interface.
+var _ codegen.Server = (*streamer_server_stub)(nil)
+
+// GetStubFn implements the codegen.Server interface.
+func (s streamer_server_stub) GetStubFn(method string) func(ctx context.Context, args []byte) ([]byte, error) {
+	switch method {
+	case "Count":
+		return s.count
+	case "Ints":
+		return s.ints
+	case "Upper":
+		return s.upper
+	default:
+		return nil
+	}
+}
+
+func (s streamer_server_stub) count(ctx context.Context, args []byte) (res []byte, err error) {
+	// Catch and return any panics detected during encoding/decoding/rpc.
+	defer func() {
+		if err == nil {
+			err = codegen.CatchPanics(recover())
+		}
+	}()
+
+	// Get the call's streams.
+	conn, err := codegen.ServerStream(ctx)
+	if err != nil {
+		return nil, err
+	}
+	a0 := codegen.NewStreamReader(conn, func(dec *codegen.Decoder) (x string) {
+		x = dec.String()
+		return
+	})
+
+	// TODO(rgrandl): The deferred function above will recover from panics in the
+	// user code: fix this.
+	// Call the local method.
+	r0, appErr := s.impl.Count(ctx, a0)
+
+	// Encode the results.
+	enc := codegen.NewEncoder()
+	enc.Int(r0)
+	enc.Error(appErr)
+
+	// The method has no result stream; end it right away.
+	conn.CloseSend(nil)
+	return enc.Data(), nil
+}
+
+func (s streamer_server_stub) ints(ctx context.Context, args []byte) (res []byte, err error) {
+	// Catch and return any panics detected during encoding/decoding/rpc.
+	defer func() {
+		if err == nil {
+			err = codegen.CatchPanics(recover())
+		}
+	}()
+
+	// Get the call's streams.
+	conn, err := codegen.ServerStream(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Decode arguments.
+	dec := codegen.NewDecoder(args)
+	var a0 int
+	a0 = dec.Int()
+
+	// TODO(rgrandl): The deferred function above will recover from panics in the
+	// user code: fix this.
+	// Call the local method.
+	r0, appErr := s.impl.Ints(ctx, a0)
+
+	// Encode the results.
+	enc := codegen.NewEncoder()
+	enc.Error(appErr)
+
+	// Send the result stream.
+	if appErr != nil {
+		conn.CloseSend(nil)
+	} else {
+		go codegen.SendStream(ctx, conn, r0, func(enc *codegen.Encoder, x int) { enc.Int(x) })
+	}
+	return enc.Data(), nil
+}
+
+func (s streamer_server_stub) upper(ctx context.Context, args []byte) (res []byte, err error) {
+	// Catch and return any panics detected during encoding/decoding/rpc.
+	defer func() {
+		if err == nil {
+			err = codegen.CatchPanics(recover())
+		}
+	}()
+
+	// Get the call's streams.
+	conn, err := codegen.ServerStream(ctx)
+	if err != nil {
+		return nil, err
+	}
+	a0 := codegen.NewStreamReader(conn, func(dec *codegen.Decoder) (x string) {
+		x = dec.String()
+		return
+	})
+
+	// TODO(rgrandl): The deferred function above will recover from panics in the
+	// user code: fix this.
+	// Call the local method.
+	r0, appErr := s.impl.Upper(ctx, a0)
+
+	// Encode the results.
+	enc := codegen.NewEncoder()
+	enc.Error(appErr)
+
+	// Send the result stream.
+	if appErr != nil {
+		conn.CloseSend(nil)
+	} else {
+		go codegen.SendStream(ctx, conn, r0, func(enc *codegen.Encoder, x string) { enc.String(x) })
+	}
+	return enc.Data(), nil
+}
+
 // Reflect stub implementations.
 
 type destination_reflect_stub struct {
@@ -1273,6 +1691,28 @@ func (s source_reflect_stub) Emit(ctx context.Context, a0 string, a1 string) (er
 	return
 }
 
+type streamer_reflect_stub struct {
+	caller func(string, context.Context, []any, []any) error
+}
+
+// Check that streamer_reflect_stub implements the Streamer interface.
+var _ Streamer = (*streamer_reflect_stub)(nil)
+
+func (s streamer_reflect_stub) Count(ctx context.Context, a0 weaver.Stream[string]) (r0 int, err error) {
+	err = s.caller("Count", ctx, []any{a0}, []any{&r0})
+	return
+}
+
+func (s streamer_reflect_stub) Ints(ctx context.Context, a0 int) (r0 weaver.Stream[int], err error) {
+	err = s.caller("Ints", ctx, []any{a0}, []any{&r0})
+	return
+}
+
+func (s streamer_reflect_stub) Upper(ctx context.Context, a0 weaver.Stream[string]) (r0 weaver.Stream[string], err error) {
+	err = s.caller("Upper", ctx, []any{a0}, []any{&r0})
+	return
+}
+
 // Router methods.
 
 // _hashDestination returns a 64 bit hash of the provided value.
@@ -59,7 +59,9 @@ func initMultiProcess(ctx context.Context, t testing.TB, isBench bool, runner Ru
 			os.Exit(1)
 		}()
 
-		opts := weaver.RemoteWeaveletOptions{}
+		// Faults are injected into the calls of every weavelet, but
+		// replicas are only restarted by the deployer in the test process.
+		opts := weaver.RemoteWeaveletOptions{Faults: opts.Faults}
 		wlet, err := weaver.NewRemoteWeavelet(ctx, codegen.Registered(), bootstrap, opts)
 		if err != nil {
 			panic(err)
@@ -96,8 +98,16 @@ func initMultiProcess(ctx context.Context, t testing.TB, isBench bool, runner Ru
 		InternalAddress: "localhost:0",
 	}
 
+	if runner.Faults != nil {
+		// Subprocesses inject faults using the same seed.
+		appConfig.Env = append(appConfig.Env, fmt.Sprintf("%s=%d", faultSeedKey, runner.Faults.Seed))
+	}
+
 	// Launch the deployer.
 	d := newDeployer(ctx, wlet, appConfig, runner, locals, logWriter, t.TempDir())
+	if inj, ok := opts.Faults.(*injector); ok {
+		inj.restart = d.restart
+	}
 	weavelet, err := d.start(opts)
 	if err != nil {
 		return nil, nil, err